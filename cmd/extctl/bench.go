@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/extended-protocol/extended-sdk-golang/extended"
+	"github.com/extended-protocol/extended-sdk-golang/extended/models"
+	"github.com/shopspring/decimal"
+)
+
+// runBenchOrders measures how fast orders are signed and, without -dry-run,
+// how long placing them takes. Placed orders are post-only buys at half the
+// mark price by default and are cancelled right away; they are only placed
+// on testnet.
+func runBenchOrders(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("bench orders", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprint(stderr, "usage: extctl bench orders [flags]\n\nSigns orders locally and, without -dry-run, places and cancels them on testnet.\nThe signing account is read from EXTENDED_PRIVATE_KEY, EXTENDED_PUBLIC_KEY and EXTENDED_VAULT.\n\n")
+		fs.PrintDefaults()
+	}
+	var conn connection
+	conn.register(fs)
+	var cfg models.OrderBenchConfig
+	fs.BoolVar(&cfg.DryRun, "dry-run", false, "only sign orders locally, sending nothing")
+	fs.IntVar(&cfg.Orders, "orders", 100, "number of orders")
+	fs.IntVar(&cfg.Concurrency, "concurrency", 1, "orders signed or placed at once")
+	market := fs.String("market", "BTC-USD", "market of the orders")
+	qty := fs.String("qty", "", "order size, the market minimum if empty")
+	price := fs.String("price", "", "limit price of the buys, half the mark price if empty")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	n, err := conn.resolve()
+	if err != nil {
+		fmt.Fprintln(stderr, "extctl:", err)
+		return 2
+	}
+	if !cfg.DryRun && conn.network != "testnet" {
+		fmt.Fprintln(stderr, "extctl: orders are only placed on testnet; pass -dry-run to measure signing only")
+		return 2
+	}
+	account, err := accountFromEnv()
+	if err != nil {
+		fmt.Fprintln(stderr, "extctl:", err)
+		return 2
+	}
+
+	client := conn.newClient(n, account)
+	defer client.Close()
+	params, err := benchParams(ctx, client, n, account, *market, *qty, *price)
+	if err != nil {
+		fmt.Fprintln(stderr, "extctl:", err)
+		return 1
+	}
+	report, err := client.BenchOrders(ctx, params, cfg)
+	if err != nil {
+		fmt.Fprintln(stderr, "extctl:", err)
+		return 1
+	}
+	fmt.Fprint(stdout, report)
+	return 0
+}
+
+// benchParams describes a post-only buy resting far below the market, so
+// that placed orders do not fill before they are cancelled
+func benchParams(ctx context.Context, client *extended.APIClient, n network, account *extended.StarkPerpetualAccount, marketName, qty, price string) (extended.CreateOrderObjectParams, error) {
+	markets, err := client.GetMarkets(ctx, []string{marketName})
+	if err != nil {
+		return extended.CreateOrderObjectParams{}, fmt.Errorf("failed to get market %s: %w", marketName, err)
+	}
+	if len(markets) == 0 {
+		return extended.CreateOrderObjectParams{}, fmt.Errorf("unknown market %s", marketName)
+	}
+	params := extended.CreateOrderObjectParams{
+		Market:                   markets[0],
+		Account:                  *account,
+		SyntheticAmount:          markets[0].TradingConfig.MinOrderSize,
+		Side:                     models.OrderSideBuy,
+		Signer:                   account.Sign,
+		StarknetDomain:           n.domain,
+		PostOnly:                 true,
+		TimeInForce:              models.TimeInForceGTT,
+		SelfTradeProtectionLevel: models.SelfTradeProtectionAccount,
+	}
+
+	if qty != "" {
+		if params.SyntheticAmount, err = decimal.NewFromString(qty); err != nil {
+			return params, fmt.Errorf("invalid -qty: %w", err)
+		}
+	}
+	if !params.SyntheticAmount.IsPositive() {
+		return params, errors.New("the market has no minimum order size; pass -qty")
+	}
+
+	if price != "" {
+		if params.Price, err = decimal.NewFromString(price); err != nil {
+			return params, fmt.Errorf("invalid -price: %w", err)
+		}
+	} else {
+		stats, err := client.GetMarketStats(ctx, marketName)
+		if err != nil {
+			return params, fmt.Errorf("failed to get the mark price of %s: %w", marketName, err)
+		}
+		params.Price = stats.MarkPrice.Div(decimal.NewFromInt(2))
+	}
+	if !params.Price.IsPositive() {
+		return params, errors.New("no price to place the orders at; pass -price")
+	}
+	return params, nil
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+)
+
+// runHealth prints the diagnostic report of the exchange connection, the
+// first thing to run when something does not work. It exits with 1 when a
+// check failed.
+func runHealth(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("health", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprint(stderr, "usage: extctl health [flags]\n\nChecks REST latency, clock skew, the API key in EXTENDED_API_KEY if set, and stream connectivity.\n\n")
+		fs.PrintDefaults()
+	}
+	var conn connection
+	conn.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	n, err := conn.resolve()
+	if err != nil {
+		fmt.Fprintln(stderr, "extctl:", err)
+		return 2
+	}
+
+	client := conn.newClient(n, nil)
+	defer client.Close()
+	report := client.Diagnose(ctx)
+	fmt.Fprint(stdout, report)
+	if !report.OK() {
+		return 1
+	}
+	return 0
+}
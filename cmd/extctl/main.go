@@ -0,0 +1,142 @@
+// Command extctl is a command line companion of the SDK for checking a setup
+// and sizing the infrastructure of a strategy:
+//
+//	extctl health                  check REST latency, streams, auth and clock skew
+//	extctl bench orders -dry-run   measure local order signing throughput
+//
+// Every command talks to testnet unless -network mainnet is given; -api-url
+// and -stream-url override the endpoints of the network. Credentials are read
+// from the environment: EXTENDED_API_KEY, and for commands signing orders
+// EXTENDED_PRIVATE_KEY, EXTENDED_PUBLIC_KEY and EXTENDED_VAULT.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"time"
+
+	"github.com/extended-protocol/extended-sdk-golang/extended"
+	"github.com/extended-protocol/extended-sdk-golang/extended/models"
+)
+
+const usage = `usage: extctl <command> [flags]
+
+commands:
+  health         check REST latency, stream connectivity, auth and clock skew
+  bench orders   measure order signing throughput and placement latency
+
+Run "extctl <command> -h" for the flags of a command.
+`
+
+// network holds the endpoints and signing domain of an exchange deployment
+type network struct {
+	endpoints extended.EndpointConfig
+	domain    models.StarknetDomain
+}
+
+var networks = map[string]network{
+	"testnet": {
+		endpoints: extended.EndpointConfig{
+			APIBaseURL: "https://api.starknet.sepolia.extended.exchange/api/v1",
+			StreamURL:  "wss://api.starknet.sepolia.extended.exchange/stream.extended.exchange/v1",
+		},
+		domain: models.StarknetDomain{Name: "Perpetuals", Version: "v0", ChainID: "SN_SEPOLIA", Revision: "1"},
+	},
+	"mainnet": {
+		endpoints: extended.EndpointConfig{
+			APIBaseURL: "https://api.starknet.extended.exchange/api/v1",
+			StreamURL:  "wss://api.starknet.extended.exchange/stream.extended.exchange/v1",
+		},
+		domain: models.StarknetDomain{Name: "Perpetuals", Version: "v0", ChainID: "SN_MAIN", Revision: "1"},
+	},
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	os.Exit(run(ctx, os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the command in args and returns the exit code: 0 on success,
+// 1 when the command failed and 2 on invalid usage
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+	switch args[0] {
+	case "health":
+		return runHealth(ctx, args[1:], stdout, stderr)
+	case "bench":
+		if len(args) < 2 || args[1] != "orders" {
+			fmt.Fprint(stderr, "usage: extctl bench orders [flags]\n")
+			return 2
+		}
+		return runBenchOrders(ctx, args[2:], stdout, stderr)
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(stdout, usage)
+		return 0
+	default:
+		fmt.Fprintf(stderr, "extctl: unknown command %q\n\n%s", args[0], usage)
+		return 2
+	}
+}
+
+// connection holds the flags selecting the exchange, shared by all commands
+type connection struct {
+	network   string
+	apiURL    string
+	streamURL string
+	timeout   time.Duration
+}
+
+func (c *connection) register(fs *flag.FlagSet) {
+	fs.StringVar(&c.network, "network", "testnet", "exchange deployment, testnet or mainnet")
+	fs.StringVar(&c.apiURL, "api-url", "", "REST base URL, overriding the one of the network")
+	fs.StringVar(&c.streamURL, "stream-url", "", "stream base URL, overriding the one of the network")
+	fs.DurationVar(&c.timeout, "timeout", 10*time.Second, "timeout of a single request")
+}
+
+// resolve returns the selected network with the endpoint overrides applied
+func (c *connection) resolve() (network, error) {
+	n, ok := networks[c.network]
+	if !ok {
+		return network{}, fmt.Errorf("unknown network %q, want testnet or mainnet", c.network)
+	}
+	if c.apiURL != "" {
+		n.endpoints.APIBaseURL = c.apiURL
+	}
+	if c.streamURL != "" {
+		n.endpoints.StreamURL = c.streamURL
+	}
+	return n, nil
+}
+
+// newClient returns a client signing with account, if any, and authenticated
+// with EXTENDED_API_KEY when it is set; without either it is a public client
+func (c *connection) newClient(n network, account *extended.StarkPerpetualAccount) *extended.APIClient {
+	apiKey := os.Getenv("EXTENDED_API_KEY")
+	if apiKey == "" && account == nil {
+		return extended.NewPublicClient(n.endpoints, extended.WithRequestTimeout(c.timeout))
+	}
+	return extended.NewAPIClient(n.endpoints, apiKey, account, c.timeout)
+}
+
+// accountFromEnv builds the signing account from the environment
+func accountFromEnv() (*extended.StarkPerpetualAccount, error) {
+	privateKey, publicKey := os.Getenv("EXTENDED_PRIVATE_KEY"), os.Getenv("EXTENDED_PUBLIC_KEY")
+	if privateKey == "" || publicKey == "" {
+		return nil, errors.New("EXTENDED_PRIVATE_KEY and EXTENDED_PUBLIC_KEY must be set to sign orders")
+	}
+	vault, err := strconv.ParseUint(os.Getenv("EXTENDED_VAULT"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("EXTENDED_VAULT must be set to the vault ID: %w", err)
+	}
+	return extended.NewStarkPerpetualAccount(vault, privateKey, publicKey, os.Getenv("EXTENDED_API_KEY"))
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/extended-protocol/extended-sdk-golang/src/sdktest"
+	"github.com/stretchr/testify/assert"
+)
+
+// endpointFlags point a command at the fake exchange
+func endpointFlags(ex *sdktest.Exchange) []string {
+	cfg := ex.EndpointConfig()
+	return []string{"-api-url", cfg.APIBaseURL, "-stream-url", cfg.StreamURL}
+}
+
+func setAccountEnv(t *testing.T) {
+	t.Setenv("EXTENDED_API_KEY", "extctl-api-key")
+	t.Setenv("EXTENDED_PRIVATE_KEY", "0x7a7ff6fd3cab02ccdcd4a572563f5976f8976899b03a39773795a3c486d4986")
+	t.Setenv("EXTENDED_PUBLIC_KEY", "0x61c5e7e8339b7d56f197f54ea91b776776690e3232313de0f2ecbd0ef76f466")
+	t.Setenv("EXTENDED_VAULT", "10002")
+}
+
+func TestRun_Usage(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, 2, run(context.Background(), nil, &stdout, &stderr))
+	assert.Contains(t, stderr.String(), "usage: extctl")
+
+	stderr.Reset()
+	assert.Equal(t, 2, run(context.Background(), []string{"bench"}, &stdout, &stderr))
+	assert.Equal(t, 2, run(context.Background(), []string{"health", "-network", "devnet"}, &stdout, &stderr))
+	assert.Contains(t, stderr.String(), `unknown network "devnet"`)
+}
+
+func TestRun_Health(t *testing.T) {
+	ex := sdktest.NewExchange()
+	defer ex.Close()
+	setAccountEnv(t)
+
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), append([]string{"health"}, endpointFlags(ex)...), &stdout, &stderr)
+	assert.Equal(t, 0, code, stderr.String())
+	for _, check := range []string{"rest", "clock", "auth", "stream"} {
+		assert.Contains(t, stdout.String(), check)
+	}
+	assert.Contains(t, stdout.String(), "API key accepted")
+
+	ex.SetFaults(sdktest.Faults{ServerErrorRate: 1})
+	stdout.Reset()
+	code = run(context.Background(), append([]string{"health"}, endpointFlags(ex)...), &stdout, &stderr)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stdout.String(), "rest    FAIL")
+}
+
+func TestRun_BenchOrders(t *testing.T) {
+	ex := sdktest.NewExchange()
+	defer ex.Close()
+	setAccountEnv(t)
+	bench := func(args ...string) (int, string, string) {
+		var stdout, stderr bytes.Buffer
+		args = append(append([]string{"bench", "orders", "-orders", "5", "-qty", "0.01", "-price", "20000"}, endpointFlags(ex)...), args...)
+		code := run(context.Background(), args, &stdout, &stderr)
+		return code, stdout.String(), stderr.String()
+	}
+
+	code, stdout, stderr := bench("-dry-run")
+	assert.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "skipped (dry run)")
+	assert.Empty(t, ex.RestingOrders())
+
+	code, stdout, stderr = bench("-concurrency", "2")
+	assert.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "place")
+	assert.NotContains(t, stdout, "dry run")
+	assert.Empty(t, ex.RestingOrders(), "benchmark orders are cancelled")
+
+	code, _, stderr = bench("-network", "mainnet")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, "only placed on testnet")
+}
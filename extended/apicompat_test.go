@@ -0,0 +1,147 @@
+package extended_test
+
+import (
+	"flag"
+	"fmt"
+	"go/importer"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+var updateAPI = flag.Bool("update-api", false, "rewrite testdata/api.txt with the current exported API")
+
+// stablePackages are the import paths covered by the compatibility guarantee
+var stablePackages = []string{
+	"github.com/extended-protocol/extended-sdk-golang/extended",
+	"github.com/extended-protocol/extended-sdk-golang/extended/models",
+}
+
+const apiSnapshot = "testdata/api.txt"
+
+// TestAPICompat fails when a symbol recorded in testdata/api.txt is removed or
+// changes signature. Additions are allowed; run with -update-api to record them.
+func TestAPICompat(t *testing.T) {
+	imp := importer.ForCompiler(token.NewFileSet(), "source", nil)
+
+	var current []string
+	for _, path := range stablePackages {
+		pkg, err := imp.Import(path)
+		if err != nil {
+			t.Fatalf("failed to type-check %s: %v", path, err)
+		}
+		current = append(current, describePackage(pkg)...)
+	}
+	sort.Strings(current)
+
+	if *updateAPI {
+		if err := os.MkdirAll(filepath.Dir(apiSnapshot), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(apiSnapshot, []byte(strings.Join(current, "\n")+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	data, err := os.ReadFile(apiSnapshot)
+	if err != nil {
+		t.Fatalf("failed to read API snapshot, run go test -run TestAPICompat -update-api: %v", err)
+	}
+	recorded := strings.Split(strings.TrimSpace(string(data)), "\n")
+
+	have := make(map[string]bool, len(current))
+	for _, line := range current {
+		have[line] = true
+	}
+	want := make(map[string]bool, len(recorded))
+	var broken []string
+	for _, line := range recorded {
+		want[line] = true
+		if !have[line] {
+			broken = append(broken, line)
+		}
+	}
+	if len(broken) > 0 {
+		t.Errorf("breaking API changes, these symbols were removed or changed:\n\t%s", strings.Join(broken, "\n\t"))
+	}
+
+	added := 0
+	for _, line := range current {
+		if !want[line] {
+			added++
+		}
+	}
+	if added > 0 {
+		t.Logf("%d API additions not yet recorded, run go test -run TestAPICompat -update-api", added)
+	}
+}
+
+// describePackage renders one line per exported symbol, field and method.
+// Aliases are expanded so that changes to the aliased src types are caught.
+func describePackage(pkg *types.Package) []string {
+	qualifier := func(p *types.Package) string { return p.Name() }
+	prefix := pkg.Name() + "."
+
+	var lines []string
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		obj := scope.Lookup(name)
+		if !obj.Exported() {
+			continue
+		}
+		switch o := obj.(type) {
+		case *types.Const:
+			if b, ok := o.Type().(*types.Basic); ok && b.Info()&types.IsUntyped != 0 {
+				// Values of untyped constants such as Version change between releases
+				lines = append(lines, fmt.Sprintf("const %s%s", prefix, name))
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("const %s%s %s = %s", prefix, name, types.TypeString(o.Type(), qualifier), o.Val()))
+		case *types.Var:
+			lines = append(lines, fmt.Sprintf("var %s%s %s", prefix, name, types.TypeString(o.Type(), qualifier)))
+		case *types.Func:
+			lines = append(lines, fmt.Sprintf("func %s%s%s", prefix, name, strings.TrimPrefix(types.TypeString(o.Type(), qualifier), "func")))
+		case *types.TypeName:
+			lines = append(lines, describeType(prefix+name, types.Unalias(o.Type()), qualifier)...)
+		}
+	}
+	return lines
+}
+
+func describeType(name string, typ types.Type, qualifier types.Qualifier) []string {
+	var lines []string
+	switch u := typ.Underlying().(type) {
+	case *types.Struct:
+		lines = append(lines, fmt.Sprintf("type %s struct", name))
+		for i := 0; i < u.NumFields(); i++ {
+			f := u.Field(i)
+			if f.Exported() {
+				lines = append(lines, fmt.Sprintf("type %s field %s %s", name, f.Name(), types.TypeString(f.Type(), qualifier)))
+			}
+		}
+	case *types.Interface:
+		lines = append(lines, fmt.Sprintf("type %s interface", name))
+		for i := 0; i < u.NumMethods(); i++ {
+			m := u.Method(i)
+			lines = append(lines, fmt.Sprintf("type %s method %s%s", name, m.Name(), strings.TrimPrefix(types.TypeString(m.Type(), qualifier), "func")))
+		}
+		return lines
+	default:
+		lines = append(lines, fmt.Sprintf("type %s %s", name, types.TypeString(u, qualifier)))
+	}
+
+	// Methods of both T and *T, including those promoted from embedded fields
+	mset := types.NewMethodSet(types.NewPointer(typ))
+	for i := 0; i < mset.Len(); i++ {
+		m := mset.At(i).Obj()
+		if m.Exported() {
+			lines = append(lines, fmt.Sprintf("type %s method %s%s", name, m.Name(), strings.TrimPrefix(types.TypeString(m.Type(), qualifier), "func")))
+		}
+	}
+	return lines
+}
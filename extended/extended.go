@@ -0,0 +1,340 @@
+// Package extended is the stable entry point of the Extended exchange SDK.
+//
+// It re-exports the client, account and order construction APIs implemented
+// in the src package under an idiomatic import path. Types are aliases, so
+// code may migrate from the src import path incrementally. Request and
+// response types live in the models subpackage.
+package extended
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"time"
+
+	"github.com/extended-protocol/extended-sdk-golang/extended/models"
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/shopspring/decimal"
+)
+
+// Version of the SDK reported in the User-Agent header
+const Version = sdk.SDKVersion
+
+// Client and configuration
+type (
+	APIClient        = sdk.APIClient
+	EndpointConfig   = sdk.EndpointConfig
+	ClientOption     = sdk.ClientOption
+	OrderQueueConfig = sdk.OrderQueueConfig
+	RetryConfig      = sdk.RetryConfig
+	HealthConfig     = sdk.HealthConfig
+	Codec            = sdk.Codec
+	JSONCodec        = sdk.JSONCodec
+	StrictJSONCodec  = sdk.StrictJSONCodec
+	SessionStats     = sdk.SessionStats
+	EndpointStats    = sdk.EndpointStats
+	Clock            = sdk.Clock
+	NonceGenerator   = sdk.NonceGenerator
+	Journal          = sdk.Journal
+	FileJournal      = sdk.FileJournal
+	PoolConfig       = sdk.PoolConfig
+	ClientPool       = sdk.ClientPool
+	SchedulerConfig  = sdk.SchedulerConfig
+	PoolScheduler    = sdk.PoolScheduler
+	AddressBook      = sdk.AddressBook
+	AddressBookEntry = sdk.AddressBookEntry
+	ApprovalHook     = sdk.ApprovalHook
+	ApprovalHookFunc = sdk.ApprovalHookFunc
+	ApprovalRequest  = sdk.ApprovalRequest
+	ApprovalKind     = sdk.ApprovalKind
+)
+
+// Operations an ApprovalHook is asked about
+const (
+	ApprovalWithdrawal = sdk.ApprovalWithdrawal
+	ApprovalTransfer   = sdk.ApprovalTransfer
+)
+
+// Request options
+type (
+	PlaceOrderOption = sdk.PlaceOrderOption
+	MarketsOption    = sdk.MarketsOption
+	OrderbookOption  = sdk.OrderbookOption
+	OrderbookSource  = sdk.OrderbookSource
+	SelfTradeAction  = sdk.SelfTradeAction
+)
+
+// Self-trade pre-check actions
+const (
+	SelfTradeWarn   = sdk.SelfTradeWarn
+	SelfTradeCancel = sdk.SelfTradeCancel
+)
+
+// Accounts and orders
+type (
+	StarkPerpetualAccount   = sdk.StarkPerpetualAccount
+	CreateOrderObjectParams = sdk.CreateOrderObjectParams
+	OperationHandle[T any]  = sdk.OperationHandle[T]
+)
+
+// Errors
+type (
+	APIError            = sdk.APIError
+	OrderNotFilledError = sdk.OrderNotFilledError
+	RequestError        = sdk.RequestError
+)
+
+var (
+	ErrAPIKeyNotSet       = sdk.ErrAPIKeyNotSet
+	ErrStarkAccountNotSet = sdk.ErrStarkAccountNotSet
+	ErrClientClosed       = sdk.ErrClientClosed
+	ErrResponseTooLarge   = sdk.ErrResponseTooLarge
+	ErrPinMismatch        = sdk.ErrPinMismatch
+	ErrOrderQueueClosed   = sdk.ErrOrderQueueClosed
+	ErrUnknownMarket      = sdk.ErrUnknownMarket
+	ErrInvalidOrder       = sdk.ErrInvalidOrder
+	ErrNoPositionToReduce = sdk.ErrNoPositionToReduce
+	ErrMaxImpactExceeded  = sdk.ErrMaxImpactExceeded
+	ErrServiceDegraded    = sdk.ErrServiceDegraded
+	ErrAPIKeyRejected     = sdk.ErrAPIKeyRejected
+
+	ErrInvalidWithdrawalAmount = sdk.ErrInvalidWithdrawalAmount
+	ErrAddressNotListed        = sdk.ErrAddressNotListed
+	ErrWrongPassphrase         = sdk.ErrWrongPassphrase
+	ErrApprovalDenied          = sdk.ErrApprovalDenied
+)
+
+// NewAPIClient creates a new API client instance
+func NewAPIClient(cfg EndpointConfig, apiKey string, starkAccount *StarkPerpetualAccount, clientTimeout time.Duration, opts ...ClientOption) *APIClient {
+	return sdk.NewAPIClient(cfg, apiKey, starkAccount, clientTimeout, opts...)
+}
+
+// NewPublicClient creates a client for the public market data endpoints, without credentials
+func NewPublicClient(cfg EndpointConfig, opts ...ClientOption) *APIClient {
+	return sdk.NewPublicClient(cfg, opts...)
+}
+
+// NewStarkPerpetualAccount constructs the account, validating hex inputs
+func NewStarkPerpetualAccount(vault uint64, privateKeyHex, publicKeyHex, apiKey string) (*StarkPerpetualAccount, error) {
+	return sdk.NewStarkPerpetualAccount(vault, privateKeyHex, publicKeyHex, apiKey)
+}
+
+// CreateOrderObject creates a signed order ready for submission
+func CreateOrderObject(params CreateOrderObjectParams) (*models.PerpetualOrderModel, error) {
+	return sdk.CreateOrderObject(params)
+}
+
+// CreateOrderObjectContext creates a signed order, stopping as soon as ctx is done
+func CreateOrderObjectContext(ctx context.Context, params CreateOrderObjectParams) (*models.PerpetualOrderModel, error) {
+	return sdk.CreateOrderObjectContext(ctx, params)
+}
+
+// CreateOrderObjects signs a batch of orders, stopping as soon as ctx is done
+func CreateOrderObjects(ctx context.Context, params []CreateOrderObjectParams) ([]*models.PerpetualOrderModel, error) {
+	return sdk.CreateOrderObjects(ctx, params)
+}
+
+// NewOCOOrders signs an entry order with reduce-only take profit and stop loss exits
+func NewOCOOrders(params CreateOrderObjectParams, takeProfitPrice, stopLossTrigger decimal.Decimal) (models.OCOOrders, error) {
+	return sdk.NewOCOOrders(params, takeProfitPrice, stopLossTrigger)
+}
+
+// IsOrderRejected reports whether err is an APIError rejecting an order for the given reason
+func IsOrderRejected(err error, reason models.OrderStatusReason) bool {
+	return sdk.IsOrderRejected(err, reason)
+}
+
+// WithUserAgent appends an application name and version to the SDK User-Agent
+func WithUserAgent(appName, appVersion string) ClientOption {
+	return sdk.WithUserAgent(appName, appVersion)
+}
+
+// WithClientID sets the X-Client-Id header sent with every request
+func WithClientID(clientID string) ClientOption {
+	return sdk.WithClientID(clientID)
+}
+
+// WithLanguage sets the Accept-Language header, asking for localized error messages
+func WithLanguage(tag string) ClientOption {
+	return sdk.WithLanguage(tag)
+}
+
+// WithRequestTimeout bounds a whole request, replacing the constructor clientTimeout
+func WithRequestTimeout(timeout time.Duration) ClientOption {
+	return sdk.WithRequestTimeout(timeout)
+}
+
+// DefaultMaxResponseSize bounds response bodies unless changed with WithMaxResponseSize
+const DefaultMaxResponseSize = sdk.DefaultMaxResponseSize
+
+// WithMaxResponseSize bounds response bodies; larger ones fail with ErrResponseTooLarge
+func WithMaxResponseSize(limit int64) ClientOption {
+	return sdk.WithMaxResponseSize(limit)
+}
+
+// WithTLSConfig sets the TLS configuration of connections to the API
+func WithTLSConfig(cfg *tls.Config) ClientOption {
+	return sdk.WithTLSConfig(cfg)
+}
+
+// WithPinnedSPKI only accepts servers presenting one of the pinned public keys
+func WithPinnedSPKI(pins ...string) ClientOption {
+	return sdk.WithPinnedSPKI(pins...)
+}
+
+// SPKIHash returns the pin of a certificate public key, see WithPinnedSPKI
+func SPKIHash(rawSubjectPublicKeyInfo []byte) string {
+	return sdk.SPKIHash(rawSubjectPublicKeyInfo)
+}
+
+// WithConnectTimeout bounds the dial and TLS handshake separately from the request timeout
+func WithConnectTimeout(timeout time.Duration) ClientOption {
+	return sdk.WithConnectTimeout(timeout)
+}
+
+// WithLocalAddr sends requests from the given local IP address, e.g. the allowlisted one
+func WithLocalAddr(ip net.IP) ClientOption {
+	return sdk.WithLocalAddr(ip)
+}
+
+// WithLocalInterface sends requests from an address of the named network interface
+func WithLocalInterface(name string) ClientOption {
+	return sdk.WithLocalInterface(name)
+}
+
+// WithRetry resends idempotent requests on transport errors and retryable statuses
+func WithRetry(cfg RetryConfig) ClientOption {
+	return sdk.WithRetry(cfg)
+}
+
+// WithStrictDecoding fails responses carrying fields the SDK does not model
+func WithStrictDecoding() ClientOption {
+	return sdk.WithStrictDecoding()
+}
+
+// WithManualFees stops fetched fees from being cached and used to sign orders
+func WithManualFees() ClientOption {
+	return sdk.WithManualFees()
+}
+
+// WithPreloadMarkets fetches every market in the background when the client is created
+func WithPreloadMarkets(preload bool) ClientOption {
+	return sdk.WithPreloadMarkets(preload)
+}
+
+// WithHealthConfig sets when services are considered degraded and whether to fail fast while they are
+func WithHealthConfig(cfg HealthConfig) ClientOption {
+	return sdk.WithHealthConfig(cfg)
+}
+
+// WithIdempotent marks the requests made with ctx as safe to resend
+func WithIdempotent(ctx context.Context) context.Context {
+	return sdk.WithIdempotent(ctx)
+}
+
+// WithCodec replaces the codec used for API payloads
+func WithCodec(codec Codec) ClientOption {
+	return sdk.WithCodec(codec)
+}
+
+// WithFreshness records when the data of responses made with ctx was read into f
+func WithFreshness(ctx context.Context, f *models.Freshness) context.Context {
+	return sdk.WithFreshness(ctx, f)
+}
+
+// WithUnknownFields records the response fields the SDK does not model of
+// requests made with ctx into u
+func WithUnknownFields(ctx context.Context, u *models.UnknownFields) context.Context {
+	return sdk.WithUnknownFields(ctx, u)
+}
+
+// WithClock replaces the system clock used by the client
+func WithClock(clock Clock) ClientOption {
+	return sdk.WithClock(clock)
+}
+
+// WithNonceGenerator sets the nonce policy used by the client
+func WithNonceGenerator(generator NonceGenerator) ClientOption {
+	return sdk.WithNonceGenerator(generator)
+}
+
+// NewClientPool creates a pool of account clients sharing one HTTP transport
+func NewClientPool(cfg PoolConfig) *ClientPool {
+	return sdk.NewClientPool(cfg)
+}
+
+// WithJournal records order and cancel intents before sending them, for crash recovery
+func WithJournal(journal Journal) ClientOption {
+	return sdk.WithJournal(journal)
+}
+
+// NewFileJournal returns a journal kept in the JSON file at path
+func NewFileJournal(path string) *FileJournal {
+	return sdk.NewFileJournal(path)
+}
+
+// OpenAddressBook decrypts the address book at path, creating it if missing
+func OpenAddressBook(path, passphrase string) (*AddressBook, error) {
+	return sdk.OpenAddressBook(path, passphrase)
+}
+
+// WithAddressBook makes Withdraw refuse addresses not in book
+func WithAddressBook(book *AddressBook) ClientOption {
+	return sdk.WithAddressBook(book)
+}
+
+// WithApprovalHook makes withdrawals and transfers above threshold wait for hook to approve them
+func WithApprovalHook(hook ApprovalHook, threshold decimal.Decimal) ClientOption {
+	return sdk.WithApprovalHook(hook, threshold)
+}
+
+// WithOrderQueue routes order traffic through a rate-limited priority queue
+func WithOrderQueue(cfg OrderQueueConfig) ClientOption {
+	return sdk.WithOrderQueue(cfg)
+}
+
+// WithReduceOnlyCap caps reduce-only orders placed with PlaceOrder at the current position size
+func WithReduceOnlyCap() ClientOption {
+	return sdk.WithReduceOnlyCap()
+}
+
+// WithSnapToTick resends an order rejected for an off-tick price once, snapped to the tick
+func WithSnapToTick() PlaceOrderOption {
+	return sdk.WithSnapToTick()
+}
+
+// WithShrinkOnInsufficientFunds resends an order rejected for insufficient funds once, reduced by fraction
+func WithShrinkOnInsufficientFunds(fraction decimal.Decimal) PlaceOrderOption {
+	return sdk.WithShrinkOnInsufficientFunds(fraction)
+}
+
+// WithRepriceOnPostOnlyFailed resends a rejected post-only order once, one tick further from the book
+func WithRepriceOnPostOnlyFailed() PlaceOrderOption {
+	return sdk.WithRepriceOnPostOnlyFailed()
+}
+
+// WithRemediationHandler calls fn for every change made to a rejected order before it is resent
+func WithRemediationHandler(fn func(models.RemediationEvent)) PlaceOrderOption {
+	return sdk.WithRemediationHandler(fn)
+}
+
+// WithMaxImpactBps refuses orders whose estimated impact on the book exceeds
+// bps basis points, estimated from a synced book among books when there is one
+func WithMaxImpactBps(bps decimal.Decimal, books ...OrderbookSource) PlaceOrderOption {
+	return sdk.WithMaxImpactBps(bps, books...)
+}
+
+// WithSelfTradeCheck looks up own open orders before sending an order that may cross them
+func WithSelfTradeCheck(action SelfTradeAction, onConflict func(models.SelfTradeConflict)) PlaceOrderOption {
+	return sdk.WithSelfTradeCheck(action, onConflict)
+}
+
+// WithInactiveMarkets controls whether GetMarkets returns inactive markets
+func WithInactiveMarkets(include bool) MarketsOption {
+	return sdk.WithInactiveMarkets(include)
+}
+
+// WithDepth limits an orderbook snapshot to the top n levels per side
+func WithDepth(n int) OrderbookOption {
+	return sdk.WithDepth(n)
+}
@@ -0,0 +1,29 @@
+package extended_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/extended-protocol/extended-sdk-golang/extended"
+	"github.com/extended-protocol/extended-sdk-golang/extended/models"
+	"github.com/extended-protocol/extended-sdk-golang/src/sdktest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFacadeInteroperatesWithSrc(t *testing.T) {
+	ex := sdktest.NewExchange()
+	defer ex.Close()
+
+	client := extended.NewAPIClient(ex.EndpointConfig(), "key", nil, 0, extended.WithClientID("facade"))
+	markets, err := client.GetMarkets(context.Background(), []string{"BTC-USD"})
+	require.NoError(t, err)
+
+	var market models.MarketModel = markets[0]
+	assert.Equal(t, "BTC-USD", market.Name)
+
+	_, err = client.SubmitOrder(context.Background(), &models.PerpetualOrderModel{
+		ID: "facade", Market: "BTC-USD", Side: models.OrderSideSell, Qty: "1", Price: "1", ReduceOnly: true,
+	})
+	assert.True(t, extended.IsOrderRejected(err, models.OrderStatusReasonReduceOnlyFailed))
+}
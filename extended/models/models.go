@@ -0,0 +1,384 @@
+// Package models contains the request and response types of the Extended
+// exchange API. The types are aliases of the implementation in the src
+// package, so values can be passed freely between both import paths.
+package models
+
+import (
+	"time"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/shopspring/decimal"
+)
+
+// Markets and fees
+type (
+	L2ConfigModel      = sdk.L2ConfigModel
+	TradingConfigModel = sdk.TradingConfigModel
+	MarketModel        = sdk.MarketModel
+	MarketStatsModel   = sdk.MarketStatsModel
+	TradingFeeModel    = sdk.TradingFeeModel
+	StarknetDomain     = sdk.StarknetDomain
+
+	OrderbookUpdateModel   = sdk.OrderbookUpdateModel
+	OrderbookQuantityModel = sdk.OrderbookQuantityModel
+
+	CandleModel = sdk.CandleModel
+
+	MarketFilter   = sdk.MarketFilter
+	ScreenedMarket = sdk.ScreenedMarket
+
+	FundingSchedule = sdk.FundingSchedule
+	FundingEvent    = sdk.FundingEvent
+
+	FundingRateModel     = sdk.FundingRateModel
+	FundingHistoryFilter = sdk.FundingHistoryFilter
+	CarryCost            = sdk.CarryCost
+
+	MarketChangeKind  = sdk.MarketChangeKind
+	MarketChangeEvent = sdk.MarketChangeEvent
+
+	AuthEventKind = sdk.AuthEventKind
+	AuthEvent     = sdk.AuthEvent
+
+	TradingConfigField  = sdk.TradingConfigField
+	TradingConfigChange = sdk.TradingConfigChange
+)
+
+// Orders
+type (
+	RemediationEvent    = sdk.RemediationEvent
+	SelfTradeConflict   = sdk.SelfTradeConflict
+	OpenOrdersFilter    = sdk.OpenOrdersFilter
+	PerpetualOrderModel = sdk.PerpetualOrderModel
+	OpenOrderModel      = sdk.OpenOrderModel
+	Settlement          = sdk.Settlement
+	Signature           = sdk.Signature
+	ConditionalTrigger  = sdk.ConditionalTrigger
+	TpSlTrigger         = sdk.TpSlTrigger
+	TpSlParams          = sdk.TpSlParams
+	MassCancelParams    = sdk.MassCancelParams
+	OrderResponse       = sdk.OrderResponse
+	OCOOrders           = sdk.OCOOrders
+	OCOResult           = sdk.OCOResult
+)
+
+// Account
+type (
+	PositionModel      = sdk.PositionModel
+	BalanceModel       = sdk.BalanceModel
+	AccountUpdateModel = sdk.AccountUpdateModel
+
+	ExposureReport = sdk.ExposureReport
+	AssetExposure  = sdk.AssetExposure
+
+	IncidentReport = sdk.IncidentReport
+
+	WithdrawalLimitsModel  = sdk.WithdrawalLimitsModel
+	WithdrawalFeeTierModel = sdk.WithdrawalFeeTierModel
+
+	AccountTradeModel    = sdk.AccountTradeModel
+	AccountLeverageModel = sdk.AccountLeverageModel
+	TradesFilter         = sdk.TradesFilter
+	PositionsFilter      = sdk.PositionsFilter
+
+	AssetOperationModel      = sdk.AssetOperationModel
+	AssetOperationsFilter    = sdk.AssetOperationsFilter
+	AssetOperationIDResponse = sdk.AssetOperationIDResponse
+	WithdrawalModel          = sdk.WithdrawalModel
+	WithdrawalSettlement     = sdk.WithdrawalSettlement
+	TransferModel            = sdk.TransferModel
+	TransferSettlement       = sdk.TransferSettlement
+
+	FeeCheck       = sdk.FeeCheck
+	FeeDiscrepancy = sdk.FeeDiscrepancy
+
+	Intent          = sdk.Intent
+	IntentKind      = sdk.IntentKind
+	IntentOutcome   = sdk.IntentOutcome
+	RecoveredIntent = sdk.RecoveredIntent
+	ReconcileReport = sdk.ReconcileReport
+	AccountUsage    = sdk.AccountUsage
+
+	ConsistencyToken = sdk.ConsistencyToken
+	Freshness        = sdk.Freshness
+	UnknownFields    = sdk.UnknownFields
+
+	ServiceHealth = sdk.ServiceHealth
+	HealthSummary = sdk.HealthSummary
+
+	DiagnosticCheck  = sdk.DiagnosticCheck
+	DiagnosticReport = sdk.DiagnosticReport
+
+	OrderBenchConfig   = sdk.OrderBenchConfig
+	OrderBenchReport   = sdk.OrderBenchReport
+	LatencyPercentiles = sdk.LatencyPercentiles
+)
+
+// Enums
+type (
+	OrderType                = sdk.OrderType
+	OrderSide                = sdk.OrderSide
+	TimeInForce              = sdk.TimeInForce
+	SelfTradeProtectionLevel = sdk.SelfTradeProtectionLevel
+	TriggerPriceType         = sdk.TriggerPriceType
+	TriggerDirection         = sdk.TriggerDirection
+	ExecutionPriceType       = sdk.ExecutionPriceType
+	TpSlType                 = sdk.TpSlType
+	OrderStatus              = sdk.OrderStatus
+	OrderStatusReason        = sdk.OrderStatusReason
+	PositionSide             = sdk.PositionSide
+	CandleInterval           = sdk.CandleInterval
+	CandleType               = sdk.CandleType
+	MarketStatus             = sdk.MarketStatus
+	TradeType                = sdk.TradeType
+	Service                  = sdk.Service
+	HealthState              = sdk.HealthState
+	DiagnosticStatus         = sdk.DiagnosticStatus
+	AssetOperationType       = sdk.AssetOperationType
+	AssetOperationStatus     = sdk.AssetOperationStatus
+)
+
+const (
+	OrderTypeLimit       = sdk.OrderTypeLimit
+	OrderTypeMarket      = sdk.OrderTypeMarket
+	OrderTypeConditional = sdk.OrderTypeConditional
+	OrderTypeTpsl        = sdk.OrderTypeTpsl
+
+	OrderSideBuy  = sdk.OrderSideBuy
+	OrderSideSell = sdk.OrderSideSell
+
+	TimeInForceGTT = sdk.TimeInForceGTT
+	TimeInForceFOK = sdk.TimeInForceFOK
+	TimeInForceIOC = sdk.TimeInForceIOC
+
+	SelfTradeProtectionDisabled = sdk.SelfTradeProtectionDisabled
+	SelfTradeProtectionAccount  = sdk.SelfTradeProtectionAccount
+	SelfTradeProtectionClient   = sdk.SelfTradeProtectionClient
+
+	TriggerPriceTypeLast  = sdk.TriggerPriceTypeLast
+	TriggerPriceTypeMid   = sdk.TriggerPriceTypeMid
+	TriggerPriceTypeMark  = sdk.TriggerPriceTypeMark
+	TriggerPriceTypeIndex = sdk.TriggerPriceTypeIndex
+
+	TriggerDirectionUp   = sdk.TriggerDirectionUp
+	TriggerDirectionDown = sdk.TriggerDirectionDown
+
+	ExecutionPriceTypeLimit  = sdk.ExecutionPriceTypeLimit
+	ExecutionPriceTypeMarket = sdk.ExecutionPriceTypeMarket
+
+	TpSlTypeOrder    = sdk.TpSlTypeOrder
+	TpSlTypePosition = sdk.TpSlTypePosition
+
+	OrderStatusNew             = sdk.OrderStatusNew
+	OrderStatusPartiallyFilled = sdk.OrderStatusPartiallyFilled
+	OrderStatusFilled          = sdk.OrderStatusFilled
+	OrderStatusUntriggered     = sdk.OrderStatusUntriggered
+	OrderStatusTriggered       = sdk.OrderStatusTriggered
+	OrderStatusCancelled       = sdk.OrderStatusCancelled
+	OrderStatusRejected        = sdk.OrderStatusRejected
+	OrderStatusExpired         = sdk.OrderStatusExpired
+
+	OrderStatusReasonUnknownMarket       = sdk.OrderStatusReasonUnknownMarket
+	OrderStatusReasonDisabledMarket      = sdk.OrderStatusReasonDisabledMarket
+	OrderStatusReasonNotEnoughFunds      = sdk.OrderStatusReasonNotEnoughFunds
+	OrderStatusReasonNoLiquidity         = sdk.OrderStatusReasonNoLiquidity
+	OrderStatusReasonInvalidFee          = sdk.OrderStatusReasonInvalidFee
+	OrderStatusReasonInvalidQty          = sdk.OrderStatusReasonInvalidQty
+	OrderStatusReasonInvalidPrice        = sdk.OrderStatusReasonInvalidPrice
+	OrderStatusReasonInvalidValue        = sdk.OrderStatusReasonInvalidValue
+	OrderStatusReasonSelfTradeProtection = sdk.OrderStatusReasonSelfTradeProtection
+	OrderStatusReasonPostOnlyFailed      = sdk.OrderStatusReasonPostOnlyFailed
+	OrderStatusReasonReduceOnlyFailed    = sdk.OrderStatusReasonReduceOnlyFailed
+	OrderStatusReasonInvalidExpireTime   = sdk.OrderStatusReasonInvalidExpireTime
+
+	PositionSideLong  = sdk.PositionSideLong
+	PositionSideShort = sdk.PositionSideShort
+
+	CandleInterval1Minute   = sdk.CandleInterval1Minute
+	CandleInterval5Minutes  = sdk.CandleInterval5Minutes
+	CandleInterval15Minutes = sdk.CandleInterval15Minutes
+	CandleInterval30Minutes = sdk.CandleInterval30Minutes
+	CandleInterval1Hour     = sdk.CandleInterval1Hour
+	CandleInterval2Hours    = sdk.CandleInterval2Hours
+	CandleInterval4Hours    = sdk.CandleInterval4Hours
+	CandleInterval1Day      = sdk.CandleInterval1Day
+
+	CandleTypeTrades      = sdk.CandleTypeTrades
+	CandleTypeMarkPrices  = sdk.CandleTypeMarkPrices
+	CandleTypeIndexPrices = sdk.CandleTypeIndexPrices
+
+	MarketStatusActive     = sdk.MarketStatusActive
+	MarketStatusReduceOnly = sdk.MarketStatusReduceOnly
+	MarketStatusDelisted   = sdk.MarketStatusDelisted
+	MarketStatusPrelisted  = sdk.MarketStatusPrelisted
+	MarketStatusDisabled   = sdk.MarketStatusDisabled
+
+	TradeTypeTrade       = sdk.TradeTypeTrade
+	TradeTypeLiquidation = sdk.TradeTypeLiquidation
+	TradeTypeDeleverage  = sdk.TradeTypeDeleverage
+
+	MarketChangeListed        = sdk.MarketChangeListed
+	MarketChangeDelisted      = sdk.MarketChangeDelisted
+	MarketChangeStatus        = sdk.MarketChangeStatus
+	MarketChangeTradingConfig = sdk.MarketChangeTradingConfig
+
+	AuthEventRejected = sdk.AuthEventRejected
+	AuthEventRestored = sdk.AuthEventRestored
+
+	AssetOperationDeposit    = sdk.AssetOperationDeposit
+	AssetOperationWithdrawal = sdk.AssetOperationWithdrawal
+	AssetOperationTransfer   = sdk.AssetOperationTransfer
+
+	AssetOperationStatusCreated      = sdk.AssetOperationStatusCreated
+	AssetOperationStatusInProgress   = sdk.AssetOperationStatusInProgress
+	AssetOperationStatusReadyToClaim = sdk.AssetOperationStatusReadyToClaim
+	AssetOperationStatusCompleted    = sdk.AssetOperationStatusCompleted
+	AssetOperationStatusRejected     = sdk.AssetOperationStatusRejected
+
+	TradingConfigMinOrderSize        = sdk.TradingConfigMinOrderSize
+	TradingConfigMinOrderSizeChange  = sdk.TradingConfigMinOrderSizeChange
+	TradingConfigMinPriceChange      = sdk.TradingConfigMinPriceChange
+	TradingConfigMaxMarketOrderValue = sdk.TradingConfigMaxMarketOrderValue
+	TradingConfigMaxLimitOrderValue  = sdk.TradingConfigMaxLimitOrderValue
+	TradingConfigMaxPositionValue    = sdk.TradingConfigMaxPositionValue
+	TradingConfigMaxLeverage         = sdk.TradingConfigMaxLeverage
+	TradingConfigMaxNumOrders        = sdk.TradingConfigMaxNumOrders
+	TradingConfigLimitPriceCap       = sdk.TradingConfigLimitPriceCap
+	TradingConfigLimitPriceFloor     = sdk.TradingConfigLimitPriceFloor
+
+	ServiceMarketData = sdk.ServiceMarketData
+	ServiceTrading    = sdk.ServiceTrading
+	ServiceAccount    = sdk.ServiceAccount
+
+	HealthHealthy  = sdk.HealthHealthy
+	HealthDegraded = sdk.HealthDegraded
+
+	DiagnosticOK      = sdk.DiagnosticOK
+	DiagnosticFailed  = sdk.DiagnosticFailed
+	DiagnosticSkipped = sdk.DiagnosticSkipped
+
+	IntentPlaceOrder              = sdk.IntentPlaceOrder
+	IntentCancelOrder             = sdk.IntentCancelOrder
+	IntentCancelOrderByExternalID = sdk.IntentCancelOrderByExternalID
+	IntentMassCancel              = sdk.IntentMassCancel
+	IntentApplied                 = sdk.IntentApplied
+	IntentNotApplied              = sdk.IntentNotApplied
+)
+
+// ErrInvalidEnumValue is wrapped by the Parse* helpers for unknown input
+var ErrInvalidEnumValue = sdk.ErrInvalidEnumValue
+
+// ParseOrderType converts user input to an order type, ignoring case
+func ParseOrderType(s string) (OrderType, error) {
+	return sdk.ParseOrderType(s)
+}
+
+// ParseOrderSide converts user input to an order side, ignoring case
+func ParseOrderSide(s string) (OrderSide, error) {
+	return sdk.ParseOrderSide(s)
+}
+
+// ParseTimeInForce converts user input to a time in force, ignoring case
+func ParseTimeInForce(s string) (TimeInForce, error) {
+	return sdk.ParseTimeInForce(s)
+}
+
+// ParseSelfTradeProtectionLevel converts user input to a self-trade protection level, ignoring case
+func ParseSelfTradeProtectionLevel(s string) (SelfTradeProtectionLevel, error) {
+	return sdk.ParseSelfTradeProtectionLevel(s)
+}
+
+// ParseTriggerPriceType converts user input to a trigger price type, ignoring case
+func ParseTriggerPriceType(s string) (TriggerPriceType, error) {
+	return sdk.ParseTriggerPriceType(s)
+}
+
+// ParseTriggerDirection converts user input to a trigger direction, ignoring case
+func ParseTriggerDirection(s string) (TriggerDirection, error) {
+	return sdk.ParseTriggerDirection(s)
+}
+
+// ParseExecutionPriceType converts user input to an execution price type, ignoring case
+func ParseExecutionPriceType(s string) (ExecutionPriceType, error) {
+	return sdk.ParseExecutionPriceType(s)
+}
+
+// ParseTpSlType converts user input to a TPSL type, ignoring case
+func ParseTpSlType(s string) (TpSlType, error) {
+	return sdk.ParseTpSlType(s)
+}
+
+// ParseOrderStatus converts user input to an order status, ignoring case
+func ParseOrderStatus(s string) (OrderStatus, error) {
+	return sdk.ParseOrderStatus(s)
+}
+
+// ParseOrderStatusReason converts user input to an order status reason, ignoring case
+func ParseOrderStatusReason(s string) (OrderStatusReason, error) {
+	return sdk.ParseOrderStatusReason(s)
+}
+
+// ParsePositionSide converts user input to a position side, ignoring case
+func ParsePositionSide(s string) (PositionSide, error) {
+	return sdk.ParsePositionSide(s)
+}
+
+// ParseCandleInterval converts user input to a candle interval, ignoring case
+func ParseCandleInterval(s string) (CandleInterval, error) {
+	return sdk.ParseCandleInterval(s)
+}
+
+// ParseCandleType converts user input to a candle type, ignoring case
+func ParseCandleType(s string) (CandleType, error) {
+	return sdk.ParseCandleType(s)
+}
+
+// ParseTradeType converts user input to a trade type, ignoring case
+func ParseTradeType(s string) (TradeType, error) {
+	return sdk.ParseTradeType(s)
+}
+
+// ParseMarketStatus converts user input to a market status, ignoring case
+func ParseMarketStatus(s string) (MarketStatus, error) {
+	return sdk.ParseMarketStatus(s)
+}
+
+// ParseAssetOperationType converts user input to an asset operation type, ignoring case
+func ParseAssetOperationType(s string) (AssetOperationType, error) {
+	return sdk.ParseAssetOperationType(s)
+}
+
+// ParseAssetOperationStatus converts user input to an asset operation status, ignoring case
+func ParseAssetOperationStatus(s string) (AssetOperationStatus, error) {
+	return sdk.ParseAssetOperationStatus(s)
+}
+
+// IntervalFromDuration returns the candle interval of exactly the given width
+func IntervalFromDuration(d time.Duration) (CandleInterval, error) {
+	return sdk.IntervalFromDuration(d)
+}
+
+// FloorToInterval returns the open time of the candle containing t
+func FloorToInterval(t time.Time, interval CandleInterval) time.Time {
+	return sdk.FloorToInterval(t, interval)
+}
+
+// CeilToInterval returns the open time of the first candle starting at or after t
+func CeilToInterval(t time.Time, interval CandleInterval) time.Time {
+	return sdk.CeilToInterval(t, interval)
+}
+
+// DiffMarkets compares two market listings and returns the changes by market name
+func DiffMarkets(previous, current []MarketModel) []MarketChangeEvent {
+	return sdk.DiffMarkets(previous, current)
+}
+
+// DiffTradingConfig returns the trading parameters that differ between two configs of a market
+func DiffTradingConfig(market string, previous, current TradingConfigModel) []TradingConfigChange {
+	return sdk.DiffTradingConfig(market, previous, current)
+}
+
+// ComputeExposure nets positions per asset into gross and net exposure, leverage and concentration
+func ComputeExposure(positions []PositionModel, markets []MarketModel, equity decimal.Decimal) ExposureReport {
+	return sdk.ComputeExposure(positions, markets, equity)
+}
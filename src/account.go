@@ -0,0 +1,48 @@
+package sdk
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// AccountUpdateModel is a message of the private account stream. An update
+// carries the orders, positions and fills that changed, or the balance after
+// a change; a snapshot carries all open orders and positions and the
+// balance. Fields the message does not concern are empty.
+type AccountUpdateModel struct {
+	Orders    []OpenOrderModel    `json:"orders,omitempty"`
+	Positions []PositionModel     `json:"positions,omitempty"`
+	Trades    []AccountTradeModel `json:"trades,omitempty"`
+	Balance   *BalanceModel       `json:"balance,omitempty"`
+}
+
+// Fee returns the fee charged for withdrawing amount: the flat fee plus the
+// proportional rate of the tier the amount falls into
+func (l WithdrawalLimitsModel) Fee(amount decimal.Decimal) decimal.Decimal {
+	var tier *WithdrawalFeeTierModel
+	for i := range l.Fees {
+		if l.Fees[i].MinAmount.LessThanOrEqual(amount) && (tier == nil || l.Fees[i].MinAmount.GreaterThan(tier.MinAmount)) {
+			tier = &l.Fees[i]
+		}
+	}
+	if tier == nil {
+		return decimal.Zero
+	}
+	return tier.Fee.Add(amount.Mul(tier.FeeRate))
+}
+
+// Validate checks amount against the chain limits. A zero MaxAmount means the
+// chain has no upper bound.
+func (l WithdrawalLimitsModel) Validate(amount decimal.Decimal) error {
+	if !amount.IsPositive() {
+		return fmt.Errorf("%w: amount must be positive", ErrInvalidWithdrawalAmount)
+	}
+	if amount.LessThan(l.MinAmount) {
+		return fmt.Errorf("%w: %s is below the minimum of %s on %s", ErrInvalidWithdrawalAmount, amount, l.MinAmount, l.Chain)
+	}
+	if l.MaxAmount.IsPositive() && amount.GreaterThan(l.MaxAmount) {
+		return fmt.Errorf("%w: %s is above the maximum of %s on %s", ErrInvalidWithdrawalAmount, amount, l.MaxAmount, l.Chain)
+	}
+	return nil
+}
@@ -0,0 +1,266 @@
+package sdk
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrAddressNotListed is returned for a withdrawal to an address missing from
+// the address book of the client
+var ErrAddressNotListed = errors.New("withdrawal address is not in the address book")
+
+// ErrWrongPassphrase is returned when an address book cannot be decrypted,
+// because the passphrase is wrong or the file was modified
+var ErrWrongPassphrase = errors.New("wrong address book passphrase")
+
+const (
+	addressBookVersion = 1
+	// addressBookIterations is the PBKDF2-SHA256 work factor for new books
+	addressBookIterations = 600_000
+	addressBookSaltSize   = 16
+)
+
+// addressBookAAD binds the ciphertext to the file format
+var addressBookAAD = []byte("extended-sdk-golang address book v1")
+
+// AddressBookEntry is a withdrawal address of a chain
+type AddressBookEntry struct {
+	Chain   string    `json:"chain"`
+	Address string    `json:"address"`
+	Label   string    `json:"label,omitempty"`
+	AddedAt time.Time `json:"addedAt"`
+}
+
+// addressBookFile is the encrypted file of an AddressBook
+type addressBookFile struct {
+	Version    int    `json:"version"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// AddressBook is the list of addresses withdrawals may go to, kept in a local
+// file encrypted with AES-256-GCM under a key derived from a passphrase. The
+// entries are decrypted once when the book is opened. A client given one
+// with WithAddressBook refuses withdrawals to addresses not in it. It is
+// safe for concurrent use.
+type AddressBook struct {
+	mu         sync.Mutex
+	path       string
+	key        []byte
+	salt       []byte
+	iterations int
+	entries    map[string]AddressBookEntry
+	clock      Clock
+}
+
+// OpenAddressBook decrypts the address book at path with passphrase, or
+// creates an empty one encrypted with it if the file does not exist. A
+// passphrase that does not decrypt the file returns ErrWrongPassphrase.
+func OpenAddressBook(path, passphrase string) (*AddressBook, error) {
+	if passphrase == "" {
+		return nil, errors.New("address book passphrase must not be empty")
+	}
+	b := &AddressBook{path: path, entries: make(map[string]AddressBookEntry), clock: SystemClock}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		b.salt = make([]byte, addressBookSaltSize)
+		if _, err := rand.Read(b.salt); err != nil {
+			return nil, fmt.Errorf("failed to generate salt: %w", err)
+		}
+		b.iterations = addressBookIterations
+		if b.key, err = pbkdf2.Key(sha256.New, passphrase, b.salt, b.iterations, 32); err != nil {
+			return nil, fmt.Errorf("failed to derive key: %w", err)
+		}
+		return b, b.write()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read address book: %w", err)
+	}
+
+	var file addressBookFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid address book file %s: %w", path, err)
+	}
+	if file.Version != addressBookVersion {
+		return nil, fmt.Errorf("unsupported address book version %d", file.Version)
+	}
+	b.salt, b.iterations = file.Salt, file.Iterations
+	if b.key, err = pbkdf2.Key(sha256.New, passphrase, b.salt, b.iterations, 32); err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	gcm, err := b.cipher()
+	if err != nil {
+		return nil, err
+	}
+	if len(file.Nonce) != gcm.NonceSize() {
+		return nil, fmt.Errorf("invalid address book file %s: nonce of %d bytes", path, len(file.Nonce))
+	}
+	plaintext, err := gcm.Open(nil, file.Nonce, file.Ciphertext, addressBookAAD)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	var entries []AddressBookEntry
+	if err := json.Unmarshal(plaintext, &entries); err != nil {
+		return nil, fmt.Errorf("invalid address book entries: %w", err)
+	}
+	for _, e := range entries {
+		b.entries[addressKey(e.Chain, e.Address)] = e
+	}
+	return b, nil
+}
+
+// WithAddressBook makes Withdraw, and ValidateWithdrawal, refuse addresses
+// not in book with ErrAddressNotListed. Entries added afterwards are stamped
+// by the client clock.
+func WithAddressBook(book *AddressBook) ClientOption {
+	return func(m *BaseModule) {
+		m.addressBook = book
+	}
+}
+
+// setClock makes the book stamp new entries with clock
+func (b *AddressBook) setClock(clock Clock) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.clock = clock
+}
+
+// Add lists an address of a chain, replacing the label of a listed one
+func (b *AddressBook) Add(chain, address, label string) error {
+	if chain == "" || address == "" {
+		return errors.New("chain and address must not be empty")
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.entries[addressKey(chain, address)] = AddressBookEntry{
+		Chain:   chain,
+		Address: address,
+		Label:   label,
+		AddedAt: b.clock.Now().UTC(),
+	}
+	return b.write()
+}
+
+// Remove unlists an address; unknown addresses are ignored
+func (b *AddressBook) Remove(chain, address string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	key := addressKey(chain, address)
+	if _, ok := b.entries[key]; !ok {
+		return nil
+	}
+	delete(b.entries, key)
+	return b.write()
+}
+
+// Entries returns the listed addresses, ordered by chain and address
+func (b *AddressBook) Entries() []AddressBookEntry {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.sorted()
+}
+
+// Check returns ErrAddressNotListed unless the address is listed for chain.
+// Addresses are compared ignoring case, as hex addresses are
+// case-insensitive.
+func (b *AddressBook) Check(chain, address string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.entries[addressKey(chain, address)]; !ok {
+		return fmt.Errorf("%w: %s on %s", ErrAddressNotListed, address, chain)
+	}
+	return nil
+}
+
+func (b *AddressBook) cipher() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(b.key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+func (b *AddressBook) sorted() []AddressBookEntry {
+	entries := make([]AddressBookEntry, 0, len(b.entries))
+	for _, e := range b.entries {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Chain != entries[j].Chain {
+			return entries[i].Chain < entries[j].Chain
+		}
+		return entries[i].Address < entries[j].Address
+	})
+	return entries
+}
+
+// write encrypts the entries under a fresh nonce and replaces the file
+func (b *AddressBook) write() error {
+	plaintext, err := json.Marshal(b.sorted())
+	if err != nil {
+		return fmt.Errorf("failed to encode address book: %w", err)
+	}
+	gcm, err := b.cipher()
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	data, err := json.Marshal(addressBookFile{
+		Version:    addressBookVersion,
+		Iterations: b.iterations,
+		Salt:       b.salt,
+		Nonce:      nonce,
+		Ciphertext: gcm.Seal(nil, nonce, plaintext, addressBookAAD),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode address book: %w", err)
+	}
+	if err := writeFileAtomic(b.path, data); err != nil {
+		return fmt.Errorf("failed to write address book: %w", err)
+	}
+	return nil
+}
+
+func addressKey(chain, address string) string {
+	return strings.ToUpper(chain) + "/" + strings.ToLower(address)
+}
+
+// ValidateWithdrawal checks a withdrawal of amount to address on chain before
+// it is signed; Withdraw checks it again before sending it. The address must
+// be in the address book of the client, if it has one, and the amount within
+// the limits of the chain. It returns the fee the withdrawal will be charged.
+func (c *APIClient) ValidateWithdrawal(ctx context.Context, chain, address string, amount decimal.Decimal) (decimal.Decimal, error) {
+	if c.addressBook != nil {
+		if err := c.addressBook.Check(chain, address); err != nil {
+			return decimal.Zero, err
+		}
+	}
+	limits, err := c.GetWithdrawalLimits(ctx, chain)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("failed to get withdrawal limits: %w", err)
+	}
+	if err := limits.Validate(amount); err != nil {
+		return decimal.Zero, err
+	}
+	return limits.Fee(amount), nil
+}
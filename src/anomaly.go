@@ -0,0 +1,391 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/extended-protocol/extended-sdk-golang/src/internal/fanout"
+	"github.com/shopspring/decimal"
+)
+
+// AnomalyKind classifies account activity the client did not initiate
+type AnomalyKind string
+
+const (
+	// AnomalyUnknownOrder is an order update for an external ID the client
+	// did not place
+	AnomalyUnknownOrder AnomalyKind = "UNKNOWN_ORDER"
+	// AnomalyUnknownFill is a fill of an order the client did not place
+	AnomalyUnknownFill AnomalyKind = "UNKNOWN_FILL"
+	// AnomalyLeverageChange is a leverage setting the client did not make
+	AnomalyLeverageChange AnomalyKind = "LEVERAGE_CHANGE"
+	// AnomalyWithdrawal is a withdrawal or an outgoing transfer the client
+	// did not submit
+	AnomalyWithdrawal AnomalyKind = "WITHDRAWAL"
+)
+
+// Anomaly is account activity that may mean someone else holds the API or
+// Stark key. Time is when it happened on the exchange.
+type Anomaly struct {
+	Kind       AnomalyKind
+	Time       time.Time
+	Market     string
+	OrderID    int64
+	ExternalID string
+	Detail     string
+}
+
+// AnomalyMonitor detects account activity not initiated through the clients
+// it is attached to with WithAnomalyMonitor, and reports it to a security
+// callback, e.g. one that alerts, cancels all orders and closes the client.
+// Activity is pushed in as it arrives, e.g. from an account stream, or
+// fetched by Sync. Activity older than the monitor is ignored, as are orders
+// placed before it was attached unless they are passed to ExpectOrder.
+type AnomalyMonitor struct {
+	onAnomaly func(Anomaly)
+	clock     Clock
+	started   time.Time
+
+	mu          sync.Mutex
+	externalIDs map[string]bool
+	orderIDs    map[int64]bool
+	leverage    map[string]decimal.Decimal
+	// pendingLeverage holds the leverage changes sent but not confirmed, per
+	// market; the exchange may report either the old or the new value
+	pendingLeverage map[string][]decimal.Decimal
+	// inFlight counts the orders sent whose exchange IDs are not known yet.
+	// Fills of unknown orders are held while it is positive, since they may
+	// be immediate fills of those orders.
+	inFlight int
+	held     []AccountTradeModel
+	// opsInFlight counts the withdrawals and transfers sent whose asset
+	// operation IDs are not known yet; outgoing operations are held while it
+	// is positive
+	opsInFlight int
+	heldOps     []AssetOperationModel
+	ownOps      map[string]bool
+	// reported holds the unknown orders already reported, so that every
+	// update of one order raises a single anomaly
+	reported   map[int64]bool
+	seenTrades map[int64]bool
+	seenOps    map[string]bool
+}
+
+// NewAnomalyMonitor creates a monitor calling onAnomaly for every anomaly.
+// onAnomaly is called synchronously from the goroutine recording the
+// activity. The monitor starts at the current time of clock; nil uses
+// SystemClock.
+func NewAnomalyMonitor(onAnomaly func(Anomaly), clock Clock) *AnomalyMonitor {
+	clock = clockOrDefault(clock)
+	return &AnomalyMonitor{
+		onAnomaly:       onAnomaly,
+		clock:           clock,
+		started:         clock.Now(),
+		externalIDs:     make(map[string]bool),
+		orderIDs:        make(map[int64]bool),
+		leverage:        make(map[string]decimal.Decimal),
+		pendingLeverage: make(map[string][]decimal.Decimal),
+		reported:        make(map[int64]bool),
+		seenTrades:      make(map[int64]bool),
+		seenOps:         make(map[string]bool),
+		ownOps:          make(map[string]bool),
+	}
+}
+
+// WithAnomalyMonitor registers the orders placed and leverage set by the
+// client with monitor, so it can tell them from activity of others. Clients
+// of one account, e.g. of a pool, may share a monitor.
+func WithAnomalyMonitor(monitor *AnomalyMonitor) ClientOption {
+	return func(m *BaseModule) {
+		m.anomalyMonitor = monitor
+	}
+}
+
+// ExpectOrder marks an order as placed by a trusted party, e.g. by this
+// process before a restart
+func (a *AnomalyMonitor) ExpectOrder(externalID string) {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.externalIDs[externalID] = true
+}
+
+// expectSubmission registers an order about to be sent. The returned func
+// completes the submission with the ID the exchange assigned to the order,
+// or 0 if it is unknown, and releases the fills held meanwhile.
+func (a *AnomalyMonitor) expectSubmission(externalID string) func(orderID int64) {
+	if a == nil {
+		return func(int64) {}
+	}
+	a.mu.Lock()
+	a.externalIDs[externalID] = true
+	a.inFlight++
+	a.mu.Unlock()
+
+	return func(orderID int64) {
+		a.mu.Lock()
+		a.inFlight--
+		if orderID != 0 {
+			a.orderIDs[orderID] = true
+		}
+		var unknown []AccountTradeModel
+		held := a.held[:0]
+		for _, trade := range a.held {
+			switch {
+			case a.orderIDs[trade.OrderID]:
+			case a.inFlight > 0:
+				held = append(held, trade)
+			default:
+				unknown = append(unknown, trade)
+			}
+		}
+		a.held = held
+		a.mu.Unlock()
+
+		for _, trade := range unknown {
+			a.reportFill(trade)
+		}
+	}
+}
+
+// expectOperation registers a withdrawal or transfer about to be sent. The
+// returned func completes it with the ID of the asset operation created, or
+// "" if it is unknown, and releases the operations held meanwhile.
+func (a *AnomalyMonitor) expectOperation() func(id string) {
+	if a == nil {
+		return func(string) {}
+	}
+	a.mu.Lock()
+	a.opsInFlight++
+	a.mu.Unlock()
+
+	return func(id string) {
+		a.mu.Lock()
+		a.opsInFlight--
+		if id != "" {
+			a.ownOps[id] = true
+		}
+		var unknown []AssetOperationModel
+		held := a.heldOps[:0]
+		for _, op := range a.heldOps {
+			switch {
+			case a.ownOps[op.ID]:
+			case a.opsInFlight > 0:
+				held = append(held, op)
+			default:
+				unknown = append(unknown, op)
+			}
+		}
+		a.heldOps = held
+		a.mu.Unlock()
+
+		for _, op := range unknown {
+			a.reportOperation(op)
+		}
+	}
+}
+
+// expectLeverage registers a leverage change about to be sent. The returned
+// func completes it with the outcome of the request: the change is confirmed
+// on success and withdrawn when the exchange refused it. After other
+// failures it stays expected, since it may have been applied.
+func (a *AnomalyMonitor) expectLeverage(market string, leverage decimal.Decimal) func(err error) {
+	if a == nil {
+		return func(error) {}
+	}
+	a.mu.Lock()
+	a.pendingLeverage[market] = append(a.pendingLeverage[market], leverage)
+	a.mu.Unlock()
+
+	return func(err error) {
+		if err != nil && !answered(err) {
+			return
+		}
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		pending := a.pendingLeverage[market]
+		if i := slices.IndexFunc(pending, leverage.Equal); i >= 0 {
+			pending = slices.Delete(pending, i, i+1)
+		}
+		if len(pending) == 0 {
+			delete(a.pendingLeverage, market)
+		} else {
+			a.pendingLeverage[market] = pending
+		}
+		if err == nil {
+			a.leverage[market] = leverage
+		}
+	}
+}
+
+// RecordOrder checks an order update
+func (a *AnomalyMonitor) RecordOrder(order OpenOrderModel) {
+	a.mu.Lock()
+	if a.externalIDs[order.ExternalID] {
+		a.orderIDs[order.ID] = true
+	}
+	if a.orderIDs[order.ID] || a.reported[order.ID] || order.UpdatedTime < a.started.UnixMilli() {
+		a.mu.Unlock()
+		return
+	}
+	a.reported[order.ID] = true
+	a.mu.Unlock()
+
+	a.onAnomaly(Anomaly{
+		Kind:       AnomalyUnknownOrder,
+		Time:       time.UnixMilli(order.UpdatedTime).UTC(),
+		Market:     order.Market,
+		OrderID:    order.ID,
+		ExternalID: order.ExternalID,
+		Detail:     fmt.Sprintf("%s %s %s at %s, status %s", order.Side, order.Qty, order.Market, order.Price, order.Status),
+	})
+}
+
+// RecordTrade checks a fill. A fill belongs to the client if its order was
+// placed through it or was recorded with RecordOrder, so order updates
+// should be recorded before the fills of the same order. Fills of unknown
+// orders are held until the orders being sent through the client are
+// acknowledged.
+func (a *AnomalyMonitor) RecordTrade(trade AccountTradeModel) {
+	a.mu.Lock()
+	if a.seenTrades[trade.ID] {
+		a.mu.Unlock()
+		return
+	}
+	a.seenTrades[trade.ID] = true
+	if a.orderIDs[trade.OrderID] || trade.CreatedTime < a.started.UnixMilli() {
+		a.mu.Unlock()
+		return
+	}
+	if a.inFlight > 0 {
+		a.held = append(a.held, trade)
+		a.mu.Unlock()
+		return
+	}
+	a.mu.Unlock()
+	a.reportFill(trade)
+}
+
+func (a *AnomalyMonitor) reportFill(trade AccountTradeModel) {
+	a.onAnomaly(Anomaly{
+		Kind:    AnomalyUnknownFill,
+		Time:    time.UnixMilli(trade.CreatedTime).UTC(),
+		Market:  trade.Market,
+		OrderID: trade.OrderID,
+		Detail:  fmt.Sprintf("%s %s %s at %s, trade %d", trade.Side, trade.Qty, trade.Market, trade.Price, trade.ID),
+	})
+}
+
+// RecordLeverage checks a leverage setting. The first setting seen for a
+// market is taken as its baseline. While a change sent through the client
+// is pending, both the previous and the requested value are expected.
+func (a *AnomalyMonitor) RecordLeverage(setting AccountLeverageModel) {
+	a.mu.Lock()
+	previous, known := a.leverage[setting.Market]
+	a.leverage[setting.Market] = setting.Leverage
+	expected := slices.ContainsFunc(a.pendingLeverage[setting.Market], setting.Leverage.Equal)
+	a.mu.Unlock()
+	if !known || expected || previous.Equal(setting.Leverage) {
+		return
+	}
+
+	a.onAnomaly(Anomaly{
+		Kind:   AnomalyLeverageChange,
+		Time:   a.clock.Now().UTC(),
+		Market: setting.Market,
+		Detail: fmt.Sprintf("leverage changed from %s to %s", previous, setting.Leverage),
+	})
+}
+
+// RecordAssetOperation checks a deposit, withdrawal or transfer. Withdrawals
+// and outgoing transfers are reported unless they were submitted through the
+// client; they are held while one is being submitted.
+func (a *AnomalyMonitor) RecordAssetOperation(op AssetOperationModel) {
+	outgoing := op.Type == AssetOperationWithdrawal ||
+		(op.Type == AssetOperationTransfer && op.Amount.IsNegative())
+	a.mu.Lock()
+	if a.seenOps[op.ID] {
+		a.mu.Unlock()
+		return
+	}
+	a.seenOps[op.ID] = true
+	if !outgoing || a.ownOps[op.ID] || op.Time < a.started.UnixMilli() {
+		a.mu.Unlock()
+		return
+	}
+	if a.opsInFlight > 0 {
+		a.heldOps = append(a.heldOps, op)
+		a.mu.Unlock()
+		return
+	}
+	a.mu.Unlock()
+	a.reportOperation(op)
+}
+
+func (a *AnomalyMonitor) reportOperation(op AssetOperationModel) {
+	detail := fmt.Sprintf("%s of %s, status %s", op.Type, op.Amount.Abs(), op.Status)
+	if op.CounterpartyAccountID != 0 {
+		detail += fmt.Sprintf(", to account %d", op.CounterpartyAccountID)
+	}
+	a.onAnomaly(Anomaly{
+		Kind:   AnomalyWithdrawal,
+		Time:   time.UnixMilli(op.Time).UTC(),
+		Detail: detail,
+	})
+}
+
+// Sync fetches the fills, leverage settings and asset operations of the
+// account through client and checks them
+func (a *AnomalyMonitor) Sync(ctx context.Context, client *APIClient) error {
+	var (
+		trades   []AccountTradeModel
+		leverage []AccountLeverageModel
+		ops      []AssetOperationModel
+	)
+	err := fanout.Run(ctx, 3, 3, func(ctx context.Context, i int) error {
+		var err error
+		switch i {
+		case 0:
+			trades, err = client.tradesSince(ctx, a.started, func(id int64) bool {
+				a.mu.Lock()
+				defer a.mu.Unlock()
+				return a.seenTrades[id]
+			})
+			if err != nil {
+				return fmt.Errorf("failed to sync trades: %w", err)
+			}
+		case 1:
+			if leverage, err = client.GetLeverage(ctx, nil); err != nil {
+				return fmt.Errorf("failed to get leverage: %w", err)
+			}
+		case 2:
+			ops, err = client.GetAssetOperations(ctx, AssetOperationsFilter{
+				Types: []AssetOperationType{AssetOperationWithdrawal, AssetOperationTransfer},
+				Since: a.started,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to get asset operations: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, trade := range trades {
+		a.RecordTrade(trade)
+	}
+	for _, setting := range leverage {
+		a.RecordLeverage(setting)
+	}
+	for _, op := range ops {
+		a.RecordAssetOperation(op)
+	}
+	return nil
+}
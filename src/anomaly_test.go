@@ -0,0 +1,56 @@
+package sdk
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnomalyMonitor_ExpectsActivityInFlight(t *testing.T) {
+	var anomalies []Anomaly
+	monitor := NewAnomalyMonitor(func(a Anomaly) { anomalies = append(anomalies, a) }, nil)
+	now := time.Now().UnixMilli() + 1
+
+	// A fill streamed before the order is acknowledged
+	submitted := monitor.expectSubmission("own")
+	monitor.RecordTrade(AccountTradeModel{ID: 1, OrderID: 10, CreatedTime: now})
+	monitor.RecordTrade(AccountTradeModel{ID: 2, OrderID: 20, CreatedTime: now})
+	assert.Empty(t, anomalies)
+	submitted(10)
+	if assert.Len(t, anomalies, 1) {
+		assert.Equal(t, AnomalyUnknownFill, anomalies[0].Kind)
+		assert.Equal(t, int64(20), anomalies[0].OrderID)
+	}
+
+	// A leverage sync racing the response may see either value
+	anomalies = nil
+	monitor.RecordLeverage(AccountLeverageModel{Market: "BTC-USD", Leverage: decimal.NewFromInt(10)})
+	updated := monitor.expectLeverage("BTC-USD", decimal.NewFromInt(5))
+	monitor.RecordLeverage(AccountLeverageModel{Market: "BTC-USD", Leverage: decimal.NewFromInt(10)})
+	monitor.RecordLeverage(AccountLeverageModel{Market: "BTC-USD", Leverage: decimal.NewFromInt(5)})
+	updated(nil)
+	assert.Empty(t, anomalies)
+
+	// A refused change is withdrawn
+	updated = monitor.expectLeverage("BTC-USD", decimal.NewFromInt(50))
+	updated(&APIError{StatusCode: http.StatusBadRequest})
+	monitor.RecordLeverage(AccountLeverageModel{Market: "BTC-USD", Leverage: decimal.NewFromInt(50)})
+	if assert.Len(t, anomalies, 1) {
+		assert.Equal(t, AnomalyLeverageChange, anomalies[0].Kind)
+	}
+
+	// A withdrawal streamed before it is acknowledged
+	anomalies = nil
+	withdrawn := monitor.expectOperation()
+	monitor.RecordAssetOperation(AssetOperationModel{ID: "own", Type: AssetOperationWithdrawal, Amount: decimal.NewFromInt(10), Time: now})
+	monitor.RecordAssetOperation(AssetOperationModel{ID: "other", Type: AssetOperationWithdrawal, Amount: decimal.NewFromInt(20), Time: now})
+	assert.Empty(t, anomalies)
+	withdrawn("own")
+	if assert.Len(t, anomalies, 1) {
+		assert.Equal(t, AnomalyWithdrawal, anomalies[0].Kind)
+		assert.Contains(t, anomalies[0].Detail, "WITHDRAWAL of 20")
+	}
+}
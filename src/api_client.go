@@ -3,15 +3,28 @@ package sdk
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
+	"io"
+	"math/rand/v2"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/extended-protocol/extended-sdk-golang/src/internal/fanout"
 )
 
 // APIClient provides REST API functionality for perpetual trading
 // It embeds BaseModule to reuse common functionality like HTTP client, auth, etc.
+//
+// An APIClient is safe for concurrent use by multiple goroutines. Options are
+// applied once by NewAPIClient and never change afterwards; the caches,
+// nonce generator, stats and health it keeps are synchronized internally.
+// Close may be called concurrently with requests and more than once.
 type APIClient struct {
 	*BaseModule
+	orderQueue orderRouter
+	fees       feeCache
+	markets    marketCache
 }
 
 // NewAPIClient creates a new API client instance
@@ -20,11 +33,43 @@ func NewAPIClient(
 	apiKey string,
 	starkAccount *StarkPerpetualAccount,
 	clientTimeout time.Duration,
+	opts ...ClientOption,
 ) *APIClient {
-	baseModule := NewBaseModule(cfg, apiKey, starkAccount, nil, clientTimeout)
-	return &APIClient{
+	baseModule := NewBaseModule(cfg, apiKey, starkAccount, nil, clientTimeout, opts...)
+	client := &APIClient{
 		BaseModule: baseModule,
 	}
+	if baseModule.orderQueueConfig != nil {
+		client.orderQueue = NewOrderQueue(client, *baseModule.orderQueueConfig)
+	}
+	if baseModule.preloadMarkets {
+		client.preloadMarkets()
+	}
+	return client
+}
+
+// DefaultClientTimeout is the request timeout of clients built by
+// NewPublicClient, unless changed with WithRequestTimeout
+const DefaultClientTimeout = 30 * time.Second
+
+// NewPublicClient creates a client for the public market data endpoints, which
+// need neither an API key nor a Stark account. Requests to private endpoints
+// fail with ErrAPIKeyNotSet without being sent.
+func NewPublicClient(cfg EndpointConfig, opts ...ClientOption) *APIClient {
+	publicOnly := func(m *BaseModule) { m.publicOnly = true }
+	return NewAPIClient(cfg, "", nil, DefaultClientTimeout, append([]ClientOption{publicOnly}, opts...)...)
+}
+
+// Close stops the order queue, if any, cancels a market preload in flight
+// and releases idle connections
+func (c *APIClient) Close() {
+	if c.orderQueue != nil {
+		c.orderQueue.Close()
+	}
+	if c.markets.cancel != nil {
+		c.markets.cancel()
+	}
+	c.BaseModule.Close()
 }
 
 // ===== Market Data Operations =====
@@ -35,10 +80,16 @@ type MarketResponse struct {
 	Status string        `json:"status"`
 }
 
-// GetMarkets retrieves all available markets from the API
-func (c *APIClient) GetMarkets(ctx context.Context, market []string) ([]MarketModel, error) {
+// GetMarkets retrieves all available markets from the API, or only the named
+// ones. Requesting a market that is not listed returns ErrUnknownMarket.
+func (c *APIClient) GetMarkets(ctx context.Context, market []string, opts ...MarketsOption) ([]MarketModel, error) {
+	var options marketsOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	// Build the URL manually to handle multiple market parameters correctly
-	baseURL := c.BaseModule.EndpointConfig().APIBaseURL + "/info/markets"
+	baseURL := c.BaseModule.EndpointConfig().APIBaseURL + getMarketsPath
 
 	if len(market) > 0 {
 		baseURL += "?market=" + market[0]
@@ -50,6 +101,9 @@ func (c *APIClient) GetMarkets(ctx context.Context, market []string) ([]MarketMo
 	// Use the new DoRequest method to handle the HTTP request and JSON parsing
 	var marketResponse MarketResponse
 	if err := c.BaseModule.DoRequest(ctx, "GET", baseURL, nil, &marketResponse); err != nil {
+		if len(market) > 0 && isUnknownMarket(err) {
+			return nil, fmt.Errorf("%w: %s: %w", ErrUnknownMarket, strings.Join(market, ", "), err)
+		}
 		return nil, err
 	}
 
@@ -58,7 +112,97 @@ func (c *APIClient) GetMarkets(ctx context.Context, market []string) ([]MarketMo
 		return nil, fmt.Errorf("API returned error status: %s", marketResponse.Status)
 	}
 
-	return marketResponse.Data, nil
+	// The API may silently drop names it does not know
+	if missing := missingMarkets(market, marketResponse.Data); len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownMarket, strings.Join(missing, ", "))
+	}
+
+	if !options.excludeInactive {
+		return marketResponse.Data, nil
+	}
+	active := make([]MarketModel, 0, len(marketResponse.Data))
+	for _, m := range marketResponse.Data {
+		if m.Active {
+			active = append(active, m)
+		}
+	}
+	return active, nil
+}
+
+func missingMarkets(requested []string, markets []MarketModel) []string {
+	listed := make(map[string]bool, len(markets))
+	for _, m := range markets {
+		listed[m.Name] = true
+	}
+	var missing []string
+	for _, name := range requested {
+		if !listed[name] {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// OrderbookResponse represents the API response for an orderbook snapshot
+type OrderbookResponse struct {
+	Data   OrderbookUpdateModel `json:"data"`
+	Status string               `json:"status"`
+}
+
+// GetOrderbookSnapshot retrieves the current orderbook of a market.
+// Use WithDepth to only return the best levels on each side.
+func (c *APIClient) GetOrderbookSnapshot(ctx context.Context, market string, opts ...OrderbookOption) (*OrderbookUpdateModel, error) {
+	var options orderbookOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	var query map[string]string
+	if options.depth > 0 {
+		query = map[string]string{"depth": strconv.Itoa(options.depth)}
+	}
+	baseUrl, err := c.GetURL(getOrderbookPath(market), query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build URL: %w", err)
+	}
+
+	var orderbookResponse OrderbookResponse
+	if err := c.BaseModule.DoRequest(withRequestSubject(ctx, market, ""), "GET", baseUrl, nil, &orderbookResponse); err != nil {
+		return nil, err
+	}
+
+	if orderbookResponse.Status != "OK" {
+		return nil, fmt.Errorf("API returned error status: %v", orderbookResponse.Status)
+	}
+
+	// Enforce the depth locally as well in case the server returns the full book
+	orderbookResponse.Data.Truncate(options.depth)
+	return &orderbookResponse.Data, nil
+}
+
+// MarketStatsResponse represents the response from the market stats API
+type MarketStatsResponse struct {
+	Data   MarketStatsModel `json:"data"`
+	Status string           `json:"status"`
+}
+
+// GetMarketStats retrieves the 24h statistics of a single market
+func (c *APIClient) GetMarketStats(ctx context.Context, market string) (*MarketStatsModel, error) {
+	baseUrl, err := c.GetURL(getMarketStatsPath(market), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build URL: %w", err)
+	}
+
+	var statsResponse MarketStatsResponse
+	if err := c.BaseModule.DoRequest(withRequestSubject(ctx, market, ""), "GET", baseUrl, nil, &statsResponse); err != nil {
+		return nil, err
+	}
+
+	if statsResponse.Status != "OK" {
+		return nil, fmt.Errorf("API returned error status: %v", statsResponse.Status)
+	}
+
+	return &statsResponse.Data, nil
 }
 
 // ===== Fee Data Operations =====
@@ -69,16 +213,17 @@ type FeeResponse struct {
 	Status string            `json:"status"`
 }
 
-// GetMarketFee retrieves current trading fees for a specific market
+// GetMarketFee retrieves current trading fees for a specific market. The
+// fees are cached for PlaceOrder and CachedMarketFee, see WithManualFees.
 func (c *APIClient) GetMarketFee(ctx context.Context, market string) ([]TradingFeeModel, error) {
-	baseUrl, err := c.GetURL("/user/fees", map[string]string{"market": market})
+	baseUrl, err := c.GetURL(getFeesPath, map[string]string{"market": market})
 	if err != nil {
 		return nil, fmt.Errorf("failed to build URL: %w", err)
 	}
 
 	// Use the new DoRequest method to handle the HTTP request and JSON parsing
 	var feeResponse FeeResponse
-	if err := c.BaseModule.DoRequest(ctx, "GET", baseUrl, nil, &feeResponse); err != nil {
+	if err := c.BaseModule.DoRequest(withRequestSubject(ctx, market, ""), "GET", baseUrl, nil, &feeResponse); err != nil {
 		return nil, err
 	}
 
@@ -86,6 +231,9 @@ func (c *APIClient) GetMarketFee(ctx context.Context, market string) ([]TradingF
 		return nil, fmt.Errorf("API returned error status: %v", feeResponse.Status)
 	}
 
+	if !c.manualFees {
+		c.fees.store(feeResponse.Data...)
+	}
 	return feeResponse.Data, nil
 }
 
@@ -107,22 +255,32 @@ type OrderResponse struct {
 	}
 }
 
-// SubmitOrder submits a perpetual order to the trading API
+// SubmitOrder submits a signed perpetual order to the trading API. Orders are
+// typically built with CreateOrderObject, which signs them with the account key.
 func (c *APIClient) SubmitOrder(ctx context.Context, order *PerpetualOrderModel) (*OrderResponse, error) {
+	if c.orderQueue != nil {
+		return c.orderQueue.SubmitOrder(ctx, order)
+	}
+	return c.submitOrder(ctx, order)
+}
+
+func (c *APIClient) submitOrder(ctx context.Context, order *PerpetualOrderModel) (*OrderResponse, error) {
 	// Validate order object is complete and properly signed
 	if order == nil {
 		return nil, fmt.Errorf("order is nil")
 	}
 
-	baseUrl, err := c.GetURL("/user/order", nil)
+	baseUrl, err := c.GetURL(placeOrderPath, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build URL: %w", err)
 	}
 
-	// Marshal the order to JSON
-	orderJSON, err := json.Marshal(order)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal order to JSON: %w", err)
+	// Marshal the order to JSON, unless it was serialized ahead with Payload
+	orderJSON := order.payload
+	if orderJSON == nil {
+		if orderJSON, err = c.codec.Marshal(order); err != nil {
+			return nil, fmt.Errorf("failed to marshal order to JSON: %w", err)
+		}
 	}
 
 	// Create a buffer with the JSON data
@@ -130,7 +288,13 @@ func (c *APIClient) SubmitOrder(ctx context.Context, order *PerpetualOrderModel)
 
 	// Use the new DoRequest method to handle the HTTP request and JSON parsing
 	var orderResponse OrderResponse
-	if err := c.BaseModule.DoRequest(ctx, "POST", baseUrl, jsonData, &orderResponse); err != nil {
+	var orderID int64
+	submitted := c.anomalyMonitor.expectSubmission(order.ID)
+	defer func() { submitted(orderID) }()
+	intent := Intent{ID: intentID(IntentPlaceOrder, order.ID), Kind: IntentPlaceOrder, ExternalID: order.ID, Market: order.Market}
+	if err := c.journaled(intent, func() error {
+		return c.BaseModule.DoRequest(withRequestSubject(ctx, order.Market, order.ID), "POST", baseUrl, jsonData, &orderResponse)
+	}); err != nil {
 		return nil, err
 	}
 
@@ -142,5 +306,291 @@ func (c *APIClient) SubmitOrder(ctx context.Context, order *PerpetualOrderModel)
 		return nil, fmt.Errorf("mismatched order ID in response: got %s, expected %s", orderResponse.Data.ExternalID, order.ID)
 	}
 
+	c.stats.recordOrders(1, 0)
+	orderID = int64(orderResponse.Data.OrderID)
+
 	return &orderResponse, nil
 }
+
+// SubmitOrders submits a batch of orders with at most concurrency requests in flight.
+// Responses are returned in the order of the input. The first failure stops any
+// submissions that have not started yet and is returned as the error.
+func (c *APIClient) SubmitOrders(ctx context.Context, orders []*PerpetualOrderModel, concurrency int) ([]*OrderResponse, error) {
+	return fanout.Map(ctx, orders, concurrency, c.SubmitOrder)
+}
+
+// ===== Account Operations =====
+
+// PositionsResponse represents the API response for open positions
+type PositionsResponse struct {
+	Data   []PositionModel `json:"data"`
+	Status string          `json:"status"`
+}
+
+// PositionsFilter selects open positions. Zero fields are not filtered on.
+type PositionsFilter struct {
+	Markets []string     `query:"market"`
+	Side    PositionSide `query:"side"`
+}
+
+// GetPositions retrieves the open positions of the account, optionally filtered by market
+func (c *APIClient) GetPositions(ctx context.Context, market []string) ([]PositionModel, error) {
+	return c.GetPositionsFiltered(ctx, PositionsFilter{Markets: market})
+}
+
+// GetPositionsFiltered retrieves the open positions of the account matching filter
+func (c *APIClient) GetPositionsFiltered(ctx context.Context, filter PositionsFilter) ([]PositionModel, error) {
+	baseURL, err := c.getURLWithFilter(getPositionsPath, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build URL: %w", err)
+	}
+
+	var positionsResponse PositionsResponse
+	if err := c.BaseModule.DoRequest(ctx, "GET", baseURL, nil, &positionsResponse); err != nil {
+		return nil, err
+	}
+
+	if positionsResponse.Status != "OK" {
+		return nil, fmt.Errorf("API returned error status: %v", positionsResponse.Status)
+	}
+
+	return positionsResponse.Data, nil
+}
+
+// BalanceResponse represents the API response for the account balance
+type BalanceResponse struct {
+	Data   BalanceModel `json:"data"`
+	Status string       `json:"status"`
+}
+
+// GetBalance retrieves the collateral balance of the account
+func (c *APIClient) GetBalance(ctx context.Context) (*BalanceModel, error) {
+	baseUrl, err := c.GetURL(getBalancePath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build URL: %w", err)
+	}
+
+	var balanceResponse BalanceResponse
+	if err := c.BaseModule.DoRequest(ctx, "GET", baseUrl, nil, &balanceResponse); err != nil {
+		return nil, err
+	}
+
+	if balanceResponse.Status != "OK" {
+		return nil, fmt.Errorf("API returned error status: %v", balanceResponse.Status)
+	}
+
+	return &balanceResponse.Data, nil
+}
+
+// WithdrawalLimitsResponse represents the API response for withdrawal limits
+type WithdrawalLimitsResponse struct {
+	Data   WithdrawalLimitsModel `json:"data"`
+	Status string                `json:"status"`
+}
+
+// GetWithdrawalLimits retrieves the minimum and maximum withdrawal amounts and
+// the fee schedule of a chain, e.g. "STRK" or "ETH"
+func (c *APIClient) GetWithdrawalLimits(ctx context.Context, chain string) (*WithdrawalLimitsModel, error) {
+	baseUrl, err := c.GetURL(getWithdrawalLimitsPath, map[string]string{"chain": chain})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build URL: %w", err)
+	}
+
+	var limitsResponse WithdrawalLimitsResponse
+	if err := c.BaseModule.DoRequest(ctx, "GET", baseUrl, nil, &limitsResponse); err != nil {
+		return nil, err
+	}
+
+	if limitsResponse.Status != "OK" {
+		return nil, fmt.Errorf("API returned error status: %v", limitsResponse.Status)
+	}
+
+	return &limitsResponse.Data, nil
+}
+
+// OrdersResponse represents the API response for order queries
+type OrdersResponse struct {
+	Data   []OpenOrderModel `json:"data"`
+	Status string           `json:"status"`
+}
+
+// GetOrderByExternalID retrieves the orders created with the given external ID
+func (c *APIClient) GetOrderByExternalID(ctx context.Context, externalID string) ([]OpenOrderModel, error) {
+	baseUrl, err := c.GetURL(getOrdersByExternalIDPath(externalID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build URL: %w", err)
+	}
+
+	var ordersResponse OrdersResponse
+	if err := c.BaseModule.DoRequest(withRequestSubject(ctx, "", externalID), "GET", baseUrl, nil, &ordersResponse); err != nil {
+		return nil, err
+	}
+
+	if ordersResponse.Status != "OK" {
+		return nil, fmt.Errorf("API returned error status: %v", ordersResponse.Status)
+	}
+
+	return ordersResponse.Data, nil
+}
+
+// OpenOrdersFilter selects open orders. Zero fields are not filtered on.
+type OpenOrdersFilter struct {
+	Markets []string  `query:"market"`
+	Type    OrderType `query:"type"`
+	Side    OrderSide `query:"side"`
+}
+
+// GetOpenOrders retrieves the orders of the account resting on the book
+func (c *APIClient) GetOpenOrders(ctx context.Context, filter OpenOrdersFilter) ([]OpenOrderModel, error) {
+	baseURL, err := c.getURLWithFilter(getOpenOrdersPath, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build URL: %w", err)
+	}
+
+	var ordersResponse OrdersResponse
+	if err := c.BaseModule.DoRequest(ctx, "GET", baseURL, nil, &ordersResponse); err != nil {
+		return nil, err
+	}
+
+	if ordersResponse.Status != "OK" {
+		return nil, fmt.Errorf("API returned error status: %v", ordersResponse.Status)
+	}
+
+	return ordersResponse.Data, nil
+}
+
+// ===== Cancel Operations =====
+
+// CancelResponse represents the API response for cancel requests
+type CancelResponse struct {
+	Status string `json:"status"`
+}
+
+// CancelOrder cancels a single order by its exchange-assigned ID
+func (c *APIClient) CancelOrder(ctx context.Context, orderID int64) error {
+	if c.orderQueue != nil {
+		return c.orderQueue.CancelOrder(ctx, orderID)
+	}
+	return c.cancelOrder(ctx, orderID)
+}
+
+func (c *APIClient) cancelOrder(ctx context.Context, orderID int64) error {
+	baseUrl, err := c.GetURL(cancelOrderPath(orderID), nil)
+	if err != nil {
+		return fmt.Errorf("failed to build URL: %w", err)
+	}
+	intent := Intent{ID: intentID(IntentCancelOrder, strconv.FormatInt(orderID, 10)), Kind: IntentCancelOrder, OrderID: orderID}
+	return c.journaled(intent, func() error {
+		return c.doCancel(ctx, "DELETE", baseUrl, nil, 1)
+	})
+}
+
+// CancelOrderByExternalID cancels a single order by the external ID set at creation
+func (c *APIClient) CancelOrderByExternalID(ctx context.Context, externalID string) error {
+	if c.orderQueue != nil {
+		return c.orderQueue.CancelOrderByExternalID(ctx, externalID)
+	}
+	return c.cancelOrderByExternalID(ctx, externalID)
+}
+
+func (c *APIClient) cancelOrderByExternalID(ctx context.Context, externalID string) error {
+	baseUrl, err := c.GetURL(cancelOrderByExternalIDPath, map[string]string{"externalId": externalID})
+	if err != nil {
+		return fmt.Errorf("failed to build URL: %w", err)
+	}
+	intent := Intent{ID: intentID(IntentCancelOrderByExternalID, externalID), Kind: IntentCancelOrderByExternalID, ExternalID: externalID}
+	return c.journaled(intent, func() error {
+		return c.doCancel(withRequestSubject(ctx, "", externalID), "DELETE", baseUrl, nil, 1)
+	})
+}
+
+// MassCancelParams selects the orders cancelled by MassCancel
+type MassCancelParams struct {
+	OrderIDs         []int64  `json:"orderIds,omitempty"`
+	ExternalOrderIDs []string `json:"externalOrderIds,omitempty"`
+	Markets          []string `json:"markets,omitempty"`
+	CancelAll        bool     `json:"cancelAll,omitempty"`
+}
+
+// MassCancel cancels several orders in a single request. Cancelling by
+// market or all orders lists the open orders first, to count them in the
+// session stats.
+func (c *APIClient) MassCancel(ctx context.Context, params MassCancelParams) error {
+	if c.orderQueue != nil {
+		return c.orderQueue.Do(ctx, OrderPriorityRiskReducing, func(ctx context.Context) error {
+			return c.massCancel(ctx, params)
+		})
+	}
+	return c.massCancel(ctx, params)
+}
+
+func (c *APIClient) massCancel(ctx context.Context, params MassCancelParams) error {
+	baseUrl, err := c.GetURL(massCancelPath, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build URL: %w", err)
+	}
+
+	paramsJSON, err := c.codec.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("failed to marshal mass cancel params to JSON: %w", err)
+	}
+
+	cancelled := c.countMassCancel(ctx, params)
+	// The random suffix keeps intents apart when the clock stands still
+	id := strconv.FormatInt(c.Clock().Now().UnixNano(), 10) + "-" + strconv.FormatUint(rand.Uint64(), 36)
+	intent := Intent{ID: intentID(IntentMassCancel, id), Kind: IntentMassCancel, MassCancel: &params}
+	return c.journaled(intent, func() error {
+		return c.doCancel(ctx, "POST", baseUrl, bytes.NewBuffer(paramsJSON), cancelled)
+	})
+}
+
+// countMassCancel returns the number of orders params cancels. The response
+// does not tell, so the open orders of the markets cancelled are listed up
+// front; failing to list them does not hold the cancellation back.
+func (c *APIClient) countMassCancel(ctx context.Context, params MassCancelParams) uint64 {
+	explicit := uint64(len(params.OrderIDs) + len(params.ExternalOrderIDs))
+	if !params.CancelAll && len(params.Markets) == 0 {
+		return explicit
+	}
+	filter := OpenOrdersFilter{}
+	if !params.CancelAll {
+		filter.Markets = params.Markets
+	}
+	orders, err := c.GetOpenOrders(ctx, filter)
+	if err != nil {
+		return explicit
+	}
+
+	listedIDs := make(map[int64]bool, len(orders))
+	listedExternalIDs := make(map[string]bool, len(orders))
+	for _, order := range orders {
+		listedIDs[order.ID] = true
+		listedExternalIDs[order.ExternalID] = true
+	}
+	cancelled := uint64(len(orders))
+	for _, id := range params.OrderIDs {
+		if !listedIDs[id] {
+			cancelled++
+		}
+	}
+	for _, id := range params.ExternalOrderIDs {
+		if !listedExternalIDs[id] {
+			cancelled++
+		}
+	}
+	return cancelled
+}
+
+func (c *APIClient) doCancel(ctx context.Context, method, url string, body io.Reader, cancelled uint64) error {
+	var cancelResponse CancelResponse
+	if err := c.BaseModule.DoRequest(ctx, method, url, body, &cancelResponse); err != nil {
+		return err
+	}
+
+	if cancelResponse.Status != "OK" {
+		return fmt.Errorf("API returned error status: %v", cancelResponse.Status)
+	}
+
+	c.stats.recordOrders(0, cancelled)
+	return nil
+}
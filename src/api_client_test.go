@@ -31,7 +31,7 @@ func load() {
 		wd = parent
 	}
 }
-func createTestClient() *APIClient {
+func createTestClient(opts ...ClientOption) *APIClient {
 	cfg := EndpointConfig{
 		APIBaseURL: "https://api.starknet.sepolia.extended.exchange/api/v1",
 	}
@@ -47,7 +47,23 @@ func createTestClient() *APIClient {
 		panic("Failed to create StarkPerpetualAccount: " + err.Error())
 	}
 
-	return NewAPIClient(cfg, apiKey, account, 30*time.Second)
+	return NewAPIClient(cfg, apiKey, account, 30*time.Second, opts...)
+}
+
+// TestAPIClient_SchemaDrift decodes live responses strictly to flag fields the
+// exchange added since the models were written. It is opt-in, as drift is
+// expected between releases and must not fail the regular live tests.
+func TestAPIClient_SchemaDrift(t *testing.T) {
+	if os.Getenv("TEST_SCHEMA_DRIFT") == "" {
+		t.Skip("set TEST_SCHEMA_DRIFT=1 to check live responses against the models")
+	}
+	client := createTestClient(WithStrictDecoding())
+	ctx := context.Background()
+
+	_, err := client.GetMarkets(ctx, []string{"BTC-USD"})
+	assert.NoError(t, err, "markets")
+	_, err = client.GetMarketFee(ctx, "BTC-USD")
+	assert.NoError(t, err, "fees")
 }
 
 func TestAPIClient_GetMarkets_SingleValidMarket(t *testing.T) {
@@ -179,7 +195,8 @@ func TestAPIClient_SubmitOrder_ValidOrder(t *testing.T) {
 	require.NoError(t, err, "Should be able to get account")
 
 	// Create order parameters
-	nonce := int(time.Now().Unix()) // Use timestamp as nonce for uniqueness
+	nonce, err := client.NextNonce()
+	require.NoError(t, err, "Should be able to generate a nonce")
 	expireTime := time.Now().Add(1 * time.Hour)
 
 	params := CreateOrderObjectParams{
@@ -0,0 +1,79 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrApprovalDenied is returned when the ApprovalHook of the client rejects
+// an operation
+var ErrApprovalDenied = errors.New("operation not approved")
+
+// ApprovalKind is the kind of operation an ApprovalHook is asked about
+type ApprovalKind string
+
+const (
+	ApprovalWithdrawal ApprovalKind = "WITHDRAWAL"
+	ApprovalTransfer   ApprovalKind = "TRANSFER"
+)
+
+// ApprovalRequest describes an operation awaiting approval. Chain, Address
+// and Fee are set for withdrawals, ToAccount for transfers.
+type ApprovalRequest struct {
+	Kind      ApprovalKind
+	Chain     string
+	Address   string
+	ToAccount int64
+	Amount    decimal.Decimal
+	Fee       decimal.Decimal
+}
+
+// ApprovalHook approves sensitive operations, e.g. by asking a second person
+// through a chat bot. Approve blocks until the operation is decided; it
+// returns nil to approve and an error to reject. It should return when ctx
+// is done.
+type ApprovalHook interface {
+	Approve(ctx context.Context, req ApprovalRequest) error
+}
+
+// ApprovalHookFunc adapts a function to an ApprovalHook
+type ApprovalHookFunc func(ctx context.Context, req ApprovalRequest) error
+
+// Approve calls f
+func (f ApprovalHookFunc) Approve(ctx context.Context, req ApprovalRequest) error {
+	return f(ctx, req)
+}
+
+// approvalConfig is the hook of a client and the amount above which it is
+// asked
+type approvalConfig struct {
+	hook      ApprovalHook
+	threshold decimal.Decimal
+}
+
+// WithApprovalHook makes Withdraw and Transfer wait for hook to approve
+// operations of more than threshold before sending them; rejected ones are
+// never sent. A zero threshold asks about every operation.
+func WithApprovalHook(hook ApprovalHook, threshold decimal.Decimal) ClientOption {
+	return func(m *BaseModule) {
+		m.approval = &approvalConfig{hook: hook, threshold: threshold}
+	}
+}
+
+// approve asks the approval hook of the client about req if its amount is
+// above the threshold. A rejection is returned wrapping ErrApprovalDenied.
+func (m *BaseModule) approve(ctx context.Context, req ApprovalRequest) error {
+	if m.approval == nil || !req.Amount.GreaterThan(m.approval.threshold) {
+		return nil
+	}
+	if err := m.approval.hook.Approve(ctx, req); err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("approval of %s of %s not received: %w", req.Kind, req.Amount, ctx.Err())
+		}
+		return fmt.Errorf("%w: %s of %s: %w", ErrApprovalDenied, req.Kind, req.Amount, err)
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// AssetOperationsFilter selects asset operations. Zero fields are not
+// filtered on.
+type AssetOperationsFilter struct {
+	Types    []AssetOperationType   `query:"type"`
+	Statuses []AssetOperationStatus `query:"status"`
+	// Since and Until bound the operation time
+	Since time.Time `query:"startTime"`
+	Until time.Time `query:"endTime"`
+	// Cursor continues a previous listing, pass the ID of its last operation
+	Cursor *int64 `query:"cursor"`
+	Limit  int    `query:"limit"`
+}
+
+// AssetOperationsResponse represents the API response for asset operations
+type AssetOperationsResponse struct {
+	Data   []AssetOperationModel `json:"data"`
+	Status string                `json:"status"`
+}
+
+// GetAssetOperations retrieves the deposits, withdrawals and transfers of the
+// account matching filter, newest first
+func (c *APIClient) GetAssetOperations(ctx context.Context, filter AssetOperationsFilter) ([]AssetOperationModel, error) {
+	baseURL, err := c.getURLWithFilter(getAssetOperationsPath, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build URL: %w", err)
+	}
+
+	var operationsResponse AssetOperationsResponse
+	if err := c.BaseModule.DoRequest(ctx, "GET", baseURL, nil, &operationsResponse); err != nil {
+		return nil, err
+	}
+
+	if operationsResponse.Status != "OK" {
+		return nil, fmt.Errorf("API returned error status: %v", operationsResponse.Status)
+	}
+
+	return operationsResponse.Data, nil
+}
+
+// WithdrawalSettlement is the part of a withdrawal signed with the Stark key
+// of the account. Amount is in units of the collateral resolution.
+type WithdrawalSettlement struct {
+	Recipient    string    `json:"recipient"`
+	PositionID   int64     `json:"positionId"`
+	CollateralID string    `json:"collateralId"`
+	Amount       int64     `json:"amount"`
+	Expiration   int64     `json:"expiration"`
+	Salt         int64     `json:"salt"`
+	Signature    Signature `json:"signature"`
+}
+
+// WithdrawalModel is a withdrawal of collateral to Settlement.Recipient on a
+// chain, e.g. "STRK" or "ETH". The settlement is signed by the caller over the
+// exchange withdrawal hash; the signing library of the SDK hashes orders only.
+type WithdrawalModel struct {
+	AccountID  int64                `json:"accountId"`
+	Amount     decimal.Decimal      `json:"amount"`
+	ChainID    string               `json:"chainId"`
+	Asset      string               `json:"asset"`
+	Settlement WithdrawalSettlement `json:"settlement"`
+}
+
+// AssetOperationIDResponse represents the API response for a withdrawal or
+// transfer, the ID of the asset operation created
+type AssetOperationIDResponse struct {
+	Data   string `json:"data"`
+	Status string `json:"status"`
+}
+
+// Withdraw submits a withdrawal and returns the ID of its asset operation. It
+// is checked with ValidateWithdrawal first, so a client with an address book
+// never sends a withdrawal to an address missing from it. With an
+// ApprovalHook, withdrawals above the threshold are then sent only once
+// approved. Withdrawals are never retried.
+func (c *APIClient) Withdraw(ctx context.Context, withdrawal WithdrawalModel) (string, error) {
+	address := withdrawal.Settlement.Recipient
+	if address == "" {
+		return "", errors.New("withdrawal recipient must not be empty")
+	}
+	fee, err := c.ValidateWithdrawal(ctx, withdrawal.ChainID, address, withdrawal.Amount)
+	if err != nil {
+		return "", err
+	}
+	err = c.approve(ctx, ApprovalRequest{
+		Kind:    ApprovalWithdrawal,
+		Chain:   withdrawal.ChainID,
+		Address: address,
+		Amount:  withdrawal.Amount,
+		Fee:     fee,
+	})
+	if err != nil {
+		return "", err
+	}
+	return c.submitAssetOperation(ctx, withdrawPath, withdrawal)
+}
+
+// TransferSettlement is the part of a transfer signed with the Stark key of
+// the account. Amount is in units of the collateral resolution.
+type TransferSettlement struct {
+	Amount              int64     `json:"amount"`
+	AssetID             string    `json:"assetId"`
+	ExpirationTimestamp int64     `json:"expirationTimestamp"`
+	Nonce               int64     `json:"nonce"`
+	ReceiverPositionID  int64     `json:"receiverPositionId"`
+	ReceiverPublicKey   string    `json:"receiverPublicKey"`
+	SenderPositionID    int64     `json:"senderPositionId"`
+	SenderPublicKey     string    `json:"senderPublicKey"`
+	Signature           Signature `json:"signature"`
+}
+
+// TransferModel is a transfer of collateral to another account. Like the
+// settlement of a withdrawal, its settlement is signed by the caller.
+type TransferModel struct {
+	FromAccount      int64              `json:"fromAccount"`
+	ToAccount        int64              `json:"toAccount"`
+	Amount           decimal.Decimal    `json:"amount"`
+	TransferredAsset string             `json:"transferredAsset"`
+	Settlement       TransferSettlement `json:"settlement"`
+}
+
+// Transfer submits a transfer and returns the ID of its asset operation. With
+// an ApprovalHook, transfers above the threshold are sent only once
+// approved. Transfers are never retried.
+func (c *APIClient) Transfer(ctx context.Context, transfer TransferModel) (string, error) {
+	if !transfer.Amount.IsPositive() {
+		return "", errors.New("transfer amount must be positive")
+	}
+	err := c.approve(ctx, ApprovalRequest{
+		Kind:      ApprovalTransfer,
+		ToAccount: transfer.ToAccount,
+		Amount:    transfer.Amount,
+	})
+	if err != nil {
+		return "", err
+	}
+	return c.submitAssetOperation(ctx, transferPath, transfer)
+}
+
+// submitAssetOperation posts a withdrawal or transfer, registering it with
+// the anomaly monitor of the client so that it is not reported
+func (c *APIClient) submitAssetOperation(ctx context.Context, path string, operation any) (string, error) {
+	baseURL, err := c.GetURL(path, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to build URL: %w", err)
+	}
+	body, err := c.codec.Marshal(operation)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	var response AssetOperationIDResponse
+	submitted := c.anomalyMonitor.expectOperation()
+	defer func() { submitted(response.Data) }()
+	if err := c.BaseModule.DoRequest(ctx, "POST", baseURL, bytes.NewBuffer(body), &response); err != nil {
+		return "", err
+	}
+
+	if response.Status != "OK" {
+		return "", fmt.Errorf("API returned error status: %v", response.Status)
+	}
+	return response.Data, nil
+}
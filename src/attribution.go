@@ -0,0 +1,234 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// TagPnL is the PnL attributed to a strategy tag. Realised PnL is computed
+// per market against the average open price of the position the tag built
+// up, so tags trading the same market in opposite directions do not net.
+type TagPnL struct {
+	Tag           string
+	Fills         int
+	Volume        decimal.Decimal
+	Fees          decimal.Decimal
+	RealisedPnl   decimal.Decimal
+	UnrealisedPnl decimal.Decimal
+	// Positions holds the signed size the tag holds per market, negative
+	// for short, without closed markets
+	Positions map[string]decimal.Decimal
+}
+
+// NetPnl returns realised plus unrealised PnL, minus fees
+func (p TagPnL) NetPnl() decimal.Decimal {
+	return p.RealisedPnl.Add(p.UnrealisedPnl).Sub(p.Fees)
+}
+
+// replayedPosition is a position rebuilt from fills. size is negative when
+// short; openPrice is the average price of the open part.
+type replayedPosition struct {
+	size      decimal.Decimal
+	openPrice decimal.Decimal
+}
+
+// apply adds a fill to the position and returns the PnL it realised
+func (pos *replayedPosition) apply(trade AccountTradeModel) decimal.Decimal {
+	qty := trade.Qty
+	if trade.Side == OrderSideSell {
+		qty = qty.Neg()
+	}
+	if pos.size.IsZero() || pos.size.Sign() == qty.Sign() {
+		// Opening or adding: average the open price
+		size := pos.size.Add(qty)
+		pos.openPrice = pos.openPrice.Mul(pos.size.Abs()).Add(trade.Price.Mul(qty.Abs())).Div(size.Abs())
+		pos.size = size
+		return decimal.Zero
+	}
+	// Reducing, closing or flipping: realise the closed part
+	closed := decimal.Min(pos.size.Abs(), qty.Abs())
+	pnl := trade.Price.Sub(pos.openPrice).Mul(closed)
+	if pos.size.IsNegative() {
+		pnl = pnl.Neg()
+	}
+	pos.size = pos.size.Add(qty)
+	if pos.size.IsZero() {
+		pos.openPrice = decimal.Zero
+	} else if pos.size.Sign() == qty.Sign() {
+		pos.openPrice = trade.Price
+	}
+	return pnl
+}
+
+type tagBook struct {
+	fills     int
+	volume    decimal.Decimal
+	fees      decimal.Decimal
+	realised  decimal.Decimal
+	positions map[string]*replayedPosition
+}
+
+// PnLAttributor attributes fills, fees and PnL to the strategy tag of the
+// order they belong to. Orders are tagged when placed and trades are pushed
+// in as they arrive, e.g. from an account stream, or fetched by Sync. Trades
+// of untagged orders are attributed to the empty tag. Trades are
+// deduplicated by ID, so overlapping pushes and polls are safe.
+type PnLAttributor struct {
+	mu     sync.Mutex
+	tags   map[string]*tagBook
+	orders map[int64]string
+	seen   map[int64]bool
+	marks  map[string]decimal.Decimal
+	// last holds the price of the last fill per market
+	last map[string]decimal.Decimal
+	// since is the creation time of the newest trade recorded, where Sync
+	// resumes
+	since time.Time
+}
+
+// NewPnLAttributor creates an empty attributor
+func NewPnLAttributor() *PnLAttributor {
+	return &PnLAttributor{
+		tags:   make(map[string]*tagBook),
+		orders: make(map[int64]string),
+		seen:   make(map[int64]bool),
+		marks:  make(map[string]decimal.Decimal),
+		last:   make(map[string]decimal.Decimal),
+	}
+}
+
+// TagOrder attributes the trades of an order, by exchange order ID, to tag.
+// Trades recorded before the order was tagged stay where they were
+// attributed.
+func (a *PnLAttributor) TagOrder(orderID int64, tag string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.orders[orderID] = tag
+}
+
+// PlaceOrder places an order through client and tags it
+func (a *PnLAttributor) PlaceOrder(ctx context.Context, client *APIClient, params CreateOrderObjectParams, tag string, opts ...PlaceOrderOption) (*PerpetualOrderModel, *OrderResponse, error) {
+	order, resp, err := client.PlaceOrder(ctx, params, opts...)
+	if err != nil {
+		return order, resp, err
+	}
+	a.TagOrder(int64(resp.Data.OrderID), tag)
+	return order, resp, nil
+}
+
+// RecordTrade attributes a trade to the tag of its order. It reports false
+// if the trade was recorded before.
+func (a *PnLAttributor) RecordTrade(trade AccountTradeModel) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.record(trade)
+}
+
+func (a *PnLAttributor) record(trade AccountTradeModel) bool {
+	if a.seen[trade.ID] {
+		return false
+	}
+	a.seen[trade.ID] = true
+	if created := time.UnixMilli(trade.CreatedTime); created.After(a.since) {
+		a.since = created
+	}
+
+	tag := a.orders[trade.OrderID]
+	book, ok := a.tags[tag]
+	if !ok {
+		book = &tagBook{positions: make(map[string]*replayedPosition)}
+		a.tags[tag] = book
+	}
+	book.fills++
+	book.volume = book.volume.Add(trade.Price.Mul(trade.Qty))
+	book.fees = book.fees.Add(trade.Fee)
+	a.last[trade.Market] = trade.Price
+
+	pos, ok := book.positions[trade.Market]
+	if !ok {
+		pos = &replayedPosition{}
+		book.positions[trade.Market] = pos
+	}
+	book.realised = book.realised.Add(pos.apply(trade))
+	return true
+}
+
+// UpdateMarkPrice records the latest mark price of a market, at which open
+// positions are valued. Positions of markets without one are valued at the
+// price of their last fill.
+func (a *PnLAttributor) UpdateMarkPrice(market string, price decimal.Decimal) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.marks[market] = price
+}
+
+// Sync fetches the trades created since the newest trade recorded and
+// attributes them, returning how many were new
+func (a *PnLAttributor) Sync(ctx context.Context, client *APIClient) (int, error) {
+	a.mu.Lock()
+	since := a.since
+	a.mu.Unlock()
+	trades, err := client.tradesSince(ctx, since, func(id int64) bool {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		return a.seen[id]
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to sync trades: %w", err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	added := 0
+	for _, trade := range trades {
+		if a.record(trade) {
+			added++
+		}
+	}
+	return added, nil
+}
+
+// Tag returns the PnL attributed to a tag
+func (a *PnLAttributor) Tag(tag string) TagPnL {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.tagPnL(tag)
+}
+
+// Report returns the PnL of every tag with trades, ordered by tag
+func (a *PnLAttributor) Report() []TagPnL {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	report := make([]TagPnL, 0, len(a.tags))
+	for tag := range a.tags {
+		report = append(report, a.tagPnL(tag))
+	}
+	sort.Slice(report, func(i, j int) bool { return report[i].Tag < report[j].Tag })
+	return report
+}
+
+func (a *PnLAttributor) tagPnL(tag string) TagPnL {
+	p := TagPnL{Tag: tag, Positions: make(map[string]decimal.Decimal)}
+	book, ok := a.tags[tag]
+	if !ok {
+		return p
+	}
+	p.Fills, p.Volume, p.Fees, p.RealisedPnl = book.fills, book.volume, book.fees, book.realised
+	for market, pos := range book.positions {
+		if pos.size.IsZero() {
+			continue
+		}
+		p.Positions[market] = pos.size
+		mark, ok := a.marks[market]
+		if !ok {
+			mark = a.last[market]
+		}
+		p.UnrealisedPnl = p.UnrealisedPnl.Add(mark.Sub(pos.openPrice).Mul(pos.size))
+	}
+	return p
+}
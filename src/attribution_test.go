@@ -0,0 +1,53 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPnLAttributor(t *testing.T) {
+	d := decimal.RequireFromString
+	trade := func(id, orderID int64, market string, side OrderSide, qty, price, fee string) AccountTradeModel {
+		return AccountTradeModel{ID: id, OrderID: orderID, Market: market, Side: side, Qty: d(qty), Price: d(price), Fee: d(fee), CreatedTime: 1700000000000 + id}
+	}
+
+	a := NewPnLAttributor()
+	a.TagOrder(1, "mm")
+	a.TagOrder(2, "trend")
+	assert.True(t, a.RecordTrade(trade(1, 1, "BTC-USD", OrderSideBuy, "1", "100", "0.1")))
+	assert.True(t, a.RecordTrade(trade(2, 1, "BTC-USD", OrderSideBuy, "1", "110", "0.1")))
+	// Closes the long of 2 at an average of 105 and opens a short of 1
+	assert.True(t, a.RecordTrade(trade(3, 1, "BTC-USD", OrderSideSell, "3", "120", "0.3")))
+	assert.True(t, a.RecordTrade(trade(4, 2, "ETH-USD", OrderSideSell, "0.5", "200", "0.05")))
+	assert.True(t, a.RecordTrade(trade(5, 9, "BTC-USD", OrderSideBuy, "1", "100", "0")))
+	assert.False(t, a.RecordTrade(trade(1, 1, "BTC-USD", OrderSideBuy, "1", "100", "0.1")), "duplicates are ignored")
+	a.UpdateMarkPrice("BTC-USD", d("110"))
+
+	mm := a.Tag("mm")
+	assert.Equal(t, 3, mm.Fills)
+	assert.Equal(t, "570", mm.Volume.String())
+	assert.Equal(t, "0.5", mm.Fees.String())
+	assert.Equal(t, "30", mm.RealisedPnl.String())
+	assert.Equal(t, "10", mm.UnrealisedPnl.String(), "short 1 from 120 marked at 110")
+	assert.Equal(t, "39.5", mm.NetPnl().String())
+	assert.Equal(t, map[string]decimal.Decimal{"BTC-USD": d("-1")}, mm.Positions)
+
+	trend := a.Tag("trend")
+	assert.True(t, trend.UnrealisedPnl.IsZero(), "valued at the last fill without a mark")
+	assert.Equal(t, "-0.05", trend.NetPnl().String())
+
+	report := a.Report()
+	require.Len(t, report, 3)
+	assert.Equal(t, []string{"", "mm", "trend"}, []string{report[0].Tag, report[1].Tag, report[2].Tag})
+	assert.Equal(t, "10", report[0].UnrealisedPnl.String(), "untagged orders")
+	assert.Zero(t, a.Tag("unknown").Fills)
+
+	// Closing the short realises against its open price
+	assert.True(t, a.RecordTrade(trade(6, 1, "BTC-USD", OrderSideBuy, "1", "115", "0")))
+	mm = a.Tag("mm")
+	assert.Equal(t, "35", mm.RealisedPnl.String())
+	assert.Empty(t, mm.Positions)
+}
@@ -1,24 +1,36 @@
 package sdk
 
 import (
+	"bytes"
 	"context"
-	"encoding/json"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"io"
 	"math/big"
+	"net"
 	"net/http"
 	"net/url"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
 type EndpointConfig struct {
 	APIBaseURL string
+	// StreamURL is the base URL of the WebSocket streams, e.g.
+	// wss://api.starknet.extended.exchange/stream.extended.exchange/v1
+	StreamURL string
 }
 
 var (
 	ErrAPIKeyNotSet       = errors.New("api key is not set")
 	ErrStarkAccountNotSet = errors.New("stark account is not set")
+	// ErrClientClosed is returned for requests made after Close
+	ErrClientClosed = errors.New("client is closed")
+	// ErrResponseTooLarge is returned for responses above the limit set with
+	// WithMaxResponseSize
+	ErrResponseTooLarge = errors.New("response too large")
 )
 
 // BaseModule provides common functionality for API modules.
@@ -26,8 +38,41 @@ type BaseModule struct {
 	endpointConfig EndpointConfig
 	apiKey         string
 	starkAccount   *StarkPerpetualAccount
-	httpClient     *http.Client
+	httpClientOnce sync.Once
+	httpClient     atomic.Pointer[http.Client]
+	closeOnce      sync.Once
+	closed         atomic.Bool
 	clientTimeout  time.Duration
+	connectTimeout time.Duration
+	// maxResponseSize bounds response bodies, negative for no limit
+	maxResponseSize int64
+	transport       http.RoundTripper
+	tlsConfig       *tls.Config
+	spkiPins        []string
+	localAddr       net.IP
+	localInterface  string
+	stats           *sessionStats
+	health          *healthTracker
+	authErr         atomic.Pointer[error]
+	healthConfig    HealthConfig
+	userAgent       string
+	clientID        string
+	language        string
+	clock           Clock
+	codec           Codec
+	publicOnly      bool
+	manualFees      bool
+	preloadMarkets  bool
+	journal         Journal
+	anomalyMonitor  *AnomalyMonitor
+	addressBook     *AddressBook
+	approval        *approvalConfig
+	nonceGenerator  NonceGenerator
+	nonceOnce       sync.Once
+
+	orderQueueConfig *OrderQueueConfig
+	capReduceOnly    bool
+	retry            *RetryConfig
 }
 
 // NewBaseModule constructs a BaseModule with all fields explicitly provided.
@@ -38,14 +83,30 @@ func NewBaseModule(
 	starkAccount *StarkPerpetualAccount,
 	httpClient *http.Client,
 	clientTimeout time.Duration,
+	opts ...ClientOption,
 ) *BaseModule {
-	return &BaseModule{
-		endpointConfig: cfg,
-		apiKey:         apiKey,
-		starkAccount:   starkAccount,
-		httpClient:     httpClient,
-		clientTimeout:  clientTimeout,
+	m := &BaseModule{
+		endpointConfig:  cfg,
+		apiKey:          apiKey,
+		starkAccount:    starkAccount,
+		clientTimeout:   clientTimeout,
+		maxResponseSize: DefaultMaxResponseSize,
+		userAgent:       defaultUserAgent,
+		clock:           SystemClock,
+		codec:           JSONCodec{},
 	}
+	if httpClient != nil {
+		m.httpClient.Store(httpClient)
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	if m.addressBook != nil {
+		m.addressBook.setClock(m.clock)
+	}
+	m.stats = newSessionStats(m.clock)
+	m.health = newHealthTracker(m.clock, m.healthConfig)
+	return m
 }
 
 func (m *BaseModule) EndpointConfig() EndpointConfig {
@@ -66,21 +127,39 @@ func (m *BaseModule) StarkAccount() (*StarkPerpetualAccount, error) {
 	return m.starkAccount, nil
 }
 
+// HTTPClient returns the client requests are sent with, creating it on first
+// use. It is safe for concurrent use and keeps returning the same client
+// after Close.
 func (m *BaseModule) HTTPClient() *http.Client {
-	if m.httpClient == nil {
-		m.httpClient = &http.Client{
+	if client := m.httpClient.Load(); client != nil {
+		return client
+	}
+	// Batch APIs issue requests concurrently, so lazy creation must be guarded
+	m.httpClientOnce.Do(func() {
+		client := &http.Client{
 			Timeout: m.clientTimeout,
 		}
-	}
-	return m.httpClient
+		if m.transport != nil {
+			client.Transport = m.transport
+		} else if transport := newTransport(m.transportConfig()); transport != nil {
+			client.Transport = transport
+		}
+		m.httpClient.CompareAndSwap(nil, client)
+	})
+	return m.httpClient.Load()
 }
 
-// Close analogous to closing aiohttp session.
+// Close releases idle connections, analogous to closing an aiohttp session.
+// Requests in flight complete; later ones fail with ErrClientClosed. Calling
+// Close more than once is a no-op.
 func (m *BaseModule) Close() {
-	if m.httpClient != nil {
-		m.httpClient.CloseIdleConnections()
-		m.httpClient = nil
-	}
+	m.closeOnce.Do(func() {
+		m.closed.Store(true)
+		// A shared transport is closed by its owner
+		if client := m.httpClient.Load(); client != nil && m.transport == nil {
+			client.CloseIdleConnections()
+		}
+	})
 }
 
 // GetURL builds a full URL with optional query params.
@@ -101,19 +180,101 @@ func (m *BaseModule) GetURL(path string, query map[string]string) (string, error
 }
 
 // DoRequest performs an HTTP request and unmarshals the JSON response into the provided object
-// This function deduplicates common HTTP request logic across the SDK
+// This function deduplicates common HTTP request logic across the SDK.
+// The body is buffered so that the request can be resent: with WithRetry,
+// idempotent requests are retried on transport errors and retryable statuses.
+// Errors of requests to a degraded service wrap ErrServiceDegraded. Every
+// error is a *RequestError telling where the request was sent.
 func (m *BaseModule) DoRequest(ctx context.Context, method, url string, body io.Reader, result interface{}) error {
+	endpoint := m.endpointKey(method, url)
+	if err := m.sendRequest(ctx, endpoint, method, url, body, result); err != nil {
+		return newRequestError(ctx, endpoint, err)
+	}
+	return nil
+}
+
+// sendRequest performs a request with its retries
+func (m *BaseModule) sendRequest(ctx context.Context, endpoint, method, url string, body io.Reader, result interface{}) error {
+	if m.closed.Load() {
+		return ErrClientClosed
+	}
+	if m.publicOnly && serviceOf(endpoint) != ServiceMarketData {
+		return fmt.Errorf("%w: %s needs credentials", ErrAPIKeyNotSet, endpoint)
+	}
+	if err := m.health.allow(serviceOf(endpoint)); err != nil {
+		return err
+	}
+
+	var payload []byte
+	if buf, ok := body.(*bytes.Buffer); ok {
+		// Buffered bodies are sent without copying them
+		payload = buf.Bytes()
+	} else if body != nil {
+		var err error
+		if payload, err = io.ReadAll(body); err != nil {
+			return fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+
+	attempts := 1
+	if m.retry != nil && isIdempotent(ctx, method) {
+		attempts = m.retry.MaxAttempts
+	}
+	for attempt := 1; ; attempt++ {
+		err := m.doRequest(ctx, method, url, payload, body != nil, result)
+		if err == nil || attempt >= attempts || !isRetryable(ctx, err) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-m.clock.After(m.retry.delay(attempt)):
+		}
+	}
+}
+
+// doRequest performs a single attempt of a request
+func (m *BaseModule) doRequest(ctx context.Context, method, url string, payload []byte, hasBody bool, result interface{}) (err error) {
+	// A bytes.Reader lets net/http rewind the body itself, e.g. on redirects
+	var body io.Reader
+	if hasBody {
+		body = bytes.NewReader(payload)
+	}
+
 	// Create HTTP request
 	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
+	// Record usage and service health once the outcome is known
+	var received int64
+	defer func() {
+		endpoint := m.endpointKey(method, url)
+		m.stats.recordRequest(endpoint, req.ContentLength, received, err != nil)
+		if ctx.Err() != nil {
+			return
+		}
+		service := serviceOf(endpoint)
+		if m.health.record(service, err, isOutage(ctx, err)) && err != nil {
+			err = fmt.Errorf("%w: %s: %w", ErrServiceDegraded, service, err)
+		}
+	}()
+
 	// Only set Content-Type if we have a request body
-	if body != nil {
+	if hasBody {
 		req.Header.Set("Content-Type", "application/json")
 	}
 
+	// Identify the SDK and the integration using it
+	req.Header.Set("User-Agent", m.userAgent)
+	if m.clientID != "" {
+		req.Header.Set("X-Client-Id", m.clientID)
+	}
+	if m.language != "" {
+		req.Header.Set("Accept-Language", m.language)
+	}
+
 	// Add API key authentication if available
 	if apiKey, err := m.APIKey(); err == nil {
 		req.Header.Set("X-API-Key", apiKey)
@@ -121,31 +282,59 @@ func (m *BaseModule) DoRequest(ctx context.Context, method, url string, body io.
 
 	// Execute request
 	client := m.HTTPClient()
+	requestedAt := m.clock.Now()
 	resp, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to execute request: %w", err)
 	}
 	defer resp.Body.Close()
+	recordFreshness(ctx, requestedAt, m.clock.Now(), resp.Header)
 
 	// Read response body
-	responseBody, err := io.ReadAll(resp.Body)
+	responseBody, err := readBody(resp, m.maxResponseSize)
+	received = int64(len(responseBody))
 	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+		return err
 	}
 
 	// Check for HTTP errors
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(responseBody))
+		return newAPIError(resp.StatusCode, responseBody)
 	}
 
 	// Parse JSON response into the provided result object
-	if err := json.Unmarshal(responseBody, result); err != nil {
+	if err := m.codec.Unmarshal(responseBody, result); err != nil {
 		return fmt.Errorf("failed to parse response: %w", err)
 	}
+	recordUnknownFields(ctx, responseBody, result)
 
 	return nil
 }
 
+// readBody reads a response body of at most limit bytes, unless limit is
+// negative. Bodies announced larger are refused without being read; others
+// are read incrementally and abandoned once they cross the limit.
+func readBody(resp *http.Response, limit int64) ([]byte, error) {
+	if limit < 0 {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return body, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return body, nil
+	}
+	if resp.ContentLength > limit {
+		return nil, fmt.Errorf("%w: %d bytes announced, limit is %d", ErrResponseTooLarge, resp.ContentLength, limit)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return body, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if int64(len(body)) > limit {
+		return body, fmt.Errorf("%w: more than %d bytes", ErrResponseTooLarge, limit)
+	}
+	return body, nil
+}
+
 type StarkPerpetualAccount struct {
 	vault      uint64
 	privateKey string
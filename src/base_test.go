@@ -0,0 +1,63 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBaseModule_CloseUnderConcurrentRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"OK"}`))
+	}))
+	defer server.Close()
+	client := NewAPIClient(EndpointConfig{APIBaseURL: server.URL}, "key", nil, 5*time.Second)
+
+	var wg sync.WaitGroup
+	clients := make([]*http.Client, 16)
+	for i := range clients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			clients[i] = client.HTTPClient()
+			var resp CancelResponse
+			err := client.DoRequest(context.Background(), http.MethodGet, server.URL+"/info/markets", nil, &resp)
+			if err != nil {
+				assert.ErrorIs(t, err, ErrClientClosed)
+			}
+		}()
+	}
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client.Close()
+		}()
+	}
+	wg.Wait()
+
+	for _, c := range clients {
+		assert.Same(t, clients[0], c, "one HTTP client is created")
+	}
+	assert.Same(t, clients[0], client.HTTPClient(), "the client is kept after Close")
+
+	var resp CancelResponse
+	err := client.DoRequest(context.Background(), http.MethodGet, server.URL+"/info/markets", nil, &resp)
+	require.ErrorIs(t, err, ErrClientClosed)
+	assert.ErrorIs(t, client.Warmup(context.Background(), false), ErrClientClosed)
+}
+
+func TestBaseModule_ProvidedHTTPClient(t *testing.T) {
+	provided := &http.Client{}
+	m := NewBaseModule(EndpointConfig{}, "", nil, provided, time.Second)
+	assert.Same(t, provided, m.HTTPClient())
+	m.Close()
+	m.Close()
+	assert.Same(t, provided, m.HTTPClient())
+}
@@ -0,0 +1,61 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIClientSubmitOrders_Concurrent(t *testing.T) {
+	var received int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var order PerpetualOrderModel
+		if err := json.NewDecoder(r.Body).Decode(&order); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		atomic.AddInt32(&received, 1)
+		fmt.Fprintf(w, `{"status":"OK","data":{"id":1,"externalId":%q}}`, order.ID)
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(EndpointConfig{APIBaseURL: server.URL}, "key", nil, 5*time.Second)
+
+	orders := make([]*PerpetualOrderModel, 20)
+	for i := range orders {
+		orders[i] = &PerpetualOrderModel{ID: fmt.Sprintf("order-%d", i), Market: "BTC-USD"}
+	}
+
+	responses, err := client.SubmitOrders(context.Background(), orders, 4)
+	require.NoError(t, err)
+	require.Len(t, responses, len(orders))
+	for i, resp := range responses {
+		assert.Equal(t, orders[i].ID, resp.Data.ExternalID)
+	}
+	assert.Equal(t, int32(len(orders)), received)
+	assert.Equal(t, uint64(len(orders)), client.Stats().OrdersPlaced)
+}
+
+func TestAPIClientSubmitOrders_StopsOnError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"status":"ERROR"}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(EndpointConfig{APIBaseURL: server.URL}, "key", nil, 5*time.Second)
+
+	orders := []*PerpetualOrderModel{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+	responses, err := client.SubmitOrders(context.Background(), orders, 1)
+
+	require.Error(t, err)
+	assert.Nil(t, responses)
+}
@@ -0,0 +1,153 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"text/tabwriter"
+	"time"
+
+	"github.com/extended-protocol/extended-sdk-golang/src/internal/fanout"
+)
+
+// OrderBenchConfig configures BenchOrders
+type OrderBenchConfig struct {
+	// Orders is the number of orders signed, and placed unless DryRun;
+	// defaults to 100
+	Orders int
+	// Concurrency is the number of orders signed or placed at once; defaults
+	// to 1
+	Concurrency int
+	// DryRun only signs orders locally and sends nothing
+	DryRun bool
+}
+
+// LatencyPercentiles summarizes a set of latencies
+type LatencyPercentiles struct {
+	P50 time.Duration
+	P90 time.Duration
+	P99 time.Duration
+	Max time.Duration
+}
+
+func (p LatencyPercentiles) String() string {
+	return fmt.Sprintf("p50 %s  p90 %s  p99 %s  max %s", roundLatency(p.P50), roundLatency(p.P90), roundLatency(p.P99), roundLatency(p.Max))
+}
+
+// OrderBenchReport is the result of BenchOrders
+type OrderBenchReport struct {
+	Orders      int
+	Concurrency int
+	DryRun      bool
+	// SignElapsed is the wall time taken to sign all orders
+	SignElapsed time.Duration
+	// SignsPerSecond is the local signing throughput
+	SignsPerSecond float64
+	// Sign is the time taken to build and sign a single order
+	Sign LatencyPercentiles
+	// Place is the round trip of placing a single order, including signing;
+	// zero for dry runs
+	Place LatencyPercentiles
+	// CancelFailed counts placed orders whose cancellation failed, e.g.
+	// because they filled; those left resting must be cancelled by hand
+	CancelFailed int
+}
+
+// String formats the report as a table
+func (r OrderBenchReport) String() string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "orders\t%d (concurrency %d)\n", r.Orders, r.Concurrency)
+	fmt.Fprintf(w, "sign\t%.0f/s\t%s\n", r.SignsPerSecond, r.Sign)
+	if r.DryRun {
+		fmt.Fprintf(w, "place\tskipped (dry run)\n")
+	} else {
+		fmt.Fprintf(w, "place\t\t%s\n", r.Place)
+		if r.CancelFailed > 0 {
+			fmt.Fprintf(w, "cancel\t%d failed\n", r.CancelFailed)
+		}
+	}
+	w.Flush()
+	return b.String()
+}
+
+// BenchOrders measures how fast orders built from params are signed locally
+// and, unless cfg.DryRun, the latency of placing them, to size the
+// infrastructure of a strategy. Every order gets a fresh nonce and external
+// ID. Placed orders are cancelled right away, so params should describe an
+// order that rests, e.g. post-only and far from the market, on an account
+// meant for testing such as testnet. The first error stops the run.
+func (c *APIClient) BenchOrders(ctx context.Context, params CreateOrderObjectParams, cfg OrderBenchConfig) (OrderBenchReport, error) {
+	if cfg.Orders <= 0 {
+		cfg.Orders = 100
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 1
+	}
+	params.Nonce, params.OrderExternalID = nil, nil
+	if params.NonceGenerator == nil {
+		params.NonceGenerator = c.BaseModule
+	}
+	report := OrderBenchReport{Orders: cfg.Orders, Concurrency: cfg.Concurrency, DryRun: cfg.DryRun}
+
+	sign := make([]time.Duration, cfg.Orders)
+	start := time.Now()
+	err := fanout.Run(ctx, cfg.Orders, cfg.Concurrency, func(ctx context.Context, i int) error {
+		start := time.Now()
+		_, err := CreateOrderObjectContext(ctx, params)
+		sign[i] = time.Since(start)
+		return err
+	})
+	if err != nil {
+		return report, fmt.Errorf("failed to sign order: %w", err)
+	}
+	report.SignElapsed = time.Since(start)
+	report.SignsPerSecond = float64(cfg.Orders) / report.SignElapsed.Seconds()
+	report.Sign = percentiles(sign)
+	if cfg.DryRun {
+		return report, nil
+	}
+
+	place := make([]time.Duration, cfg.Orders)
+	var cancelFailed atomic.Int32
+	err = fanout.Run(ctx, cfg.Orders, cfg.Concurrency, func(ctx context.Context, i int) error {
+		start := time.Now()
+		order, _, err := c.PlaceOrder(ctx, params)
+		place[i] = time.Since(start)
+		if err != nil {
+			return err
+		}
+		if err := c.CancelOrderByExternalID(ctx, order.ID); err != nil {
+			cancelFailed.Add(1)
+		}
+		return nil
+	})
+	report.CancelFailed = int(cancelFailed.Load())
+	if err != nil {
+		return report, fmt.Errorf("failed to place order: %w", err)
+	}
+	report.Place = percentiles(place)
+	return report, nil
+}
+
+// percentiles returns the nearest-rank percentiles of latencies
+func percentiles(latencies []time.Duration) LatencyPercentiles {
+	if len(latencies) == 0 {
+		return LatencyPercentiles{}
+	}
+	sorted := slices.Clone(latencies)
+	slices.Sort(sorted)
+	rank := func(p int) time.Duration {
+		return sorted[(len(sorted)*p+99)/100-1]
+	}
+	return LatencyPercentiles{P50: rank(50), P90: rank(90), P99: rank(99), Max: sorted[len(sorted)-1]}
+}
+
+func roundLatency(d time.Duration) time.Duration {
+	if d >= time.Millisecond {
+		return d.Round(10 * time.Microsecond)
+	}
+	return d.Round(time.Microsecond)
+}
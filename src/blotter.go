@@ -0,0 +1,245 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// BlotterEventKind distinguishes fills from order updates
+type BlotterEventKind string
+
+const (
+	BlotterEventFill  BlotterEventKind = "FILL"
+	BlotterEventOrder BlotterEventKind = "ORDER"
+)
+
+// BlotterEvent is a fill or order update normalized for downstream systems.
+// Fills carry the trade fields, order updates the order fields.
+type BlotterEvent struct {
+	Kind       BlotterEventKind `json:"kind"`
+	Time       time.Time        `json:"time"`
+	Market     string           `json:"market"`
+	Side       OrderSide        `json:"side"`
+	OrderID    int64            `json:"orderId"`
+	ExternalID string           `json:"externalId,omitempty"`
+	// Price and Qty are the fill price and quantity for fills, the limit
+	// price and order quantity for orders
+	Price decimal.Decimal `json:"price"`
+	Qty   decimal.Decimal `json:"qty"`
+
+	TradeID   int64           `json:"tradeId,omitempty"`
+	Fee       decimal.Decimal `json:"fee"`
+	Taker     bool            `json:"taker,omitempty"`
+	TradeType TradeType       `json:"tradeType,omitempty"`
+
+	Status       OrderStatus       `json:"status,omitempty"`
+	StatusReason OrderStatusReason `json:"statusReason,omitempty"`
+	FilledQty    decimal.Decimal   `json:"filledQty"`
+	AveragePrice decimal.Decimal   `json:"averagePrice"`
+}
+
+// FillEvent normalizes a trade of the account
+func FillEvent(trade AccountTradeModel) BlotterEvent {
+	return BlotterEvent{
+		Kind:      BlotterEventFill,
+		Time:      time.UnixMilli(trade.CreatedTime).UTC(),
+		Market:    trade.Market,
+		Side:      trade.Side,
+		OrderID:   trade.OrderID,
+		Price:     trade.Price,
+		Qty:       trade.Qty,
+		TradeID:   trade.ID,
+		Fee:       trade.Fee,
+		Taker:     trade.IsTaker,
+		TradeType: trade.TradeType,
+	}
+}
+
+// OrderEvent normalizes an order update
+func OrderEvent(order OpenOrderModel) BlotterEvent {
+	return BlotterEvent{
+		Kind:         BlotterEventOrder,
+		Time:         time.UnixMilli(order.UpdatedTime).UTC(),
+		Market:       order.Market,
+		Side:         order.Side,
+		OrderID:      order.ID,
+		ExternalID:   order.ExternalID,
+		Price:        order.Price,
+		Qty:          order.Qty,
+		Status:       order.Status,
+		StatusReason: order.StatusReason,
+		FilledQty:    order.FilledQty,
+		AveragePrice: order.AveragePrice,
+	}
+}
+
+// BlotterSink receives blotter events. Publish is called from one goroutine
+// at a time per Blotter, in the order events were recorded.
+type BlotterSink interface {
+	Publish(ctx context.Context, event BlotterEvent) error
+}
+
+// BlotterSinkFunc adapts a function to a BlotterSink
+type BlotterSinkFunc func(ctx context.Context, event BlotterEvent) error
+
+// Publish calls f
+func (f BlotterSinkFunc) Publish(ctx context.Context, event BlotterEvent) error {
+	return f(ctx, event)
+}
+
+type jsonlSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewJSONLSink writes every event to w as a line of JSON, e.g. to os.Stdout
+// or a file tailed by a log shipper
+func NewJSONLSink(w io.Writer) BlotterSink {
+	return &jsonlSink{w: w}
+}
+
+func (s *jsonlSink) Publish(_ context.Context, event BlotterEvent) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(append(line, '\n'))
+	return err
+}
+
+// NewPublisherSink sends every event as JSON through publish, keyed by
+// market so that a partitioned log keeps the events of a market in order.
+// It plugs message brokers in without the SDK depending on their clients,
+// e.g. a Kafka writer:
+//
+//	sdk.NewPublisherSink(func(ctx context.Context, key string, value []byte) error {
+//		return writer.WriteMessages(ctx, kafka.Message{Key: []byte(key), Value: value})
+//	})
+//
+// or NATS, with the key as the subject suffix:
+//
+//	sdk.NewPublisherSink(func(_ context.Context, key string, value []byte) error {
+//		return nc.Publish("fills."+key, value)
+//	})
+func NewPublisherSink(publish func(ctx context.Context, key string, value []byte) error) BlotterSink {
+	return BlotterSinkFunc(func(ctx context.Context, event BlotterEvent) error {
+		value, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		return publish(ctx, event.Market, value)
+	})
+}
+
+// Blotter forwards fills and order updates of an account to sinks. Events
+// are pushed in as they arrive, e.g. from an account stream with
+// stream.RecordAccountUpdate, or fills are fetched by Sync. Fills are
+// deduplicated by trade ID, so overlapping pushes and polls publish each fill
+// once. A fill that fails to publish is retried with every sink, so delivery
+// is at least once.
+type Blotter struct {
+	// publishMu serializes publishing, keeping events in order per sink
+	publishMu sync.Mutex
+	sinks     []BlotterSink
+
+	mu    sync.Mutex
+	seen  map[int64]bool
+	since time.Time
+}
+
+// NewBlotter creates a blotter publishing to sinks
+func NewBlotter(sinks ...BlotterSink) *Blotter {
+	return &Blotter{sinks: sinks, seen: make(map[int64]bool)}
+}
+
+// Publish sends an event to every sink. A failing sink does not stop the
+// others; their errors are joined.
+func (b *Blotter) Publish(ctx context.Context, event BlotterEvent) error {
+	b.publishMu.Lock()
+	defer b.publishMu.Unlock()
+	var errs []error
+	for _, sink := range b.sinks {
+		if err := sink.Publish(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("failed to publish %s event: %w", event.Kind, err)
+	}
+	return nil
+}
+
+// RecordTrade publishes a fill unless it was published before
+func (b *Blotter) RecordTrade(ctx context.Context, trade AccountTradeModel) error {
+	_, err := b.publishFill(ctx, trade)
+	return err
+}
+
+// RecordOrder publishes an order update
+func (b *Blotter) RecordOrder(ctx context.Context, order OpenOrderModel) error {
+	return b.Publish(ctx, OrderEvent(order))
+}
+
+// Sync fetches the fills created since the newest one recorded and publishes
+// them oldest first, returning how many were published. It stops at the
+// first fill that cannot be published; the next Sync retries it.
+func (b *Blotter) Sync(ctx context.Context, client *APIClient) (int, error) {
+	b.mu.Lock()
+	since := b.since
+	b.mu.Unlock()
+	trades, err := client.tradesSince(ctx, since, func(id int64) bool {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		return b.seen[id]
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to sync trades: %w", err)
+	}
+
+	published := 0
+	for _, trade := range trades {
+		ok, err := b.publishFill(ctx, trade)
+		if err != nil {
+			return published, err
+		}
+		if ok {
+			published++
+		}
+	}
+	return published, nil
+}
+
+// publishFill publishes a trade unless it was published before and reports
+// whether it did. A trade that fails to publish is not recorded, so it is
+// published again when it is next seen.
+func (b *Blotter) publishFill(ctx context.Context, trade AccountTradeModel) (bool, error) {
+	b.mu.Lock()
+	if b.seen[trade.ID] {
+		b.mu.Unlock()
+		return false, nil
+	}
+	b.seen[trade.ID] = true
+	b.mu.Unlock()
+
+	if err := b.Publish(ctx, FillEvent(trade)); err != nil {
+		b.mu.Lock()
+		delete(b.seen, trade.ID)
+		b.mu.Unlock()
+		return false, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if created := time.UnixMilli(trade.CreatedTime); created.After(b.since) {
+		b.since = created
+	}
+	return true, nil
+}
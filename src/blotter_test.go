@@ -0,0 +1,75 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlotter(t *testing.T) {
+	ctx := context.Background()
+	var jsonl bytes.Buffer
+	var keys []string
+	fail := errors.New("broker down")
+	var down bool
+	b := NewBlotter(
+		NewJSONLSink(&jsonl),
+		NewPublisherSink(func(_ context.Context, key string, value []byte) error {
+			if down {
+				return fail
+			}
+			keys = append(keys, key)
+			return nil
+		}),
+	)
+
+	trade := AccountTradeModel{
+		ID: 7, OrderID: 42, Market: "BTC-USD", Side: OrderSideBuy,
+		Price: decimal.RequireFromString("40000"), Qty: decimal.RequireFromString("0.01"),
+		Fee: decimal.RequireFromString("0.2"), IsTaker: true, TradeType: TradeTypeTrade,
+		CreatedTime: 1700000000000,
+	}
+	require.NoError(t, b.RecordTrade(ctx, trade))
+	require.NoError(t, b.RecordTrade(ctx, trade), "duplicates are skipped")
+	require.NoError(t, b.RecordOrder(ctx, OpenOrderModel{
+		ID: 42, ExternalID: "ext-1", Market: "BTC-USD", Side: OrderSideBuy, Status: OrderStatusFilled,
+		Price: decimal.RequireFromString("40000"), Qty: decimal.RequireFromString("0.01"),
+		FilledQty: decimal.RequireFromString("0.01"), UpdatedTime: 1700000000001,
+	}))
+	assert.Equal(t, []string{"BTC-USD", "BTC-USD"}, keys)
+
+	lines := strings.Split(strings.TrimSpace(jsonl.String()), "\n")
+	require.Len(t, lines, 2)
+	var fill map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &fill))
+	assert.Equal(t, "FILL", fill["kind"])
+	assert.Equal(t, "2023-11-14T22:13:20Z", fill["time"])
+	assert.Equal(t, "40000", fill["price"])
+	assert.Equal(t, "0.2", fill["fee"])
+	assert.Equal(t, float64(7), fill["tradeId"])
+	assert.Equal(t, true, fill["taker"])
+	assert.NotContains(t, fill, "status")
+	var order BlotterEvent
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &order))
+	assert.Equal(t, BlotterEventOrder, order.Kind)
+	assert.Equal(t, "ext-1", order.ExternalID)
+	assert.Equal(t, OrderStatusFilled, order.Status)
+
+	// A failed fill is published again, to every sink
+	down = true
+	trade.ID = 8
+	err := b.RecordTrade(ctx, trade)
+	assert.ErrorIs(t, err, fail)
+	assert.ErrorContains(t, err, "failed to publish FILL event")
+	down = false
+	require.NoError(t, b.RecordTrade(ctx, trade))
+	assert.Len(t, keys, 3)
+	assert.Equal(t, 4, strings.Count(jsonl.String(), "\n"))
+}
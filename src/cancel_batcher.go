@@ -0,0 +1,104 @@
+package sdk
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultCancelBatchWindow is the coalescing window used when none is given
+const DefaultCancelBatchWindow = 10 * time.Millisecond
+
+// CancelBatcher coalesces cancel requests issued within a short window into a
+// single MassCancel call, reducing the request count when many quotes are
+// pulled at once.
+type CancelBatcher struct {
+	client *APIClient
+	window time.Duration
+
+	mu      sync.Mutex
+	pending *cancelBatch
+}
+
+type cancelBatch struct {
+	params MassCancelParams
+	done   chan struct{}
+	err    error
+}
+
+// NewCancelBatcher creates a batcher flushing every window. A non-positive
+// window falls back to DefaultCancelBatchWindow.
+func NewCancelBatcher(client *APIClient, window time.Duration) *CancelBatcher {
+	if window <= 0 {
+		window = DefaultCancelBatchWindow
+	}
+	return &CancelBatcher{
+		client: client,
+		window: window,
+	}
+}
+
+// CancelOrder queues a cancel by exchange order ID and waits for the batch result
+func (b *CancelBatcher) CancelOrder(ctx context.Context, orderID int64) error {
+	return b.wait(ctx, b.enqueue(func(p *MassCancelParams) {
+		p.OrderIDs = append(p.OrderIDs, orderID)
+	}))
+}
+
+// CancelOrderByExternalID queues a cancel by external ID and waits for the batch result
+func (b *CancelBatcher) CancelOrderByExternalID(ctx context.Context, externalID string) error {
+	return b.wait(ctx, b.enqueue(func(p *MassCancelParams) {
+		p.ExternalOrderIDs = append(p.ExternalOrderIDs, externalID)
+	}))
+}
+
+// Flush sends the pending batch immediately instead of waiting for the window to elapse
+func (b *CancelBatcher) Flush() {
+	b.mu.Lock()
+	batch := b.pending
+	b.pending = nil
+	b.mu.Unlock()
+
+	if batch != nil {
+		b.send(batch)
+	}
+}
+
+func (b *CancelBatcher) enqueue(add func(*MassCancelParams)) *cancelBatch {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.pending == nil {
+		batch := &cancelBatch{done: make(chan struct{})}
+		b.pending = batch
+		time.AfterFunc(b.window, func() {
+			b.mu.Lock()
+			if b.pending != batch {
+				// Already flushed manually
+				b.mu.Unlock()
+				return
+			}
+			b.pending = nil
+			b.mu.Unlock()
+			b.send(batch)
+		})
+	}
+	add(&b.pending.params)
+	return b.pending
+}
+
+func (b *CancelBatcher) send(batch *cancelBatch) {
+	// The batch is shared by several callers, so it is not bound to any one
+	// caller's context; the client timeout still applies.
+	batch.err = b.client.MassCancel(context.Background(), batch.params)
+	close(batch.done)
+}
+
+func (b *CancelBatcher) wait(ctx context.Context, batch *cancelBatch) error {
+	select {
+	case <-batch.done:
+		return batch.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
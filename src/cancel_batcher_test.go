@@ -0,0 +1,93 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCancelBatcher_CoalescesIntoMassCancel(t *testing.T) {
+	var mu sync.Mutex
+	var calls []MassCancelParams
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/user/order/massCancel", r.URL.Path)
+		var params MassCancelParams
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&params))
+		mu.Lock()
+		calls = append(calls, params)
+		mu.Unlock()
+		w.Write([]byte(`{"status":"OK"}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(EndpointConfig{APIBaseURL: server.URL}, "key", nil, 5*time.Second)
+	batcher := NewCancelBatcher(client, 50*time.Millisecond)
+
+	var wg sync.WaitGroup
+	for _, id := range []string{"a", "b", "c"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, batcher.CancelOrderByExternalID(context.Background(), id))
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, batcher.CancelOrder(context.Background(), 42))
+	}()
+	wg.Wait()
+
+	require.Len(t, calls, 1)
+	assert.ElementsMatch(t, []string{"a", "b", "c"}, calls[0].ExternalOrderIDs)
+	assert.Equal(t, []int64{42}, calls[0].OrderIDs)
+	assert.Equal(t, uint64(4), client.Stats().OrdersCancelled)
+}
+
+func TestCancelBatcher_PropagatesError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(EndpointConfig{APIBaseURL: server.URL}, "key", nil, 5*time.Second)
+	batcher := NewCancelBatcher(client, time.Millisecond)
+
+	err := batcher.CancelOrderByExternalID(context.Background(), "a")
+	require.Error(t, err)
+}
+
+func TestCancelBatcher_Flush(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"OK"}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(EndpointConfig{APIBaseURL: server.URL}, "key", nil, 5*time.Second)
+	batcher := NewCancelBatcher(client, time.Hour)
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- batcher.CancelOrder(context.Background(), 1) }()
+
+	// Wait until the cancel is queued before flushing
+	require.Eventually(t, func() bool {
+		batcher.mu.Lock()
+		defer batcher.mu.Unlock()
+		return batcher.pending != nil
+	}, time.Second, time.Millisecond)
+	batcher.Flush()
+
+	select {
+	case err := <-errCh:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("flush did not complete the pending cancel")
+	}
+}
@@ -0,0 +1,69 @@
+package sdk
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var candleIntervalDurations = map[CandleInterval]time.Duration{
+	CandleInterval1Minute:   time.Minute,
+	CandleInterval5Minutes:  5 * time.Minute,
+	CandleInterval15Minutes: 15 * time.Minute,
+	CandleInterval30Minutes: 30 * time.Minute,
+	CandleInterval1Hour:     time.Hour,
+	CandleInterval2Hours:    2 * time.Hour,
+	CandleInterval4Hours:    4 * time.Hour,
+	CandleInterval1Day:      24 * time.Hour,
+}
+
+// Duration returns the width of the interval, or 0 for an unknown interval
+func (c CandleInterval) Duration() time.Duration {
+	return candleIntervalDurations[c]
+}
+
+// IntervalFromDuration returns the candle interval of exactly the given width
+func IntervalFromDuration(d time.Duration) (CandleInterval, error) {
+	for _, c := range candleIntervalValues {
+		if candleIntervalDurations[c] == d {
+			return c, nil
+		}
+	}
+	return "", fmt.Errorf("%w: no candle interval of %s", ErrInvalidEnumValue, d)
+}
+
+// FloorToInterval returns the open time of the candle containing t. Candles
+// are aligned to the Unix epoch in UTC, so daily candles open at midnight UTC.
+// t is returned unchanged for an unknown interval.
+func FloorToInterval(t time.Time, interval CandleInterval) time.Time {
+	d := interval.Duration()
+	if d == 0 {
+		return t
+	}
+	return t.UTC().Truncate(d)
+}
+
+// CeilToInterval returns the open time of the first candle starting at or
+// after t
+func CeilToInterval(t time.Time, interval CandleInterval) time.Time {
+	floor := FloorToInterval(t, interval)
+	if floor.Equal(t) {
+		return floor
+	}
+	return floor.Add(interval.Duration())
+}
+
+// CandleModel is an OHLC candle of a market. Timestamp is its open time in
+// Unix milliseconds. Volume is only set for trades candles.
+type CandleModel struct {
+	Open      decimal.Decimal `json:"o"`
+	Low       decimal.Decimal `json:"l"`
+	High      decimal.Decimal `json:"h"`
+	Close     decimal.Decimal `json:"c"`
+	Volume    decimal.Decimal `json:"v"`
+	Timestamp int64           `json:"T"`
+	// Closed is set by the candles stream once the candle is final; a candle
+	// still forming is updated by later events
+	Closed bool `json:"-"`
+}
@@ -0,0 +1,41 @@
+package sdk
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCandleIntervalDuration(t *testing.T) {
+	assert.Equal(t, 15*time.Minute, CandleInterval15Minutes.Duration())
+	assert.Equal(t, 24*time.Hour, CandleInterval1Day.Duration())
+	assert.Equal(t, time.Duration(0), CandleInterval("PT3M").Duration())
+
+	for _, c := range candleIntervalValues {
+		back, err := IntervalFromDuration(c.Duration())
+		require.NoError(t, err)
+		assert.Equal(t, c, back)
+	}
+
+	_, err := IntervalFromDuration(3 * time.Minute)
+	assert.True(t, errors.Is(err, ErrInvalidEnumValue))
+}
+
+func TestFloorToInterval(t *testing.T) {
+	ts := time.Date(2024, 3, 10, 13, 47, 12, 500, time.UTC)
+
+	assert.Equal(t, time.Date(2024, 3, 10, 13, 45, 0, 0, time.UTC), FloorToInterval(ts, CandleInterval15Minutes))
+	assert.Equal(t, time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC), FloorToInterval(ts, CandleInterval4Hours))
+	assert.Equal(t, time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC), FloorToInterval(ts, CandleInterval1Day))
+
+	// Alignment is in UTC regardless of the location of t
+	local := ts.In(time.FixedZone("UTC+5:30", 5*3600+1800))
+	assert.True(t, FloorToInterval(local, CandleInterval1Day).Equal(time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)))
+
+	assert.Equal(t, time.Date(2024, 3, 10, 14, 0, 0, 0, time.UTC), CeilToInterval(ts, CandleInterval1Hour))
+	aligned := time.Date(2024, 3, 10, 14, 0, 0, 0, time.UTC)
+	assert.Equal(t, aligned, CeilToInterval(aligned, CandleInterval1Hour))
+}
@@ -0,0 +1,80 @@
+package sdk
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// DefaultCarryLookback is the funding history ProjectCarryCost averages over
+// when no lookback is given
+const DefaultCarryLookback = 24 * time.Hour
+
+// CarryCost is the projected funding cost of holding a position
+type CarryCost struct {
+	Market string
+	Side   PositionSide
+	// Notional is the position value the funding rate applies to
+	Notional  decimal.Decimal
+	Intervals int
+	// AverageRate is the mean funding rate per interval of the history
+	AverageRate decimal.Decimal
+	// Samples is the number of funding rates averaged
+	Samples int
+	// Cost is the funding paid over Intervals at AverageRate. It is negative
+	// when the position is expected to receive funding.
+	Cost decimal.Decimal
+}
+
+// EstimateCarryCost projects the funding paid for holding position over the
+// next intervals funding payments, assuming the mean rate of history persists
+// and the notional stays at the current value. Longs pay positive rates and
+// shorts receive them. Rates of other markets in history are ignored.
+func EstimateCarryCost(position PositionModel, history []FundingRateModel, intervals int) CarryCost {
+	cost := CarryCost{
+		Market:    position.Market,
+		Side:      position.Side,
+		Notional:  position.Value.Abs(),
+		Intervals: intervals,
+	}
+	if cost.Notional.IsZero() {
+		cost.Notional = position.Size.Mul(position.MarkPrice).Abs()
+	}
+
+	sum := decimal.Zero
+	for _, rate := range history {
+		if rate.Market != "" && rate.Market != position.Market {
+			continue
+		}
+		sum = sum.Add(rate.FundingRate)
+		cost.Samples++
+	}
+	if cost.Samples == 0 || intervals <= 0 {
+		return cost
+	}
+	cost.AverageRate = sum.Div(decimal.NewFromInt(int64(cost.Samples)))
+
+	cost.Cost = cost.Notional.Mul(cost.AverageRate).Mul(decimal.NewFromInt(int64(intervals)))
+	if position.Side == PositionSideShort {
+		cost.Cost = cost.Cost.Neg()
+	}
+	return cost
+}
+
+// ProjectCarryCost estimates the funding cost of holding position over the
+// next intervals funding payments from the funding rates of the lookback
+// period before now, see EstimateCarryCost. A non-positive lookback falls
+// back to DefaultCarryLookback.
+func (c *APIClient) ProjectCarryCost(ctx context.Context, position PositionModel, intervals int, lookback time.Duration) (*CarryCost, error) {
+	if lookback <= 0 {
+		lookback = DefaultCarryLookback
+	}
+	now := c.Clock().Now()
+	history, err := c.GetFundingHistory(ctx, position.Market, FundingHistoryFilter{Since: now.Add(-lookback), Until: now})
+	if err != nil {
+		return nil, err
+	}
+	cost := EstimateCarryCost(position, history, intervals)
+	return &cost, nil
+}
@@ -0,0 +1,35 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateCarryCost(t *testing.T) {
+	history := []FundingRateModel{
+		{Market: "BTC-USD", FundingRate: d("0.0001")},
+		{Market: "BTC-USD", FundingRate: d("0.0003")},
+		{Market: "ETH-USD", FundingRate: d("0.01")},
+	}
+	long := PositionModel{Market: "BTC-USD", Side: PositionSideLong, Size: d("2"), MarkPrice: d("50000")}
+
+	cost := EstimateCarryCost(long, history, 24)
+	assert.Equal(t, 2, cost.Samples)
+	assert.True(t, d("100000").Equal(cost.Notional))
+	assert.True(t, d("0.0002").Equal(cost.AverageRate))
+	assert.True(t, d("480").Equal(cost.Cost), "cost %s", cost.Cost)
+
+	short := long
+	short.Side = PositionSideShort
+	short.Value = d("-90000")
+	cost = EstimateCarryCost(short, history, 24)
+	assert.True(t, d("90000").Equal(cost.Notional), "value takes precedence over size times mark")
+	assert.True(t, d("-432").Equal(cost.Cost), "shorts receive positive funding: %s", cost.Cost)
+
+	cost = EstimateCarryCost(long, nil, 24)
+	assert.Zero(t, cost.Samples)
+	assert.True(t, cost.Cost.IsZero())
+	assert.True(t, decimal.Zero.Equal(EstimateCarryCost(long, history, 0).Cost))
+}
@@ -0,0 +1,44 @@
+package sdk
+
+import "time"
+
+// Clock is the source of the current time for everything time dependent in
+// the SDK (default order expiry, nonce generation, rate limiting, statistics),
+// so tests can control time instead of sleeping
+type Clock interface {
+	Now() time.Time
+	// After returns a channel that receives the time once d has passed on
+	// this clock. Waits computed from Now, such as for rate limit tokens,
+	// use it, so a fake clock releases them when it is advanced.
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// SystemClock is the Clock backed by time.Now
+var SystemClock Clock = systemClock{}
+
+// WithClock replaces the system clock used by the client
+func WithClock(clock Clock) ClientOption {
+	return func(m *BaseModule) {
+		if clock != nil {
+			m.clock = clock
+		}
+	}
+}
+
+// Clock returns the clock used by the module
+func (m *BaseModule) Clock() Clock {
+	return m.clock
+}
+
+func clockOrDefault(clock Clock) Clock {
+	if clock == nil {
+		return SystemClock
+	}
+	return clock
+}
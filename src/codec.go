@@ -0,0 +1,69 @@
+package sdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+// Codec encodes request bodies and decodes response bodies. It lets callers
+// swap encoding/json for a faster implementation with the same semantics,
+// e.g. one honouring the json struct tags and the json.Marshaler and
+// json.Unmarshaler methods of decimal.Decimal. Implementations must be safe
+// for concurrent use.
+type Codec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+// JSONCodec is the default Codec, backed by encoding/json
+type JSONCodec struct{}
+
+// Marshal encodes v with json.Marshal
+func (JSONCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal decodes data with json.Unmarshal
+func (JSONCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+// StrictJSONCodec is a JSONCodec that rejects response fields without a
+// matching struct field and trailing data after the JSON value. It turns
+// exchange schema drift into errors, e.g. in tests running against the API.
+type StrictJSONCodec struct{}
+
+// Marshal encodes v with json.Marshal
+func (StrictJSONCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal decodes data with unknown fields disallowed
+func (StrictJSONCodec) Unmarshal(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if dec.More() {
+		return errors.New("unexpected data after JSON value")
+	}
+	return nil
+}
+
+// WithStrictDecoding makes responses fail to parse when they carry fields
+// the SDK does not model or values of the wrong type, see StrictJSONCodec.
+// It takes precedence over WithUnknownFields, which then captures nothing.
+func WithStrictDecoding() ClientOption {
+	return WithCodec(StrictJSONCodec{})
+}
+
+// WithCodec replaces the codec used for API payloads. A nil codec keeps JSONCodec.
+func WithCodec(codec Codec) ClientOption {
+	return func(m *BaseModule) {
+		if codec != nil {
+			m.codec = codec
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingCodec struct {
+	JSONCodec
+	decoded atomic.Int64
+}
+
+func (c *countingCodec) Unmarshal(data []byte, v any) error {
+	c.decoded.Add(1)
+	return c.JSONCodec.Unmarshal(data, v)
+}
+
+func TestStrictJSONCodec(t *testing.T) {
+	var codec StrictJSONCodec
+	var balance BalanceResponse
+
+	require.NoError(t, codec.Unmarshal([]byte(`{"status":"OK","data":{"balance":"10"}}`), &balance))
+	assert.Equal(t, "10", balance.Data.Balance.String())
+
+	err := codec.Unmarshal([]byte(`{"status":"OK","data":{"balance":"10","bonus":"1"}}`), &balance)
+	assert.ErrorContains(t, err, `unknown field "bonus"`)
+	err = codec.Unmarshal([]byte(`{"status":"OK","data":{"updatedTime":"soon"}}`), &balance)
+	assert.Error(t, err, "type mismatch")
+	err = codec.Unmarshal([]byte(`{"status":"OK"} {}`), &balance)
+	assert.Error(t, err, "trailing data")
+
+	// Lenient decoding accepts the same drift
+	assert.NoError(t, JSONCodec{}.Unmarshal([]byte(`{"status":"OK","data":{"bonus":"1"}}`), &balance))
+}
+
+func TestWithCodec(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(orderbookPayload(5))
+	}))
+	defer server.Close()
+
+	codec := &countingCodec{}
+	client := NewAPIClient(EndpointConfig{APIBaseURL: server.URL}, "", nil, 5*time.Second, WithCodec(codec))
+	book, err := client.GetOrderbookSnapshot(context.Background(), "BTC-USD")
+	require.NoError(t, err)
+	assert.Len(t, book.Bid, 5)
+	assert.Equal(t, int64(1), codec.decoded.Load())
+}
+
+// orderbookPayload returns a snapshot response with the given number of levels per side
+func orderbookPayload(levels int) []byte {
+	side := func(start, step int) string {
+		parts := make([]string, levels)
+		for i := range parts {
+			parts[i] = fmt.Sprintf(`{"qty":"%d.12345","price":"%d.5"}`, i+1, start+i*step)
+		}
+		return strings.Join(parts, ",")
+	}
+	return []byte(`{"status":"OK","data":{"market":"BTC-USD","bid":[` + side(50000, -1) + `],"ask":[` + side(50001, 1) + `]}}`)
+}
+
+// BenchmarkCodecOrderbook decodes deep orderbook snapshots, the largest
+// payloads of the API. Add alternative Codec implementations to the table to
+// compare them against encoding/json.
+func BenchmarkCodecOrderbook(b *testing.B) {
+	codecs := map[string]Codec{"encoding/json": JSONCodec{}}
+	payload := orderbookPayload(500)
+	for name, codec := range codecs {
+		b.Run(name, func(b *testing.B) {
+			b.SetBytes(int64(len(payload)))
+			b.ReportAllocs()
+			for b.Loop() {
+				var resp OrderbookResponse
+				if err := codec.Unmarshal(payload, &resp); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package sdk
 
-import "github.com/shopspring/decimal"
+import (
+	"github.com/shopspring/decimal"
+)
 
 type StarknetDomain struct {
 	Name     string `json:"name"`
@@ -9,14 +11,6 @@ type StarknetDomain struct {
 	Revision string `json:"revision"`
 }
 
-// TradingFeeModel represents trading fees for a market
-type TradingFeeModel struct {
-	Market         string          `json:"market"`
-	MakerFeeRate   decimal.Decimal `json:"makerFeeRate"`
-	TakerFeeRate   decimal.Decimal `json:"takerFeeRate"`
-	BuilderFeeRate decimal.Decimal `json:"builderFeeRate"`
-}
-
 var DefaultFees = TradingFeeModel{
 	Market:         "BTC-USD",
 	MakerFeeRate:   decimal.NewFromFloat(0.0002), // 2/10000 = 0.0002
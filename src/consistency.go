@@ -0,0 +1,88 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// ConsistencyToken versions an account entity by the time the exchange last
+// updated it, in Unix millis. A later token supersedes an earlier one, so a
+// cache can drop a read that is older than what it already holds.
+type ConsistencyToken int64
+
+// Time returns the update time the token stands for
+func (t ConsistencyToken) Time() time.Time {
+	return time.UnixMilli(int64(t)).UTC()
+}
+
+// Newer reports whether t is a later version than other
+func (t ConsistencyToken) Newer(other ConsistencyToken) bool {
+	return t > other
+}
+
+// ConsistencyToken returns the version of the position
+func (p PositionModel) ConsistencyToken() ConsistencyToken {
+	return ConsistencyToken(p.UpdatedAt)
+}
+
+// ConsistencyToken returns the version of the balance
+func (b BalanceModel) ConsistencyToken() ConsistencyToken {
+	return ConsistencyToken(b.UpdatedTime)
+}
+
+// ConsistencyToken returns the version of the order
+func (o OpenOrderModel) ConsistencyToken() ConsistencyToken {
+	return ConsistencyToken(o.UpdatedTime)
+}
+
+// Freshness reports when the data of a response was read
+type Freshness struct {
+	// RequestedAt and ReceivedAt are taken from the client clock when the
+	// request was sent and its response arrived
+	RequestedAt time.Time
+	ReceivedAt  time.Time
+	// ServerTime is the Date header of the response, zero if absent. It has
+	// a resolution of one second.
+	ServerTime time.Time
+}
+
+// Age returns how old the data is at now. It is measured from the time the
+// request was sent, the earliest moment the exchange may have read the data.
+func (f Freshness) Age(now time.Time) time.Duration {
+	return now.Sub(f.RequestedAt)
+}
+
+// IsStale reports whether the data is older than maxAge at now
+func (f Freshness) IsStale(now time.Time, maxAge time.Duration) bool {
+	return f.Age(now) > maxAge
+}
+
+type freshnessKey struct{}
+
+// WithFreshness makes requests made with the returned context record the
+// freshness of their response into f, e.g.
+//
+//	var f Freshness
+//	positions, err := client.GetPositions(WithFreshness(ctx, &f), nil)
+//	if f.IsStale(time.Now(), time.Second) { ... }
+//
+// With retries, f describes the last attempt. f must not be shared by
+// concurrent requests.
+func WithFreshness(ctx context.Context, f *Freshness) context.Context {
+	return context.WithValue(ctx, freshnessKey{}, f)
+}
+
+// recordFreshness fills the Freshness carried by ctx, if any
+func recordFreshness(ctx context.Context, requestedAt, receivedAt time.Time, header http.Header) {
+	f, _ := ctx.Value(freshnessKey{}).(*Freshness)
+	if f == nil {
+		return
+	}
+	f.RequestedAt = requestedAt
+	f.ReceivedAt = receivedAt
+	f.ServerTime = time.Time{}
+	if date, err := http.ParseTime(header.Get("Date")); err == nil {
+		f.ServerTime = date
+	}
+}
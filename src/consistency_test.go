@@ -0,0 +1,47 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsistencyToken(t *testing.T) {
+	older := PositionModel{UpdatedAt: 1700000000000}
+	newer := PositionModel{UpdatedAt: 1700000000500}
+	assert.True(t, newer.ConsistencyToken().Newer(older.ConsistencyToken()))
+	assert.False(t, older.ConsistencyToken().Newer(older.ConsistencyToken()))
+	assert.Equal(t, time.UnixMilli(1700000000500).UTC(), newer.ConsistencyToken().Time())
+
+	tracker := NewPnLTracker()
+	tracker.SetBalance(BalanceModel{UpdatedTime: 1700000000200})
+	tracker.SetPositions([]PositionModel{older, newer})
+	assert.Equal(t, newer.ConsistencyToken(), tracker.Snapshot().AsOf)
+}
+
+func TestWithFreshness(t *testing.T) {
+	serverTime := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", serverTime.Format(http.TimeFormat))
+		w.Write([]byte(`{"status":"OK","data":[]}`))
+	}))
+	defer server.Close()
+
+	now := time.Date(2024, 1, 1, 12, 0, 1, 0, time.UTC)
+	client := NewAPIClient(EndpointConfig{APIBaseURL: server.URL}, "", nil, 5*time.Second, WithClock(fixedClock(now)))
+
+	var f Freshness
+	_, err := client.GetPositions(WithFreshness(context.Background(), &f), nil)
+	require.NoError(t, err)
+	assert.Equal(t, now, f.RequestedAt)
+	assert.Equal(t, now, f.ReceivedAt)
+	assert.True(t, serverTime.Equal(f.ServerTime))
+	assert.Equal(t, 2*time.Second, f.Age(now.Add(2*time.Second)))
+	assert.True(t, f.IsStale(now.Add(2*time.Second), time.Second))
+	assert.False(t, f.IsStale(now.Add(time.Second), time.Second))
+}
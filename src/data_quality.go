@@ -0,0 +1,210 @@
+package sdk
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// QualityIssue is a problem found in market data
+type QualityIssue string
+
+const (
+	// QualityCrossedBook is a book whose best bid is at or above its best ask
+	QualityCrossedBook QualityIssue = "CROSSED_BOOK"
+	// QualityStale is data older than QualityConfig.MaxAge
+	QualityStale QualityIssue = "STALE"
+	// QualityOutlier is a price too far from the median of the recent prices
+	// of its market
+	QualityOutlier QualityIssue = "OUTLIER"
+)
+
+// minQualityPrints is the number of prices of a market a DataQualityMonitor
+// needs before it judges outliers
+const minQualityPrints = 5
+
+// QualityEvent reports market data with a quality issue. Time is the
+// timestamp of the data.
+type QualityEvent struct {
+	Issue  QualityIssue
+	Market string
+	Time   time.Time
+	Detail string
+}
+
+// QualityConfig configures a DataQualityMonitor
+type QualityConfig struct {
+	// MaxAge is the age at which data is stale; defaults to 5s
+	MaxAge time.Duration
+	// MaxDeviation is the fraction of the median of the recent prices a
+	// price may deviate by before it is an outlier; defaults to 0.05
+	MaxDeviation decimal.Decimal
+	// Window is the number of recent prices per market the median is taken
+	// over; defaults to 20
+	Window int
+	Clock  Clock // Defaults to SystemClock
+}
+
+// DataQualityMonitor checks market data from REST snapshots and streams for
+// crossed books, stale timestamps and outlier prices. Every check reports
+// whether the data is sound, so strategies can discount bad ticks, and
+// passes each issue found to the callback of the monitor. It is safe for
+// concurrent use.
+type DataQualityMonitor struct {
+	cfg     QualityConfig
+	onEvent func(QualityEvent)
+
+	mu     sync.Mutex
+	prices map[string][]decimal.Decimal
+}
+
+// NewDataQualityMonitor creates a monitor calling onEvent, if not nil, for
+// every issue found. onEvent is called synchronously from the goroutine
+// running the check.
+func NewDataQualityMonitor(cfg QualityConfig, onEvent func(QualityEvent)) *DataQualityMonitor {
+	if cfg.MaxAge <= 0 {
+		cfg.MaxAge = 5 * time.Second
+	}
+	if !cfg.MaxDeviation.IsPositive() {
+		cfg.MaxDeviation = decimal.RequireFromString("0.05")
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = 20
+	}
+	cfg.Clock = clockOrDefault(cfg.Clock)
+	return &DataQualityMonitor{cfg: cfg, onEvent: onEvent, prices: make(map[string][]decimal.Decimal)}
+}
+
+// CheckOrderbook checks a book read at at, e.g. the Time of a stream event
+// or the ServerTime of the Freshness of a REST response, for staleness and
+// crossed sides. A zero at skips the staleness check.
+func (m *DataQualityMonitor) CheckOrderbook(book OrderbookUpdateModel, at time.Time) bool {
+	issues := m.checkAge(book.Market, at)
+	bid, hasBid := bestPrice(book.Bid, true)
+	ask, hasAsk := bestPrice(book.Ask, false)
+	if hasBid && hasAsk && bid.GreaterThanOrEqual(ask) {
+		issues = append(issues, QualityEvent{
+			Issue:  QualityCrossedBook,
+			Market: book.Market,
+			Time:   at,
+			Detail: fmt.Sprintf("best bid %s at or above best ask %s", bid, ask),
+		})
+	}
+	return m.report(issues)
+}
+
+// CheckPrice checks a trade or mark price of a market read at at for
+// staleness and against the median of the recent prices checked for the
+// market. The price joins the recent prices either way, so a lasting move
+// stops being reported once it dominates the window. A zero at skips the
+// staleness check.
+func (m *DataQualityMonitor) CheckPrice(market string, price decimal.Decimal, at time.Time) bool {
+	issues := m.checkAge(market, at)
+	if event, ok := m.checkOutlier(market, price, at); !ok {
+		issues = append(issues, event)
+	}
+	return m.report(issues)
+}
+
+// CheckStats checks the market statistics of a REST response read at at for
+// staleness, a crossed top of book and an outlier last price
+func (m *DataQualityMonitor) CheckStats(market string, stats MarketStatsModel, at time.Time) bool {
+	issues := m.checkAge(market, at)
+	if stats.BidPrice.IsPositive() && stats.AskPrice.IsPositive() && stats.BidPrice.GreaterThanOrEqual(stats.AskPrice) {
+		issues = append(issues, QualityEvent{
+			Issue:  QualityCrossedBook,
+			Market: market,
+			Time:   at,
+			Detail: fmt.Sprintf("bid %s at or above ask %s", stats.BidPrice, stats.AskPrice),
+		})
+	}
+	if stats.LastPrice.IsPositive() {
+		if event, ok := m.checkOutlier(market, stats.LastPrice, at); !ok {
+			issues = append(issues, event)
+		}
+	}
+	return m.report(issues)
+}
+
+func (m *DataQualityMonitor) checkAge(market string, at time.Time) []QualityEvent {
+	if at.IsZero() {
+		return nil
+	}
+	age := m.cfg.Clock.Now().Sub(at)
+	if age <= m.cfg.MaxAge {
+		return nil
+	}
+	return []QualityEvent{{
+		Issue:  QualityStale,
+		Market: market,
+		Time:   at,
+		Detail: fmt.Sprintf("data is %s old, more than %s", age.Round(time.Millisecond), m.cfg.MaxAge),
+	}}
+}
+
+func (m *DataQualityMonitor) checkOutlier(market string, price decimal.Decimal, at time.Time) (QualityEvent, bool) {
+	m.mu.Lock()
+	recent := m.prices[market]
+	median := decimal.Zero
+	if len(recent) >= minQualityPrints {
+		median = medianPrice(recent)
+	}
+	recent = append(recent, price)
+	if len(recent) > m.cfg.Window {
+		recent = recent[len(recent)-m.cfg.Window:]
+	}
+	m.prices[market] = recent
+	m.mu.Unlock()
+
+	if !median.IsPositive() {
+		return QualityEvent{}, true
+	}
+	deviation := price.Sub(median).Abs().Div(median)
+	if deviation.LessThanOrEqual(m.cfg.MaxDeviation) {
+		return QualityEvent{}, true
+	}
+	return QualityEvent{
+		Issue:  QualityOutlier,
+		Market: market,
+		Time:   at,
+		Detail: fmt.Sprintf("price %s deviates %s%% from the median %s", price, deviation.Mul(decimal.NewFromInt(100)).Round(2), median),
+	}, false
+}
+
+func (m *DataQualityMonitor) report(issues []QualityEvent) bool {
+	if m.onEvent != nil {
+		for _, issue := range issues {
+			m.onEvent(issue)
+		}
+	}
+	return len(issues) == 0
+}
+
+// bestPrice returns the highest bid or lowest ask price of levels with a
+// positive quantity
+func bestPrice(levels []OrderbookQuantityModel, bid bool) (decimal.Decimal, bool) {
+	var best decimal.Decimal
+	found := false
+	for _, l := range levels {
+		if !l.Qty.IsPositive() {
+			continue
+		}
+		if !found || (bid && l.Price.GreaterThan(best)) || (!bid && l.Price.LessThan(best)) {
+			best, found = l.Price, true
+		}
+	}
+	return best, found
+}
+
+func medianPrice(prices []decimal.Decimal) decimal.Decimal {
+	sorted := append([]decimal.Decimal(nil), prices...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].LessThan(sorted[j]) })
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid]
+	}
+	return sorted[mid-1].Add(sorted[mid]).Div(decimal.NewFromInt(2))
+}
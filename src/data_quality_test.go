@@ -0,0 +1,51 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataQualityMonitor(t *testing.T) {
+	d := decimal.RequireFromString
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	var events []QualityEvent
+	m := NewDataQualityMonitor(QualityConfig{Clock: fixedClock(now)}, func(e QualityEvent) { events = append(events, e) })
+
+	book := OrderbookUpdateModel{
+		Market: "BTC-USD",
+		Bid:    []OrderbookQuantityModel{{Price: d("99"), Qty: d("1")}, {Price: d("100"), Qty: d("2")}},
+		Ask:    []OrderbookQuantityModel{{Price: d("101"), Qty: d("1")}},
+	}
+	assert.True(t, m.CheckOrderbook(book, now.Add(-time.Second)))
+	assert.True(t, m.CheckOrderbook(book, time.Time{}), "books without a timestamp are not stale")
+
+	book.Ask = append(book.Ask, OrderbookQuantityModel{Price: d("99.5"), Qty: d("1")})
+	assert.False(t, m.CheckOrderbook(book, now.Add(-10*time.Second)))
+	require.Len(t, events, 2)
+	assert.Equal(t, QualityStale, events[0].Issue)
+	assert.Equal(t, QualityCrossedBook, events[1].Issue)
+	assert.Equal(t, "BTC-USD", events[1].Market)
+	events = nil
+
+	// Outliers are judged once enough prices were seen
+	for _, price := range []string{"100", "101", "99", "100.5", "100"} {
+		assert.True(t, m.CheckPrice("BTC-USD", d(price), now))
+	}
+	assert.True(t, m.CheckPrice("BTC-USD", d("104"), now), "within 5% of the median")
+	assert.False(t, m.CheckPrice("BTC-USD", d("120"), now))
+	require.Len(t, events, 1)
+	assert.Equal(t, QualityOutlier, events[0].Issue)
+	assert.Contains(t, events[0].Detail, "median 100")
+	assert.True(t, m.CheckPrice("ETH-USD", d("3000"), now), "markets are judged separately")
+	events = nil
+
+	stats := MarketStatsModel{BidPrice: d("100"), AskPrice: d("100"), LastPrice: d("60")}
+	assert.False(t, m.CheckStats("BTC-USD", stats, now))
+	require.Len(t, events, 2)
+	assert.Equal(t, QualityCrossedBook, events[0].Issue)
+	assert.Equal(t, QualityOutlier, events[1].Issue)
+}
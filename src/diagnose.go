@@ -0,0 +1,148 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/extended-protocol/extended-sdk-golang/src/internal/websocket"
+)
+
+// maxClockSkew is the clock offset from the exchange above which the clock
+// check fails. Order expiry and time based nonces rely on the local clock.
+const maxClockSkew = 2 * time.Second
+
+// DiagnosticStatus is the outcome of a single diagnostic check
+type DiagnosticStatus string
+
+const (
+	DiagnosticOK      DiagnosticStatus = "OK"
+	DiagnosticFailed  DiagnosticStatus = "FAIL"
+	DiagnosticSkipped DiagnosticStatus = "SKIPPED"
+)
+
+// DiagnosticCheck is the result of a single diagnostic check
+type DiagnosticCheck struct {
+	Name    string
+	Status  DiagnosticStatus
+	Latency time.Duration
+	Detail  string
+	Err     error
+}
+
+// DiagnosticReport is the result of Diagnose
+type DiagnosticReport struct {
+	BaseURL string
+	At      time.Time
+	Checks  []DiagnosticCheck
+	// ClockSkew is the estimated offset of the local clock from the
+	// exchange, positive when the local clock is ahead
+	ClockSkew time.Duration
+}
+
+// OK reports whether no check failed
+func (r DiagnosticReport) OK() bool {
+	for _, check := range r.Checks {
+		if check.Status == DiagnosticFailed {
+			return false
+		}
+	}
+	return true
+}
+
+// String formats the report as a table for support requests
+func (r DiagnosticReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s at %s\n", r.BaseURL, r.At.UTC().Format(time.RFC3339))
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	for _, check := range r.Checks {
+		latency := "-"
+		if check.Latency > 0 {
+			latency = check.Latency.Round(time.Millisecond).String()
+		}
+		detail := check.Detail
+		if check.Err != nil {
+			// Errors may end in a response body with a trailing newline
+			detail = strings.TrimSpace(check.Err.Error())
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", check.Name, check.Status, latency, detail)
+	}
+	w.Flush()
+	return b.String()
+}
+
+// diagnosticStreamPath is the stream dialled by Diagnose: the funding rates
+// of all markets, which carries little traffic
+const diagnosticStreamPath = "/funding"
+
+// Diagnose checks REST latency, the offset of the local clock from the
+// exchange, when an API key is set that the key is accepted and, when the
+// endpoint has a StreamURL, that a stream connection opens. Failed
+// checks are reported in the result rather than as an error, so that the
+// whole report is available when something is wrong.
+func (c *APIClient) Diagnose(ctx context.Context) DiagnosticReport {
+	report := DiagnosticReport{BaseURL: c.EndpointConfig().APIBaseURL, At: c.Clock().Now()}
+
+	var f Freshness
+	markets, err := c.GetMarkets(WithFreshness(ctx, &f), nil)
+	rest := DiagnosticCheck{Name: "rest", Status: DiagnosticOK, Latency: f.ReceivedAt.Sub(f.RequestedAt)}
+	if err != nil {
+		rest.Status, rest.Err = DiagnosticFailed, err
+	} else {
+		rest.Detail = fmt.Sprintf("%d markets", len(markets))
+	}
+	report.Checks = append(report.Checks, rest)
+
+	clock := DiagnosticCheck{Name: "clock", Status: DiagnosticSkipped, Detail: "no Date header"}
+	if !f.ServerTime.IsZero() {
+		// The Date header is truncated to the second, so compare against the
+		// middle of that second and of the round trip
+		local := f.RequestedAt.Add(f.ReceivedAt.Sub(f.RequestedAt) / 2)
+		report.ClockSkew = local.Sub(f.ServerTime.Add(500 * time.Millisecond))
+		clock.Status, clock.Detail = DiagnosticOK, fmt.Sprintf("skew %s", report.ClockSkew.Round(time.Millisecond))
+		if report.ClockSkew > maxClockSkew || report.ClockSkew < -maxClockSkew {
+			clock.Status = DiagnosticFailed
+			clock.Detail += fmt.Sprintf(", more than %s", maxClockSkew)
+		}
+	}
+	report.Checks = append(report.Checks, clock)
+
+	auth := DiagnosticCheck{Name: "auth", Status: DiagnosticSkipped, Detail: "no API key"}
+	if _, err := c.APIKey(); err == nil {
+		start := c.Clock().Now()
+		_, err := c.GetBalance(ctx)
+		auth.Status, auth.Detail, auth.Latency = DiagnosticOK, "API key accepted", c.Clock().Now().Sub(start)
+		if err != nil {
+			auth.Status, auth.Err = DiagnosticFailed, err
+		}
+	}
+	report.Checks = append(report.Checks, auth)
+	report.Checks = append(report.Checks, c.diagnoseStream(ctx))
+	return report
+}
+
+// diagnoseStream opens and closes a connection to a public stream
+func (c *APIClient) diagnoseStream(ctx context.Context) DiagnosticCheck {
+	check := DiagnosticCheck{Name: "stream", Status: DiagnosticSkipped, Detail: "no stream URL"}
+	streamURL := strings.TrimRight(c.EndpointConfig().StreamURL, "/")
+	if streamURL == "" {
+		return check
+	}
+	if c.clientTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.clientTimeout)
+		defer cancel()
+	}
+	start := c.Clock().Now()
+	conn, err := websocket.Dial(ctx, streamURL+diagnosticStreamPath, websocket.DialOptions{TLSConfig: c.clientTLSConfig()})
+	check.Latency = c.Clock().Now().Sub(start)
+	if err != nil {
+		check.Status, check.Detail, check.Err = DiagnosticFailed, "", err
+		return check
+	}
+	conn.Close(websocket.CloseNormal, "")
+	check.Status, check.Detail = DiagnosticOK, "connected"
+	return check
+}
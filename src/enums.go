@@ -0,0 +1,24 @@
+package sdk
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidEnumValue is wrapped by the Parse* helpers when the input does
+// not name a known value
+var ErrInvalidEnumValue = errors.New("invalid enum value")
+
+// parseEnum matches s case-insensitively against values, ignoring surrounding
+// whitespace, and returns the canonical value
+func parseEnum[T ~string](kind string, values []T, s string) (T, error) {
+	s = strings.TrimSpace(s)
+	for _, v := range values {
+		if strings.EqualFold(string(v), s) {
+			return v, nil
+		}
+	}
+	var zero T
+	return zero, fmt.Errorf("%w: %q is not a valid %s", ErrInvalidEnumValue, s, kind)
+}
@@ -0,0 +1,39 @@
+package sdk
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnumIsValid(t *testing.T) {
+	assert.True(t, OrderTypeLimit.IsValid())
+	assert.True(t, CandleTypeMarkPrices.IsValid())
+	assert.False(t, OrderType("STOP").IsValid())
+	assert.False(t, TimeInForce("").IsValid())
+	assert.Equal(t, "PT15M", CandleInterval15Minutes.String())
+}
+
+func TestParseEnums(t *testing.T) {
+	side, err := ParseOrderSide(" buy ")
+	require.NoError(t, err)
+	assert.Equal(t, OrderSideBuy, side)
+
+	tif, err := ParseTimeInForce("ioc")
+	require.NoError(t, err)
+	assert.Equal(t, TimeInForceIOC, tif)
+
+	candleType, err := ParseCandleType("INDEX-PRICES")
+	require.NoError(t, err)
+	assert.Equal(t, CandleTypeIndexPrices, candleType)
+
+	interval, err := ParseCandleInterval("pt1h")
+	require.NoError(t, err)
+	assert.Equal(t, CandleInterval1Hour, interval)
+
+	_, err = ParseSelfTradeProtectionLevel("MARKET")
+	assert.True(t, errors.Is(err, ErrInvalidEnumValue))
+	assert.Contains(t, err.Error(), "self-trade protection level")
+}
@@ -0,0 +1,219 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// DefaultEquitySampleInterval is used by NewEquitySampler when no interval is given
+const DefaultEquitySampleInterval = time.Minute
+
+// EquitySample is a point of the account equity curve, with the leverage
+// and margin ratio of the account at that time
+type EquitySample struct {
+	Time          time.Time
+	Balance       decimal.Decimal
+	Equity        decimal.Decimal
+	UnrealisedPnl decimal.Decimal
+	Leverage      decimal.Decimal
+	MarginRatio   decimal.Decimal
+}
+
+// EquityStore persists equity samples. Implementations must be safe for use
+// by a single sampler goroutine; Append is never called concurrently by it.
+type EquityStore interface {
+	Append(ctx context.Context, sample EquitySample) error
+}
+
+// MemoryEquityStore keeps samples in memory
+type MemoryEquityStore struct {
+	mu      sync.Mutex
+	samples []EquitySample
+}
+
+// Append records a sample
+func (s *MemoryEquityStore) Append(_ context.Context, sample EquitySample) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.samples = append(s.samples, sample)
+	return nil
+}
+
+// Samples returns a copy of the recorded samples, oldest first
+func (s *MemoryEquityStore) Samples() []EquitySample {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]EquitySample(nil), s.samples...)
+}
+
+// EquitySampler records account balance, equity, leverage and margin ratio
+// to a store at a fixed cadence, building an equity curve for drawdown and
+// return metrics and a margin usage history for risk reports
+type EquitySampler struct {
+	client   *APIClient
+	store    EquityStore
+	interval time.Duration
+	onError  func(error)
+}
+
+// NewEquitySampler creates a sampler writing to store every interval. A
+// non-positive interval falls back to DefaultEquitySampleInterval. onError,
+// if not nil, is called when a sample cannot be taken or stored; sampling
+// continues with the next tick.
+func NewEquitySampler(client *APIClient, store EquityStore, interval time.Duration, onError func(error)) *EquitySampler {
+	if interval <= 0 {
+		interval = DefaultEquitySampleInterval
+	}
+	return &EquitySampler{
+		client:   client,
+		store:    store,
+		interval: interval,
+		onError:  onError,
+	}
+}
+
+// Sample takes a single sample and appends it to the store
+func (s *EquitySampler) Sample(ctx context.Context) (EquitySample, error) {
+	balance, err := s.client.GetBalance(ctx)
+	if err != nil {
+		return EquitySample{}, fmt.Errorf("failed to sample equity: %w", err)
+	}
+	sample := EquitySample{
+		Time:          s.client.Clock().Now(),
+		Balance:       balance.Balance,
+		Equity:        balance.Equity,
+		UnrealisedPnl: balance.UnrealisedPnl,
+		Leverage:      balance.Leverage,
+		MarginRatio:   balance.MarginRatio,
+	}
+	if err := s.store.Append(ctx, sample); err != nil {
+		return EquitySample{}, fmt.Errorf("failed to store equity sample: %w", err)
+	}
+	return sample, nil
+}
+
+// Start samples immediately and then every interval until the handle is
+// cancelled or ctx is done. The handle result is the number of samples stored.
+func (s *EquitySampler) Start(ctx context.Context) *OperationHandle[int] {
+	return startOperation(ctx, func(ctx context.Context, _ func(float64)) (int, error) {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		stored := 0
+		for {
+			if _, err := s.Sample(ctx); err == nil {
+				stored++
+			} else if ctx.Err() == nil && s.onError != nil {
+				s.onError(err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return stored, ctx.Err()
+			case <-ticker.C:
+			}
+		}
+	})
+}
+
+// MaxDrawdown returns the largest peak-to-trough decline of equity across the
+// samples as a fraction of the peak, e.g. 0.25 for a 25% drawdown
+func MaxDrawdown(samples []EquitySample) decimal.Decimal {
+	maxDrawdown := decimal.Zero
+	peak := decimal.Zero
+	for _, sample := range samples {
+		if sample.Equity.GreaterThan(peak) {
+			peak = sample.Equity
+			continue
+		}
+		if peak.IsPositive() {
+			if dd := peak.Sub(sample.Equity).Div(peak); dd.GreaterThan(maxDrawdown) {
+				maxDrawdown = dd
+			}
+		}
+	}
+	return maxDrawdown
+}
+
+// DailyRisk is the leverage and margin usage of the account over one UTC day
+type DailyRisk struct {
+	Day time.Time
+	// MaxLeverage is the highest leverage sampled during the day, at
+	// MaxLeverageTime
+	MaxLeverage     decimal.Decimal
+	MaxLeverageTime time.Time
+	// MarginUsage is the margin ratio weighted by how long it held during
+	// the part of the day covered by samples
+	MarginUsage decimal.Decimal
+}
+
+// DailyRiskReport summarises samples, oldest first, per UTC day. A sample's
+// margin ratio holds until the next sample, so the ratio sampled last on a
+// day carries into the next one. Days without any sample are skipped.
+func DailyRiskReport(samples []EquitySample) []DailyRisk {
+	var report []DailyRisk
+	for i := 0; i < len(samples); {
+		day := samples[i].Time.UTC().Truncate(24 * time.Hour)
+		risk := DailyRisk{Day: day}
+		j := i
+		for ; j < len(samples) && samples[j].Time.UTC().Truncate(24*time.Hour).Equal(day); j++ {
+			if j == i || samples[j].Leverage.GreaterThan(risk.MaxLeverage) {
+				risk.MaxLeverage, risk.MaxLeverageTime = samples[j].Leverage, samples[j].Time
+			}
+		}
+		from := day
+		if i == 0 {
+			from = samples[0].Time
+		}
+		to := day.Add(24 * time.Hour)
+		if last := samples[len(samples)-1].Time; last.Before(to) {
+			to = last
+		}
+		risk.MarginUsage = timeWeightedMarginRatio(samples, from, to)
+		report = append(report, risk)
+		i = j
+	}
+	return report
+}
+
+// TimeWeightedMarginRatio returns the margin ratio of the samples, oldest
+// first, weighted by how long each held until the next sample
+func TimeWeightedMarginRatio(samples []EquitySample) decimal.Decimal {
+	if len(samples) == 0 {
+		return decimal.Zero
+	}
+	return timeWeightedMarginRatio(samples, samples[0].Time, samples[len(samples)-1].Time)
+}
+
+// timeWeightedMarginRatio averages the margin ratio of the samples over
+// [from, to], each holding from its time until the next sample. An empty
+// range returns the ratio in force at from.
+func timeWeightedMarginRatio(samples []EquitySample, from, to time.Time) decimal.Decimal {
+	weighted, total := decimal.Zero, decimal.Zero
+	current := decimal.Zero
+	for i, sample := range samples {
+		if !sample.Time.After(from) {
+			current = sample.MarginRatio
+		}
+		start, end := sample.Time, to
+		if i+1 < len(samples) && samples[i+1].Time.Before(to) {
+			end = samples[i+1].Time
+		}
+		if start.Before(from) {
+			start = from
+		}
+		if !end.After(start) {
+			continue
+		}
+		seconds := decimal.NewFromFloat(end.Sub(start).Seconds())
+		weighted = weighted.Add(sample.MarginRatio.Mul(seconds))
+		total = total.Add(seconds)
+	}
+	if total.IsZero() {
+		return current
+	}
+	return weighted.Div(total)
+}
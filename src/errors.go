@@ -0,0 +1,173 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrUnknownMarket is returned when a requested market is not listed
+var ErrUnknownMarket = errors.New("unknown market")
+
+// ErrInvalidOrder is wrapped by every problem reported by order validation
+var ErrInvalidOrder = errors.New("invalid order")
+
+// ErrInvalidWithdrawalAmount is returned when a withdrawal amount falls
+// outside the limits of its chain
+var ErrInvalidWithdrawalAmount = errors.New("invalid withdrawal amount")
+
+// APIError is returned when the API responds with a non-success HTTP status.
+// Code and Message are populated when the body carries the exchange error object.
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	// LocalizedMessage is the message translated for end users, set when the
+	// exchange returns one, see WithLanguage
+	LocalizedMessage string
+	Body             string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API request failed with status %d: %s", e.StatusCode, e.Body)
+}
+
+// UserMessage returns the message to show end users: the localized message
+// if the exchange returned one, else the English message
+func (e *APIError) UserMessage() string {
+	if e.LocalizedMessage != "" {
+		return e.LocalizedMessage
+	}
+	return e.Message
+}
+
+// RequestError wraps the error of a request with where it was sent, so that
+// failures can be grouped by service, endpoint, market or order without
+// parsing messages. Every error returned by DoRequest is one. Its message is
+// that of Err, which errors.Is and errors.As still reach, e.g. an *APIError.
+type RequestError struct {
+	Service Service
+	// Endpoint is the method and path of the request, e.g. "POST /user/order"
+	Endpoint string
+	// Market and ExternalID are set for requests about a single market or
+	// order
+	Market     string
+	ExternalID string
+	Err        error
+}
+
+func (e *RequestError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+type requestSubjectKey struct{}
+
+type requestSubject struct {
+	market, externalID string
+}
+
+// withRequestSubject records the market and order the requests made with ctx
+// are about, for RequestError
+func withRequestSubject(ctx context.Context, market, externalID string) context.Context {
+	return context.WithValue(ctx, requestSubjectKey{}, requestSubject{market: market, externalID: externalID})
+}
+
+func newRequestError(ctx context.Context, endpoint string, err error) *RequestError {
+	subject, _ := ctx.Value(requestSubjectKey{}).(requestSubject)
+	return &RequestError{
+		Service:    serviceOf(endpoint),
+		Endpoint:   endpoint,
+		Market:     subject.market,
+		ExternalID: subject.externalID,
+		Err:        err,
+	}
+}
+
+// errorResponse is the error envelope returned by the API,
+// e.g. {"status":"ERROR","error":{"code":1100,"message":"..."}}
+type errorResponse struct {
+	Status string `json:"status"`
+	Error  struct {
+		Code             json.RawMessage `json:"code"`
+		Message          string          `json:"message"`
+		LocalizedMessage string          `json:"localizedMessage"`
+	} `json:"error"`
+}
+
+func newAPIError(statusCode int, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: statusCode,
+		Body:       string(body),
+	}
+	var envelope errorResponse
+	if err := json.Unmarshal(body, &envelope); err == nil {
+		// Codes are numeric on the exchange but tolerate string codes too
+		apiErr.Code = strings.Trim(string(envelope.Error.Code), `"`)
+		apiErr.Message = envelope.Error.Message
+		apiErr.LocalizedMessage = envelope.Error.LocalizedMessage
+	}
+	return apiErr
+}
+
+// orderRejectCodes maps the numeric error codes the exchange rejects orders
+// with to the reason of the rejection. Several codes share a reason, e.g.
+// every quantity check reports INVALID_QTY.
+var orderRejectCodes = map[string]OrderStatusReason{
+	"1001": OrderStatusReasonUnknownMarket,
+	"1002": OrderStatusReasonDisabledMarket,
+	"1100": OrderStatusReasonInvalidQty,
+	"1101": OrderStatusReasonInvalidQty,
+	"1102": OrderStatusReasonInvalidValue,
+	"1103": OrderStatusReasonInvalidQty,
+	"1104": OrderStatusReasonInvalidPrice,
+	"1105": OrderStatusReasonInvalidPrice,
+	"1108": OrderStatusReasonInvalidFee,
+	"1115": OrderStatusReasonInvalidExpireTime,
+	"1116": OrderStatusReasonReduceOnlyFailed,
+	"1117": OrderStatusReasonReduceOnlyFailed,
+	"1118": OrderStatusReasonReduceOnlyFailed,
+	"1120": OrderStatusReasonNotEnoughFunds,
+	"1121": OrderStatusReasonInvalidPrice,
+	"1136": OrderStatusReasonNoLiquidity,
+	"1137": OrderStatusReasonSelfTradeProtection,
+	"1138": OrderStatusReasonPostOnlyFailed,
+}
+
+// Reason returns the order status reason the error rejects an order for.
+// Numeric exchange codes are mapped to their reason; a code naming a reason,
+// e.g. "POST_ONLY_FAILED", is accepted as is.
+func (e *APIError) Reason() (OrderStatusReason, bool) {
+	if reason, ok := orderRejectCodes[e.Code]; ok {
+		return reason, true
+	}
+	if reason := OrderStatusReason(e.Code); reason.IsValid() {
+		return reason, true
+	}
+	return "", false
+}
+
+// isUnknownMarket reports whether err is the API rejecting a market name
+func isUnknownMarket(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return IsOrderRejected(err, OrderStatusReasonUnknownMarket) || apiErr.StatusCode == http.StatusNotFound
+}
+
+// IsOrderRejected reports whether err is an APIError rejecting an order for the given reason
+func IsOrderRejected(err error, reason OrderStatusReason) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	got, ok := apiErr.Reason()
+	return ok && got == reason
+}
@@ -0,0 +1,74 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAPIError(t *testing.T) {
+	apiErr := newAPIError(400, []byte(`{"status":"ERROR","error":{"code":1100,"message":"Invalid quantity","localizedMessage":"Ungültige Menge"}}`))
+	assert.Equal(t, "1100", apiErr.Code)
+	assert.Equal(t, "Invalid quantity", apiErr.Message)
+	assert.Equal(t, "Ungültige Menge", apiErr.UserMessage())
+	reason, ok := apiErr.Reason()
+	assert.True(t, ok)
+	assert.Equal(t, OrderStatusReasonInvalidQty, reason, "numeric codes map to their reason")
+
+	apiErr = newAPIError(400, []byte(`{"status":"ERROR","error":{"code":"UNKNOWN_MARKET","message":"Unknown market"}}`))
+	assert.Equal(t, "UNKNOWN_MARKET", apiErr.Code)
+	assert.Empty(t, apiErr.LocalizedMessage)
+	assert.Equal(t, "Unknown market", apiErr.UserMessage())
+	assert.True(t, IsOrderRejected(apiErr, OrderStatusReasonUnknownMarket))
+
+	apiErr = newAPIError(400, []byte(`{"status":"ERROR","error":{"code":9999}}`))
+	_, ok = apiErr.Reason()
+	assert.False(t, ok, "unmapped codes have no reason")
+
+	apiErr = newAPIError(502, []byte("Bad Gateway"))
+	assert.Empty(t, apiErr.UserMessage())
+	assert.Equal(t, "Bad Gateway", apiErr.Body)
+}
+
+func TestRequestError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"status":"ERROR","error":{"code":1120,"message":"Not enough funds"}}`))
+	}))
+	defer server.Close()
+	client := NewAPIClient(EndpointConfig{APIBaseURL: server.URL}, "key", nil, 5*time.Second)
+	ctx := context.Background()
+
+	_, err := client.SubmitOrder(ctx, &PerpetualOrderModel{ID: "ext-1", Market: "BTC-USD"})
+	var reqErr *RequestError
+	require.True(t, errors.As(err, &reqErr), "%v", err)
+	assert.Equal(t, ServiceTrading, reqErr.Service)
+	assert.Equal(t, "POST /user/order", reqErr.Endpoint)
+	assert.Equal(t, "BTC-USD", reqErr.Market)
+	assert.Equal(t, "ext-1", reqErr.ExternalID)
+	assert.True(t, IsOrderRejected(err, OrderStatusReasonNotEnoughFunds), "the API error is still reachable")
+	assert.Equal(t, reqErr.Err.Error(), err.Error())
+
+	err = client.CancelOrderByExternalID(ctx, "ext-2")
+	require.True(t, errors.As(err, &reqErr))
+	assert.Equal(t, "DELETE /user/order", reqErr.Endpoint)
+	assert.Empty(t, reqErr.Market)
+	assert.Equal(t, "ext-2", reqErr.ExternalID)
+
+	_, err = client.GetMarketStats(ctx, "ETH-USD")
+	require.True(t, errors.As(err, &reqErr))
+	assert.Equal(t, ServiceMarketData, reqErr.Service)
+	assert.Equal(t, "ETH-USD", reqErr.Market)
+
+	client.Close()
+	_, err = client.GetBalance(ctx)
+	require.True(t, errors.As(err, &reqErr))
+	assert.Equal(t, "GET /user/balance", reqErr.Endpoint)
+	assert.ErrorIs(t, err, ErrClientClosed)
+}
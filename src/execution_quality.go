@@ -0,0 +1,249 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/shopspring/decimal"
+)
+
+var bpsPerUnit = decimal.NewFromInt(10000)
+
+// ExecutionFill is a single fill of a tracked order
+type ExecutionFill struct {
+	Price decimal.Decimal
+	Qty   decimal.Decimal
+	// Maker is true when the fill provided liquidity
+	Maker bool
+	// Mid is the mid price when the fill happened, used for the effective
+	// spread. Zero falls back to the arrival mid.
+	Mid decimal.Decimal
+}
+
+// OrderExecution is the execution quality of a single order. Slippage and
+// effective spread are in basis points and signed so that positive values
+// are a cost: paying above the mid for buys, receiving below it for sells.
+type OrderExecution struct {
+	ExternalID   string
+	Market       string
+	Side         OrderSide
+	Tag          string
+	ArrivalMid   decimal.Decimal
+	FilledQty    decimal.Decimal
+	AveragePrice decimal.Decimal
+	MakerQty     decimal.Decimal
+	TakerQty     decimal.Decimal
+	// SlippageBps compares the average fill price with the arrival mid
+	SlippageBps decimal.Decimal
+	// EffectiveSpreadBps is twice the quantity weighted distance of the fills
+	// from the mid prevailing at each fill
+	EffectiveSpreadBps decimal.Decimal
+}
+
+// ExecutionSummary aggregates the execution quality of several orders.
+// Slippage and effective spread are weighted by filled notional.
+type ExecutionSummary struct {
+	Orders             int
+	FilledQty          decimal.Decimal
+	Notional           decimal.Decimal
+	MakerQty           decimal.Decimal
+	TakerQty           decimal.Decimal
+	SlippageBps        decimal.Decimal
+	EffectiveSpreadBps decimal.Decimal
+}
+
+// MakerRatio returns the fraction of the filled quantity that provided liquidity
+func (s ExecutionSummary) MakerRatio() decimal.Decimal {
+	if !s.FilledQty.IsPositive() {
+		return decimal.Zero
+	}
+	return s.MakerQty.Div(s.FilledQty)
+}
+
+// ExecutionReport holds the execution quality of every tracked order with
+// fills, oldest first, along with totals overall and per strategy tag
+type ExecutionReport struct {
+	Orders []OrderExecution
+	ByTag  map[string]ExecutionSummary
+	Total  ExecutionSummary
+}
+
+type trackedOrder struct {
+	market     string
+	side       OrderSide
+	tag        string
+	arrivalMid decimal.Decimal
+	fills      []ExecutionFill
+}
+
+// ExecutionTracker measures execution quality of orders against the mid price
+// at the time they were sent. Arrivals are recorded when an order is
+// submitted and fills are pushed in as they arrive, e.g. from an account
+// stream or by polling the order.
+type ExecutionTracker struct {
+	mu     sync.Mutex
+	orders map[string]*trackedOrder
+	ids    []string // in arrival order
+}
+
+// NewExecutionTracker creates an empty tracker
+func NewExecutionTracker() *ExecutionTracker {
+	return &ExecutionTracker{orders: make(map[string]*trackedOrder)}
+}
+
+// RecordArrival starts tracking an order with the mid price at submission.
+// The tag groups orders in reports, e.g. by strategy; it may be empty.
+// Recording an arrival again for the same external ID resets its fills.
+func (t *ExecutionTracker) RecordArrival(externalID, market string, side OrderSide, tag string, arrivalMid decimal.Decimal) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.orders[externalID]; !ok {
+		t.ids = append(t.ids, externalID)
+	}
+	t.orders[externalID] = &trackedOrder{market: market, side: side, tag: tag, arrivalMid: arrivalMid}
+}
+
+// RecordFill adds a fill to a tracked order. It reports false if no arrival
+// was recorded for the external ID.
+func (t *ExecutionTracker) RecordFill(externalID string, fill ExecutionFill) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	o, ok := t.orders[externalID]
+	if !ok {
+		return false
+	}
+	o.fills = append(o.fills, fill)
+	return true
+}
+
+// Submit records the arrival mid from the top of the book and submits the
+// order through client
+func (t *ExecutionTracker) Submit(ctx context.Context, client *APIClient, order *PerpetualOrderModel, tag string) (*OrderResponse, error) {
+	if order == nil {
+		return nil, fmt.Errorf("order is nil")
+	}
+	book, err := client.GetOrderbookSnapshot(ctx, order.Market, WithDepth(1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch arrival price: %w", err)
+	}
+	mid, ok := book.MidPrice()
+	if !ok {
+		return nil, fmt.Errorf("no arrival price: orderbook of %s is one-sided", order.Market)
+	}
+	t.RecordArrival(order.ID, order.Market, order.Side, tag, mid)
+	return client.SubmitOrder(ctx, order)
+}
+
+// Order returns the execution quality of a tracked order. The boolean is
+// false if the order is not tracked.
+func (t *ExecutionTracker) Order(externalID string) (OrderExecution, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	o, ok := t.orders[externalID]
+	if !ok {
+		return OrderExecution{}, false
+	}
+	return o.execution(externalID), true
+}
+
+// Report computes the execution quality of every tracked order with fills
+func (t *ExecutionTracker) Report() ExecutionReport {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	report := ExecutionReport{ByTag: make(map[string]ExecutionSummary)}
+	var total summaryBuilder
+	tags := make(map[string]*summaryBuilder)
+	for _, id := range t.ids {
+		o := t.orders[id]
+		if len(o.fills) == 0 {
+			continue
+		}
+		execution := o.execution(id)
+		report.Orders = append(report.Orders, execution)
+		total.add(execution)
+		if tags[o.tag] == nil {
+			tags[o.tag] = &summaryBuilder{}
+		}
+		tags[o.tag].add(execution)
+	}
+	for tag, b := range tags {
+		report.ByTag[tag] = b.summary()
+	}
+	report.Total = total.summary()
+	return report
+}
+
+func (o *trackedOrder) execution(externalID string) OrderExecution {
+	e := OrderExecution{
+		ExternalID: externalID,
+		Market:     o.market,
+		Side:       o.side,
+		Tag:        o.tag,
+		ArrivalMid: o.arrivalMid,
+	}
+	notional := decimal.Zero
+	spreadCost := decimal.Zero
+	for _, fill := range o.fills {
+		e.FilledQty = e.FilledQty.Add(fill.Qty)
+		notional = notional.Add(fill.Price.Mul(fill.Qty))
+		if fill.Maker {
+			e.MakerQty = e.MakerQty.Add(fill.Qty)
+		} else {
+			e.TakerQty = e.TakerQty.Add(fill.Qty)
+		}
+		mid := fill.Mid
+		if mid.IsZero() {
+			mid = o.arrivalMid
+		}
+		if mid.IsPositive() {
+			spreadCost = spreadCost.Add(o.costBps(fill.Price, mid).Mul(fill.Qty))
+		}
+	}
+	if !e.FilledQty.IsPositive() {
+		return e
+	}
+	e.AveragePrice = notional.Div(e.FilledQty)
+	e.EffectiveSpreadBps = spreadCost.Div(e.FilledQty).Mul(decimal.NewFromInt(2))
+	if o.arrivalMid.IsPositive() {
+		e.SlippageBps = o.costBps(e.AveragePrice, o.arrivalMid)
+	}
+	return e
+}
+
+// costBps is the distance of price from mid in basis points, positive when
+// it is worse than the mid for the side of the order
+func (o *trackedOrder) costBps(price, mid decimal.Decimal) decimal.Decimal {
+	cost := price.Sub(mid).Div(mid).Mul(bpsPerUnit)
+	if o.side == OrderSideSell {
+		cost = cost.Neg()
+	}
+	return cost
+}
+
+type summaryBuilder struct {
+	s          ExecutionSummary
+	slippage   decimal.Decimal
+	spreadCost decimal.Decimal
+}
+
+func (b *summaryBuilder) add(e OrderExecution) {
+	notional := e.AveragePrice.Mul(e.FilledQty)
+	b.s.Orders++
+	b.s.FilledQty = b.s.FilledQty.Add(e.FilledQty)
+	b.s.Notional = b.s.Notional.Add(notional)
+	b.s.MakerQty = b.s.MakerQty.Add(e.MakerQty)
+	b.s.TakerQty = b.s.TakerQty.Add(e.TakerQty)
+	b.slippage = b.slippage.Add(e.SlippageBps.Mul(notional))
+	b.spreadCost = b.spreadCost.Add(e.EffectiveSpreadBps.Mul(notional))
+}
+
+func (b *summaryBuilder) summary() ExecutionSummary {
+	s := b.s
+	if s.Notional.IsPositive() {
+		s.SlippageBps = b.slippage.Div(s.Notional)
+		s.EffectiveSpreadBps = b.spreadCost.Div(s.Notional)
+	}
+	return s
+}
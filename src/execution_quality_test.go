@@ -0,0 +1,59 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func d(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+func TestExecutionTracker(t *testing.T) {
+	tracker := NewExecutionTracker()
+	tracker.RecordArrival("buy-1", "BTC-USD", OrderSideBuy, "momentum", d("50000"))
+	tracker.RecordArrival("sell-1", "BTC-USD", OrderSideSell, "maker", d("50000"))
+	tracker.RecordArrival("idle", "BTC-USD", OrderSideBuy, "maker", d("50000"))
+
+	// Taker buy walking the book while the mid moves up
+	require.True(t, tracker.RecordFill("buy-1", ExecutionFill{Price: d("50010"), Qty: d("1"), Mid: d("50000")}))
+	require.True(t, tracker.RecordFill("buy-1", ExecutionFill{Price: d("50030"), Qty: d("1"), Mid: d("50020")}))
+	// Passive sell filled above the mid
+	require.True(t, tracker.RecordFill("sell-1", ExecutionFill{Price: d("50005"), Qty: d("2"), Maker: true}))
+	assert.False(t, tracker.RecordFill("unknown", ExecutionFill{Price: d("1"), Qty: d("1")}))
+
+	buy, ok := tracker.Order("buy-1")
+	require.True(t, ok)
+	assert.Equal(t, "50020", buy.AveragePrice.String())
+	assert.Equal(t, "4", buy.SlippageBps.String())
+	assert.True(t, buy.EffectiveSpreadBps.Sub(d("3.999")).Abs().LessThan(d("0.001")), buy.EffectiveSpreadBps.String())
+	assert.Equal(t, "2", buy.TakerQty.String())
+
+	sell, ok := tracker.Order("sell-1")
+	require.True(t, ok)
+	assert.Equal(t, "-1", sell.SlippageBps.String())
+	assert.Equal(t, "-2", sell.EffectiveSpreadBps.String())
+
+	report := tracker.Report()
+	require.Len(t, report.Orders, 2)
+	assert.Equal(t, "buy-1", report.Orders[0].ExternalID)
+	assert.Equal(t, 1, report.ByTag["maker"].Orders)
+	assert.Equal(t, "1", report.ByTag["maker"].MakerRatio().String())
+	assert.Equal(t, "0.5", report.Total.MakerRatio().String())
+	assert.Equal(t, "200050", report.Total.Notional.String())
+	// Notional weighted: (4 * 100040 - 1 * 100010) / 200050
+	assert.Equal(t, "1.5", report.Total.SlippageBps.Round(1).String())
+}
+
+func TestOrderbookMidPrice(t *testing.T) {
+	book := OrderbookUpdateModel{Bid: levels("99", "1"), Ask: levels("101", "1")}
+	mid, ok := book.MidPrice()
+	require.True(t, ok)
+	assert.Equal(t, "100", mid.String())
+
+	_, ok = OrderbookUpdateModel{Bid: levels("99", "1")}.MidPrice()
+	assert.False(t, ok)
+}
@@ -0,0 +1,96 @@
+package sdk
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+// exportTimeLayout formats timestamps in exports, always in UTC
+const exportTimeLayout = "2006-01-02 15:04:05"
+
+type csvColumn[T any] struct {
+	header string
+	value  func(T) string
+}
+
+// orderCSVColumns is the column layout of the order history download of the
+// exchange web UI
+var orderCSVColumns = []csvColumn[OpenOrderModel]{
+	{"Date", func(o OpenOrderModel) string { return exportTime(o.CreatedTime) }},
+	{"Market", func(o OpenOrderModel) string { return o.Market }},
+	{"Type", func(o OpenOrderModel) string { return string(o.Type) }},
+	{"Side", func(o OpenOrderModel) string { return string(o.Side) }},
+	{"Price", func(o OpenOrderModel) string { return o.Price.String() }},
+	{"Average Price", func(o OpenOrderModel) string { return o.AveragePrice.String() }},
+	{"Size", func(o OpenOrderModel) string { return o.Qty.String() }},
+	{"Filled", func(o OpenOrderModel) string { return o.FilledQty.String() }},
+	{"Status", func(o OpenOrderModel) string { return string(o.Status) }},
+	{"Reduce Only", func(o OpenOrderModel) string { return strconv.FormatBool(o.ReduceOnly) }},
+	{"Post Only", func(o OpenOrderModel) string { return strconv.FormatBool(o.PostOnly) }},
+	{"Order ID", func(o OpenOrderModel) string { return strconv.FormatInt(o.ID, 10) }},
+	{"External ID", func(o OpenOrderModel) string { return o.ExternalID }},
+}
+
+// tradeCSVColumns is the column layout of the trade history download of the
+// exchange web UI
+var tradeCSVColumns = []csvColumn[AccountTradeModel]{
+	{"Date", func(t AccountTradeModel) string { return exportTime(t.CreatedTime) }},
+	{"Market", func(t AccountTradeModel) string { return t.Market }},
+	{"Side", func(t AccountTradeModel) string { return string(t.Side) }},
+	{"Type", func(t AccountTradeModel) string { return string(t.TradeType) }},
+	{"Price", func(t AccountTradeModel) string { return t.Price.String() }},
+	{"Size", func(t AccountTradeModel) string { return t.Qty.String() }},
+	{"Value", func(t AccountTradeModel) string { return t.Value.String() }},
+	{"Fee", func(t AccountTradeModel) string { return t.Fee.String() }},
+	{"Liquidity", func(t AccountTradeModel) string {
+		if t.IsTaker {
+			return "Taker"
+		}
+		return "Maker"
+	}},
+	{"Trade ID", func(t AccountTradeModel) string { return strconv.FormatInt(t.ID, 10) }},
+	{"Order ID", func(t AccountTradeModel) string { return strconv.FormatInt(t.OrderID, 10) }},
+}
+
+// WriteOrdersCSV writes orders as CSV in the layout of the order history
+// download of the exchange web UI, so that exports can be diffed against it.
+// Times are in UTC.
+func WriteOrdersCSV(w io.Writer, orders []OpenOrderModel) error {
+	return writeCSV(w, orderCSVColumns, orders)
+}
+
+// WriteTradesCSV writes trades as CSV in the layout of the trade history
+// download of the exchange web UI. Times are in UTC.
+func WriteTradesCSV(w io.Writer, trades []AccountTradeModel) error {
+	return writeCSV(w, tradeCSVColumns, trades)
+}
+
+func writeCSV[T any](w io.Writer, columns []csvColumn[T], rows []T) error {
+	cw := csv.NewWriter(w)
+	record := make([]string, len(columns))
+	for i, column := range columns {
+		record[i] = column.header
+	}
+	if err := cw.Write(record); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		for i, column := range columns {
+			record[i] = column.value(row)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func exportTime(millis int64) string {
+	if millis <= 0 {
+		return ""
+	}
+	return time.UnixMilli(millis).UTC().Format(exportTimeLayout)
+}
@@ -0,0 +1,41 @@
+package sdk
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteTradesCSV(t *testing.T) {
+	trades := []AccountTradeModel{
+		{ID: 7, OrderID: 3, Market: "BTC-USD", Side: OrderSideBuy, TradeType: TradeTypeTrade,
+			Price: d("40000.5"), Qty: d("0.01"), Value: d("400.005"), Fee: d("0.2"), IsTaker: true, CreatedTime: 1709251200000},
+		{ID: 8, OrderID: 4, Market: "ETH-USD", Side: OrderSideSell, TradeType: TradeTypeLiquidation,
+			Price: d("3000"), Qty: d("1"), Value: d("3000"), Fee: d("0"), CreatedTime: 1709251201500},
+	}
+	var b strings.Builder
+	require.NoError(t, WriteTradesCSV(&b, trades))
+	assert.Equal(t, `Date,Market,Side,Type,Price,Size,Value,Fee,Liquidity,Trade ID,Order ID
+2024-03-01 00:00:00,BTC-USD,BUY,TRADE,40000.5,0.01,400.005,0.2,Taker,7,3
+2024-03-01 00:00:01,ETH-USD,SELL,LIQUIDATION,3000,1,3000,0,Maker,8,4
+`, b.String())
+}
+
+func TestWriteOrdersCSV(t *testing.T) {
+	orders := []OpenOrderModel{{
+		ID: 3, ExternalID: "ext,1", Market: "BTC-USD", Type: OrderTypeLimit, Side: OrderSideBuy,
+		Status: OrderStatusPartiallyFilled, Price: d("40000"), AveragePrice: d("39999.5"),
+		Qty: d("1"), FilledQty: d("0.4"), PostOnly: true, CreatedTime: 1709251200000,
+	}}
+	var b strings.Builder
+	require.NoError(t, WriteOrdersCSV(&b, orders))
+	assert.Equal(t, `Date,Market,Type,Side,Price,Average Price,Size,Filled,Status,Reduce Only,Post Only,Order ID,External ID
+2024-03-01 00:00:00,BTC-USD,LIMIT,BUY,40000,39999.5,1,0.4,PARTIALLY_FILLED,false,true,3,"ext,1"
+`, b.String())
+
+	b.Reset()
+	require.NoError(t, WriteOrdersCSV(&b, nil))
+	assert.Equal(t, 1, strings.Count(b.String(), "\n"), "header only")
+}
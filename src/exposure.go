@@ -0,0 +1,170 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/extended-protocol/extended-sdk-golang/src/internal/fanout"
+	"github.com/shopspring/decimal"
+)
+
+// AssetExposure is the exposure to a single asset, netted across the markets
+// trading it. Values are notional, in collateral.
+type AssetExposure struct {
+	Asset   string
+	Markets []string
+	Long    decimal.Decimal
+	Short   decimal.Decimal
+	// Gross is Long plus Short
+	Gross decimal.Decimal
+	// Net is Long minus Short, negative for a net short asset
+	Net decimal.Decimal
+	// Leverage is Gross over the account equity
+	Leverage decimal.Decimal
+	// LeverageUtilization is the margin the positions would need at the
+	// maximum leverage of their markets, over the account equity. 1 means
+	// the asset alone uses all the leverage the equity allows.
+	LeverageUtilization decimal.Decimal
+	// Concentration is Gross as a fraction of the gross exposure of the
+	// account
+	Concentration decimal.Decimal
+}
+
+// ExposureReport is the long and short exposure of an account across all
+// its positions, for risk dashboards. Assets are ordered by gross exposure,
+// largest first.
+type ExposureReport struct {
+	Equity              decimal.Decimal
+	Long                decimal.Decimal
+	Short               decimal.Decimal
+	Gross               decimal.Decimal
+	Net                 decimal.Decimal
+	Leverage            decimal.Decimal
+	LeverageUtilization decimal.Decimal
+	Assets              []AssetExposure
+}
+
+// Asset returns the exposure to a single asset, zero if there is none
+func (r ExposureReport) Asset(asset string) AssetExposure {
+	for _, a := range r.Assets {
+		if a.Asset == asset {
+			return a
+		}
+	}
+	return AssetExposure{Asset: asset}
+}
+
+// ComputeExposure nets positions per asset, valuing them at their reported
+// notional value or else at size times mark price. markets maps positions to their
+// asset and maximum leverage; positions of markets not among them are
+// grouped under the base of the market name, e.g. BTC for BTC-USD, and do
+// not count towards leverage utilization. Ratios are zero when equity is not
+// positive.
+func ComputeExposure(positions []PositionModel, markets []MarketModel, equity decimal.Decimal) ExposureReport {
+	byName := make(map[string]MarketModel, len(markets))
+	for _, m := range markets {
+		byName[m.Name] = m
+	}
+
+	report := ExposureReport{Equity: equity}
+	assets := make(map[string]*AssetExposure)
+	margin := make(map[string]decimal.Decimal)
+	for _, p := range positions {
+		value := p.Value.Abs()
+		if value.IsZero() {
+			value = p.Size.Mul(p.MarkPrice).Abs()
+		}
+		if value.IsZero() {
+			continue
+		}
+		market, known := byName[p.Market]
+		asset := market.AssetName
+		if !known || asset == "" {
+			asset, _, _ = strings.Cut(p.Market, "-")
+		}
+		a, ok := assets[asset]
+		if !ok {
+			a = &AssetExposure{Asset: asset}
+			assets[asset] = a
+		}
+		if !slices.Contains(a.Markets, p.Market) {
+			a.Markets = append(a.Markets, p.Market)
+		}
+		if p.Side == PositionSideShort {
+			a.Short = a.Short.Add(value)
+		} else {
+			a.Long = a.Long.Add(value)
+		}
+		if maxLeverage := market.TradingConfig.MaxLeverage; known && maxLeverage.IsPositive() {
+			margin[asset] = margin[asset].Add(value.Div(maxLeverage))
+		}
+	}
+
+	totalMargin := decimal.Zero
+	for _, a := range assets {
+		a.Gross, a.Net = a.Long.Add(a.Short), a.Long.Sub(a.Short)
+		sort.Strings(a.Markets)
+		report.Long, report.Short = report.Long.Add(a.Long), report.Short.Add(a.Short)
+		totalMargin = totalMargin.Add(margin[a.Asset])
+	}
+	report.Gross, report.Net = report.Long.Add(report.Short), report.Long.Sub(report.Short)
+	if equity.IsPositive() {
+		report.Leverage = report.Gross.Div(equity)
+		report.LeverageUtilization = totalMargin.Div(equity)
+	}
+
+	report.Assets = make([]AssetExposure, 0, len(assets))
+	for _, a := range assets {
+		if equity.IsPositive() {
+			a.Leverage = a.Gross.Div(equity)
+			a.LeverageUtilization = margin[a.Asset].Div(equity)
+		}
+		if report.Gross.IsPositive() {
+			a.Concentration = a.Gross.Div(report.Gross)
+		}
+		report.Assets = append(report.Assets, *a)
+	}
+	sort.Slice(report.Assets, func(i, j int) bool {
+		if c := report.Assets[i].Gross.Cmp(report.Assets[j].Gross); c != 0 {
+			return c > 0
+		}
+		return report.Assets[i].Asset < report.Assets[j].Asset
+	})
+	return report
+}
+
+// GetExposure fetches the positions, markets and balance of the account and
+// returns its exposure, see ComputeExposure
+func (c *APIClient) GetExposure(ctx context.Context) (*ExposureReport, error) {
+	var (
+		positions []PositionModel
+		markets   []MarketModel
+		balance   *BalanceModel
+	)
+	err := fanout.Run(ctx, 3, 3, func(ctx context.Context, i int) error {
+		var err error
+		switch i {
+		case 0:
+			if positions, err = c.GetPositions(ctx, nil); err != nil {
+				return fmt.Errorf("failed to get positions: %w", err)
+			}
+		case 1:
+			if markets, err = c.GetMarkets(ctx, nil); err != nil {
+				return fmt.Errorf("failed to list markets: %w", err)
+			}
+		case 2:
+			if balance, err = c.GetBalance(ctx); err != nil {
+				return fmt.Errorf("failed to get balance: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	report := ComputeExposure(positions, markets, balance.Equity)
+	return &report, nil
+}
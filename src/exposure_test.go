@@ -0,0 +1,55 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeExposure(t *testing.T) {
+	d := decimal.RequireFromString
+	markets := []MarketModel{
+		{Name: "BTC-USD", AssetName: "BTC", TradingConfig: TradingConfigModel{MaxLeverage: d("50")}},
+		{Name: "BTC-USDT", AssetName: "BTC", TradingConfig: TradingConfigModel{MaxLeverage: d("20")}},
+		{Name: "ETH-USD", AssetName: "ETH", TradingConfig: TradingConfigModel{MaxLeverage: d("25")}},
+	}
+	positions := []PositionModel{
+		{Market: "BTC-USD", Side: PositionSideLong, Value: d("5000")},
+		{Market: "BTC-USDT", Side: PositionSideShort, Value: d("2000")},
+		{Market: "ETH-USD", Side: PositionSideShort, Size: d("1"), MarkPrice: d("2500")},
+		// Not listed: grouped by the market name, no maximum leverage
+		{Market: "SOL-USD", Side: PositionSideLong, Value: d("500")},
+		{Market: "DOGE-USD", Side: PositionSideLong},
+	}
+
+	report := ComputeExposure(positions, markets, d("2000"))
+	assert.Equal(t, "5500", report.Long.String())
+	assert.Equal(t, "4500", report.Short.String())
+	assert.Equal(t, "10000", report.Gross.String())
+	assert.Equal(t, "1000", report.Net.String())
+	assert.Equal(t, "5", report.Leverage.String())
+	// 5000/50 + 2000/20 + 2500/25 = 300 of margin at maximum leverage
+	assert.Equal(t, "0.15", report.LeverageUtilization.String())
+
+	require.Len(t, report.Assets, 3, "empty positions are skipped")
+	assert.Equal(t, []string{"BTC", "ETH", "SOL"}, []string{report.Assets[0].Asset, report.Assets[1].Asset, report.Assets[2].Asset})
+	btc := report.Asset("BTC")
+	assert.Equal(t, []string{"BTC-USD", "BTC-USDT"}, btc.Markets)
+	assert.Equal(t, "7000", btc.Gross.String())
+	assert.Equal(t, "3000", btc.Net.String())
+	assert.Equal(t, "3.5", btc.Leverage.String())
+	assert.Equal(t, "0.1", btc.LeverageUtilization.String())
+	assert.Equal(t, "0.7", btc.Concentration.String())
+	eth := report.Asset("ETH")
+	assert.Equal(t, "-2500", eth.Net.String())
+	assert.Equal(t, "0.25", eth.Concentration.String())
+	assert.True(t, report.Asset("SOL").LeverageUtilization.IsZero())
+	assert.True(t, report.Asset("XRP").Gross.IsZero())
+
+	report = ComputeExposure(positions, markets, decimal.Zero)
+	assert.True(t, report.Leverage.IsZero())
+	assert.Equal(t, "0.7", report.Asset("BTC").Concentration.String(), "concentration does not need equity")
+	assert.Empty(t, ComputeExposure(nil, markets, d("1000")).Assets)
+}
@@ -0,0 +1,139 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/shopspring/decimal"
+)
+
+// feeCache holds the trading fees of markets already fetched by the client
+type feeCache struct {
+	mu   sync.Mutex
+	fees map[string]TradingFeeModel
+}
+
+func (f *feeCache) store(fees ...TradingFeeModel) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.fees == nil {
+		f.fees = make(map[string]TradingFeeModel)
+	}
+	for _, fee := range fees {
+		f.fees[fee.Market] = fee
+	}
+}
+
+func (f *feeCache) lookup(market string) (TradingFeeModel, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	fees, ok := f.fees[market]
+	return fees, ok
+}
+
+// WithManualFees stops the client from managing fees on its own: GetMarketFee
+// no longer fills the fee cache and PlaceOrder signs orders without Fees set
+// with DefaultFees rather than the cached fees of their market
+func WithManualFees() ClientOption {
+	return func(m *BaseModule) {
+		m.manualFees = true
+	}
+}
+
+// CachedMarketFee returns the trading fees of a market, fetching them on
+// first use and serving later calls from memory. The result can be passed as
+// CreateOrderObjectParams.Fees.
+func (c *APIClient) CachedMarketFee(ctx context.Context, market string) (*TradingFeeModel, error) {
+	if fees, ok := c.fees.lookup(market); ok {
+		return &fees, nil
+	}
+
+	all, err := c.GetMarketFee(ctx, market)
+	if err != nil {
+		return nil, err
+	}
+	for _, fees := range all {
+		if fees.Market == market {
+			c.fees.store(fees)
+			return &fees, nil
+		}
+	}
+	return nil, fmt.Errorf("no fees returned for market %s", market)
+}
+
+// InvalidateFeeCache drops all cached fees, e.g. after the account moved to
+// a different fee tier
+func (c *APIClient) InvalidateFeeCache() {
+	c.fees.mu.Lock()
+	defer c.fees.mu.Unlock()
+	c.fees.fees = nil
+}
+
+// FeeCheck configures fee reconciliation
+type FeeCheck struct {
+	// Tolerance is the absolute fee difference that is not reported, e.g. to
+	// allow for rounding by the exchange
+	Tolerance decimal.Decimal
+	// BuilderOrders holds the IDs of orders placed with a builder fee, whose
+	// trades also pay the builder fee rate
+	BuilderOrders map[int64]bool
+}
+
+// FeeDiscrepancy is a trade whose charged fee differs from the fee schedule
+type FeeDiscrepancy struct {
+	Trade    AccountTradeModel
+	Expected decimal.Decimal
+	// Difference is the charged fee minus the expected one, positive when
+	// the account was overcharged
+	Difference decimal.Decimal
+}
+
+// ExpectedTradeFee returns the fee a trade should be charged under fees: the
+// taker or maker rate, plus the builder rate if withBuilder, times the trade value
+func ExpectedTradeFee(trade AccountTradeModel, fees TradingFeeModel, withBuilder bool) decimal.Decimal {
+	rate := fees.MakerFeeRate
+	if trade.IsTaker {
+		rate = fees.TakerFeeRate
+	}
+	if withBuilder {
+		rate = rate.Add(fees.BuilderFeeRate)
+	}
+	return trade.Value.Abs().Mul(rate)
+}
+
+// ReconcileFees recomputes the fee of every trade from the schedule of its
+// market and returns the trades charged differently, in input order. It
+// fails if the schedule lacks a market that was traded.
+func ReconcileFees(trades []AccountTradeModel, schedule map[string]TradingFeeModel, check FeeCheck) ([]FeeDiscrepancy, error) {
+	var discrepancies []FeeDiscrepancy
+	for _, trade := range trades {
+		fees, ok := schedule[trade.Market]
+		if !ok {
+			return nil, fmt.Errorf("no fee schedule for market %s", trade.Market)
+		}
+		expected := ExpectedTradeFee(trade, fees, check.BuilderOrders[trade.OrderID])
+		if diff := trade.Fee.Sub(expected); diff.Abs().GreaterThan(check.Tolerance) {
+			discrepancies = append(discrepancies, FeeDiscrepancy{Trade: trade, Expected: expected, Difference: diff})
+		}
+	}
+	return discrepancies, nil
+}
+
+// ReconcileTradeFees checks trades against the fee schedules returned by
+// CachedMarketFee, see ReconcileFees. The cached schedules are current, so
+// trades from before a fee tier change are reported as discrepancies.
+func (c *APIClient) ReconcileTradeFees(ctx context.Context, trades []AccountTradeModel, check FeeCheck) ([]FeeDiscrepancy, error) {
+	schedule := make(map[string]TradingFeeModel)
+	for _, trade := range trades {
+		if _, ok := schedule[trade.Market]; ok {
+			continue
+		}
+		fees, err := c.CachedMarketFee(ctx, trade.Market)
+		if err != nil {
+			return nil, err
+		}
+		schedule[trade.Market] = *fees
+	}
+	return ReconcileFees(trades, schedule, check)
+}
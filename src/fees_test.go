@@ -0,0 +1,32 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconcileFees(t *testing.T) {
+	schedule := map[string]TradingFeeModel{
+		"BTC-USD": {Market: "BTC-USD", MakerFeeRate: d("0.0002"), TakerFeeRate: d("0.0005"), BuilderFeeRate: d("0.0001")},
+	}
+	trades := []AccountTradeModel{
+		{ID: 1, OrderID: 10, Market: "BTC-USD", Value: d("10000"), Fee: d("5"), IsTaker: true},
+		{ID: 2, OrderID: 11, Market: "BTC-USD", Value: d("-10000"), Fee: d("2")},
+		{ID: 3, OrderID: 12, Market: "BTC-USD", Value: d("10000"), Fee: d("6"), IsTaker: true},
+		{ID: 4, OrderID: 13, Market: "BTC-USD", Value: d("10000"), Fee: d("3.004")},
+		{ID: 5, OrderID: 14, Market: "BTC-USD", Value: d("10000"), Fee: d("2.5")},
+	}
+	check := FeeCheck{Tolerance: d("0.01"), BuilderOrders: map[int64]bool{12: true, 13: true}}
+
+	discrepancies, err := ReconcileFees(trades, schedule, check)
+	require.NoError(t, err)
+	require.Len(t, discrepancies, 1)
+	assert.Equal(t, int64(5), discrepancies[0].Trade.ID)
+	assert.True(t, d("2").Equal(discrepancies[0].Expected))
+	assert.True(t, d("0.5").Equal(discrepancies[0].Difference))
+
+	_, err = ReconcileFees([]AccountTradeModel{{Market: "ETH-USD"}}, schedule, check)
+	assert.ErrorContains(t, err, "ETH-USD")
+}
@@ -0,0 +1,187 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// FundingInterval is the time between funding payments. Funding is settled
+// hourly on every market.
+const FundingInterval = time.Hour
+
+// FundingSchedule describes when a market next pays funding
+type FundingSchedule struct {
+	Market      string
+	Interval    time.Duration
+	NextFunding time.Time
+	FundingRate decimal.Decimal
+}
+
+// NextFundingTime returns the time of the next funding payment reported by
+// the stats. The API reports it in the nextFundingRate field as Unix millis.
+func (s MarketStatsModel) NextFundingTime() time.Time {
+	if s.NextFundingRate <= 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(s.NextFundingRate).UTC()
+}
+
+// TimeToNextFunding returns the time left until the first funding payment
+// after now. Payments that already passed are rolled forward by the interval.
+func (s FundingSchedule) TimeToNextFunding(now time.Time) time.Duration {
+	return s.nextAfter(now).Sub(now)
+}
+
+func (s FundingSchedule) nextAfter(now time.Time) time.Time {
+	next := s.NextFunding
+	if next.IsZero() {
+		// Unknown, assume payments on interval boundaries
+		next = now.UTC().Truncate(s.Interval)
+	}
+	if !next.After(now) {
+		periods := now.Sub(next)/s.Interval + 1
+		next = next.Add(periods * s.Interval)
+	}
+	return next
+}
+
+// GetFundingSchedule returns the funding interval, next funding time and
+// current funding rate of a market
+func (c *APIClient) GetFundingSchedule(ctx context.Context, market string) (*FundingSchedule, error) {
+	stats, err := c.GetMarketStats(ctx, market)
+	if err != nil {
+		return nil, err
+	}
+	return &FundingSchedule{
+		Market:      market,
+		Interval:    FundingInterval,
+		NextFunding: stats.NextFundingTime(),
+		FundingRate: stats.FundingRate,
+	}, nil
+}
+
+// TimeToNextFunding returns the time left until the next funding payment of a market
+func (c *APIClient) TimeToNextFunding(ctx context.Context, market string) (time.Duration, error) {
+	schedule, err := c.GetFundingSchedule(ctx, market)
+	if err != nil {
+		return 0, err
+	}
+	return schedule.TimeToNextFunding(c.Clock().Now()), nil
+}
+
+// PlaceGoodTillFundingOrder places an order expiring exactly at the next
+// funding payment of its market, e.g. to capture a funding payment without
+// leaving the order resting past it. The expiry is taken from the market
+// stats at the time of the call and overrides params.ExpireTime; it is
+// returned with the order. An order placed just before a payment expires
+// almost at once.
+func (c *APIClient) PlaceGoodTillFundingOrder(ctx context.Context, params CreateOrderObjectParams, opts ...PlaceOrderOption) (*PerpetualOrderModel, *OrderResponse, time.Time, error) {
+	schedule, err := c.GetFundingSchedule(ctx, params.Market.Name)
+	if err != nil {
+		return nil, nil, time.Time{}, fmt.Errorf("failed to get funding schedule: %w", err)
+	}
+	expire := schedule.nextAfter(c.Clock().Now())
+	params.ExpireTime = &expire
+	order, resp, err := c.PlaceOrder(ctx, params, opts...)
+	return order, resp, expire, err
+}
+
+// FundingEvent announces an upcoming funding payment
+type FundingEvent struct {
+	Market      string
+	FundingTime time.Time
+	FundingRate decimal.Decimal
+}
+
+// NotifyBeforeFunding sends an event lead before every funding payment of a
+// market, with the funding rate fetched at that moment. The schedule is
+// refreshed before each event. Errors are sent on the error channel and the
+// watcher retries at the next interval. Both channels are closed once ctx is done.
+func (c *APIClient) NotifyBeforeFunding(ctx context.Context, market string, lead time.Duration) (<-chan FundingEvent, <-chan error) {
+	events := make(chan FundingEvent, 1)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(events)
+		defer close(errs)
+
+		var notified time.Time
+		for {
+			wait := FundingInterval
+			schedule, err := c.GetFundingSchedule(ctx, market)
+			if err != nil {
+				select {
+				case errs <- err:
+				default:
+				}
+			} else {
+				now := c.Clock().Now()
+				next := schedule.nextAfter(now)
+				if next.Equal(notified) {
+					next = next.Add(schedule.Interval)
+				}
+				wait = next.Add(-lead).Sub(now)
+				if wait <= 0 {
+					notified = next
+					select {
+					case events <- FundingEvent{Market: market, FundingTime: next, FundingRate: schedule.FundingRate}:
+					case <-ctx.Done():
+						return
+					}
+					continue
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-c.Clock().After(wait):
+			}
+		}
+	}()
+	return events, errs
+}
+
+// FundingRateModel is a funding rate applied to a market at a funding payment
+type FundingRateModel struct {
+	Market      string          `json:"m"`
+	FundingRate decimal.Decimal `json:"f"`
+	Timestamp   int64           `json:"T"`
+}
+
+// FundingHistoryFilter bounds a funding rate history query. Zero fields are
+// not filtered on.
+type FundingHistoryFilter struct {
+	Since time.Time `query:"startTime"`
+	Until time.Time `query:"endTime"`
+	// Cursor continues a previous listing
+	Cursor *int64 `query:"cursor"`
+	Limit  int    `query:"limit"`
+}
+
+// FundingHistoryResponse represents the API response for funding rate history
+type FundingHistoryResponse struct {
+	Data   []FundingRateModel `json:"data"`
+	Status string             `json:"status"`
+}
+
+// GetFundingHistory retrieves the funding rates applied to a market, newest first
+func (c *APIClient) GetFundingHistory(ctx context.Context, market string, filter FundingHistoryFilter) ([]FundingRateModel, error) {
+	baseURL, err := c.getURLWithFilter(getFundingHistoryPath(market), filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build URL: %w", err)
+	}
+
+	var historyResponse FundingHistoryResponse
+	if err := c.BaseModule.DoRequest(withRequestSubject(ctx, market, ""), "GET", baseURL, nil, &historyResponse); err != nil {
+		return nil, err
+	}
+
+	if historyResponse.Status != "OK" {
+		return nil, fmt.Errorf("API returned error status: %v", historyResponse.Status)
+	}
+
+	return historyResponse.Data, nil
+}
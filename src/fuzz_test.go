@@ -0,0 +1,109 @@
+package sdk
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var hexPattern = regexp.MustCompile(`^(0[xX])?[0-9a-fA-F]+$`)
+
+func FuzzIsHexString(f *testing.F) {
+	for _, seed := range []string{"", "0x", "0X", "0x1f", "ABCdef", "0xg", "0x0x1", "１"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		err := isHexString(s)
+		if valid := hexPattern.MatchString(s); valid != (err == nil) {
+			t.Fatalf("isHexString(%q) = %v, want valid=%v", s, err, valid)
+		}
+	})
+}
+
+func FuzzSignMessage(f *testing.F) {
+	f.Add("0x4de4c009e0d0c5a70a7da0e2039fb2b99f376d53496f89d9f437e736add6b48", "0x1234def56789012345678901234567890123456789012345678901234567890")
+	f.Add("zz", "0x1")
+	f.Add("0x1", "0x"+strings.Repeat("f", 70))
+	f.Fuzz(func(t *testing.T, message, privateKey string) {
+		sig, err := SignMessage(message, privateKey)
+		if err == nil && sig == "" {
+			t.Fatalf("SignMessage(%q, %q) returned an empty signature", message, privateKey)
+		}
+	})
+}
+
+func FuzzGetOrderHash(f *testing.F) {
+	f.Add("100", "0x2", "100", "-156", "74", "100", "123", "Perpetuals", "1")
+	f.Add("abc", "zz", "1e5", "", "-74", "x", "0", "Perpe\x00tuals", "x")
+	f.Add("99999999999999999999999", "0x"+strings.Repeat("f", 70), "0", "0", "0", "0", "0", "", "0")
+	f.Fuzz(func(t *testing.T, positionID, assetID, baseAmount, quoteAmount, feeAmount, expiration, salt, domainName, revision string) {
+		hash, err := GetOrderHash(
+			positionID, assetID, baseAmount,
+			"0x1", quoteAmount,
+			"0x1", feeAmount,
+			expiration, salt,
+			"0x5d05989e9302dcebc74e241001e3e3ac3f4402ccf2f8e6f74b034b07ad6a904",
+			domainName, "v0", "SN_SEPOLIA", revision,
+		)
+		if err != nil {
+			return
+		}
+		if !strings.HasPrefix(hash, "0x") || isHexString(hash) != nil {
+			t.Fatalf("GetOrderHash returned %q, not a hash", hash)
+		}
+	})
+}
+
+// FuzzDecodeResponses decodes arbitrary bodies into every response model
+// with both codecs and the error envelope; decoding may fail but must not
+// panic, and whatever decodes must encode again
+func FuzzDecodeResponses(f *testing.F) {
+	for _, seed := range []string{
+		`{"status":"OK","data":[{"name":"BTC-USD","assetPrecision":5,"tradingConfig":{"minOrderSizeChange":"0.0001"}}]}`,
+		`{"status":"OK","data":{"market":"BTC-USD","bid":[{"qty":"1","price":"50000"}],"ask":[]}}`,
+		`{"status":"OK","data":{"id":1,"externalId":"abc"}}`,
+		`{"status":"OK","data":[{"id":1,"market":"BTC-USD","side":"BUY","price":"1e3","qty":"-1"}]}`,
+		`{"status":"ERROR","error":{"code":1140,"message":"m","localizedMessage":"l"}}`,
+		`{"status":"ERROR","error":{"code":"x"}}`,
+		`{"status":"OK","data":null}`,
+		`{"data":{"balance":"NaN"}}`,
+		`[]`, `null`, `"`, ``,
+	} {
+		f.Add([]byte(seed))
+	}
+	models := []func() any{
+		func() any { return new(MarketResponse) },
+		func() any { return new(OrderbookResponse) },
+		func() any { return new(MarketStatsResponse) },
+		func() any { return new(FeeResponse) },
+		func() any { return new(OrderResponse) },
+		func() any { return new(PositionsResponse) },
+		func() any { return new(BalanceResponse) },
+		func() any { return new(WithdrawalLimitsResponse) },
+		func() any { return new(OrdersResponse) },
+		func() any { return new(CancelResponse) },
+		func() any { return new(FundingHistoryResponse) },
+		func() any { return new(LeverageResponse) },
+		func() any { return new(UpdateLeverageResponse) },
+		func() any { return new(TradesResponse) },
+		func() any { return new(PerpetualOrderModel) },
+	}
+	codecs := []Codec{JSONCodec{}, StrictJSONCodec{}}
+	f.Fuzz(func(t *testing.T, body []byte) {
+		for _, codec := range codecs {
+			for _, model := range models {
+				v := model()
+				if codec.Unmarshal(body, v) != nil {
+					continue
+				}
+				if _, err := json.Marshal(v); err != nil {
+					t.Fatalf("%T decoded from %q but does not encode: %v", v, body, err)
+				}
+			}
+		}
+		apiErr := newAPIError(500, body)
+		_ = apiErr.Error()
+		_ = apiErr.UserMessage()
+	})
+}
@@ -0,0 +1,8 @@
+package sdk
+
+// The enums, models and endpoint paths of the exchange API are generated from
+// openapi/spec.yaml, the part of the exchange OpenAPI spec the SDK covers.
+// The hand-written services build their requests from the generated paths,
+// decode responses into the generated models and add methods to them.
+
+//go:generate go run ./internal/cmd/apigen -spec openapi/spec.yaml -enums enums_gen.go -models models_gen.go -endpoints endpoints_gen.go
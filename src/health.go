@@ -0,0 +1,190 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrServiceDegraded wraps errors of requests to a service that is degraded,
+// so that callers can tell an outage apart from a rejected request
+var ErrServiceDegraded = errors.New("service degraded")
+
+// Service is a part of the API whose health is tracked on its own
+type Service string
+
+const (
+	// ServiceMarketData serves the public /info endpoints
+	ServiceMarketData Service = "MARKET_DATA"
+	// ServiceTrading accepts order placement and cancellation
+	ServiceTrading Service = "TRADING"
+	// ServiceAccount serves the other private endpoints, e.g. balance,
+	// positions and order history
+	ServiceAccount Service = "ACCOUNT"
+)
+
+var services = []Service{ServiceMarketData, ServiceTrading, ServiceAccount}
+
+// HealthState is the health of a service or of the API as a whole
+type HealthState string
+
+const (
+	HealthHealthy  HealthState = "HEALTHY"
+	HealthDegraded HealthState = "DEGRADED"
+)
+
+// HealthConfig configures the health tracking of services
+type HealthConfig struct {
+	// FailureThreshold is the number of consecutive outage failures after
+	// which a service is degraded; defaults to 3
+	FailureThreshold int
+	// Cooldown, if positive, fails requests to a degraded service with
+	// ErrServiceDegraded without sending them until Cooldown passed since
+	// its last failure. The next request then probes the service.
+	Cooldown time.Duration
+}
+
+// ServiceHealth is the health of a single service
+type ServiceHealth struct {
+	Service Service
+	State   HealthState
+	// ConsecutiveFailures counts outage failures since the last success
+	ConsecutiveFailures int
+	LastError           error
+	LastSuccess         time.Time
+	LastFailure         time.Time
+}
+
+// HealthSummary is the health of every service. State is degraded if any
+// service is.
+type HealthSummary struct {
+	State    HealthState
+	Services []ServiceHealth
+}
+
+// Service returns the health of a single service
+func (s HealthSummary) Service(service Service) ServiceHealth {
+	for _, h := range s.Services {
+		if h.Service == service {
+			return h
+		}
+	}
+	return ServiceHealth{Service: service, State: HealthHealthy}
+}
+
+// WithHealthConfig changes when services are considered degraded and
+// enables failing fast while they are
+func WithHealthConfig(cfg HealthConfig) ClientOption {
+	return func(m *BaseModule) {
+		m.healthConfig = cfg
+	}
+}
+
+type healthTracker struct {
+	mu       sync.Mutex
+	clock    Clock
+	cfg      HealthConfig
+	services map[Service]*ServiceHealth
+}
+
+func newHealthTracker(clock Clock, cfg HealthConfig) *healthTracker {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = 3
+	}
+	h := &healthTracker{
+		clock:    clockOrDefault(clock),
+		cfg:      cfg,
+		services: make(map[Service]*ServiceHealth, len(services)),
+	}
+	for _, s := range services {
+		h.services[s] = &ServiceHealth{Service: s, State: HealthHealthy}
+	}
+	return h
+}
+
+// allow returns ErrServiceDegraded if requests to service should fail fast
+func (h *healthTracker) allow(service Service) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	s := h.services[service]
+	if h.cfg.Cooldown <= 0 || s.State != HealthDegraded || h.clock.Now().Sub(s.LastFailure) >= h.cfg.Cooldown {
+		return nil
+	}
+	return fmt.Errorf("%w: %s: %w", ErrServiceDegraded, service, s.LastError)
+}
+
+// record updates the health of service with the outcome of a request and
+// reports whether the service is degraded
+func (h *healthTracker) record(service Service, err error, outage bool) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	s := h.services[service]
+	now := h.clock.Now()
+	if !outage {
+		if err == nil || s.State == HealthDegraded {
+			// Any response from the service shows it is up again
+			s.State, s.ConsecutiveFailures = HealthHealthy, 0
+		}
+		if err == nil {
+			s.LastSuccess = now
+		}
+		return false
+	}
+	s.ConsecutiveFailures++
+	s.LastError, s.LastFailure = err, now
+	if s.ConsecutiveFailures >= h.cfg.FailureThreshold {
+		s.State = HealthDegraded
+	}
+	return s.State == HealthDegraded
+}
+
+func (h *healthTracker) summary() HealthSummary {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	summary := HealthSummary{State: HealthHealthy, Services: make([]ServiceHealth, 0, len(services))}
+	for _, service := range services {
+		s := *h.services[service]
+		if s.State == HealthDegraded {
+			summary.State = HealthDegraded
+		}
+		summary.Services = append(summary.Services, s)
+	}
+	return summary
+}
+
+// Health returns the health of every service as seen by the requests made
+// by this client. Services that were not used yet are healthy.
+func (m *BaseModule) Health() HealthSummary {
+	return m.health.summary()
+}
+
+// serviceOf returns the service an endpoint key of endpointKey belongs to
+func serviceOf(endpoint string) Service {
+	method, path, _ := strings.Cut(endpoint, " ")
+	switch {
+	case strings.HasPrefix(path, "/info/"):
+		return ServiceMarketData
+	case method != http.MethodGet && strings.HasPrefix(path, "/user/order"):
+		return ServiceTrading
+	}
+	return ServiceAccount
+}
+
+// isOutage reports whether a failed request points at the service being
+// unavailable rather than at the request itself
+func isOutage(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return true
+	}
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode >= http.StatusInternalServerError
+}
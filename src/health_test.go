@@ -0,0 +1,81 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceHealth(t *testing.T) {
+	var marketDataDown atomic.Bool
+	marketDataDown.Store(true)
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/info/") && marketDataDown.Load():
+			w.WriteHeader(http.StatusBadGateway)
+		case r.URL.Path == "/user/order":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"status":"ERROR","error":{"code":"INVALID_PRICE"}}`))
+		default:
+			w.Write([]byte(`{"status":"OK"}`))
+		}
+	}))
+	defer server.Close()
+
+	clock := fixedClock(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	m := NewBaseModule(EndpointConfig{APIBaseURL: server.URL}, "", nil, nil, 5*time.Second,
+		WithClock(&clock), WithHealthConfig(HealthConfig{FailureThreshold: 2, Cooldown: time.Minute}))
+	ctx := context.Background()
+	var resp struct{ Status string }
+
+	err := m.DoRequest(ctx, "GET", server.URL+"/info/markets", nil, &resp)
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrServiceDegraded, "below the threshold")
+	err = m.DoRequest(ctx, "GET", server.URL+"/info/markets", nil, &resp)
+	assert.ErrorIs(t, err, ErrServiceDegraded)
+	var apiErr *APIError
+	assert.ErrorAs(t, err, &apiErr, "the cause is kept")
+
+	// Other services keep working, and rejections do not count as outages
+	require.NoError(t, m.DoRequest(ctx, "GET", server.URL+"/user/balance", nil, &resp))
+	err = m.DoRequest(ctx, "POST", server.URL+"/user/order", strings.NewReader("{}"), &resp)
+	assert.True(t, IsOrderRejected(err, OrderStatusReasonInvalidPrice))
+	assert.NotErrorIs(t, err, ErrServiceDegraded)
+
+	health := m.Health()
+	assert.Equal(t, HealthDegraded, health.State)
+	assert.Equal(t, HealthDegraded, health.Service(ServiceMarketData).State)
+	assert.Equal(t, 2, health.Service(ServiceMarketData).ConsecutiveFailures)
+	assert.Equal(t, HealthHealthy, health.Service(ServiceTrading).State)
+	assert.Equal(t, HealthHealthy, health.Service(ServiceAccount).State)
+
+	// Within the cooldown requests fail without being sent
+	sent := requests.Load()
+	err = m.DoRequest(ctx, "GET", server.URL+"/info/markets/BTC-USD/stats", nil, &resp)
+	assert.ErrorIs(t, err, ErrServiceDegraded)
+	assert.Equal(t, sent, requests.Load())
+
+	// After it the next request probes the service
+	marketDataDown.Store(false)
+	clock = fixedClock(time.Time(clock).Add(time.Minute))
+	require.NoError(t, m.DoRequest(ctx, "GET", server.URL+"/info/markets", nil, &resp))
+	assert.Equal(t, HealthHealthy, m.Health().State)
+}
+
+func TestServiceOf(t *testing.T) {
+	assert.Equal(t, ServiceMarketData, serviceOf("GET /info/markets/BTC-USD/orderbook"))
+	assert.Equal(t, ServiceTrading, serviceOf("POST /user/order"))
+	assert.Equal(t, ServiceTrading, serviceOf("DELETE /user/order/12"))
+	assert.Equal(t, ServiceTrading, serviceOf("POST /user/order/massCancel"))
+	assert.Equal(t, ServiceAccount, serviceOf("GET /user/orders"))
+	assert.Equal(t, ServiceAccount, serviceOf("GET /user/balance"))
+}
@@ -0,0 +1,161 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/shopspring/decimal"
+)
+
+// HedgeConfig configures a Hedger
+type HedgeConfig struct {
+	// BaseMarket is the market whose position is hedged
+	BaseMarket string
+	// Order is the template of the hedge orders: market, account, signer and
+	// domain. Side, quantity, price and reduce-only are set per order. It must
+	// carry a NonceGenerator since every adjustment is signed anew.
+	Order CreateOrderObjectParams
+	// Ratio is the hedge notional per unit of base notional, e.g. the beta of
+	// the base market to the hedge market. The hedge takes the opposite side.
+	Ratio decimal.Decimal
+	// SlippageBps prices hedge orders this many basis points through the
+	// hedge mark price so that they fill immediately
+	SlippageBps decimal.Decimal
+}
+
+// HedgeAdjustment describes one rebalance of a Hedger. Order is nil when the
+// hedge was within one order size step of its target.
+type HedgeAdjustment struct {
+	BaseNotional decimal.Decimal
+	// TargetSize and CurrentSize are signed hedge sizes, negative when short
+	TargetSize  decimal.Decimal
+	CurrentSize decimal.Decimal
+	Order       *PerpetualOrderModel
+}
+
+// HedgeTarget returns the signed hedge size that offsets a signed base
+// position: ratio times the base notional, converted at the hedge mark price,
+// on the opposite side
+func HedgeTarget(baseSize, baseMark, hedgeMark, ratio decimal.Decimal) decimal.Decimal {
+	if !hedgeMark.IsPositive() {
+		return decimal.Zero
+	}
+	return baseSize.Mul(baseMark).Mul(ratio).Div(hedgeMark).Neg()
+}
+
+// Hedger keeps a position in a second market that offsets the position in a
+// base market, so that the pair stays delta-neutral. Rebalance is called
+// whenever the base position may have changed, e.g. after each fill or on a
+// timer; it compares both positions and sends an immediate-or-cancel order
+// for the difference. An unfilled hedge order is retried by the next call.
+type Hedger struct {
+	client *APIClient
+	cfg    HedgeConfig
+
+	mu sync.Mutex
+}
+
+// NewHedger creates a hedger for the pair described by cfg
+func NewHedger(client *APIClient, cfg HedgeConfig) (*Hedger, error) {
+	if cfg.BaseMarket == "" || cfg.Order.Market.Name == "" || cfg.BaseMarket == cfg.Order.Market.Name {
+		return nil, fmt.Errorf("%w: hedge needs two distinct markets", ErrInvalidOrder)
+	}
+	if !cfg.Ratio.IsPositive() {
+		return nil, fmt.Errorf("%w: hedge ratio must be positive, got %s", ErrInvalidOrder, cfg.Ratio)
+	}
+	if cfg.Order.NonceGenerator == nil {
+		return nil, fmt.Errorf("%w: a nonce generator is required to sign hedge orders", ErrInvalidOrder)
+	}
+	return &Hedger{client: client, cfg: cfg}, nil
+}
+
+// Rebalance fetches both positions and places the order that brings the
+// hedge to its target. Calls are serialised so that concurrent fills do not
+// hedge twice.
+func (h *Hedger) Rebalance(ctx context.Context) (*HedgeAdjustment, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	market := h.cfg.Order.Market
+	positions, err := h.client.GetPositions(ctx, []string{h.cfg.BaseMarket, market.Name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch positions: %w", err)
+	}
+	var base, hedge PositionModel
+	for _, p := range positions {
+		switch p.Market {
+		case h.cfg.BaseMarket:
+			base = p
+		case market.Name:
+			hedge = p
+		}
+	}
+
+	hedgeMark := hedge.MarkPrice
+	if !hedgeMark.IsPositive() {
+		stats, err := h.client.GetMarketStats(ctx, market.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch hedge mark price: %w", err)
+		}
+		hedgeMark = stats.MarkPrice
+	}
+	if !hedgeMark.IsPositive() {
+		return nil, fmt.Errorf("no mark price for %s", market.Name)
+	}
+
+	baseSize := signedSize(base)
+	adjustment := &HedgeAdjustment{
+		BaseNotional: baseSize.Mul(base.MarkPrice),
+		TargetSize:   HedgeTarget(baseSize, base.MarkPrice, hedgeMark, h.cfg.Ratio),
+		CurrentSize:  signedSize(hedge),
+	}
+
+	delta := adjustment.TargetSize.Sub(adjustment.CurrentSize)
+	qty := delta.Abs()
+	if step := market.TradingConfig.MinOrderSizeChange; step.IsPositive() {
+		qty = qty.Div(step).Floor().Mul(step)
+	} else {
+		qty = qty.RoundFloor(market.QtyPrecision())
+	}
+	if !qty.IsPositive() || qty.LessThan(market.TradingConfig.MinOrderSize) {
+		return adjustment, nil
+	}
+
+	params := h.cfg.Order
+	params.Side = OrderSideBuy
+	slippage := decimal.NewFromInt(1).Add(h.cfg.SlippageBps.Div(decimal.NewFromInt(10000)))
+	if delta.IsNegative() {
+		params.Side = OrderSideSell
+		slippage = decimal.NewFromInt(2).Sub(slippage)
+	}
+	params.Price = hedgeMark.Mul(slippage)
+	if tick := market.TradingConfig.MinPriceChange; tick.IsPositive() {
+		params.Price = params.Price.Div(tick).Round(0).Mul(tick)
+	} else {
+		params.Price = params.Price.Round(market.PricePrecision())
+	}
+	params.SyntheticAmount = qty
+	// Shrinking the hedge must not flip it to the other side
+	params.ReduceOnly = !adjustment.CurrentSize.IsZero() &&
+		delta.Sign() != adjustment.CurrentSize.Sign() && qty.LessThanOrEqual(adjustment.CurrentSize.Abs())
+	if params.TimeInForce == "" {
+		params.TimeInForce = TimeInForceIOC
+	}
+	params.Nonce = nil
+
+	order, _, err := h.client.PlaceOrder(ctx, params)
+	if err != nil {
+		return adjustment, fmt.Errorf("failed to place hedge order: %w", err)
+	}
+	adjustment.Order = order
+	return adjustment, nil
+}
+
+// signedSize returns the size of a position, negative when short
+func signedSize(p PositionModel) decimal.Decimal {
+	if p.Side == PositionSideShort {
+		return p.Size.Abs().Neg()
+	}
+	return p.Size.Abs()
+}
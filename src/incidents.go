@@ -0,0 +1,110 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// IncidentReport describes a liquidation or auto-deleverage of a position,
+// replayed from the account trades
+type IncidentReport struct {
+	// Type is TradeTypeLiquidation or TradeTypeDeleverage
+	Type    TradeType
+	Market  string
+	OrderID int64
+	// Time is when the first forced fill happened
+	Time     time.Time
+	TradeIDs []int64
+	// Side is the side of the forced fills, reducing the position
+	Side OrderSide
+	// Price is the average price of the forced fills and Size their total
+	// quantity
+	Price decimal.Decimal
+	Size  decimal.Decimal
+	Fees  decimal.Decimal
+	// PositionBefore and PositionAfter are the signed position sizes, negative
+	// when short, and EntryPrice the average open price before the incident
+	PositionBefore decimal.Decimal
+	PositionAfter  decimal.Decimal
+	EntryPrice     decimal.Decimal
+	// PnLImpact is the PnL the forced fills realised against EntryPrice,
+	// minus Fees
+	PnLImpact decimal.Decimal
+	// PriorMarginRatio is the margin ratio of the last equity sample taken
+	// before the incident, at PriorSampleTime. Both are zero without one.
+	PriorMarginRatio decimal.Decimal
+	PriorSampleTime  time.Time
+}
+
+// BuildIncidentReports replays trades, oldest first, and reports every
+// liquidation and auto-deleverage among them, oldest first. The fills of one
+// forced order make one incident. Positions are rebuilt from the trades, so
+// the trades must reach back to when the positions were opened for
+// PositionBefore, EntryPrice and PnLImpact to be accurate. samples, oldest
+// first, e.g. from an EquitySampler, provide the margin ratio before each
+// incident and may be nil.
+func BuildIncidentReports(trades []AccountTradeModel, samples []EquitySample) []IncidentReport {
+	positions := make(map[string]*replayedPosition)
+	var reports []IncidentReport
+	current := -1
+	for _, trade := range trades {
+		pos, ok := positions[trade.Market]
+		if !ok {
+			pos = &replayedPosition{}
+			positions[trade.Market] = pos
+		}
+		if trade.TradeType != TradeTypeLiquidation && trade.TradeType != TradeTypeDeleverage {
+			pos.apply(trade)
+			continue
+		}
+
+		if current < 0 || reports[current].OrderID != trade.OrderID || reports[current].Market != trade.Market {
+			report := IncidentReport{
+				Type:           trade.TradeType,
+				Market:         trade.Market,
+				OrderID:        trade.OrderID,
+				Time:           time.UnixMilli(trade.CreatedTime).UTC(),
+				Side:           trade.Side,
+				PositionBefore: pos.size,
+				EntryPrice:     pos.openPrice,
+			}
+			report.PriorMarginRatio, report.PriorSampleTime = priorMarginRatio(samples, report.Time)
+			reports = append(reports, report)
+			current = len(reports) - 1
+		}
+		report := &reports[current]
+		report.TradeIDs = append(report.TradeIDs, trade.ID)
+		size := report.Size.Add(trade.Qty)
+		report.Price = report.Price.Mul(report.Size).Add(trade.Price.Mul(trade.Qty)).Div(size)
+		report.Size = size
+		report.Fees = report.Fees.Add(trade.Fee)
+		report.PnLImpact = report.PnLImpact.Add(pos.apply(trade)).Sub(trade.Fee)
+		report.PositionAfter = pos.size
+	}
+	return reports
+}
+
+// priorMarginRatio returns the margin ratio and time of the last sample
+// taken before t
+func priorMarginRatio(samples []EquitySample, t time.Time) (decimal.Decimal, time.Time) {
+	for i := len(samples) - 1; i >= 0; i-- {
+		if samples[i].Time.Before(t) {
+			return samples[i].MarginRatio, samples[i].Time
+		}
+	}
+	return decimal.Zero, time.Time{}
+}
+
+// IncidentReports fetches the trades created since and reports the
+// liquidations and auto-deleverages among them, see BuildIncidentReports.
+// since should precede the opening of the positions involved.
+func (c *APIClient) IncidentReports(ctx context.Context, since time.Time, samples []EquitySample) ([]IncidentReport, error) {
+	trades, err := c.tradesSince(ctx, since, func(int64) bool { return false })
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trades: %w", err)
+	}
+	return BuildIncidentReports(trades, samples), nil
+}
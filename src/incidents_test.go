@@ -0,0 +1,63 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildIncidentReports(t *testing.T) {
+	d := decimal.RequireFromString
+	base := time.UnixMilli(1700000000000).UTC()
+	trade := func(id, orderID int64, market string, side OrderSide, qty, price, fee string, tradeType TradeType) AccountTradeModel {
+		return AccountTradeModel{
+			ID: id, OrderID: orderID, Market: market, Side: side, Qty: d(qty), Price: d(price), Fee: d(fee),
+			TradeType: tradeType, CreatedTime: base.Add(time.Duration(id) * time.Second).UnixMilli(),
+		}
+	}
+	trades := []AccountTradeModel{
+		trade(1, 1, "BTC-USD", OrderSideBuy, "2", "100", "0.1", TradeTypeTrade),
+		trade(2, 1, "BTC-USD", OrderSideBuy, "2", "90", "0.1", TradeTypeTrade),
+		trade(3, 2, "ETH-USD", OrderSideSell, "1", "200", "0.1", TradeTypeTrade),
+		trade(4, 50, "BTC-USD", OrderSideSell, "1", "80", "0.5", TradeTypeLiquidation),
+		trade(5, 50, "BTC-USD", OrderSideSell, "1", "76", "1", TradeTypeLiquidation),
+		trade(6, 3, "BTC-USD", OrderSideSell, "2", "85", "0.1", TradeTypeTrade),
+		trade(7, 60, "ETH-USD", OrderSideBuy, "1", "250", "0", TradeTypeDeleverage),
+	}
+	samples := []EquitySample{
+		{Time: base.Add(2 * time.Second), MarginRatio: d("0.5")},
+		{Time: base.Add(3500 * time.Millisecond), MarginRatio: d("0.9")},
+		{Time: base.Add(4500 * time.Millisecond), MarginRatio: d("1")},
+	}
+
+	reports := BuildIncidentReports(trades, samples)
+	require.Len(t, reports, 2)
+
+	liquidation := reports[0]
+	assert.Equal(t, TradeTypeLiquidation, liquidation.Type)
+	assert.Equal(t, "BTC-USD", liquidation.Market)
+	assert.Equal(t, base.Add(4*time.Second), liquidation.Time)
+	assert.Equal(t, []int64{4, 5}, liquidation.TradeIDs)
+	assert.Equal(t, OrderSideSell, liquidation.Side)
+	assert.Equal(t, "78", liquidation.Price.String())
+	assert.Equal(t, "2", liquidation.Size.String())
+	assert.Equal(t, "1.5", liquidation.Fees.String())
+	assert.Equal(t, "4", liquidation.PositionBefore.String())
+	assert.Equal(t, "2", liquidation.PositionAfter.String())
+	assert.Equal(t, "95", liquidation.EntryPrice.String())
+	assert.Equal(t, "-35.5", liquidation.PnLImpact.String(), "sold 2 at an average of 78 against 95, minus fees")
+	assert.Equal(t, "0.9", liquidation.PriorMarginRatio.String())
+	assert.Equal(t, base.Add(3500*time.Millisecond), liquidation.PriorSampleTime)
+
+	adl := reports[1]
+	assert.Equal(t, TradeTypeDeleverage, adl.Type)
+	assert.Equal(t, "-1", adl.PositionBefore.String())
+	assert.True(t, adl.PositionAfter.IsZero())
+	assert.Equal(t, "-50", adl.PnLImpact.String(), "short from 200 bought back at 250")
+	assert.Equal(t, "1", adl.PriorMarginRatio.String())
+
+	assert.Empty(t, BuildIncidentReports(trades[:3], nil))
+}
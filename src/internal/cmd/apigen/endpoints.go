@@ -0,0 +1,171 @@
+package main
+
+import (
+	"fmt"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// endpoint is an operation of the API, generated as a constant holding its
+// path or, if the path has parameters, as a function building it
+type endpoint struct {
+	Name   string
+	Method string
+	Path   string
+	// Params is the parameter list of the function, empty for constants
+	Params string
+	// Expr is the Go expression of the path
+	Expr string
+}
+
+type operation struct {
+	OperationID string      `yaml:"operationId"`
+	Parameters  []parameter `yaml:"parameters"`
+}
+
+type parameter struct {
+	Name   string `yaml:"name"`
+	In     string `yaml:"in"`
+	Schema schema `yaml:"schema"`
+}
+
+var methods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// parseEndpoints returns the operations of an OpenAPI document, ordered by
+// name. An operation named getMarkets is generated as getMarketsPath.
+func parseEndpoints(doc *spec) ([]endpoint, error) {
+	var endpoints []endpoint
+	seen := make(map[string]string)
+	for path, item := range doc.Paths {
+		var shared []parameter
+		if node, ok := item["parameters"]; ok {
+			if err := node.Decode(&shared); err != nil {
+				return nil, fmt.Errorf("path %s: %w", path, err)
+			}
+		}
+		for _, method := range methods {
+			node, ok := item[method]
+			if !ok {
+				continue
+			}
+			e, err := parseEndpoint(path, method, node, shared)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", strings.ToUpper(method), path, err)
+			}
+			if other, ok := seen[e.Name]; ok {
+				return nil, fmt.Errorf("%s %s and %s both map to %s", e.Method, path, other, e.Name)
+			}
+			seen[e.Name] = e.Method + " " + path
+			endpoints = append(endpoints, e)
+		}
+	}
+	sort.Slice(endpoints, func(i, j int) bool { return endpoints[i].Name < endpoints[j].Name })
+	return endpoints, nil
+}
+
+func parseEndpoint(path, method string, node yaml.Node, shared []parameter) (endpoint, error) {
+	var op operation
+	if err := node.Decode(&op); err != nil {
+		return endpoint{}, err
+	}
+	if op.OperationID == "" {
+		return endpoint{}, fmt.Errorf("missing operationId")
+	}
+	e := endpoint{
+		Name:   lowerName(op.OperationID) + "Path",
+		Method: strings.ToUpper(method),
+		Path:   path,
+	}
+
+	declared := make(map[string]parameter)
+	for _, p := range append(shared, op.Parameters...) {
+		if p.In == "path" {
+			declared[p.Name] = p
+		}
+	}
+	var params, parts []string
+	for rest := path; rest != ""; {
+		start := strings.IndexByte(rest, '{')
+		if start < 0 {
+			parts = append(parts, strconv.Quote(rest))
+			break
+		}
+		end := strings.IndexByte(rest[start:], '}')
+		if end < 0 {
+			return endpoint{}, fmt.Errorf("unterminated path parameter")
+		}
+		if start > 0 {
+			parts = append(parts, strconv.Quote(rest[:start]))
+		}
+		name := rest[start+1 : start+end]
+		rest = rest[start+end+1:]
+
+		p, ok := declared[name]
+		if !ok {
+			return endpoint{}, fmt.Errorf("path parameter %s is not declared", name)
+		}
+		arg := lowerName(name)
+		if token.IsKeyword(arg) {
+			return endpoint{}, fmt.Errorf("path parameter %s is a Go keyword", name)
+		}
+		switch p.Schema.Type {
+		case "integer":
+			params = append(params, arg+" int64")
+			parts = append(parts, "strconv.FormatInt("+arg+", 10)")
+		case "string", "":
+			params = append(params, arg+" string")
+			parts = append(parts, "url.PathEscape("+arg+")")
+		default:
+			return endpoint{}, fmt.Errorf("path parameter %s has unsupported type %q", name, p.Schema.Type)
+		}
+	}
+	e.Params = strings.Join(params, ", ")
+	e.Expr = strings.Join(parts, " + ")
+	return e, nil
+}
+
+// endpointImports returns the packages the path functions of endpoints use
+func endpointImports(endpoints []endpoint) []string {
+	var imports []string
+	for _, pkg := range []string{"net/url", "strconv"} {
+		name := pkg[strings.LastIndexByte(pkg, '/')+1:]
+		for _, e := range endpoints {
+			if strings.Contains(e.Expr, name+".") {
+				imports = append(imports, pkg)
+				break
+			}
+		}
+	}
+	return imports
+}
+
+var endpointsTemplate = template.Must(template.New("endpoints").Funcs(funcs).Parse(header + `
+{{- with endpointImports .Decls}}
+import (
+{{- range .}}
+	{{printf "%q" .}}
+{{- end}}
+)
+{{- end}}
+{{range .Decls}}
+{{- if .Params}}
+// {{.Name}} returns the path of {{.Method}} {{.Path}}
+func {{.Name}}({{.Params}}) string {
+	return {{.Expr}}
+}
+{{else}}
+// {{.Name}} is the path of {{.Method}} {{.Path}}
+const {{.Name}} = {{.Expr}}
+{{end}}
+{{- end}}`))
+
+// generateEndpoints returns the gofmt formatted source declaring the paths
+// of endpoints in package pkg, relative to the API base URL
+func generateEndpoints(pkg string, endpoints []endpoint) ([]byte, error) {
+	return render(endpointsTemplate, pkg, endpoints)
+}
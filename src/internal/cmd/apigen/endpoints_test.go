@@ -0,0 +1,44 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateEndpoints(t *testing.T) {
+	endpoints, err := parseEndpoints(fixture(t))
+	require.NoError(t, err)
+	assert.Equal(t, []endpoint{
+		{Name: "cancelOrderPath", Method: "DELETE", Path: "/orders/{market}/{id}", Params: "market string, id int64",
+			Expr: `"/orders/" + url.PathEscape(market) + "/" + strconv.FormatInt(id, 10)`},
+		{Name: "listOrdersPath", Method: "GET", Path: "/orders", Expr: `"/orders"`},
+		{Name: "placeOrderPath", Method: "POST", Path: "/orders", Expr: `"/orders"`},
+	}, endpoints)
+
+	src, err := generateEndpoints("sdk", endpoints)
+	require.NoError(t, err)
+	golden, err := os.ReadFile("testdata/endpoints.golden")
+	require.NoError(t, err)
+	assert.Equal(t, string(golden), string(src))
+
+	src, err = generateEndpoints("sdk", endpoints[1:])
+	require.NoError(t, err)
+	assert.NotContains(t, string(src), "import", "constants import nothing")
+}
+
+func TestParseEndpoints_Errors(t *testing.T) {
+	_, err := parseEndpoints(parse(t, `{"paths":{"/orders":{"get":{}}}}`))
+	assert.ErrorContains(t, err, "GET /orders: missing operationId")
+
+	_, err = parseEndpoints(parse(t, `{"paths":{"/orders/{id}":{"get":{"operationId":"getOrder"}}}}`))
+	assert.ErrorContains(t, err, "path parameter id is not declared")
+
+	_, err = parseEndpoints(parse(t, `{"paths":{"/orders/{type}":{"get":{"operationId":"getOrders","parameters":[{"name":"type","in":"path"}]}}}}`))
+	assert.ErrorContains(t, err, "path parameter type is a Go keyword")
+
+	_, err = parseEndpoints(parse(t, `{"paths":{"/a":{"get":{"operationId":"list"}},"/b":{"get":{"operationId":"list"}}}}`))
+	assert.ErrorContains(t, err, "both map to listPath")
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// enum is a string schema with a fixed set of values
+type enum struct {
+	Name        string
+	Description string
+	Kind        string
+	Values      []enumValue
+}
+
+type enumValue struct {
+	Name    string
+	Value   string
+	Comment string
+}
+
+// parseEnums returns the string enum schemas of an OpenAPI document, ordered
+// by name
+func parseEnums(doc *spec) ([]enum, error) {
+	var enums []enum
+	for name, s := range doc.Components.Schemas {
+		if !s.isEnum() {
+			continue
+		}
+		if len(s.VarNames) > 0 && len(s.VarNames) != len(s.Enum) {
+			return nil, fmt.Errorf("schema %s has %d x-enum-varnames for %d values", name, len(s.VarNames), len(s.Enum))
+		}
+		if len(s.Descriptions) > 0 && len(s.Descriptions) != len(s.Enum) {
+			return nil, fmt.Errorf("schema %s has %d x-enum-descriptions for %d values", name, len(s.Descriptions), len(s.Enum))
+		}
+		e := enum{Name: typeName(name, s), Description: strings.TrimSpace(s.Description), Kind: s.Kind}
+		if e.Kind == "" {
+			e.Kind = kind(e.Name)
+		}
+		prefix := e.Name
+		if s.Prefix != "" {
+			prefix = s.Prefix
+		}
+		seen := make(map[string]string, len(s.Enum))
+		for i, value := range s.Enum {
+			suffix := goName(value)
+			if len(s.VarNames) > 0 {
+				suffix = s.VarNames[i]
+			}
+			if other, ok := seen[suffix]; ok {
+				return nil, fmt.Errorf("schema %s: values %q and %q both map to %s%s", name, other, value, prefix, suffix)
+			}
+			seen[suffix] = value
+			v := enumValue{Name: prefix + suffix, Value: value}
+			if len(s.Descriptions) > 0 {
+				v.Comment = s.Descriptions[i]
+			}
+			e.Values = append(e.Values, v)
+		}
+		enums = append(enums, e)
+	}
+	sort.Slice(enums, func(i, j int) bool { return enums[i].Name < enums[j].Name })
+	return enums, nil
+}
+
+// kind returns the name of an enum in prose, e.g. "time in force" for
+// TimeInForce
+func kind(name string) string {
+	words := camelWords(name)
+	for i, word := range words {
+		if strings.ToUpper(word) != word {
+			words[i] = strings.ToLower(word)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// article returns s preceded by a or an
+func article(s string) string {
+	if strings.ContainsRune("aeiou", unicode.ToLower([]rune(s)[0])) {
+		return "an " + s
+	}
+	return "a " + s
+}
+
+var enumsTemplate = template.Must(template.New("enums").Funcs(funcs).Parse(header + `
+import "slices"
+{{range .Decls}}{{$name := .Name}}{{$recv := receiver .Name}}
+{{if .Description}}{{comment .Description}}{{else}}// {{.Name}} is {{article .Kind}} of the exchange API{{end}}
+type {{.Name}} string
+
+const (
+{{- range .Values}}
+	{{.Name}} {{$name}} = {{printf "%q" .Value}}{{if .Comment}} // {{.Comment}}{{end}}
+{{- end}}
+)
+
+var {{unexported .Name}}Values = []{{.Name}}{
+{{- range .Values}}
+	{{.Name}},
+{{- end}}
+}
+
+// IsValid reports whether {{$recv}} is {{article (printf "known %s" .Kind)}}
+func ({{$recv}} {{.Name}}) IsValid() bool {
+	return slices.Contains({{unexported .Name}}Values, {{$recv}})
+}
+
+func ({{$recv}} {{.Name}}) String() string {
+	return string({{$recv}})
+}
+
+// Parse{{.Name}} converts user input to {{article .Kind}}, ignoring case
+func Parse{{.Name}}(s string) ({{.Name}}, error) {
+	return parseEnum({{printf "%q" .Kind}}, {{unexported .Name}}Values, s)
+}
+{{end}}`))
+
+// generateEnums returns the gofmt formatted source declaring enums in
+// package pkg
+func generateEnums(pkg string, enums []enum) ([]byte, error) {
+	return render(enumsTemplate, pkg, enums)
+}
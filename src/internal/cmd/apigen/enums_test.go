@@ -0,0 +1,81 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fixture parses testdata/spec.yaml
+func fixture(t *testing.T) *spec {
+	t.Helper()
+	data, err := os.ReadFile("testdata/spec.yaml")
+	require.NoError(t, err)
+	doc, err := parseSpec(data)
+	require.NoError(t, err)
+	return doc
+}
+
+// parse parses an inline spec
+func parse(t *testing.T, data string) *spec {
+	t.Helper()
+	doc, err := parseSpec([]byte(data))
+	require.NoError(t, err)
+	return doc
+}
+
+func TestGenerateEnums(t *testing.T) {
+	enums, err := parseEnums(fixture(t))
+	require.NoError(t, err)
+	require.Len(t, enums, 3, "objects are skipped")
+	assert.Equal(t, "OrderStatusReason", enums[0].Name)
+	assert.Equal(t, []enumValue{
+		{"OrderStatusReasonNone", "NONE", ""},
+		{"OrderStatusReasonNotEnoughFunds", "NOT_ENOUGH_FUNDS", ""},
+		{"OrderStatusReasonPostOnlyFailed", "POST_ONLY_FAILED", ""},
+	}, enums[0].Values)
+	assert.Equal(t, "order status reason", enums[0].Kind)
+	assert.Equal(t, "SelfTradeProtectionDisabled", enums[1].Values[0].Name, "x-go-prefix replaces the type name")
+	assert.Equal(t, "self-trade protection level", enums[1].Kind)
+	assert.Equal(t, enumValue{"TimeInForceGTT", "GTT", "Good till time"}, enums[2].Values[0], "x-enum-varnames wins")
+
+	src, err := generateEnums("sdk", enums)
+	require.NoError(t, err)
+	golden, err := os.ReadFile("testdata/enums.golden")
+	require.NoError(t, err)
+	assert.Equal(t, string(golden), string(src))
+
+	// Regenerating is stable
+	again, err := generateEnums("sdk", enums)
+	require.NoError(t, err)
+	assert.Equal(t, src, again)
+}
+
+func TestParseEnums_Conflicts(t *testing.T) {
+	_, err := parseEnums(parse(t, `{"components":{"schemas":{"Side":{"type":"string","enum":["BUY","buy"]}}}}`))
+	assert.ErrorContains(t, err, `"BUY" and "buy" both map to SideBuy`)
+
+	_, err = parseEnums(parse(t, `{"components":{"schemas":{"Side":{"type":"string","enum":["BUY"],"x-enum-varnames":["Buy","Sell"]}}}}`))
+	assert.ErrorContains(t, err, "2 x-enum-varnames for 1 values")
+
+	_, err = parseEnums(parse(t, `{"components":{"schemas":{"Side":{"type":"string","enum":["BUY"],"x-enum-descriptions":["a","b"]}}}}`))
+	assert.ErrorContains(t, err, "2 x-enum-descriptions for 1 values")
+
+	_, err = parseEnums(parse(t, `{"components":{"schemas":{"Side":{"type":"string","enum":["BUY","B"],"x-enum-varnames":["Buy","Buy"],"x-go-prefix":"Order"}}}}`))
+	assert.ErrorContains(t, err, "both map to OrderBuy")
+}
+
+func TestNames(t *testing.T) {
+	assert.Equal(t, "NotEnoughFunds", goName("NOT_ENOUGH_FUNDS"))
+	assert.Equal(t, "OrderSide", goName("order-side"))
+	assert.Equal(t, "TimeInForce", goName("TimeInForce"))
+	assert.Equal(t, "time in force", kind("TimeInForce"))
+	assert.Equal(t, "an order side", article(kind("OrderSide")))
+	assert.Equal(t, "CounterpartyAccountID", fieldName("counterpartyAccountId"))
+	assert.Equal(t, "L2Config", fieldName("l2Config"))
+	assert.Equal(t, "APIKey", fieldName("apiKey"))
+	assert.Equal(t, "id", lowerName("id"))
+	assert.Equal(t, "cancelOrderByExternalID", lowerName("cancelOrderByExternalId"))
+}
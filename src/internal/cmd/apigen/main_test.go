@@ -0,0 +1,62 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	o := options{
+		spec:      "testdata/spec.yaml",
+		pkg:       "sdk",
+		enums:     filepath.Join(dir, "enums_gen.go"),
+		endpoints: filepath.Join(dir, "endpoints_gen.go"),
+	}
+	require.NoError(t, run(o))
+	for file, golden := range map[string]string{o.enums: "enums.golden", o.endpoints: "endpoints.golden"} {
+		written, err := os.ReadFile(file)
+		require.NoError(t, err)
+		expected, err := os.ReadFile(filepath.Join("testdata", golden))
+		require.NoError(t, err)
+		assert.Equal(t, string(expected), string(written))
+	}
+	_, err := os.Stat(filepath.Join(dir, "models_gen.go"))
+	assert.ErrorIs(t, err, os.ErrNotExist, "outputs not asked for are not written")
+
+	o = options{spec: "testdata/spec.yaml", pkg: "sdk", types: "TimeInForce,OrderFill", enums: o.enums, models: filepath.Join(dir, "models_gen.go")}
+	require.NoError(t, run(o))
+	written, err := os.ReadFile(o.models)
+	require.NoError(t, err)
+	assert.Contains(t, string(written), "type OrderFill struct")
+	assert.NotContains(t, string(written), "OrderModel")
+	written, err = os.ReadFile(o.enums)
+	require.NoError(t, err)
+	assert.Contains(t, string(written), "type TimeInForce string")
+	assert.NotContains(t, string(written), "OrderStatusReason")
+
+	o.types = "OrderSide"
+	assert.ErrorContains(t, run(o), "no schema generates OrderSide")
+}
+
+func TestRun_SDKUpToDate(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, run(options{
+		spec:      "../../../openapi/spec.yaml",
+		pkg:       "sdk",
+		enums:     filepath.Join(dir, "enums_gen.go"),
+		models:    filepath.Join(dir, "models_gen.go"),
+		endpoints: filepath.Join(dir, "endpoints_gen.go"),
+	}))
+	for _, file := range []string{"enums_gen.go", "models_gen.go", "endpoints_gen.go"} {
+		generated, err := os.ReadFile(filepath.Join(dir, file))
+		require.NoError(t, err)
+		committed, err := os.ReadFile(filepath.Join("../../..", file))
+		require.NoError(t, err)
+		assert.Equal(t, string(generated), string(committed), "%s is stale, run go generate in src", file)
+	}
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// model is an object schema, generated as a struct
+type model struct {
+	Name        string
+	Description string
+	Fields      []modelField
+}
+
+type modelField struct {
+	Name string
+	Type string
+	Tag  string
+}
+
+// parseModels returns the object schemas of an OpenAPI document, ordered by
+// name, with their fields in the order of the properties
+func parseModels(doc *spec) ([]model, error) {
+	var models []model
+	for name, s := range doc.Components.Schemas {
+		if !s.isObject() {
+			continue
+		}
+		m := model{Name: typeName(name, s), Description: strings.TrimSpace(s.Description)}
+		required := make(map[string]bool, len(s.Required))
+		for _, prop := range s.Required {
+			required[prop] = true
+		}
+		for _, prop := range s.Properties {
+			typ, err := goType(doc, prop.Schema)
+			if err != nil {
+				return nil, fmt.Errorf("schema %s, property %s: %w", name, prop.Name, err)
+			}
+			f := modelField{Name: fieldName(prop.Name), Type: typ, Tag: prop.Name}
+			if prop.Schema.GoName != "" {
+				f.Name = prop.Schema.GoName
+			}
+			if !required[prop.Name] {
+				f.Tag += ",omitempty"
+			}
+			m.Fields = append(m.Fields, f)
+		}
+		models = append(models, m)
+	}
+	sort.Slice(models, func(i, j int) bool { return models[i].Name < models[j].Name })
+	return models, nil
+}
+
+// goType returns the Go type of a property schema. Decimals are strings of
+// format decimal on the wire.
+func goType(doc *spec, s schema) (string, error) {
+	switch {
+	case s.Ref != "":
+		name, ok := strings.CutPrefix(s.Ref, "#/components/schemas/")
+		target, found := doc.Components.Schemas[name]
+		if !ok || !found {
+			return "", fmt.Errorf("unresolved reference %s", s.Ref)
+		}
+		return typeName(name, target), nil
+	case s.isEnum():
+		return "", fmt.Errorf("inline enums are not supported, reference an enum schema")
+	case s.Type == "array" && s.Items != nil:
+		item, err := goType(doc, *s.Items)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + item, nil
+	case s.Type == "string" && s.Format == "decimal":
+		return "decimal.Decimal", nil
+	case s.Type == "string":
+		return "string", nil
+	case s.Type == "integer" && (s.Format == "int64" || s.Format == "int32"):
+		return s.Format, nil
+	case s.Type == "integer":
+		return "int", nil
+	case s.Type == "number":
+		return "float64", nil
+	case s.Type == "boolean":
+		return "bool", nil
+	}
+	return "", fmt.Errorf("unsupported schema of type %q", s.Type)
+}
+
+// usesDecimal reports whether a field of models is a decimal
+func usesDecimal(models []model) bool {
+	for _, m := range models {
+		for _, f := range m.Fields {
+			if strings.HasSuffix(f.Type, "decimal.Decimal") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+var modelsTemplate = template.Must(template.New("models").Funcs(funcs).Parse(header + `
+{{- if usesDecimal .Decls}}
+import "github.com/shopspring/decimal"
+{{- end}}
+{{range .Decls}}
+{{if .Description}}{{comment .Description}}{{else}}// {{.Name}} is a model of the exchange API{{end}}
+type {{.Name}} struct {
+{{- range .Fields}}
+	{{.Name}} {{.Type}} ` + "`json:\"{{.Tag}}\"`" + `
+{{- end}}
+}
+{{end}}`))
+
+// generateModels returns the gofmt formatted source declaring the structs of
+// models in package pkg
+func generateModels(pkg string, models []model) ([]byte, error) {
+	return render(modelsTemplate, pkg, models)
+}
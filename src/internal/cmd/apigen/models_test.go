@@ -0,0 +1,43 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateModels(t *testing.T) {
+	models, err := parseModels(fixture(t))
+	require.NoError(t, err)
+	require.Len(t, models, 2, "enums are skipped")
+	assert.Equal(t, "OrderFill", models[0].Name, "x-go-name replaces the type name")
+	assert.Equal(t, "OrderModel", models[1].Name)
+	assert.Equal(t, []modelField{
+		{"ID", "int64", "id"},
+		{"ExternalID", "string", "externalId"},
+		{"Side", "string", "side"},
+		{"Qty", "decimal.Decimal", "qty"},
+		{"TimeInForce", "TimeInForce", "timeInForce,omitempty"},
+		{"Fills", "[]OrderFill", "fills"},
+		{"Webhook", "string", "callbackUrl,omitempty"},
+	}, models[1].Fields, "fields keep the order of the properties")
+
+	src, err := generateModels("sdk", models)
+	require.NoError(t, err)
+	golden, err := os.ReadFile("testdata/models.golden")
+	require.NoError(t, err)
+	assert.Equal(t, string(golden), string(src))
+}
+
+func TestParseModels_Errors(t *testing.T) {
+	_, err := parseModels(parse(t, `{"components":{"schemas":{"Order":{"type":"object","properties":{"side":{"$ref":"#/components/schemas/Side"}}}}}}`))
+	assert.ErrorContains(t, err, "schema Order, property side: unresolved reference #/components/schemas/Side")
+
+	_, err = parseModels(parse(t, `{"components":{"schemas":{"Order":{"type":"object","properties":{"side":{"type":"string","enum":["BUY"]}}}}}}`))
+	assert.ErrorContains(t, err, "inline enums are not supported")
+
+	_, err = parseModels(parse(t, `{"components":{"schemas":{"Order":{"type":"object","properties":{"extra":{"type":"object"}}}}}}`))
+	assert.ErrorContains(t, err, `unsupported schema of type "object"`)
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// spec is the part of an OpenAPI document apigen reads
+type spec struct {
+	Components struct {
+		Schemas map[string]schema `yaml:"schemas"`
+	} `yaml:"components"`
+	Paths map[string]map[string]yaml.Node `yaml:"paths"`
+}
+
+type schema struct {
+	Type        string     `yaml:"type"`
+	Format      string     `yaml:"format"`
+	Description string     `yaml:"description"`
+	Ref         string     `yaml:"$ref"`
+	Items       *schema    `yaml:"items"`
+	Properties  properties `yaml:"properties"`
+	Required    []string   `yaml:"required"`
+	Enum        []string   `yaml:"enum"`
+	// VarNames overrides the constant suffixes derived from the values, and
+	// Descriptions comments them, the extensions used by openapi-generator
+	VarNames     []string `yaml:"x-enum-varnames"`
+	Descriptions []string `yaml:"x-enum-descriptions"`
+	// Prefix replaces the type name as the prefix of the constants, and Kind
+	// the name of the type in docs and errors, for enums that predate the
+	// generator
+	Prefix string `yaml:"x-go-prefix"`
+	Kind   string `yaml:"x-go-kind"`
+	// GoName overrides the name of the Go type of a schema or of the field of
+	// a property
+	GoName string `yaml:"x-go-name"`
+}
+
+// isEnum reports whether s is a string enum schema
+func (s schema) isEnum() bool {
+	return s.Type == "string" && len(s.Enum) > 0
+}
+
+// isObject reports whether s is an object schema with properties
+func (s schema) isObject() bool {
+	return s.Type == "object" && len(s.Properties) > 0
+}
+
+// property is a property of an object schema
+type property struct {
+	Name   string
+	Schema schema
+}
+
+// properties are the properties of an object schema in declaration order,
+// the order of the generated fields
+type properties []property
+
+func (p *properties) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: properties must be a mapping", node.Line)
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		var s schema
+		if err := node.Content[i+1].Decode(&s); err != nil {
+			return err
+		}
+		*p = append(*p, property{Name: node.Content[i].Value, Schema: s})
+	}
+	return nil
+}
+
+// parseSpec parses an OpenAPI document. JSON documents parse as YAML.
+func parseSpec(data []byte) (*spec, error) {
+	var doc spec
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// typeName returns the name of the Go type generated for a schema: the
+// schema name for enums, suffixed with Model for objects
+func typeName(name string, s schema) string {
+	switch {
+	case s.GoName != "":
+		return s.GoName
+	case s.isObject():
+		return goName(name) + "Model"
+	default:
+		return goName(name)
+	}
+}
+
+// goName converts a schema name or enum value such as NOT_ENOUGH_FUNDS or
+// order-side to an exported Go identifier, NotEnoughFunds or OrderSide.
+// Names that are already mixed case keep their inner capitals.
+func goName(s string) string {
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if strings.ToUpper(word) == word {
+			word = strings.ToLower(word)
+		}
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+	return b.String()
+}
+
+// initialisms are the words spelled in capitals in Go identifiers
+var initialisms = map[string]string{
+	"Api": "API",
+	"Id":  "ID",
+	"Url": "URL",
+}
+
+// fieldName converts a property name such as accountId to the exported Go
+// identifier AccountID, spelling initialisms in capitals
+func fieldName(s string) string {
+	words := camelWords(goName(s))
+	for i, word := range words {
+		if upper, ok := initialisms[word]; ok {
+			words[i] = upper
+		}
+	}
+	return strings.Join(words, "")
+}
+
+// lowerName converts a name like fieldName does, to an unexported
+// identifier: externalId is externalID and id is id
+func lowerName(s string) string {
+	words := camelWords(fieldName(s))
+	words[0] = strings.ToLower(words[0])
+	return strings.Join(words, "")
+}
+
+// camelWords splits a mixed case identifier before each capital that follows
+// a lower case letter or digit
+func camelWords(name string) []string {
+	var words []string
+	start := 0
+	runes := []rune(name)
+	for i := 1; i < len(runes); i++ {
+		if unicode.IsUpper(runes[i]) && !unicode.IsUpper(runes[i-1]) {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	return append(words, string(runes[start:]))
+}
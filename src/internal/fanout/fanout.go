@@ -0,0 +1,56 @@
+// Package fanout provides bounded, cancellable concurrency for SDK batch APIs.
+package fanout
+
+import (
+	"context"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// DefaultLimit is used when a non-positive concurrency limit is given
+const DefaultLimit = 8
+
+// Run calls fn for every index in [0, n) with at most limit calls in flight.
+// The first error cancels the context passed to the remaining calls and is
+// returned once all started calls have finished.
+func Run(ctx context.Context, n, limit int, fn func(ctx context.Context, i int) error) error {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(limit)
+	for i := 0; i < n; i++ {
+		// Stop scheduling new work once a call has failed or ctx is done
+		if gctx.Err() != nil {
+			break
+		}
+		g.Go(func() error {
+			if err := gctx.Err(); err != nil {
+				return err
+			}
+			return fn(gctx, i)
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
+	return ctx.Err()
+}
+
+// Map applies fn to every item concurrently and returns the results in input order
+func Map[T, R any](ctx context.Context, items []T, limit int, fn func(ctx context.Context, item T) (R, error)) ([]R, error) {
+	results := make([]R, len(items))
+	err := Run(ctx, len(items), limit, func(ctx context.Context, i int) error {
+		r, err := fn(ctx, items[i])
+		if err != nil {
+			return err
+		}
+		results[i] = r
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -0,0 +1,79 @@
+package fanout
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun_BoundsConcurrency(t *testing.T) {
+	var inFlight, peak, calls int32
+
+	err := Run(context.Background(), 50, 4, func(ctx context.Context, i int) error {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		atomic.AddInt32(&calls, 1)
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(50), calls)
+	assert.LessOrEqual(t, peak, int32(4))
+}
+
+func TestRun_FirstErrorCancelsRemaining(t *testing.T) {
+	boom := errors.New("boom")
+	var calls int32
+
+	err := Run(context.Background(), 100, 2, func(ctx context.Context, i int) error {
+		atomic.AddInt32(&calls, 1)
+		if i == 3 {
+			return boom
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(5 * time.Millisecond):
+			return nil
+		}
+	})
+
+	require.ErrorIs(t, err, boom)
+	assert.Less(t, atomic.LoadInt32(&calls), int32(100))
+}
+
+func TestRun_ParentContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := Run(ctx, 10, 2, func(ctx context.Context, i int) error {
+		t.Fatal("fn must not be called with a cancelled context")
+		return nil
+	})
+
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestMap_PreservesOrder(t *testing.T) {
+	items := []int{5, 4, 3, 2, 1}
+
+	results, err := Map(context.Background(), items, 3, func(ctx context.Context, item int) (int, error) {
+		time.Sleep(time.Duration(item) * time.Millisecond)
+		return item * 10, nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []int{50, 40, 30, 20, 10}, results)
+}
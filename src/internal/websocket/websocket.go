@@ -0,0 +1,345 @@
+// Package websocket implements the parts of RFC 6455 the stream client
+// needs: the client handshake, a server handshake for tests, and message
+// framing with automatic replies to pings and close frames. Extensions and
+// subprotocols are not supported.
+package websocket
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MessageType is the opcode of a frame
+type MessageType byte
+
+const (
+	TextMessage   MessageType = 1
+	BinaryMessage MessageType = 2
+	CloseMessage  MessageType = 8
+	PingMessage   MessageType = 9
+	PongMessage   MessageType = 10
+
+	continuation MessageType = 0
+)
+
+// Close codes of RFC 6455 section 7.4.1
+const (
+	CloseNormal         = 1000
+	CloseGoingAway      = 1001
+	CloseProtocolError  = 1002
+	CloseNoStatus       = 1005
+	CloseMessageTooBig  = 1009
+	CloseInternalError  = 1011
+	maxControlFrameSize = 125
+)
+
+// DefaultReadLimit bounds the size of a message when no limit is set
+const DefaultReadLimit = 16 << 20
+
+var (
+	// ErrReadLimit is returned for messages larger than the read limit
+	ErrReadLimit = errors.New("websocket: message exceeds read limit")
+	// ErrClosed is returned for writes after Close
+	ErrClosed   = errors.New("websocket: connection closed")
+	errProtocol = errors.New("websocket: protocol error")
+)
+
+// CloseError is returned by ReadMessage when the peer closed the connection
+type CloseError struct {
+	Code   int
+	Reason string
+}
+
+func (e *CloseError) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("websocket: closed with code %d", e.Code)
+	}
+	return fmt.Sprintf("websocket: closed with code %d: %s", e.Code, e.Reason)
+}
+
+// acceptGUID is appended to the client key to compute the accept key
+const acceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+func acceptKey(key string) string {
+	sum := sha1.Sum([]byte(key + acceptGUID))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// Conn is a WebSocket connection. One goroutine may read while others
+// write; writes are serialized.
+type Conn struct {
+	conn     net.Conn
+	br       *bufio.Reader
+	isServer bool
+
+	readLimit   int64
+	pongHandler func([]byte)
+
+	writeMu   sync.Mutex
+	closeSent bool
+	closeOnce sync.Once
+}
+
+func newConn(conn net.Conn, br *bufio.Reader, isServer bool) *Conn {
+	if br == nil {
+		br = bufio.NewReader(conn)
+	}
+	return &Conn{conn: conn, br: br, isServer: isServer, readLimit: DefaultReadLimit}
+}
+
+// SetReadLimit bounds the size of messages read; non-positive restores
+// DefaultReadLimit
+func (c *Conn) SetReadLimit(limit int64) {
+	if limit <= 0 {
+		limit = DefaultReadLimit
+	}
+	c.readLimit = limit
+}
+
+// SetPongHandler sets the function called with the payload of every pong
+// read by ReadMessage
+func (c *Conn) SetPongHandler(fn func(payload []byte)) {
+	c.pongHandler = fn
+}
+
+// SetReadDeadline sets the deadline of reads, see net.Conn
+func (c *Conn) SetReadDeadline(t time.Time) error {
+	return c.conn.SetReadDeadline(t)
+}
+
+// SetWriteDeadline sets the deadline of writes, see net.Conn
+func (c *Conn) SetWriteDeadline(t time.Time) error {
+	return c.conn.SetWriteDeadline(t)
+}
+
+// RemoteAddr returns the address of the peer
+func (c *Conn) RemoteAddr() net.Addr {
+	return c.conn.RemoteAddr()
+}
+
+// ReadMessage returns the next text or binary message. Pings are answered
+// and pongs passed to the pong handler while waiting. When the peer closes
+// the connection, the close is acknowledged and a *CloseError returned.
+func (c *Conn) ReadMessage() (MessageType, []byte, error) {
+	var (
+		typ     MessageType
+		message []byte
+	)
+	for {
+		fin, op, payload, err := c.readFrame()
+		if err != nil {
+			return 0, nil, err
+		}
+		switch op {
+		case PingMessage:
+			if err := c.writeFrame(PongMessage, payload); err != nil && !errors.Is(err, ErrClosed) {
+				return 0, nil, err
+			}
+			continue
+		case PongMessage:
+			if c.pongHandler != nil {
+				c.pongHandler(payload)
+			}
+			continue
+		case CloseMessage:
+			closeErr := &CloseError{Code: CloseNoStatus}
+			if len(payload) >= 2 {
+				closeErr.Code = int(binary.BigEndian.Uint16(payload))
+				closeErr.Reason = string(payload[2:])
+			}
+			c.Close(CloseNormal, "")
+			return 0, nil, closeErr
+		case TextMessage, BinaryMessage:
+			if typ != 0 {
+				return 0, nil, c.fail(CloseProtocolError, "new message inside a fragmented message")
+			}
+			typ = op
+		case continuation:
+			if typ == 0 {
+				return 0, nil, c.fail(CloseProtocolError, "continuation without a message")
+			}
+		default:
+			return 0, nil, c.fail(CloseProtocolError, fmt.Sprintf("unknown opcode %d", op))
+		}
+		if int64(len(message)+len(payload)) > c.readLimit {
+			c.fail(CloseMessageTooBig, "")
+			return 0, nil, ErrReadLimit
+		}
+		message = append(message, payload...)
+		if fin {
+			return typ, message, nil
+		}
+	}
+}
+
+// readFrame reads a single frame and returns its unmasked payload
+func (c *Conn) readFrame() (fin bool, op MessageType, payload []byte, err error) {
+	var header [2]byte
+	if _, err := io.ReadFull(c.br, header[:]); err != nil {
+		return false, 0, nil, err
+	}
+	fin = header[0]&0x80 != 0
+	if header[0]&0x70 != 0 {
+		return false, 0, nil, c.fail(CloseProtocolError, "reserved bits set")
+	}
+	op = MessageType(header[0] & 0x0f)
+	masked := header[1]&0x80 != 0
+	if masked != c.isServer {
+		return false, 0, nil, c.fail(CloseProtocolError, "wrong masking")
+	}
+
+	length := uint64(header[1] & 0x7f)
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.br, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.br, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+	if op >= CloseMessage && (length > maxControlFrameSize || !fin) {
+		return false, 0, nil, c.fail(CloseProtocolError, "invalid control frame")
+	}
+	if length > uint64(c.readLimit) {
+		c.fail(CloseMessageTooBig, "")
+		return false, 0, nil, ErrReadLimit
+	}
+
+	var mask [4]byte
+	if masked {
+		if _, err := io.ReadFull(c.br, mask[:]); err != nil {
+			return false, 0, nil, err
+		}
+	}
+	payload = make([]byte, length)
+	if _, err := io.ReadFull(c.br, payload); err != nil {
+		return false, 0, nil, err
+	}
+	if masked {
+		maskBytes(mask, payload)
+	}
+	return fin, op, payload, nil
+}
+
+// WriteMessage sends a text or binary message in a single frame
+func (c *Conn) WriteMessage(typ MessageType, data []byte) error {
+	if typ != TextMessage && typ != BinaryMessage {
+		return fmt.Errorf("websocket: cannot write message type %d", typ)
+	}
+	return c.writeFrame(typ, data)
+}
+
+// Ping sends a ping; the peer answers with a pong carrying payload
+func (c *Conn) Ping(payload []byte) error {
+	if len(payload) > maxControlFrameSize {
+		return fmt.Errorf("websocket: ping payload of %d bytes is too large", len(payload))
+	}
+	return c.writeFrame(PingMessage, payload)
+}
+
+func (c *Conn) writeFrame(op MessageType, payload []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if c.closeSent {
+		return ErrClosed
+	}
+	if op == CloseMessage {
+		c.closeSent = true
+	}
+
+	frame := make([]byte, 0, len(payload)+14)
+	frame = append(frame, 0x80|byte(op))
+	maskBit := byte(0)
+	if !c.isServer {
+		maskBit = 0x80
+	}
+	switch n := len(payload); {
+	case n <= 125:
+		frame = append(frame, maskBit|byte(n))
+	case n <= 0xffff:
+		frame = append(frame, maskBit|126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(n))
+	default:
+		frame = append(frame, maskBit|127)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(n))
+	}
+	if c.isServer {
+		frame = append(frame, payload...)
+	} else {
+		var mask [4]byte
+		if _, err := rand.Read(mask[:]); err != nil {
+			return err
+		}
+		frame = append(frame, mask[:]...)
+		start := len(frame)
+		frame = append(frame, payload...)
+		maskBytes(mask, frame[start:])
+	}
+	_, err := c.conn.Write(frame)
+	return err
+}
+
+// Close sends a close frame with code and reason, if none was sent yet, and
+// closes the connection. It is safe to call more than once.
+func (c *Conn) Close(code int, reason string) error {
+	var err error
+	c.closeOnce.Do(func() {
+		payload := binary.BigEndian.AppendUint16(nil, uint16(code))
+		payload = append(payload, reason...)
+		if len(payload) > maxControlFrameSize {
+			payload = payload[:maxControlFrameSize]
+		}
+		c.conn.SetWriteDeadline(time.Now().Add(time.Second))
+		if werr := c.writeFrame(CloseMessage, payload); werr != nil && !errors.Is(werr, ErrClosed) {
+			err = werr
+		}
+		if cerr := c.conn.Close(); err == nil {
+			err = cerr
+		}
+	})
+	return err
+}
+
+// fail closes the connection after a protocol violation of the peer
+func (c *Conn) fail(code int, reason string) error {
+	c.Close(code, reason)
+	if reason == "" {
+		return errProtocol
+	}
+	return fmt.Errorf("%w: %s", errProtocol, reason)
+}
+
+func maskBytes(mask [4]byte, b []byte) {
+	for i := range b {
+		b[i] ^= mask[i%4]
+	}
+}
+
+// headerContains reports whether a comma separated header lists token,
+// ignoring case
+func headerContains(values []string, token string) bool {
+	for _, value := range values {
+		for _, v := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(v), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+package websocket
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func wsURL(server *httptest.Server) string {
+	return "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+func TestDial_EchoesMessages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.Header.Get("X-API-Key"))
+		conn, err := Accept(w, r)
+		if err != nil {
+			return
+		}
+		for {
+			typ, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if err := conn.WriteMessage(typ, msg); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, err := Dial(ctx, wsURL(server)+"/echo", DialOptions{Header: http.Header{"X-API-Key": {"secret"}}})
+	require.NoError(t, err)
+	defer conn.Close(CloseNormal, "")
+
+	for _, msg := range []string{"hello", strings.Repeat("x", 300), strings.Repeat("y", 70000)} {
+		require.NoError(t, conn.WriteMessage(TextMessage, []byte(msg)))
+		typ, got, err := conn.ReadMessage()
+		require.NoError(t, err)
+		assert.Equal(t, TextMessage, typ)
+		assert.Equal(t, msg, string(got))
+	}
+
+	pong := make(chan string, 1)
+	conn.SetPongHandler(func(payload []byte) { pong <- string(payload) })
+	require.NoError(t, conn.Ping([]byte("ping")))
+	require.NoError(t, conn.WriteMessage(BinaryMessage, []byte{1}))
+	_, _, err = conn.ReadMessage()
+	require.NoError(t, err)
+	assert.Equal(t, "ping", <-pong)
+}
+
+func TestDial_ServerClose(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := Accept(w, r)
+		if err != nil {
+			return
+		}
+		conn.Close(CloseGoingAway, "restart")
+	}))
+	defer server.Close()
+
+	conn, err := Dial(context.Background(), wsURL(server), DialOptions{})
+	require.NoError(t, err)
+
+	_, _, err = conn.ReadMessage()
+	var closeErr *CloseError
+	require.ErrorAs(t, err, &closeErr)
+	assert.Equal(t, CloseGoingAway, closeErr.Code)
+	assert.Equal(t, "restart", closeErr.Reason)
+	assert.ErrorIs(t, conn.WriteMessage(TextMessage, []byte("late")), ErrClosed)
+}
+
+func TestDial_RejectedHandshake(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid api key", http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	_, err := Dial(context.Background(), wsURL(server), DialOptions{})
+	var hsErr *HandshakeError
+	require.ErrorAs(t, err, &hsErr)
+	assert.Equal(t, http.StatusUnauthorized, hsErr.StatusCode)
+	assert.Contains(t, hsErr.Body, "invalid api key")
+}
+
+func TestDial_HandshakeHonoursContext(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := Dial(ctx, wsURL(server), DialOptions{})
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "got %v", err)
+}
+
+func TestReadLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := Accept(w, r)
+		if err != nil {
+			return
+		}
+		defer conn.Close(CloseNormal, "")
+		conn.WriteMessage(TextMessage, []byte(strings.Repeat("z", 64)))
+		conn.ReadMessage()
+	}))
+	defer server.Close()
+
+	conn, err := Dial(context.Background(), wsURL(server), DialOptions{})
+	require.NoError(t, err)
+	conn.SetReadLimit(16)
+	_, _, err = conn.ReadMessage()
+	assert.ErrorIs(t, err, ErrReadLimit)
+}
+
+func TestAccept_RejectsPlainRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := Accept(w, r)
+		assert.Error(t, err)
+	}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,312 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// IntentKind is the kind of request recorded in a Journal
+type IntentKind string
+
+const (
+	IntentPlaceOrder              IntentKind = "PLACE_ORDER"
+	IntentCancelOrder             IntentKind = "CANCEL_ORDER"
+	IntentCancelOrderByExternalID IntentKind = "CANCEL_ORDER_BY_EXTERNAL_ID"
+	IntentMassCancel              IntentKind = "MASS_CANCEL"
+)
+
+// Intent is an order or cancel request recorded before it is sent
+type Intent struct {
+	// ID keys the intent in the journal
+	ID   string     `json:"id"`
+	Kind IntentKind `json:"kind"`
+	// ExternalID is the external ID of the placed or cancelled order
+	ExternalID string `json:"externalId,omitempty"`
+	// OrderID is the exchange ID of the cancelled order
+	OrderID    int64             `json:"orderId,omitempty"`
+	Market     string            `json:"market,omitempty"`
+	MassCancel *MassCancelParams `json:"massCancel,omitempty"`
+	CreatedAt  time.Time         `json:"createdAt"`
+}
+
+// Journal persists order and cancel intents so that, after a crash, the
+// requests that were in flight can be looked up on the exchange
+type Journal interface {
+	// Record persists the intent; the request is not sent if it fails
+	Record(intent Intent) error
+	// Resolve marks the intent as answered by the exchange
+	Resolve(id string) error
+	// Pending returns the intents recorded and not resolved, oldest first
+	Pending() ([]Intent, error)
+}
+
+// WithJournal records every order placement and cancellation in journal
+// before it is sent and resolves it once the exchange answered, accepted or
+// not. Requests that fail without an answer, e.g. on a timeout or a crash,
+// stay pending until RecoverJournal looks them up.
+func WithJournal(journal Journal) ClientOption {
+	return func(m *BaseModule) {
+		m.journal = journal
+	}
+}
+
+// FileJournal keeps the pending intents in one JSON file. Resolved intents are
+// removed, so the file stays as small as the number of requests in flight.
+type FileJournal struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileJournal returns a journal backed by the file at path, which is
+// created on first use
+func NewFileJournal(path string) *FileJournal {
+	return &FileJournal{path: path}
+}
+
+// Record persists the intent under its ID
+func (j *FileJournal) Record(intent Intent) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	intents, err := j.read()
+	if err != nil {
+		return err
+	}
+	intents[intent.ID] = intent
+	return j.write(intents)
+}
+
+// Resolve removes the intent; unknown IDs are ignored
+func (j *FileJournal) Resolve(id string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	intents, err := j.read()
+	if err != nil {
+		return err
+	}
+	if _, ok := intents[id]; !ok {
+		return nil
+	}
+	delete(intents, id)
+	return j.write(intents)
+}
+
+// Pending returns the unresolved intents, oldest first
+func (j *FileJournal) Pending() ([]Intent, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	intents, err := j.read()
+	if err != nil {
+		return nil, err
+	}
+	pending := make([]Intent, 0, len(intents))
+	for _, intent := range intents {
+		pending = append(pending, intent)
+	}
+	sort.Slice(pending, func(a, b int) bool {
+		if !pending[a].CreatedAt.Equal(pending[b].CreatedAt) {
+			return pending[a].CreatedAt.Before(pending[b].CreatedAt)
+		}
+		return pending[a].ID < pending[b].ID
+	})
+	return pending, nil
+}
+
+func (j *FileJournal) read() (map[string]Intent, error) {
+	intents := make(map[string]Intent)
+	data, err := os.ReadFile(j.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return intents, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+	if err := json.Unmarshal(data, &intents); err != nil {
+		return nil, fmt.Errorf("invalid journal file %s: %w", j.path, err)
+	}
+	return intents, nil
+}
+
+func (j *FileJournal) write(intents map[string]Intent) error {
+	data, err := json.Marshal(intents)
+	if err != nil {
+		return fmt.Errorf("failed to encode journal: %w", err)
+	}
+	if err := writeFileAtomic(j.path, data); err != nil {
+		return fmt.Errorf("failed to persist journal: %w", err)
+	}
+	return nil
+}
+
+// journaled records intent, runs send and resolves the intent if the
+// exchange answered, see answered
+func (c *APIClient) journaled(intent Intent, send func() error) error {
+	if c.journal == nil {
+		return send()
+	}
+	intent.CreatedAt = c.Clock().Now()
+	if err := c.journal.Record(intent); err != nil {
+		return fmt.Errorf("failed to journal %s: %w", intent.Kind, err)
+	}
+	err := send()
+	if answered(err) {
+		if resolveErr := c.journal.Resolve(intent.ID); resolveErr != nil && err == nil {
+			return fmt.Errorf("failed to resolve journaled %s: %w", intent.Kind, resolveErr)
+		}
+	}
+	return err
+}
+
+// answered reports whether the result of a request shows what the exchange
+// did with it: it succeeded or was rejected with a 4xx status. After a 5xx
+// status, e.g. from a gateway, or a transport failure the request may still
+// have taken effect.
+func answered(err error) bool {
+	if err == nil {
+		return true
+	}
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode >= 400 && apiErr.StatusCode < 500
+}
+
+// IntentOutcome is what became of a pending intent
+type IntentOutcome string
+
+const (
+	// IntentApplied means the request took effect: the order exists, or the
+	// cancelled orders are no longer open
+	IntentApplied IntentOutcome = "APPLIED"
+	// IntentNotApplied means the request did not take effect: the order was
+	// never accepted, or the cancelled orders are still open
+	IntentNotApplied IntentOutcome = "NOT_APPLIED"
+)
+
+// RecoveredIntent is a pending intent looked up on the exchange
+type RecoveredIntent struct {
+	Intent  Intent
+	Outcome IntentOutcome
+	// Order is the latest state of the placed or cancelled order, when the
+	// intent names one and the exchange knows it
+	Order *OpenOrderModel
+}
+
+// RecoverJournal looks up the fate of every pending intent of the journal set
+// with WithJournal, typically at startup after a crash, and resolves them. A
+// cancel that did not apply may be sent again; a placement that did not apply
+// was never accepted and may be re-signed.
+func (c *APIClient) RecoverJournal(ctx context.Context) ([]RecoveredIntent, error) {
+	if c.journal == nil {
+		return nil, fmt.Errorf("no journal configured, see WithJournal")
+	}
+	pending, err := c.journal.Pending()
+	if err != nil {
+		return nil, err
+	}
+
+	var open map[int64]OpenOrderModel
+	openOrders := func() (map[int64]OpenOrderModel, error) {
+		if open != nil {
+			return open, nil
+		}
+		orders, err := c.GetOpenOrders(ctx, OpenOrdersFilter{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch open orders: %w", err)
+		}
+		open = make(map[int64]OpenOrderModel, len(orders))
+		for _, o := range orders {
+			open[o.ID] = o
+		}
+		return open, nil
+	}
+
+	recovered := make([]RecoveredIntent, 0, len(pending))
+	for _, intent := range pending {
+		r := RecoveredIntent{Intent: intent}
+		switch intent.Kind {
+		case IntentPlaceOrder, IntentCancelOrderByExternalID:
+			order, err := c.latestOrder(ctx, intent.ExternalID)
+			if err != nil {
+				return recovered, fmt.Errorf("failed to look up order %s: %w", intent.ExternalID, err)
+			}
+			r.Order = order
+			applied := order != nil
+			if intent.Kind == IntentCancelOrderByExternalID {
+				applied = order == nil || order.Status.IsFinal()
+			}
+			r.Outcome = outcome(applied)
+
+		case IntentCancelOrder:
+			open, err := openOrders()
+			if err != nil {
+				return recovered, err
+			}
+			if order, ok := open[intent.OrderID]; ok {
+				r.Order = &order
+			}
+			r.Outcome = outcome(r.Order == nil)
+
+		case IntentMassCancel:
+			open, err := openOrders()
+			if err != nil {
+				return recovered, err
+			}
+			applied := true
+			for _, order := range open {
+				if intent.MassCancel != nil && intent.MassCancel.matches(order) {
+					applied = false
+					break
+				}
+			}
+			r.Outcome = outcome(applied)
+
+		default:
+			return recovered, fmt.Errorf("unknown intent kind %q", intent.Kind)
+		}
+
+		if err := c.journal.Resolve(intent.ID); err != nil {
+			return recovered, err
+		}
+		recovered = append(recovered, r)
+	}
+	return recovered, nil
+}
+
+func outcome(applied bool) IntentOutcome {
+	if applied {
+		return IntentApplied
+	}
+	return IntentNotApplied
+}
+
+// matches reports whether the mass cancel selects order
+func (p MassCancelParams) matches(order OpenOrderModel) bool {
+	if p.CancelAll {
+		return true
+	}
+	for _, market := range p.Markets {
+		if order.Market == market {
+			return true
+		}
+	}
+	for _, id := range p.OrderIDs {
+		if order.ID == id {
+			return true
+		}
+	}
+	for _, id := range p.ExternalOrderIDs {
+		if order.ExternalID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// intentID keys an intent by its kind and the order it concerns
+func intentID(kind IntentKind, key string) string {
+	return string(kind) + ":" + key
+}
@@ -0,0 +1,115 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// DefaultKeepAliveInterval is used by KeepAlive when no interval is given
+const DefaultKeepAliveInterval = 30 * time.Second
+
+// ErrAPIKeyRejected wraps the error of the last keep-alive ping while the
+// exchange rejects the API key, e.g. because it expired, was revoked or the
+// request came from an IP outside its allowlist
+var ErrAPIKeyRejected = errors.New("API key rejected")
+
+// AuthEventKind classifies a change of the API key state seen by KeepAlive
+type AuthEventKind string
+
+const (
+	// AuthEventRejected is sent when the exchange starts rejecting the key
+	AuthEventRejected AuthEventKind = "REJECTED"
+	// AuthEventRestored is sent when a rejected key is accepted again
+	AuthEventRestored AuthEventKind = "RESTORED"
+)
+
+// AuthEvent reports that the exchange started or stopped rejecting the API
+// key. Err is the error of the rejected ping, nil for AuthEventRestored.
+type AuthEvent struct {
+	Kind AuthEventKind
+	Time time.Time
+	Err  error
+}
+
+// KeepAlive pings an authenticated endpoint every interval, so that an
+// expired or blocked API key is noticed before the next trade rather than by
+// it. An event is sent when the exchange starts rejecting the key and when
+// it accepts it again; errors that say nothing about the key, such as
+// timeouts, are ignored. While the key is rejected, AuthError returns the
+// cause and PlaceOrder fails with it without sending the order. The channel
+// is closed once ctx is done. A non-positive interval falls back to
+// DefaultKeepAliveInterval.
+func (c *APIClient) KeepAlive(ctx context.Context, interval time.Duration) <-chan AuthEvent {
+	if interval <= 0 {
+		interval = DefaultKeepAliveInterval
+	}
+	events := make(chan AuthEvent, 4)
+	go func() {
+		defer close(events)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if event, ok := c.ping(ctx); ok {
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return events
+}
+
+// AuthError returns an error wrapping ErrAPIKeyRejected while KeepAlive sees
+// the API key rejected, nil otherwise
+func (m *BaseModule) AuthError() error {
+	if err := m.authErr.Load(); err != nil {
+		return *err
+	}
+	return nil
+}
+
+// ping sends one keep-alive request and returns the event to send if the
+// state of the API key changed
+func (c *APIClient) ping(ctx context.Context) (AuthEvent, bool) {
+	_, err := c.GetBalance(ctx)
+	now := c.clock.Now()
+	switch {
+	case isAuthFailure(err):
+		rejected := fmt.Errorf("%w: %w", ErrAPIKeyRejected, err)
+		if c.authErr.Swap(&rejected) == nil {
+			return AuthEvent{Kind: AuthEventRejected, Time: now, Err: rejected}, true
+		}
+	case err == nil || isAPIResponse(err):
+		// Any other answer of the exchange shows the key is accepted
+		if c.authErr.Swap(nil) != nil {
+			return AuthEvent{Kind: AuthEventRestored, Time: now}, true
+		}
+	}
+	return AuthEvent{}, false
+}
+
+// isAuthFailure reports whether the exchange refused a request because of
+// its credentials
+func isAuthFailure(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) &&
+		(apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden)
+}
+
+// isAPIResponse reports whether err was answered by the exchange
+func isAPIResponse(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode < http.StatusInternalServerError
+}
@@ -0,0 +1,84 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeepAlive(t *testing.T) {
+	var status atomic.Int32
+	status.Store(http.StatusUnauthorized)
+	var orders atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/user/order" {
+			orders.Add(1)
+		}
+		switch code := int(status.Load()); code {
+		case http.StatusOK:
+			w.Write([]byte(`{"status":"OK","data":{}}`))
+		case http.StatusNotFound:
+			// No balance yet, the key is still accepted
+			w.WriteHeader(code)
+			w.Write([]byte(`{"status":"ERROR","error":{"code":"NOT_FOUND"}}`))
+		default:
+			w.WriteHeader(code)
+			w.Write([]byte(`{"status":"ERROR","error":{"code":"UNAUTHORIZED"}}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(EndpointConfig{APIBaseURL: server.URL}, TestAPIKey, nil, 5*time.Second)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := client.KeepAlive(ctx, 5*time.Millisecond)
+	next := func() AuthEvent {
+		t.Helper()
+		select {
+		case event := <-events:
+			return event
+		case <-time.After(5 * time.Second):
+			t.Fatal("no auth event")
+			return AuthEvent{}
+		}
+	}
+
+	event := next()
+	assert.Equal(t, AuthEventRejected, event.Kind)
+	assert.ErrorIs(t, event.Err, ErrAPIKeyRejected)
+	var apiErr *APIError
+	require.ErrorAs(t, event.Err, &apiErr)
+	assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+
+	// Orders fail without reaching the exchange while the key is rejected
+	assert.ErrorIs(t, client.AuthError(), ErrAPIKeyRejected)
+	_, _, err := client.PlaceOrder(ctx, CreateOrderObjectParams{})
+	assert.ErrorIs(t, err, ErrAPIKeyRejected)
+	assert.Zero(t, orders.Load())
+
+	// Outages say nothing about the key
+	status.Store(http.StatusBadGateway)
+	time.Sleep(50 * time.Millisecond)
+	assert.Error(t, client.AuthError())
+
+	status.Store(http.StatusNotFound)
+	event = next()
+	assert.Equal(t, AuthEventRestored, event.Kind)
+	assert.NoError(t, event.Err)
+	assert.NoError(t, client.AuthError())
+
+	status.Store(http.StatusForbidden)
+	assert.Equal(t, AuthEventRejected, next().Kind)
+	status.Store(http.StatusOK)
+	assert.Equal(t, AuthEventRestored, next().Kind)
+
+	cancel()
+	for range events {
+	}
+}
@@ -0,0 +1,135 @@
+package sdk
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// DefaultMarketWatchInterval is used by WatchMarkets when no interval is given
+const DefaultMarketWatchInterval = time.Minute
+
+// MarketChangeKind classifies a change between two market listings
+type MarketChangeKind string
+
+const (
+	// MarketChangeListed is a market that was not listed before
+	MarketChangeListed MarketChangeKind = "LISTED"
+	// MarketChangeDelisted is a market that disappeared or moved to DELISTED
+	MarketChangeDelisted MarketChangeKind = "DELISTED"
+	// MarketChangeStatus is any other change of the market status
+	MarketChangeStatus MarketChangeKind = "STATUS"
+	// MarketChangeTradingConfig is a change of the trading parameters, such as
+	// the tick size, minimum order size or maximum leverage
+	MarketChangeTradingConfig MarketChangeKind = "TRADING_CONFIG"
+)
+
+// MarketChangeEvent describes how a market changed between two listings.
+// Previous is nil for listed markets and Current is nil for markets that
+// disappeared from the listing.
+type MarketChangeEvent struct {
+	Kind     MarketChangeKind
+	Market   string
+	Previous *MarketModel
+	Current  *MarketModel
+	// Changes lists the parameters that changed for MarketChangeTradingConfig
+	Changes []TradingConfigChange
+}
+
+// DiffMarkets compares two listings of markets and returns the changes, ordered
+// by market name. A market whose status and trading parameters both changed
+// produces one event for each.
+func DiffMarkets(previous, current []MarketModel) []MarketChangeEvent {
+	before := make(map[string]MarketModel, len(previous))
+	for _, m := range previous {
+		before[m.Name] = m
+	}
+	after := make(map[string]MarketModel, len(current))
+	for _, m := range current {
+		after[m.Name] = m
+	}
+
+	var events []MarketChangeEvent
+	for name, cur := range after {
+		prev, ok := before[name]
+		if !ok {
+			events = append(events, MarketChangeEvent{Kind: MarketChangeListed, Market: name, Current: &cur})
+			continue
+		}
+		if prev.Status != cur.Status || prev.Active != cur.Active {
+			kind := MarketChangeStatus
+			if cur.Status == MarketStatusDelisted {
+				kind = MarketChangeDelisted
+			}
+			events = append(events, MarketChangeEvent{Kind: kind, Market: name, Previous: &prev, Current: &cur})
+		}
+		if changes := DiffTradingConfig(name, prev.TradingConfig, cur.TradingConfig); len(changes) > 0 {
+			events = append(events, MarketChangeEvent{Kind: MarketChangeTradingConfig, Market: name, Previous: &prev, Current: &cur, Changes: changes})
+		}
+	}
+	for name, prev := range before {
+		if _, ok := after[name]; !ok {
+			events = append(events, MarketChangeEvent{Kind: MarketChangeDelisted, Market: name, Previous: &prev})
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].Market != events[j].Market {
+			return events[i].Market < events[j].Market
+		}
+		return events[i].Kind < events[j].Kind
+	})
+	return events
+}
+
+// WatchMarkets polls GetMarkets every interval and sends an event for every
+// market that was listed, delisted or had its status or trading parameters
+// changed since the previous poll. The first successful poll is the baseline
+// and produces no events. Errors are sent on the error channel and the
+// watcher retries at the next interval. Both channels are closed once ctx is
+// done. A non-positive interval falls back to DefaultMarketWatchInterval.
+func (c *APIClient) WatchMarkets(ctx context.Context, interval time.Duration) (<-chan MarketChangeEvent, <-chan error) {
+	if interval <= 0 {
+		interval = DefaultMarketWatchInterval
+	}
+	events := make(chan MarketChangeEvent, 16)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(events)
+		defer close(errs)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		var (
+			previous []MarketModel
+			baseline bool
+		)
+		for {
+			markets, err := c.GetMarkets(ctx, nil)
+			if err != nil {
+				select {
+				case errs <- err:
+				default:
+				}
+			} else {
+				if baseline {
+					for _, event := range DiffMarkets(previous, markets) {
+						select {
+						case events <- event:
+						case <-ctx.Done():
+							return
+						}
+					}
+				}
+				previous, baseline = markets, true
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return events, errs
+}
@@ -0,0 +1,79 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffMarkets(t *testing.T) {
+	btc := MarketModel{Name: "BTC-USD", Active: true, Status: MarketStatusActive}
+	btc.TradingConfig.MinPriceChange = decimal.NewFromInt(1)
+	eth := MarketModel{Name: "ETH-USD", Active: true, Status: MarketStatusActive}
+	sol := MarketModel{Name: "SOL-USD", Active: true, Status: MarketStatusActive}
+
+	retickedBTC := btc
+	retickedBTC.TradingConfig.MinPriceChange = decimal.RequireFromString("0.5")
+	retickedBTC.Status = MarketStatusReduceOnly
+	retickedBTC.Active = false
+	delistedETH := eth
+	delistedETH.Status = MarketStatusDelisted
+	delistedETH.Active = false
+	doge := MarketModel{Name: "DOGE-USD", Active: true, Status: MarketStatusActive}
+
+	events := DiffMarkets(
+		[]MarketModel{btc, eth, sol},
+		[]MarketModel{retickedBTC, delistedETH, doge},
+	)
+	require.Len(t, events, 5)
+
+	kinds := make([]string, len(events))
+	for i, e := range events {
+		kinds[i] = e.Market + " " + string(e.Kind)
+	}
+	assert.Equal(t, []string{
+		"BTC-USD STATUS",
+		"BTC-USD TRADING_CONFIG",
+		"DOGE-USD LISTED",
+		"ETH-USD DELISTED",
+		"SOL-USD DELISTED",
+	}, kinds)
+	assert.Nil(t, events[2].Previous)
+	assert.Equal(t, "0.5", events[1].Current.TradingConfig.MinPriceChange.String())
+	assert.Nil(t, events[4].Current)
+
+	// Equal decimals with different exponents are not a change
+	same := btc
+	same.TradingConfig.MinPriceChange = decimal.RequireFromString("1.00")
+	assert.Empty(t, DiffMarkets([]MarketModel{btc}, []MarketModel{same}))
+}
+
+func TestDiffTradingConfig(t *testing.T) {
+	previous := TradingConfigModel{
+		MinOrderSize:   decimal.RequireFromString("0.001"),
+		MinPriceChange: decimal.NewFromInt(1),
+		MaxLeverage:    decimal.NewFromInt(50),
+		MaxNumOrders:   200,
+	}
+	current := previous
+	current.MinOrderSize = decimal.RequireFromString("0.0010")
+	current.MaxLeverage = decimal.NewFromInt(20)
+	current.MaxNumOrders = 100
+
+	changes := DiffTradingConfig("BTC-USD", previous, current)
+	require.Len(t, changes, 2)
+	assert.Equal(t, TradingConfigMaxLeverage, changes[0].Field)
+	assert.Equal(t, "50", changes[0].Previous.String())
+	assert.Equal(t, "20", changes[0].Current.String())
+	assert.Equal(t, TradingConfigMaxNumOrders, changes[1].Field)
+	assert.Equal(t, "BTC-USD", changes[1].Market)
+
+	events := DiffMarkets(
+		[]MarketModel{{Name: "BTC-USD", TradingConfig: previous}},
+		[]MarketModel{{Name: "BTC-USD", TradingConfig: current}},
+	)
+	require.Len(t, events, 1)
+	assert.Equal(t, changes, events[0].Changes)
+}
@@ -0,0 +1,129 @@
+package sdk
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrLossLimitReached is returned for an order that is not reduce-only once
+// the session loss of a LossGuard reached its limit
+var ErrLossLimitReached = errors.New("session loss limit reached")
+
+// LossGuardConfig configures a LossGuard
+type LossGuardConfig struct {
+	// MaxLoss is the drawdown from the equity at the start of the session,
+	// in collateral, at which the guard trips
+	MaxLoss decimal.Decimal
+	// ResetAt is the time of day, as an offset from UTC midnight, at which a
+	// new session starts. Sessions last a day.
+	ResetAt time.Duration
+	Clock   Clock // Defaults to SystemClock
+}
+
+// LossGuardStatus is a point-in-time view of a LossGuard session
+type LossGuardStatus struct {
+	SessionStart time.Time
+	StartEquity  decimal.Decimal
+	// PnL is the realised and unrealised PnL of the session: the change of
+	// equity since the session started
+	PnL        decimal.Decimal
+	Tripped    bool
+	Overridden bool
+}
+
+// LossGuard blocks orders that may add risk after the account lost MaxLoss
+// within a session. Equity is read from a PnLTracker, which the caller keeps
+// refreshed, so both realised and unrealised PnL count towards the loss. Once
+// tripped the guard only lets reduce-only orders through until the next
+// session or an override.
+type LossGuard struct {
+	cfg     LossGuardConfig
+	tracker *PnLTracker
+
+	mu          sync.Mutex
+	session     time.Time
+	startEquity decimal.Decimal
+	tripped     bool
+	overridden  bool
+}
+
+// NewLossGuard creates a guard reading equity from tracker
+func NewLossGuard(tracker *PnLTracker, cfg LossGuardConfig) *LossGuard {
+	cfg.Clock = clockOrDefault(cfg.Clock)
+	return &LossGuard{cfg: cfg, tracker: tracker}
+}
+
+// Status rolls the session over if a reset is due, trips the guard if the
+// loss limit is reached and returns the resulting state. The equity at the
+// first check of a session is its starting equity.
+func (g *LossGuard) Status() LossGuardStatus {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	equity := g.tracker.Snapshot().Equity
+	if start := g.sessionStart(g.cfg.Clock.Now()); !start.Equal(g.session) {
+		g.session, g.startEquity = start, equity
+		g.tripped, g.overridden = false, false
+	}
+	pnl := equity.Sub(g.startEquity)
+	if g.cfg.MaxLoss.IsPositive() && pnl.LessThanOrEqual(g.cfg.MaxLoss.Neg()) {
+		g.tripped = true
+	}
+	return LossGuardStatus{
+		SessionStart: g.session,
+		StartEquity:  g.startEquity,
+		PnL:          pnl,
+		Tripped:      g.tripped,
+		Overridden:   g.overridden,
+	}
+}
+
+// Check returns ErrLossLimitReached if the guard is tripped and not
+// overridden, unless params are reduce-only
+func (g *LossGuard) Check(params CreateOrderObjectParams) error {
+	status := g.Status()
+	if !status.Tripped || status.Overridden || params.ReduceOnly {
+		return nil
+	}
+	return fmt.Errorf("%w: session PnL %s, limit -%s", ErrLossLimitReached,
+		status.PnL.String(), g.cfg.MaxLoss.String())
+}
+
+// Override lets orders through for the rest of the session although the
+// guard is tripped. Passing false restores blocking.
+func (g *LossGuard) Override(enabled bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.overridden = enabled
+}
+
+// Reset starts a new session from the current equity ahead of the schedule.
+// The session still ends at the next scheduled reset.
+func (g *LossGuard) Reset() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.session = g.sessionStart(g.cfg.Clock.Now())
+	g.startEquity = g.tracker.Snapshot().Equity
+	g.tripped, g.overridden = false, false
+}
+
+// sessionStart returns the latest scheduled reset at or before now
+func (g *LossGuard) sessionStart(now time.Time) time.Time {
+	start := now.UTC().Truncate(24 * time.Hour).Add(g.cfg.ResetAt % (24 * time.Hour))
+	if start.After(now) {
+		start = start.Add(-24 * time.Hour)
+	}
+	return start
+}
+
+// WithLossGuard makes PlaceOrder refuse orders that are not reduce-only while
+// guard is tripped, see LossGuard.Check
+func WithLossGuard(guard *LossGuard) PlaceOrderOption {
+	return func(o *placeOrderOptions) {
+		o.lossGuard = guard
+	}
+}
@@ -0,0 +1,57 @@
+package sdk
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLossGuard(t *testing.T) {
+	tracker := NewPnLTracker()
+	tracker.SetBalance(BalanceModel{Balance: d("10000")})
+	tracker.SetPositions([]PositionModel{{Market: "BTC-USD", Side: PositionSideLong, Size: d("1"), OpenPrice: d("50000"), MarkPrice: d("50000")}})
+
+	clock := fixedClock(time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC))
+	guard := NewLossGuard(tracker, LossGuardConfig{MaxLoss: d("500"), ResetAt: 8 * time.Hour, Clock: &clock})
+	open := CreateOrderObjectParams{}
+	reduce := CreateOrderObjectParams{ReduceOnly: true}
+
+	status := guard.Status()
+	assert.Equal(t, time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC), status.SessionStart)
+	assert.True(t, d("10000").Equal(status.StartEquity))
+	assert.NoError(t, guard.Check(open))
+
+	tracker.UpdateMarkPrice("BTC-USD", d("49600"))
+	assert.NoError(t, guard.Check(open), "a loss of 400 is within the limit")
+
+	// Realised losses count through the balance
+	tracker.SetBalance(BalanceModel{Balance: d("9900")})
+	err := guard.Check(open)
+	assert.True(t, errors.Is(err, ErrLossLimitReached), "%v", err)
+	assert.NoError(t, guard.Check(reduce))
+
+	// Recovering does not untrip the guard within the session
+	tracker.UpdateMarkPrice("BTC-USD", d("50500"))
+	assert.ErrorIs(t, guard.Check(open), ErrLossLimitReached)
+
+	guard.Override(true)
+	assert.NoError(t, guard.Check(open))
+	assert.True(t, guard.Status().Overridden)
+	guard.Override(false)
+	assert.ErrorIs(t, guard.Check(open), ErrLossLimitReached)
+
+	// The next session starts from the equity at its first check
+	clock = fixedClock(time.Date(2024, 3, 2, 8, 0, 0, 0, time.UTC))
+	status = guard.Status()
+	assert.False(t, status.Tripped)
+	assert.True(t, d("10400").Equal(status.StartEquity))
+	assert.True(t, status.PnL.IsZero())
+
+	tracker.UpdateMarkPrice("BTC-USD", d("49900"))
+	assert.ErrorIs(t, guard.Check(open), ErrLossLimitReached)
+	guard.Reset()
+	assert.NoError(t, guard.Check(open))
+	assert.Equal(t, time.Date(2024, 3, 2, 8, 0, 0, 0, time.UTC), guard.Status().SessionStart)
+}
@@ -0,0 +1,121 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// WithPreloadMarkets makes NewAPIClient fetch every market in the background,
+// so that CachedMarket is served from memory from the first call, e.g. when
+// the first order is built. MarketsReady signals when the preload finished.
+func WithPreloadMarkets(preload bool) ClientOption {
+	return func(m *BaseModule) {
+		m.preloadMarkets = preload
+	}
+}
+
+// marketCache holds the markets already fetched by the client
+type marketCache struct {
+	mu      sync.Mutex
+	markets map[string]MarketModel
+	// ready is closed once the preload finished, nil without preloading
+	ready  chan struct{}
+	err    error
+	cancel context.CancelFunc
+}
+
+var closedChan = func() chan struct{} {
+	ch := make(chan struct{})
+	close(ch)
+	return ch
+}()
+
+func (m *marketCache) store(markets ...MarketModel) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.markets == nil {
+		m.markets = make(map[string]MarketModel)
+	}
+	for _, market := range markets {
+		m.markets[market.Name] = market
+	}
+}
+
+func (m *marketCache) lookup(name string) (MarketModel, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	market, ok := m.markets[name]
+	return market, ok
+}
+
+// preloadMarkets starts fetching every market; the fetch is cancelled by Close
+func (c *APIClient) preloadMarkets() {
+	ctx, cancel := context.WithCancel(context.Background())
+	c.markets.ready, c.markets.cancel = make(chan struct{}), cancel
+	go func() {
+		defer close(c.markets.ready)
+		markets, err := c.GetMarkets(ctx, nil)
+		if err != nil {
+			c.markets.err = fmt.Errorf("failed to preload markets: %w", err)
+			return
+		}
+		c.markets.store(markets...)
+	}()
+}
+
+// MarketsReady returns a channel that is closed once the markets preloaded
+// with WithPreloadMarkets are cached, or the preload failed. Without
+// preloading the channel is already closed.
+func (c *APIClient) MarketsReady() <-chan struct{} {
+	if c.markets.ready == nil {
+		return closedChan
+	}
+	return c.markets.ready
+}
+
+// WaitForMarkets blocks until the preload finished and returns its error
+func (c *APIClient) WaitForMarkets(ctx context.Context) error {
+	select {
+	case <-c.MarketsReady():
+		return c.markets.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// CachedMarket returns a market by name, fetching it on first use and serving
+// later calls from memory. While markets are preloaded it waits for the
+// preload rather than sending a request of its own.
+func (c *APIClient) CachedMarket(ctx context.Context, name string) (*MarketModel, error) {
+	if market, ok := c.markets.lookup(name); ok {
+		return &market, nil
+	}
+	// A failed preload falls back to fetching the market
+	if err := c.WaitForMarkets(ctx); err != nil && ctx.Err() != nil {
+		return nil, err
+	}
+	if market, ok := c.markets.lookup(name); ok {
+		return &market, nil
+	}
+
+	markets, err := c.GetMarkets(ctx, []string{name})
+	if err != nil {
+		return nil, err
+	}
+	for _, market := range markets {
+		if market.Name == name {
+			c.markets.store(market)
+			return &market, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownMarket, name)
+}
+
+// InvalidateMarketCache drops all cached markets, e.g. after a trading config
+// change
+func (c *APIClient) InvalidateMarketCache() {
+	c.markets.mu.Lock()
+	defer c.markets.mu.Unlock()
+	c.markets.markets = nil
+}
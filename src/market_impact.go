@@ -0,0 +1,105 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrMaxImpactExceeded is returned by PlaceOrder when the estimated impact of
+// an order on the book exceeds the limit set with WithMaxImpactBps
+var ErrMaxImpactExceeded = errors.New("estimated market impact exceeds limit")
+
+// EstimateImpact walks the opposite side of the book for an order of qty
+// limited at price and returns the average fill price of its marketable part
+// against the mid, in basis points; positive values are a cost. The boolean
+// is false if no level crosses the price, i.e. the order would only rest.
+// On a one-sided book the best opposite level stands in for the mid.
+func (o OrderbookUpdateModel) EstimateImpact(side OrderSide, qty, price decimal.Decimal) (decimal.Decimal, bool) {
+	book := o.Ask
+	if side == OrderSideSell {
+		book = o.Bid
+	}
+	if len(book) == 0 || !qty.IsPositive() {
+		return decimal.Zero, false
+	}
+	mid, ok := o.MidPrice()
+	if !ok {
+		mid = book[0].Price
+	}
+
+	filled, notional := decimal.Zero, decimal.Zero
+	for _, level := range book {
+		if filled.Equal(qty) ||
+			(side == OrderSideBuy && level.Price.GreaterThan(price)) ||
+			(side == OrderSideSell && level.Price.LessThan(price)) {
+			break
+		}
+		take := decimal.Min(level.Qty, qty.Sub(filled))
+		filled = filled.Add(take)
+		notional = notional.Add(take.Mul(level.Price))
+	}
+	if !filled.IsPositive() {
+		return decimal.Zero, false
+	}
+
+	diff := notional.Div(filled).Sub(mid)
+	if side == OrderSideSell {
+		diff = diff.Neg()
+	}
+	return diff.Div(mid).Mul(decimal.NewFromInt(10000)), true
+}
+
+// OrderbookSource is a locally maintained orderbook, e.g. a stream.Orderbook
+type OrderbookSource interface {
+	// Snapshot returns the book, best price first, and whether it reflects
+	// every update
+	Snapshot() (OrderbookUpdateModel, bool)
+}
+
+// WithMaxImpactBps makes PlaceOrder refuse an order whose marketable part
+// would fill at an average price more than bps basis points away from the mid,
+// as estimated by EstimateImpact. The estimate comes from the synced book of
+// the order's market among books, so the check adds no request; without one
+// the book is fetched right before the order is sent. Post-only orders are not
+// checked.
+func WithMaxImpactBps(bps decimal.Decimal, books ...OrderbookSource) PlaceOrderOption {
+	return func(o *placeOrderOptions) {
+		o.maxImpactBps = &bps
+		o.impactBooks = books
+	}
+}
+
+// checkImpact runs the market impact pre-trade check for an order about to be sent
+func (c *APIClient) checkImpact(ctx context.Context, params CreateOrderObjectParams, o placeOrderOptions) error {
+	if o.maxImpactBps == nil || params.PostOnly {
+		return nil
+	}
+	book, ok := localBook(o.impactBooks, params.Market.Name)
+	if !ok {
+		snapshot, err := c.GetOrderbookSnapshot(ctx, params.Market.Name)
+		if err != nil {
+			return fmt.Errorf("market impact check failed: %w", err)
+		}
+		book = *snapshot
+	}
+	impact, ok := book.EstimateImpact(params.Side, params.SyntheticAmount, params.Price)
+	if ok && impact.GreaterThan(*o.maxImpactBps) {
+		return fmt.Errorf("%w: %s bps on %s, limit %s bps", ErrMaxImpactExceeded,
+			impact.StringFixed(2), params.Market.Name, o.maxImpactBps.String())
+	}
+	return nil
+}
+
+// localBook returns the first synced book of market among books
+func localBook(books []OrderbookSource, market string) (OrderbookUpdateModel, bool) {
+	for _, source := range books {
+		book, synced := source.Snapshot()
+		if synced && book.Market == market {
+			return book, true
+		}
+	}
+	return OrderbookUpdateModel{}, false
+}
@@ -0,0 +1,42 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateImpact(t *testing.T) {
+	book := OrderbookUpdateModel{
+		Bid: levels("99", "1", "98", "2"),
+		Ask: levels("101", "1", "102", "1", "110", "5"),
+	}
+
+	tests := []struct {
+		name       string
+		side       OrderSide
+		qty, price string
+		want       string
+		ok         bool
+	}{
+		{"top level", OrderSideBuy, "1", "101", "100", true},
+		{"two levels", OrderSideBuy, "2", "105", "150", true},
+		{"limited by price", OrderSideBuy, "10", "102", "150", true},
+		{"sell", OrderSideSell, "2", "90", "150", true},
+		{"resting only", OrderSideBuy, "1", "100", "0", false},
+		{"zero qty", OrderSideBuy, "0", "101", "0", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			impact, ok := book.EstimateImpact(tt.side, decimal.RequireFromString(tt.qty), decimal.RequireFromString(tt.price))
+			assert.Equal(t, tt.ok, ok)
+			assert.True(t, decimal.RequireFromString(tt.want).Equal(impact), "impact %s", impact)
+		})
+	}
+
+	oneSided := OrderbookUpdateModel{Ask: levels("100", "1", "110", "1")}
+	impact, ok := oneSided.EstimateImpact(OrderSideBuy, decimal.NewFromInt(2), decimal.NewFromInt(110))
+	assert.True(t, ok)
+	assert.Equal(t, "500", impact.String())
+}
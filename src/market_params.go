@@ -0,0 +1,81 @@
+package sdk
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// TradingConfigField names a parameter of TradingConfigModel, using its JSON name
+type TradingConfigField string
+
+const (
+	TradingConfigMinOrderSize        TradingConfigField = "minOrderSize"
+	TradingConfigMinOrderSizeChange  TradingConfigField = "minOrderSizeChange"
+	TradingConfigMinPriceChange      TradingConfigField = "minPriceChange"
+	TradingConfigMaxMarketOrderValue TradingConfigField = "maxMarketOrderValue"
+	TradingConfigMaxLimitOrderValue  TradingConfigField = "maxLimitOrderValue"
+	TradingConfigMaxPositionValue    TradingConfigField = "maxPositionValue"
+	TradingConfigMaxLeverage         TradingConfigField = "maxLeverage"
+	TradingConfigMaxNumOrders        TradingConfigField = "maxNumOrders"
+	TradingConfigLimitPriceCap       TradingConfigField = "limitPriceCap"
+	TradingConfigLimitPriceFloor     TradingConfigField = "limitPriceFloor"
+)
+
+// TradingConfigChange is a single trading parameter of a market that changed
+type TradingConfigChange struct {
+	Market   string
+	Field    TradingConfigField
+	Previous decimal.Decimal
+	Current  decimal.Decimal
+}
+
+// DiffTradingConfig returns the parameters that differ between two trading
+// configs of a market, in the field order of TradingConfigModel
+func DiffTradingConfig(market string, previous, current TradingConfigModel) []TradingConfigChange {
+	fields := []struct {
+		field      TradingConfigField
+		prev, curr decimal.Decimal
+	}{
+		{TradingConfigMinOrderSize, previous.MinOrderSize, current.MinOrderSize},
+		{TradingConfigMinOrderSizeChange, previous.MinOrderSizeChange, current.MinOrderSizeChange},
+		{TradingConfigMinPriceChange, previous.MinPriceChange, current.MinPriceChange},
+		{TradingConfigMaxMarketOrderValue, previous.MaxMarketOrderValue, current.MaxMarketOrderValue},
+		{TradingConfigMaxLimitOrderValue, previous.MaxLimitOrderValue, current.MaxLimitOrderValue},
+		{TradingConfigMaxPositionValue, previous.MaxPositionValue, current.MaxPositionValue},
+		{TradingConfigMaxLeverage, previous.MaxLeverage, current.MaxLeverage},
+		{TradingConfigMaxNumOrders, decimal.NewFromInt(int64(previous.MaxNumOrders)), decimal.NewFromInt(int64(current.MaxNumOrders))},
+		{TradingConfigLimitPriceCap, previous.LimitPriceCap, current.LimitPriceCap},
+		{TradingConfigLimitPriceFloor, previous.LimitPriceFloor, current.LimitPriceFloor},
+	}
+	var changes []TradingConfigChange
+	for _, f := range fields {
+		if !f.prev.Equal(f.curr) {
+			changes = append(changes, TradingConfigChange{Market: market, Field: f.field, Previous: f.prev, Current: f.curr})
+		}
+	}
+	return changes
+}
+
+// WatchTradingConfig polls the markets every interval and sends one change for
+// every trading parameter that differs from the previous snapshot of its
+// market. Newly listed markets only establish their snapshot. Errors and
+// shutdown behave as for WatchMarkets, whose polling it shares.
+func (c *APIClient) WatchTradingConfig(ctx context.Context, interval time.Duration) (<-chan TradingConfigChange, <-chan error) {
+	events, errs := c.WatchMarkets(ctx, interval)
+	changes := make(chan TradingConfigChange, 16)
+	go func() {
+		defer close(changes)
+		for event := range events {
+			for _, change := range event.Changes {
+				select {
+				case changes <- change:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return changes, errs
+}
@@ -1,19 +1,141 @@
 package sdk
 
-type L2ConfigModel struct {
-	Type                 string `json:"type"`
-	CollateralID         string `json:"collateralId"`
-	CollateralResolution int64  `json:"collateralResolution"`
-	SyntheticID          string `json:"syntheticId"`
-	SyntheticResolution  int64  `json:"syntheticResolution"`
-}
-
-type MarketModel struct {
-	Name                     string        `json:"name"`
-	AssetName                string        `json:"assetName"`
-	AssetPrecision           int           `json:"assetPrecision"`
-	CollateralAssetName      string        `json:"collateralAssetName"`
-	CollateralAssetPrecision int           `json:"collateralAssetPrecision"`
-	Active                   bool          `json:"active"`
-	L2Config                 L2ConfigModel `json:"l2Config"`
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// SpreadBps returns the bid/ask spread in basis points of the mid price.
+// The boolean is false when either side of the book is empty.
+func (s MarketStatsModel) SpreadBps() (decimal.Decimal, bool) {
+	if !s.BidPrice.IsPositive() || !s.AskPrice.IsPositive() {
+		return decimal.Zero, false
+	}
+	mid := s.BidPrice.Add(s.AskPrice).Div(decimal.NewFromInt(2))
+	return s.AskPrice.Sub(s.BidPrice).Div(mid).Mul(decimal.NewFromInt(10000)), true
+}
+
+// MarketsOption customises a GetMarkets request
+type MarketsOption func(*marketsOptions)
+
+type marketsOptions struct {
+	excludeInactive bool
+}
+
+// WithInactiveMarkets controls whether inactive markets, e.g. delisted or
+// reduce-only ones, are returned. They are included by default so that
+// metadata of delisted markets stays available.
+func WithInactiveMarkets(include bool) MarketsOption {
+	return func(o *marketsOptions) {
+		o.excludeInactive = !include
+	}
+}
+
+// PricePrecision returns the number of decimal places of a price, taken from
+// the tick size when known and the collateral precision otherwise
+func (m MarketModel) PricePrecision() int32 {
+	if m.TradingConfig.MinPriceChange.IsPositive() {
+		return decimalPlaces(m.TradingConfig.MinPriceChange)
+	}
+	return int32(m.CollateralAssetPrecision)
+}
+
+// QtyPrecision returns the number of decimal places of a quantity, taken from
+// the size step when known and the asset precision otherwise
+func (m MarketModel) QtyPrecision() int32 {
+	if m.TradingConfig.MinOrderSizeChange.IsPositive() {
+		return decimalPlaces(m.TradingConfig.MinOrderSizeChange)
+	}
+	return int32(m.AssetPrecision)
+}
+
+// priceTick returns the smallest price increment of the market
+func (m MarketModel) priceTick() decimal.Decimal {
+	if m.TradingConfig.MinPriceChange.IsPositive() {
+		return m.TradingConfig.MinPriceChange
+	}
+	return decimal.New(1, -m.PricePrecision())
+}
+
+// qtyStep returns the smallest quantity increment of the market
+func (m MarketModel) qtyStep() decimal.Decimal {
+	if m.TradingConfig.MinOrderSizeChange.IsPositive() {
+		return m.TradingConfig.MinOrderSizeChange
+	}
+	return decimal.New(1, -m.QtyPrecision())
+}
+
+// PriceToTicks converts a price to a whole number of price ticks, for
+// fixed-point arithmetic on hot paths. It fails if the price is not on the
+// tick grid or does not fit an int64.
+func (m MarketModel) PriceToTicks(price decimal.Decimal) (int64, error) {
+	return toUnits(price, m.priceTick())
+}
+
+// TicksToPrice converts a number of price ticks back to a price
+func (m MarketModel) TicksToPrice(ticks int64) decimal.Decimal {
+	return decimal.NewFromInt(ticks).Mul(m.priceTick())
+}
+
+// QtyToSteps converts a quantity to a whole number of size steps. It fails if
+// the quantity is not a multiple of the step or does not fit an int64.
+func (m MarketModel) QtyToSteps(qty decimal.Decimal) (int64, error) {
+	return toUnits(qty, m.qtyStep())
+}
+
+// StepsToQty converts a number of size steps back to a quantity
+func (m MarketModel) StepsToQty(steps int64) decimal.Decimal {
+	return decimal.NewFromInt(steps).Mul(m.qtyStep())
+}
+
+func toUnits(d, unit decimal.Decimal) (int64, error) {
+	units := d.Div(unit)
+	if !units.Mul(unit).Equal(d) || !units.IsInteger() {
+		return 0, fmt.Errorf("%s is not a multiple of %s", d, unit)
+	}
+	if !units.BigInt().IsInt64() {
+		return 0, fmt.Errorf("%s is out of range in units of %s", d, unit)
+	}
+	return units.IntPart(), nil
+}
+
+// FormatPrice renders a price with the market price precision, rounding half
+// away from zero, in plain notation with trailing zeros trimmed
+func FormatPrice(market MarketModel, d decimal.Decimal) string {
+	return formatDecimal(d, market.PricePrecision())
+}
+
+// FormatQty renders a quantity with the market quantity precision, rounding
+// half away from zero, in plain notation with trailing zeros trimmed
+func FormatQty(market MarketModel, d decimal.Decimal) string {
+	return formatDecimal(d, market.QtyPrecision())
+}
+
+func formatDecimal(d decimal.Decimal, places int32) string {
+	s := d.Round(places).StringFixed(places)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
+
+// formatPlain renders d in plain notation with all of its significant decimals
+func formatPlain(d decimal.Decimal) string {
+	return formatDecimal(d, decimalPlaces(d))
+}
+
+// decimalPlaces returns the number of significant decimal places of d,
+// e.g. 2 for 0.01 and 0 for 10
+func decimalPlaces(d decimal.Decimal) int32 {
+	s := d.String()
+	i := strings.IndexByte(s, '.')
+	if i < 0 {
+		return 0
+	}
+	return int32(len(s) - i - 1)
 }
@@ -0,0 +1,77 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatPriceAndQty(t *testing.T) {
+	market := createTestBTCUSDMarket()
+	market.TradingConfig = TradingConfigModel{
+		MinPriceChange:     decimal.RequireFromString("0.1"),
+		MinOrderSizeChange: decimal.RequireFromString("0.00001"),
+	}
+
+	tests := []struct {
+		name  string
+		value string
+		price string
+		qty   string
+	}{
+		{"integer", "43445", "43445", "43445"},
+		{"trailing zeros", "43445.10000", "43445.1", "43445.1"},
+		{"rounded", "43445.16", "43445.2", "43445.16"},
+		{"tiny", "0.0000001", "0", "0"},
+		{"exponent input", "1.5E-5", "0", "0.00002"},
+		{"large", "123456789012345.123456", "123456789012345.1", "123456789012345.12346"},
+		{"negative", "-0.04", "0", "-0.04"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := decimal.RequireFromString(tt.value)
+			assert.Equal(t, tt.price, FormatPrice(market, d))
+			assert.Equal(t, tt.qty, FormatQty(market, d))
+		})
+	}
+}
+
+func TestFormatFallsBackToAssetPrecision(t *testing.T) {
+	market := createTestBTCUSDMarket()
+
+	assert.Equal(t, int32(6), market.PricePrecision())
+	assert.Equal(t, int32(8), market.QtyPrecision())
+	assert.Equal(t, "43445.1168", FormatPrice(market, decimal.RequireFromString("43445.11680000")))
+	assert.Equal(t, "0.00000001", FormatQty(market, decimal.New(1, -8)))
+}
+
+func TestMarketFixedPointConversion(t *testing.T) {
+	market := createTestBTCUSDMarket()
+	market.TradingConfig = TradingConfigModel{
+		MinPriceChange:     decimal.RequireFromString("0.5"),
+		MinOrderSizeChange: decimal.RequireFromString("0.001"),
+	}
+
+	ticks, err := market.PriceToTicks(decimal.RequireFromString("43445.5"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(86891), ticks)
+	assert.Equal(t, "43445.5", market.TicksToPrice(ticks).String())
+
+	steps, err := market.QtyToSteps(decimal.RequireFromString("-1.234"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-1234), steps)
+	assert.Equal(t, "-1.234", market.StepsToQty(steps).String())
+
+	_, err = market.PriceToTicks(decimal.RequireFromString("43445.3"))
+	assert.Error(t, err, "off the tick grid")
+	_, err = market.QtyToSteps(decimal.RequireFromString("1e20"))
+	assert.Error(t, err, "overflows int64")
+
+	// Without a tick size the precision of the market applies
+	market.TradingConfig = TradingConfigModel{}
+	market.CollateralAssetPrecision = 2
+	ticks, err = market.PriceToTicks(decimal.RequireFromString("1.23"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(123), ticks)
+}
@@ -0,0 +1,155 @@
+package sdk
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// MaxNonce is the largest nonce accepted by the exchange
+const MaxNonce = 1<<31 - 1
+
+// NonceGenerator produces nonces for new orders
+type NonceGenerator interface {
+	NextNonce() (int, error)
+}
+
+// WithNonceGenerator sets the nonce policy used by the client, see NextNonce
+func WithNonceGenerator(generator NonceGenerator) ClientOption {
+	return func(m *BaseModule) {
+		m.nonceGenerator = generator
+	}
+}
+
+// NextNonce returns the next nonce from the configured generator.
+// Clients default to UnixSecondsNonce driven by the client clock.
+func (m *BaseModule) NextNonce() (int, error) {
+	m.nonceOnce.Do(func() {
+		if m.nonceGenerator == nil {
+			m.nonceGenerator = NewUnixSecondsNonce(m.clock)
+		}
+	})
+	return m.nonceGenerator.NextNonce()
+}
+
+// monotonicNonce guarantees strictly increasing values from a time source, so
+// two orders created within the same tick do not share a nonce
+type monotonicNonce struct {
+	mu   sync.Mutex
+	last int
+	now  func() int
+}
+
+func (n *monotonicNonce) NextNonce() (int, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	next := n.now()
+	if next <= n.last {
+		next = n.last + 1
+	}
+	if next > MaxNonce {
+		return 0, fmt.Errorf("nonce %d exceeds maximum %d", next, MaxNonce)
+	}
+	n.last = next
+	return next, nil
+}
+
+// NewUnixSecondsNonce returns nonces based on the Unix time in seconds,
+// incremented when more than one nonce is requested within a second
+func NewUnixSecondsNonce(clock Clock) NonceGenerator {
+	clock = clockOrDefault(clock)
+	return &monotonicNonce{now: func() int { return int(clock.Now().Unix()) }}
+}
+
+// NewUnixNanosNonce returns a counter seeded from the Unix time in
+// nanoseconds folded into the accepted nonce range, so that generators
+// created at different times start far apart. A generator only repeats a
+// nonce after issuing MaxNonce of them, wrapping from MaxNonce back to 1.
+// Two generators can still overlap, so use one per account.
+func NewUnixNanosNonce(clock Clock) NonceGenerator {
+	clock = clockOrDefault(clock)
+	return &wrappingNonce{next: int(clock.Now().UnixNano()%MaxNonce) + 1}
+}
+
+// wrappingNonce counts up through [1, MaxNonce] and wraps around
+type wrappingNonce struct {
+	mu   sync.Mutex
+	next int
+}
+
+func (n *wrappingNonce) NextNonce() (int, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	nonce := n.next
+	n.next = nonce%MaxNonce + 1
+	return nonce, nil
+}
+
+type randomNonce struct {
+	mu       sync.Mutex
+	rnd      *rand.Rand
+	min, max int
+}
+
+// NewRandomNonce returns uniformly random nonces in [min, max]
+func NewRandomNonce(min, max int, seed int64) (NonceGenerator, error) {
+	if min < 0 || max > MaxNonce || min > max {
+		return nil, fmt.Errorf("invalid nonce range [%d, %d]", min, max)
+	}
+	return &randomNonce{rnd: rand.New(rand.NewSource(seed)), min: min, max: max}, nil
+}
+
+func (n *randomNonce) NextNonce() (int, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.min + n.rnd.Intn(n.max-n.min+1), nil
+}
+
+// PersistentCounterNonce is a counter stored in a file so that nonces keep
+// increasing across process restarts
+type PersistentCounterNonce struct {
+	mu   sync.Mutex
+	path string
+	last int
+}
+
+// NewPersistentCounterNonce opens the counter stored at path, starting after
+// start if the file does not exist yet
+func NewPersistentCounterNonce(path string, start int) (*PersistentCounterNonce, error) {
+	n := &PersistentCounterNonce{path: path, last: start}
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, fmt.Errorf("failed to read nonce counter: %w", err)
+	default:
+		stored, err := strconv.Atoi(strings.TrimSpace(string(data)))
+		if err != nil {
+			return nil, fmt.Errorf("invalid nonce counter file %s: %w", path, err)
+		}
+		if stored > n.last {
+			n.last = stored
+		}
+	}
+	return n, nil
+}
+
+// NextNonce increments the counter and persists it before returning
+func (n *PersistentCounterNonce) NextNonce() (int, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	next := n.last + 1
+	if next > MaxNonce {
+		return 0, fmt.Errorf("nonce %d exceeds maximum %d", next, MaxNonce)
+	}
+
+	if err := writeFileAtomic(n.path, []byte(strconv.Itoa(next))); err != nil {
+		return 0, fmt.Errorf("failed to persist nonce counter: %w", err)
+	}
+	n.last = next
+	return next, nil
+}
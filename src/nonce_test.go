@@ -0,0 +1,108 @@
+package sdk
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnixSecondsNonce_StrictlyIncreasing(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	gen := NewUnixSecondsNonce(fixedClock(now))
+
+	first, err := gen.NextNonce()
+	require.NoError(t, err)
+	second, err := gen.NextNonce()
+	require.NoError(t, err)
+
+	assert.Equal(t, 1700000000, first)
+	assert.Equal(t, 1700000001, second)
+}
+
+func TestUnixNanosNonce_InRangeAndUnique(t *testing.T) {
+	gen := NewUnixNanosNonce(fixedClock(time.Unix(1700000000, 123456789)))
+
+	first, err := gen.NextNonce()
+	require.NoError(t, err)
+	second, err := gen.NextNonce()
+	require.NoError(t, err)
+
+	assert.LessOrEqual(t, first, MaxNonce)
+	assert.NotEqual(t, first, second)
+
+	// The time only seeds the counter, so nonces do not repeat when the
+	// folded time wraps
+	seen := map[int]bool{first: true, second: true}
+	for i := 0; i < 10000; i++ {
+		n, err := gen.NextNonce()
+		require.NoError(t, err)
+		require.False(t, seen[n], "nonce %d repeated", n)
+		seen[n] = true
+	}
+
+	gen = NewUnixNanosNonce(fixedClock(time.Unix(0, MaxNonce-1)))
+	last, err := gen.NextNonce()
+	require.NoError(t, err)
+	assert.Equal(t, MaxNonce, last)
+	wrapped, err := gen.NextNonce()
+	require.NoError(t, err)
+	assert.Equal(t, 1, wrapped)
+}
+
+func TestRandomNonce_Range(t *testing.T) {
+	_, err := NewRandomNonce(10, 5, 1)
+	require.Error(t, err)
+
+	gen, err := NewRandomNonce(10, 12, 1)
+	require.NoError(t, err)
+	for i := 0; i < 50; i++ {
+		n, err := gen.NextNonce()
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, n, 10)
+		assert.LessOrEqual(t, n, 12)
+	}
+}
+
+func TestPersistentCounterNonce_SurvivesRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nonce")
+
+	gen, err := NewPersistentCounterNonce(path, 100)
+	require.NoError(t, err)
+	n, err := gen.NextNonce()
+	require.NoError(t, err)
+	assert.Equal(t, 101, n)
+	n, err = gen.NextNonce()
+	require.NoError(t, err)
+	assert.Equal(t, 102, n)
+
+	// A new generator resumes from the stored value rather than start
+	gen, err = NewPersistentCounterNonce(path, 0)
+	require.NoError(t, err)
+	n, err = gen.NextNonce()
+	require.NoError(t, err)
+	assert.Equal(t, 103, n)
+
+	require.NoError(t, os.WriteFile(path, []byte("garbage"), 0o600))
+	_, err = NewPersistentCounterNonce(path, 0)
+	assert.Error(t, err)
+}
+
+func TestClientNextNonce_DefaultsToClockSeconds(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	client := NewAPIClient(EndpointConfig{}, "", nil, time.Second, WithClock(fixedClock(now)))
+
+	n, err := client.NextNonce()
+	require.NoError(t, err)
+	assert.Equal(t, 1700000000, n)
+
+	gen, err := NewRandomNonce(1, 1, 0)
+	require.NoError(t, err)
+	client = NewAPIClient(EndpointConfig{}, "", nil, time.Second, WithNonceGenerator(gen))
+	n, err = client.NextNonce()
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+}
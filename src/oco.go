@@ -0,0 +1,203 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// OCOOrders is an optional entry order with a take profit and a stop loss
+// exit, where the first exit to execute cancels the other
+type OCOOrders struct {
+	Entry      *PerpetualOrderModel
+	TakeProfit *PerpetualOrderModel
+	StopLoss   *PerpetualOrderModel
+}
+
+// OCOResult reports how an OCO pair was resolved
+type OCOResult struct {
+	// Executed is the exit that filled or triggered first
+	Executed OpenOrderModel
+	// Cancelled is the external ID of the sibling cancelled in response
+	Cancelled string
+}
+
+// NewOCOOrders signs an entry order from params together with reduce-only
+// exits closing it: a GTT limit take profit at takeProfitPrice and a stop loss
+// executed at market once the mark price crosses stopLossTrigger. Each order
+// needs its own nonce, so params must carry a NonceGenerator. Exit external
+// IDs are derived from the entry ID when one is given.
+func NewOCOOrders(params CreateOrderObjectParams, takeProfitPrice, stopLossTrigger decimal.Decimal) (OCOOrders, error) {
+	if params.NonceGenerator == nil {
+		return OCOOrders{}, fmt.Errorf("%w: a nonce generator is required to sign OCO orders", ErrInvalidOrder)
+	}
+	params.Nonce = nil
+	if params.ExpireTime == nil {
+		// Share one expiry so that the exits do not outlive the entry
+		expiry := clockOrDefault(params.Clock).Now().Add(1 * time.Hour)
+		params.ExpireTime = &expiry
+	}
+
+	entry, err := CreateOrderObject(params)
+	if err != nil {
+		return OCOOrders{}, fmt.Errorf("entry: %w", err)
+	}
+
+	exit := params
+	exit.PostOnly = false
+	exit.ReduceOnly = true
+	exit.PreviousOrderExternalID = nil
+	exit.Trigger, exit.TakeProfit, exit.StopLoss, exit.TpSlType = nil, nil, nil, nil
+	exit.Side = OrderSideSell
+	direction := TriggerDirectionDown
+	if params.Side == OrderSideSell {
+		exit.Side = OrderSideBuy
+		direction = TriggerDirectionUp
+	}
+	exitID := func(suffix string) *string {
+		if params.OrderExternalID == nil {
+			return nil
+		}
+		id := *params.OrderExternalID + suffix
+		return &id
+	}
+
+	takeProfit := exit
+	takeProfit.Type = OrderTypeLimit
+	takeProfit.TimeInForce = TimeInForceGTT
+	takeProfit.Price = takeProfitPrice
+	takeProfit.OrderExternalID = exitID("-tp")
+	takeProfitOrder, err := CreateOrderObject(takeProfit)
+	if err != nil {
+		return OCOOrders{}, fmt.Errorf("take profit: %w", err)
+	}
+
+	stopLoss := exit
+	stopLoss.Type = OrderTypeConditional
+	stopLoss.TimeInForce = TimeInForceGTT
+	stopLoss.Price = stopLossTrigger
+	stopLoss.OrderExternalID = exitID("-sl")
+	stopLoss.Trigger = &ConditionalTrigger{
+		TriggerPrice:       FormatPrice(params.Market, stopLossTrigger),
+		TriggerPriceType:   TriggerPriceTypeMark,
+		Direction:          direction,
+		ExecutionPriceType: ExecutionPriceTypeMarket,
+	}
+	stopLossOrder, err := CreateOrderObject(stopLoss)
+	if err != nil {
+		return OCOOrders{}, fmt.Errorf("stop loss: %w", err)
+	}
+
+	return OCOOrders{Entry: entry, TakeProfit: takeProfitOrder, StopLoss: stopLossOrder}, nil
+}
+
+// SubmitOCO submits the entry, if any, and places both exits once it has
+// filled, then watches the exits until one of them fills or triggers and
+// cancels the other. Without an entry the exits are placed right away and
+// their submission errors are returned directly. If the entry does not fill
+// completely, no exit is placed and the handle fails with an
+// OrderNotFilledError. If either exit ends cancelled, rejected or expired,
+// its sibling is cancelled as well and the handle fails with an
+// OrderNotFilledError. The linkage is maintained client side: cancelling the
+// handle stops the watch but leaves the placed orders on the book.
+func (c *APIClient) SubmitOCO(ctx context.Context, orders OCOOrders, pollInterval time.Duration) (*OperationHandle[*OCOResult], error) {
+	if orders.TakeProfit == nil || orders.StopLoss == nil {
+		return nil, fmt.Errorf("%w: OCO requires both a take profit and a stop loss", ErrInvalidOrder)
+	}
+	if pollInterval <= 0 {
+		pollInterval = DefaultFillPollInterval
+	}
+
+	if orders.Entry == nil {
+		if err := c.submitExits(ctx, orders); err != nil {
+			return nil, err
+		}
+		return startOperation(ctx, func(ctx context.Context, _ func(float64)) (*OCOResult, error) {
+			return c.watchExits(ctx, orders, pollInterval)
+		}), nil
+	}
+
+	if _, err := c.SubmitOrder(ctx, orders.Entry); err != nil {
+		return nil, fmt.Errorf("failed to submit entry: %w", err)
+	}
+	return startOperation(ctx, func(ctx context.Context, _ func(float64)) (*OCOResult, error) {
+		// The exits are reduce-only, so they are placed against the position
+		// opened by the entry
+		if _, err := c.WaitForFill(ctx, orders.Entry.ID, pollInterval).Wait(ctx); err != nil {
+			return nil, fmt.Errorf("entry: %w", err)
+		}
+		if err := c.submitExits(ctx, orders); err != nil {
+			return nil, err
+		}
+		return c.watchExits(ctx, orders, pollInterval)
+	}), nil
+}
+
+// submitExits places the take profit and stop loss, cancelling the take
+// profit if the stop loss cannot be placed
+func (c *APIClient) submitExits(ctx context.Context, orders OCOOrders) error {
+	if _, err := c.SubmitOrder(ctx, orders.TakeProfit); err != nil {
+		return fmt.Errorf("failed to submit take profit: %w", err)
+	}
+	if _, err := c.SubmitOrder(ctx, orders.StopLoss); err != nil {
+		if cancelErr := c.CancelOrderByExternalID(ctx, orders.TakeProfit.ID); cancelErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to cancel take profit: %w", cancelErr))
+		}
+		return fmt.Errorf("failed to submit stop loss: %w", err)
+	}
+	return nil
+}
+
+// watchExits polls both exits until one of them finishes and cancels the other
+func (c *APIClient) watchExits(ctx context.Context, orders OCOOrders, pollInterval time.Duration) (*OCOResult, error) {
+	legs := [2]string{orders.TakeProfit.ID, orders.StopLoss.ID}
+	for {
+		for i, id := range legs {
+			order, err := c.latestOrder(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			if order == nil {
+				continue
+			}
+			executed := order.Status == OrderStatusFilled || order.Status == OrderStatusTriggered
+			if !executed && !order.Status.IsFinal() {
+				continue
+			}
+
+			sibling := legs[1-i]
+			if err := c.CancelOrderByExternalID(ctx, sibling); err != nil {
+				// The sibling may have finished in the meantime
+				if other, getErr := c.latestOrder(ctx, sibling); getErr != nil || other == nil || !other.Status.IsFinal() {
+					return nil, fmt.Errorf("failed to cancel %s after %s was %s: %w", sibling, id, order.Status, err)
+				}
+			}
+			if !executed {
+				return nil, &OrderNotFilledError{Order: *order}
+			}
+			return &OCOResult{Executed: *order, Cancelled: sibling}, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-c.Clock().After(pollInterval):
+		}
+	}
+}
+
+// latestOrder returns the most recent order with the given external ID, or
+// nil if the exchange does not know it yet
+func (c *APIClient) latestOrder(ctx context.Context, externalID string) (*OpenOrderModel, error) {
+	orders, err := c.GetOrderByExternalID(ctx, externalID)
+	if err != nil {
+		return nil, err
+	}
+	if len(orders) == 0 {
+		return nil, nil
+	}
+	return &orders[len(orders)-1], nil
+}
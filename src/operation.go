@@ -0,0 +1,133 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// OperationHandle tracks a long-running helper started in the background.
+// It can be cancelled, reports progress in [0, 1] and yields the result once done.
+type OperationHandle[T any] struct {
+	cancel   context.CancelFunc
+	done     chan struct{}
+	progress atomic.Uint64
+
+	mu     sync.Mutex
+	result T
+	err    error
+}
+
+// startOperation runs fn in a new goroutine under a cancellable child of ctx
+func startOperation[T any](ctx context.Context, fn func(ctx context.Context, report func(float64)) (T, error)) *OperationHandle[T] {
+	ctx, cancel := context.WithCancel(ctx)
+	h := &OperationHandle[T]{
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	go func() {
+		defer cancel()
+		result, err := fn(ctx, h.setProgress)
+		h.mu.Lock()
+		h.result, h.err = result, err
+		h.mu.Unlock()
+		if err == nil {
+			h.setProgress(1)
+		}
+		close(h.done)
+	}()
+	return h
+}
+
+func (h *OperationHandle[T]) setProgress(p float64) {
+	h.progress.Store(math.Float64bits(math.Max(0, math.Min(1, p))))
+}
+
+// Cancel stops the operation. Result then returns context.Canceled unless the
+// operation had already finished.
+func (h *OperationHandle[T]) Cancel() {
+	h.cancel()
+}
+
+// Done is closed once the operation has finished
+func (h *OperationHandle[T]) Done() <-chan struct{} {
+	return h.done
+}
+
+// Progress returns the completed fraction of the operation in [0, 1]
+func (h *OperationHandle[T]) Progress() float64 {
+	return math.Float64frombits(h.progress.Load())
+}
+
+// Result blocks until the operation has finished and returns its outcome
+func (h *OperationHandle[T]) Result() (T, error) {
+	<-h.done
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.result, h.err
+}
+
+// Wait is like Result but gives up when ctx is done, leaving the operation running
+func (h *OperationHandle[T]) Wait(ctx context.Context) (T, error) {
+	select {
+	case <-h.done:
+		return h.Result()
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// OrderNotFilledError is returned by WaitForFill when the order reaches a final
+// state other than FILLED
+type OrderNotFilledError struct {
+	Order OpenOrderModel
+}
+
+func (e *OrderNotFilledError) Error() string {
+	if e.Order.StatusReason != "" {
+		return fmt.Sprintf("order %s was %s: %s", e.Order.ExternalID, e.Order.Status, e.Order.StatusReason)
+	}
+	return fmt.Sprintf("order %s was %s", e.Order.ExternalID, e.Order.Status)
+}
+
+// DefaultFillPollInterval is used by WaitForFill when no interval is given
+const DefaultFillPollInterval = 500 * time.Millisecond
+
+// WaitForFill polls the order with the given external ID until it is fully
+// filled. Progress reports the filled fraction of the order quantity. A fill
+// seen here is counted in the OrdersFilled of Stats.
+func (c *APIClient) WaitForFill(ctx context.Context, externalID string, pollInterval time.Duration) *OperationHandle[*OpenOrderModel] {
+	if pollInterval <= 0 {
+		pollInterval = DefaultFillPollInterval
+	}
+	return startOperation(ctx, func(ctx context.Context, report func(float64)) (*OpenOrderModel, error) {
+		for {
+			order, err := c.latestOrder(ctx, externalID)
+			if err != nil {
+				return nil, err
+			}
+			if order != nil {
+				if order.Qty.IsPositive() {
+					report(order.FilledQty.Div(order.Qty).InexactFloat64())
+				}
+				if order.Status == OrderStatusFilled {
+					c.stats.recordFill(order.ID)
+					return order, nil
+				}
+				if order.Status.IsFinal() {
+					return nil, &OrderNotFilledError{Order: *order}
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-c.Clock().After(pollInterval):
+			}
+		}
+	})
+}
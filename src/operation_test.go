@@ -0,0 +1,44 @@
+package sdk
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationHandle_ResultAndProgress(t *testing.T) {
+	step := make(chan struct{})
+	h := startOperation(context.Background(), func(ctx context.Context, report func(float64)) (int, error) {
+		report(0.5)
+		<-step
+		return 42, nil
+	})
+
+	require.Eventually(t, func() bool { return h.Progress() == 0.5 }, time.Second, time.Millisecond)
+	close(step)
+
+	result, err := h.Result()
+	require.NoError(t, err)
+	assert.Equal(t, 42, result)
+	assert.Equal(t, 1.0, h.Progress())
+}
+
+func TestOperationHandle_Cancel(t *testing.T) {
+	h := startOperation(context.Background(), func(ctx context.Context, report func(float64)) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := h.Wait(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	h.Cancel()
+	<-h.Done()
+	_, err = h.Result()
+	assert.ErrorIs(t, err, context.Canceled)
+}
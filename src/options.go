@@ -0,0 +1,193 @@
+package sdk
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// SDKVersion is reported in the default User-Agent header
+const SDKVersion = "0.1.0"
+
+const defaultUserAgent = "extended-sdk-golang/" + SDKVersion
+
+// ClientOption configures optional behaviour of a client
+type ClientOption func(*BaseModule)
+
+// WithUserAgent appends an application name and version to the SDK User-Agent,
+// e.g. "extended-sdk-golang/0.1.0 my-bot/1.2.3"
+func WithUserAgent(appName, appVersion string) ClientOption {
+	return func(m *BaseModule) {
+		if appName == "" {
+			return
+		}
+		product := appName
+		if appVersion != "" {
+			product += "/" + appVersion
+		}
+		m.userAgent = defaultUserAgent + " " + product
+	}
+}
+
+// WithClientID sets the X-Client-Id header sent with every request so that
+// exchange-side support can identify the integration
+func WithClientID(clientID string) ClientOption {
+	return func(m *BaseModule) {
+		m.clientID = clientID
+	}
+}
+
+// WithLanguage sets the Accept-Language header, e.g. "de-DE", asking the
+// exchange for error messages in that language, see APIError.LocalizedMessage
+func WithLanguage(tag string) ClientOption {
+	return func(m *BaseModule) {
+		m.language = tag
+	}
+}
+
+// WithRequestTimeout bounds a whole request, from dialing until the response
+// body is read. It replaces the clientTimeout passed to the constructor;
+// zero means no limit.
+func WithRequestTimeout(timeout time.Duration) ClientOption {
+	return func(m *BaseModule) {
+		m.clientTimeout = timeout
+	}
+}
+
+// WithConnectTimeout bounds establishing a connection, i.e. the dial and the
+// TLS handshake, separately from the request timeout. An unreachable endpoint
+// then fails fast while slow, large responses may still use the full request
+// timeout. Zero keeps the defaults of http.DefaultTransport.
+func WithConnectTimeout(timeout time.Duration) ClientOption {
+	return func(m *BaseModule) {
+		m.connectTimeout = timeout
+	}
+}
+
+// DefaultMaxResponseSize bounds the response bodies read by clients unless
+// changed with WithMaxResponseSize. It is far above the largest responses of
+// the API, deep orderbook snapshots included.
+const DefaultMaxResponseSize = 32 << 20
+
+// WithMaxResponseSize bounds the size of response bodies, protecting memory
+// when the SDK talks to the API through proxies that may return pathological
+// responses. Larger responses fail with ErrResponseTooLarge: up front when
+// the Content-Length announces them, else as soon as the limit is crossed
+// while the body is streamed in. Zero keeps DefaultMaxResponseSize and a
+// negative limit disables the check.
+func WithMaxResponseSize(limit int64) ClientOption {
+	return func(m *BaseModule) {
+		if limit == 0 {
+			limit = DefaultMaxResponseSize
+		}
+		m.maxResponseSize = limit
+	}
+}
+
+// WithLocalAddr sends requests from the given local IP address. API keys are
+// often allowlisted for a single IP, which a server with several addresses
+// must then use deterministically.
+func WithLocalAddr(ip net.IP) ClientOption {
+	return func(m *BaseModule) {
+		m.localAddr = ip
+	}
+}
+
+// WithLocalInterface sends requests from an address of the named network
+// interface, e.g. "eth1", preferring IPv4. The address is looked up on every
+// new connection, so it follows address changes; connections fail while the
+// interface has none.
+func WithLocalInterface(name string) ClientOption {
+	return func(m *BaseModule) {
+		m.localInterface = name
+	}
+}
+
+// transportConfig holds the options that need a transport of their own
+type transportConfig struct {
+	connectTimeout time.Duration
+	tlsConfig      *tls.Config
+	localAddr      net.IP
+	localInterface string
+}
+
+func (m *BaseModule) transportConfig() transportConfig {
+	return transportConfig{
+		connectTimeout: m.connectTimeout,
+		tlsConfig:      m.clientTLSConfig(),
+		localAddr:      m.localAddr,
+		localInterface: m.localInterface,
+	}
+}
+
+// newTransport returns a copy of the default transport with cfg applied, or
+// nil if cfg keeps the defaults
+func newTransport(cfg transportConfig) *http.Transport {
+	if cfg.connectTimeout <= 0 && cfg.tlsConfig == nil && cfg.localAddr == nil && cfg.localInterface == "" {
+		return nil
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// The dialer of http.DefaultTransport
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	if cfg.connectTimeout > 0 {
+		dialer.Timeout = cfg.connectTimeout
+		transport.TLSHandshakeTimeout = cfg.connectTimeout
+	}
+	if cfg.localAddr != nil {
+		dialer.LocalAddr = &net.TCPAddr{IP: cfg.localAddr}
+	}
+	transport.DialContext = dialer.DialContext
+	if cfg.localInterface != "" {
+		transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			local, err := interfaceAddr(cfg.localInterface)
+			if err != nil {
+				return nil, err
+			}
+			d := *dialer
+			d.LocalAddr = &net.TCPAddr{IP: local}
+			// Resolve the remote host in the family of the local address
+			if local.To4() != nil {
+				network = "tcp4"
+			} else {
+				network = "tcp6"
+			}
+			return d.DialContext(ctx, network, addr)
+		}
+	}
+	if cfg.tlsConfig != nil {
+		transport.TLSClientConfig = cfg.tlsConfig
+	}
+	return transport
+}
+
+// interfaceAddr returns an IP address of the named interface, IPv4 first
+func interfaceAddr(name string) (net.IP, error) {
+	iface, err := net.InterfaceByName(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find interface %s: %w", name, err)
+	}
+	addrs, err := iface.Addrs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list addresses of interface %s: %w", name, err)
+	}
+	var fallback net.IP
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok || ipNet.IP.IsLinkLocalUnicast() {
+			continue
+		}
+		if ipNet.IP.To4() != nil {
+			return ipNet.IP, nil
+		}
+		if fallback == nil {
+			fallback = ipNet.IP
+		}
+	}
+	if fallback == nil {
+		return nil, fmt.Errorf("interface %s has no usable address", name)
+	}
+	return fallback, nil
+}
@@ -0,0 +1,152 @@
+package sdk
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientMetadataHeaders(t *testing.T) {
+	var headers http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header.Clone()
+		w.Write([]byte(`{"status":"OK","data":[]}`))
+	}))
+	defer server.Close()
+
+	cfg := EndpointConfig{APIBaseURL: server.URL}
+
+	client := NewAPIClient(cfg, "", nil, 5*time.Second)
+	_, err := client.GetMarkets(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "extended-sdk-golang/"+SDKVersion, headers.Get("User-Agent"))
+	assert.Empty(t, headers.Get("X-Client-Id"))
+	assert.Empty(t, headers.Get("Accept-Language"))
+
+	client = NewAPIClient(cfg, "", nil, 5*time.Second,
+		WithUserAgent("my-bot", "1.2.3"),
+		WithClientID("integration-42"),
+		WithLanguage("de-DE"),
+	)
+	_, err = client.GetMarkets(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "extended-sdk-golang/"+SDKVersion+" my-bot/1.2.3", headers.Get("User-Agent"))
+	assert.Equal(t, "integration-42", headers.Get("X-Client-Id"))
+	assert.Equal(t, "de-DE", headers.Get("Accept-Language"))
+}
+
+func TestClientTimeouts(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Write([]byte(`{"status":"OK","data":[]}`))
+	}))
+	defer server.Close()
+	defer close(release)
+	cfg := EndpointConfig{APIBaseURL: server.URL}
+
+	client := NewAPIClient(cfg, "", nil, time.Minute,
+		WithConnectTimeout(2*time.Second),
+		WithRequestTimeout(50*time.Millisecond),
+	)
+	httpClient := client.HTTPClient()
+	assert.Equal(t, 50*time.Millisecond, httpClient.Timeout)
+	transport, ok := httpClient.Transport.(*http.Transport)
+	require.True(t, ok)
+	assert.Equal(t, 2*time.Second, transport.TLSHandshakeTimeout)
+
+	// The connection is established quickly, the slow response hits the request timeout
+	_, err := client.GetMarkets(context.Background(), nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Client.Timeout")
+
+	// Without a connect timeout the default transport is used
+	assert.Nil(t, NewAPIClient(cfg, "", nil, time.Second).HTTPClient().Transport)
+}
+
+func TestLocalAddr(t *testing.T) {
+	var remote string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		remote = r.RemoteAddr
+		w.Write([]byte(`{"status":"OK","data":[]}`))
+	}))
+	defer server.Close()
+	cfg := EndpointConfig{APIBaseURL: server.URL}
+
+	client := NewAPIClient(cfg, "", nil, 5*time.Second, WithLocalAddr(net.IPv4(127, 0, 0, 1)))
+	_, err := client.GetMarkets(context.Background(), nil)
+	require.NoError(t, err)
+	host, _, err := net.SplitHostPort(remote)
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1", host)
+
+	// An address the host does not have cannot be bound
+	client = NewAPIClient(cfg, "", nil, 5*time.Second, WithLocalAddr(net.ParseIP("192.0.2.1")))
+	_, err = client.GetMarkets(context.Background(), nil)
+	assert.Error(t, err)
+
+	loopback := loopbackInterface(t)
+	client = NewAPIClient(cfg, "", nil, 5*time.Second, WithLocalInterface(loopback))
+	_, err = client.GetMarkets(context.Background(), nil)
+	require.NoError(t, err)
+	host, _, err = net.SplitHostPort(remote)
+	require.NoError(t, err)
+	assert.True(t, net.ParseIP(host).IsLoopback())
+
+	client = NewAPIClient(cfg, "", nil, 5*time.Second, WithLocalInterface("no-such-interface"))
+	_, err = client.GetMarkets(context.Background(), nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no-such-interface")
+}
+
+func loopbackInterface(t *testing.T) string {
+	ifaces, err := net.Interfaces()
+	require.NoError(t, err)
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagLoopback != 0 && iface.Flags&net.FlagUp != 0 {
+			return iface.Name
+		}
+	}
+	t.Skip("no loopback interface")
+	return ""
+}
+
+func TestMaxResponseSize(t *testing.T) {
+	padding := strings.Repeat("x", 1000)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/stats") {
+			// Flushing before the body is written drops the Content-Length
+			w.(http.Flusher).Flush()
+			w.Write([]byte(`{"status":"OK","data":{"market":"` + padding + `"}}`))
+			return
+		}
+		w.Write([]byte(`{"status":"OK","data":[{"name":"` + padding + `"}]}`))
+	}))
+	defer server.Close()
+	cfg := EndpointConfig{APIBaseURL: server.URL}
+	ctx := context.Background()
+
+	_, err := NewAPIClient(cfg, "", nil, time.Second).GetMarkets(ctx, nil)
+	require.NoError(t, err, "the default limit is far above normal responses")
+
+	limited := NewAPIClient(cfg, "", nil, time.Second, WithMaxResponseSize(512))
+	_, err = limited.GetMarkets(ctx, nil)
+	assert.ErrorIs(t, err, ErrResponseTooLarge)
+	assert.Contains(t, err.Error(), "announced")
+	_, err = limited.GetMarketStats(ctx, "BTC-USD")
+	assert.ErrorIs(t, err, ErrResponseTooLarge)
+	assert.Contains(t, err.Error(), "more than 512 bytes")
+	assert.Equal(t, uint64(513), limited.Stats().BytesReceived, "reading stops past the limit")
+
+	_, err = NewAPIClient(cfg, "", nil, time.Second, WithMaxResponseSize(2048)).GetMarketStats(ctx, "BTC-USD")
+	assert.NoError(t, err)
+	_, err = NewAPIClient(cfg, "", nil, time.Second, WithMaxResponseSize(-1)).GetMarkets(ctx, nil)
+	assert.NoError(t, err)
+}
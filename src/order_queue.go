@@ -0,0 +1,267 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrOrderQueueClosed is returned for requests queued after, or still pending at, Close
+var ErrOrderQueueClosed = errors.New("order queue is closed")
+
+// OrderPriority selects the lane a queued request is placed in
+type OrderPriority int
+
+const (
+	// OrderPriorityRiskReducing is used for cancels and reduce-only orders
+	OrderPriorityRiskReducing OrderPriority = iota
+	// OrderPriorityNormal is used for orders that may open or increase a position
+	OrderPriorityNormal
+)
+
+// OrderQueueConfig configures the client-side submission rate limit
+type OrderQueueConfig struct {
+	// RequestsPerSecond is the sustained rate at which queued requests are sent
+	RequestsPerSecond float64
+	// Burst is the number of requests that may be sent back to back; defaults to 1
+	Burst int
+}
+
+// WithOrderQueue routes order submissions and cancels of the client through an
+// OrderQueue so that, when throttled, cancels and reduce-only orders are sent
+// before new opening orders
+func WithOrderQueue(cfg OrderQueueConfig) ClientOption {
+	return func(m *BaseModule) {
+		m.orderQueueConfig = &cfg
+	}
+}
+
+// orderRouter sends the order traffic of a client, see OrderQueue and
+// PoolScheduler
+type orderRouter interface {
+	SubmitOrder(ctx context.Context, order *PerpetualOrderModel) (*OrderResponse, error)
+	CancelOrder(ctx context.Context, orderID int64) error
+	CancelOrderByExternalID(ctx context.Context, externalID string) error
+	Do(ctx context.Context, priority OrderPriority, run func(ctx context.Context) error) error
+	Close()
+}
+
+// OrderQueue rate limits order traffic with two priority lanes. Requests in the
+// risk-reducing lane are always dispatched before those in the normal lane.
+type OrderQueue struct {
+	client *APIClient
+
+	mu     sync.Mutex
+	lanes  [2][]*queuedRequest
+	bucket tokenBucket
+
+	notify    chan struct{}
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+type queuedRequest struct {
+	ctx  context.Context
+	run  func(ctx context.Context) error
+	done chan error
+}
+
+// NewOrderQueue creates a queue sending requests through client and starts its dispatcher
+func NewOrderQueue(client *APIClient, cfg OrderQueueConfig) *OrderQueue {
+	q := &OrderQueue{
+		client: client,
+		bucket: newTokenBucket(client.Clock(), cfg.RequestsPerSecond, cfg.Burst),
+		notify: make(chan struct{}, 1),
+		closed: make(chan struct{}),
+	}
+	go q.dispatch()
+	return q
+}
+
+// SubmitOrder queues an order. Reduce-only orders use the risk-reducing lane.
+func (q *OrderQueue) SubmitOrder(ctx context.Context, order *PerpetualOrderModel) (*OrderResponse, error) {
+	priority := OrderPriorityNormal
+	if order != nil && order.ReduceOnly {
+		priority = OrderPriorityRiskReducing
+	}
+
+	var response *OrderResponse
+	err := q.Do(ctx, priority, func(ctx context.Context) error {
+		var err error
+		response, err = q.client.submitOrder(ctx, order)
+		return err
+	})
+	return response, err
+}
+
+// CancelOrder queues a cancel by exchange order ID in the risk-reducing lane
+func (q *OrderQueue) CancelOrder(ctx context.Context, orderID int64) error {
+	return q.Do(ctx, OrderPriorityRiskReducing, func(ctx context.Context) error {
+		return q.client.cancelOrder(ctx, orderID)
+	})
+}
+
+// CancelOrderByExternalID queues a cancel by external ID in the risk-reducing lane
+func (q *OrderQueue) CancelOrderByExternalID(ctx context.Context, externalID string) error {
+	return q.Do(ctx, OrderPriorityRiskReducing, func(ctx context.Context) error {
+		return q.client.cancelOrderByExternalID(ctx, externalID)
+	})
+}
+
+// Do queues an arbitrary request in the given lane and waits for it to complete
+func (q *OrderQueue) Do(ctx context.Context, priority OrderPriority, run func(ctx context.Context) error) error {
+	if priority < OrderPriorityRiskReducing || priority > OrderPriorityNormal {
+		priority = OrderPriorityNormal
+	}
+	req := &queuedRequest{ctx: ctx, run: run, done: make(chan error, 1)}
+
+	q.mu.Lock()
+	select {
+	case <-q.closed:
+		q.mu.Unlock()
+		return ErrOrderQueueClosed
+	default:
+	}
+	q.lanes[priority] = append(q.lanes[priority], req)
+	q.mu.Unlock()
+
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+
+	select {
+	case err := <-req.done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops the dispatcher. Requests still queued fail with ErrOrderQueueClosed.
+func (q *OrderQueue) Close() {
+	q.closeOnce.Do(func() {
+		q.mu.Lock()
+		close(q.closed)
+		lanes := q.lanes
+		q.lanes = [2][]*queuedRequest{}
+		q.mu.Unlock()
+
+		for _, lane := range lanes {
+			for _, req := range lane {
+				req.done <- ErrOrderQueueClosed
+			}
+		}
+	})
+}
+
+func (q *OrderQueue) dispatch() {
+	for {
+		if !q.waitForWork() || !q.waitForToken() {
+			return
+		}
+		req := q.pop()
+		if req == nil {
+			continue
+		}
+		go func() {
+			req.done <- req.run(req.ctx)
+		}()
+	}
+}
+
+func (q *OrderQueue) waitForWork() bool {
+	for {
+		q.mu.Lock()
+		pending := len(q.lanes[0]) + len(q.lanes[1])
+		q.mu.Unlock()
+		if pending > 0 {
+			return true
+		}
+		select {
+		case <-q.notify:
+		case <-q.closed:
+			return false
+		}
+	}
+}
+
+// waitForToken blocks until the token bucket allows another request
+func (q *OrderQueue) waitForToken() bool {
+	for {
+		q.mu.Lock()
+		wait := q.bucket.take()
+		q.mu.Unlock()
+		if wait == 0 {
+			return true
+		}
+
+		select {
+		case <-q.bucket.clock.After(wait):
+		case <-q.closed:
+			return false
+		}
+	}
+}
+
+// tokenBucket is the rate limit of a queue. It is not safe for concurrent use.
+type tokenBucket struct {
+	clock    Clock
+	interval time.Duration
+	burst    float64
+	tokens   float64
+	last     time.Time
+}
+
+// newTokenBucket allows requestsPerSecond on average and burst back to back;
+// a non-positive rate does not limit
+func newTokenBucket(clock Clock, requestsPerSecond float64, burst int) tokenBucket {
+	if burst <= 0 {
+		burst = 1
+	}
+	interval := time.Duration(0)
+	if requestsPerSecond > 0 {
+		interval = time.Duration(float64(time.Second) / requestsPerSecond)
+	}
+	clock = clockOrDefault(clock)
+	return tokenBucket{clock: clock, interval: interval, burst: float64(burst), tokens: float64(burst), last: clock.Now()}
+}
+
+// take consumes a token and returns zero, or returns how long to wait for
+// the next token
+func (b *tokenBucket) take() time.Duration {
+	if b.interval == 0 {
+		return 0
+	}
+	now := b.clock.Now()
+	b.tokens += float64(now.Sub(b.last)) / float64(b.interval)
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	return max(time.Duration((1-b.tokens)*float64(b.interval)), time.Nanosecond)
+}
+
+// pop removes the next request, highest priority first. Requests whose
+// context is already done are completed without being sent.
+func (q *OrderQueue) pop() *queuedRequest {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for i := range q.lanes {
+		for len(q.lanes[i]) > 0 {
+			req := q.lanes[i][0]
+			q.lanes[i] = q.lanes[i][1:]
+			if err := req.ctx.Err(); err != nil {
+				req.done <- err
+				continue
+			}
+			return req
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderQueue_RiskReducingFirst(t *testing.T) {
+	var mu sync.Mutex
+	var order []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		order = append(order, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+		w.Write([]byte(`{"status":"OK"}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(EndpointConfig{APIBaseURL: server.URL}, "key", nil, 5*time.Second)
+	// One request every 20ms with no burst, so everything below queues up
+	queue := NewOrderQueue(client, OrderQueueConfig{RequestsPerSecond: 50, Burst: 1})
+	defer queue.Close()
+
+	// Consume the initial token so that later requests compete for slots
+	require.NoError(t, queue.Do(context.Background(), OrderPriorityNormal, func(ctx context.Context) error { return nil }))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = queue.Do(context.Background(), OrderPriorityNormal, func(ctx context.Context) error {
+				return client.doCancel(ctx, "GET", server.URL+"/normal", nil, 0)
+			})
+		}()
+	}
+	// Let the normal requests queue up before the cancel arrives
+	require.Eventually(t, func() bool {
+		queue.mu.Lock()
+		defer queue.mu.Unlock()
+		return len(queue.lanes[OrderPriorityNormal]) >= 2
+	}, time.Second, time.Millisecond)
+
+	require.NoError(t, queue.CancelOrder(context.Background(), 7))
+	wg.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, order, 4)
+	cancelAt := -1
+	for i, req := range order {
+		if strings.HasPrefix(req, "DELETE") {
+			cancelAt = i
+		}
+	}
+	// At most one normal request may have been dispatched before the cancel was queued
+	assert.LessOrEqual(t, cancelAt, 1, fmt.Sprintf("cancel was not prioritised: %v", order))
+}
+
+func TestWithOrderQueue_RoutesClientCalls(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"OK","data":{"id":1,"externalId":"abc"}}`))
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(EndpointConfig{APIBaseURL: server.URL}, "key", nil, 5*time.Second,
+		WithOrderQueue(OrderQueueConfig{RequestsPerSecond: 1000, Burst: 10}),
+	)
+	require.NotNil(t, client.orderQueue)
+
+	resp, err := client.SubmitOrder(context.Background(), &PerpetualOrderModel{ID: "abc", ReduceOnly: true})
+	require.NoError(t, err)
+	assert.Equal(t, "abc", resp.Data.ExternalID)
+	require.NoError(t, client.CancelOrderByExternalID(context.Background(), "abc"))
+
+	client.Close()
+	_, err = client.SubmitOrder(context.Background(), &PerpetualOrderModel{ID: "abc"})
+	assert.ErrorIs(t, err, ErrOrderQueueClosed)
+}
@@ -0,0 +1,138 @@
+package sdk
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// Validate checks the order parameters for values the exchange would reject
+// and for options that cannot be combined. Every problem found is reported,
+// joined into a single error; each one wraps ErrInvalidOrder.
+func (p CreateOrderObjectParams) Validate() error {
+	var problems []error
+	invalid := func(format string, args ...any) {
+		problems = append(problems, invalidOrder(format, args...))
+	}
+
+	orderType := p.Type
+	if orderType == "" {
+		orderType = OrderTypeLimit
+	}
+
+	if p.Market.Name == "" {
+		invalid("market is required")
+	}
+	if !p.Side.IsValid() {
+		invalid("unknown side %q", p.Side)
+	}
+	if !orderType.IsValid() {
+		invalid("unknown order type %q", p.Type)
+	}
+	if p.TimeInForce != "" && !p.TimeInForce.IsValid() {
+		invalid("unknown time in force %q", p.TimeInForce)
+	}
+	if p.SelfTradeProtectionLevel != "" && !p.SelfTradeProtectionLevel.IsValid() {
+		invalid("unknown self-trade protection level %q", p.SelfTradeProtectionLevel)
+	}
+	if !p.SyntheticAmount.IsPositive() {
+		invalid("quantity must be positive, got %s", p.SyntheticAmount)
+	}
+	if !p.Price.IsPositive() {
+		invalid("price must be positive, got %s", p.Price)
+	}
+	if p.Signer == nil {
+		invalid("signer is required")
+	}
+	if p.BuilderFee != nil && p.BuilderFee.IsNegative() {
+		invalid("builder fee must not be negative, got %s", *p.BuilderFee)
+	}
+
+	// Post-only orders must be able to rest on the book
+	if p.PostOnly && orderType == OrderTypeMarket {
+		invalid("post-only is not allowed for market orders")
+	}
+	if p.PostOnly && (p.TimeInForce == TimeInForceIOC || p.TimeInForce == TimeInForceFOK) {
+		invalid("post-only is not allowed with time in force %s", p.TimeInForce)
+	}
+	if orderType == OrderTypeMarket && p.TimeInForce != "" && p.TimeInForce != TimeInForceIOC && p.TimeInForce != TimeInForceFOK {
+		invalid("market orders must be IOC or FOK, got %s", p.TimeInForce)
+	}
+
+	if orderType == OrderTypeConditional {
+		if p.Trigger == nil {
+			invalid("conditional orders require a trigger")
+		} else {
+			problems = append(problems, validateTrigger(*p.Trigger)...)
+		}
+	} else if p.Trigger != nil {
+		invalid("trigger is only allowed for conditional orders")
+	}
+
+	hasLegs := p.TakeProfit != nil || p.StopLoss != nil
+	if orderType == OrderTypeTpsl || hasLegs {
+		if p.TpSlType == nil {
+			invalid("TPSL orders and take profit or stop loss legs require a TPSL type")
+		} else if !p.TpSlType.IsValid() {
+			invalid("unknown TPSL type %q", *p.TpSlType)
+		}
+	} else if p.TpSlType != nil {
+		invalid("TPSL type is only allowed with TPSL orders or take profit or stop loss legs")
+	}
+	if p.TakeProfit != nil {
+		problems = append(problems, validateTpSlLeg("take profit", *p.TakeProfit)...)
+	}
+	if p.StopLoss != nil {
+		problems = append(problems, validateTpSlLeg("stop loss", *p.StopLoss)...)
+	}
+	if p.ReduceOnly && p.TpSlType != nil && *p.TpSlType == TpSlTypePosition {
+		invalid("reduce-only is not allowed with TPSL type %s", TpSlTypePosition)
+	}
+
+	return errors.Join(problems...)
+}
+
+func validateTrigger(trigger ConditionalTrigger) []error {
+	var problems []error
+	invalid := func(format string, args ...any) {
+		problems = append(problems, invalidOrder(format, args...))
+	}
+	if price, err := decimal.NewFromString(trigger.TriggerPrice); err != nil || !price.IsPositive() {
+		invalid("trigger price must be a positive number, got %q", trigger.TriggerPrice)
+	}
+	if !trigger.TriggerPriceType.IsValid() {
+		invalid("unknown trigger price type %q", trigger.TriggerPriceType)
+	}
+	if !trigger.Direction.IsValid() {
+		invalid("unknown trigger direction %q", trigger.Direction)
+	}
+	if !trigger.ExecutionPriceType.IsValid() {
+		invalid("unknown execution price type %q", trigger.ExecutionPriceType)
+	}
+	return problems
+}
+
+func validateTpSlLeg(name string, leg TpSlParams) []error {
+	var problems []error
+	invalid := func(format string, args ...any) {
+		problems = append(problems, invalidOrder(name+" "+format, args...))
+	}
+	if !leg.TriggerPrice.IsPositive() {
+		invalid("trigger price must be positive, got %s", leg.TriggerPrice)
+	}
+	if !leg.Price.IsPositive() {
+		invalid("price must be positive, got %s", leg.Price)
+	}
+	if !leg.TriggerPriceType.IsValid() {
+		invalid("has unknown trigger price type %q", leg.TriggerPriceType)
+	}
+	if !leg.PriceType.IsValid() {
+		invalid("has unknown price type %q", leg.PriceType)
+	}
+	return problems
+}
+
+func invalidOrder(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalidOrder, fmt.Sprintf(format, args...))
+}
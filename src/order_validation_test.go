@@ -0,0 +1,105 @@
+package sdk
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validOrderParams() CreateOrderObjectParams {
+	return CreateOrderObjectParams{
+		Market:          MarketModel{Name: "BTC-USD"},
+		SyntheticAmount: decimal.RequireFromString("0.1"),
+		Price:           decimal.RequireFromString("50000"),
+		Side:            OrderSideBuy,
+		TimeInForce:     TimeInForceGTT,
+		Signer: func(string) (*big.Int, *big.Int, error) {
+			return big.NewInt(1), big.NewInt(1), nil
+		},
+	}
+}
+
+func TestCreateOrderObjectParamsValidate(t *testing.T) {
+	position := TpSlTypePosition
+	trigger := &ConditionalTrigger{
+		TriggerPrice:       "49000",
+		TriggerPriceType:   TriggerPriceTypeMark,
+		Direction:          TriggerDirectionDown,
+		ExecutionPriceType: ExecutionPriceTypeLimit,
+	}
+
+	tests := []struct {
+		name   string
+		modify func(p *CreateOrderObjectParams)
+		errors int
+	}{
+		{"valid limit", func(p *CreateOrderObjectParams) {}, 0},
+		{"valid conditional", func(p *CreateOrderObjectParams) { p.Type = OrderTypeConditional; p.Trigger = trigger }, 0},
+		{"post-only market", func(p *CreateOrderObjectParams) {
+			p.Type = OrderTypeMarket
+			p.TimeInForce = TimeInForceIOC
+			p.PostOnly = true
+		}, 2},
+		{"market GTT", func(p *CreateOrderObjectParams) { p.Type = OrderTypeMarket }, 1},
+		{"post-only FOK", func(p *CreateOrderObjectParams) { p.PostOnly = true; p.TimeInForce = TimeInForceFOK }, 1},
+		{"conditional without trigger", func(p *CreateOrderObjectParams) { p.Type = OrderTypeConditional }, 1},
+		{"trigger on limit", func(p *CreateOrderObjectParams) { p.Trigger = trigger }, 1},
+		{"bad trigger", func(p *CreateOrderObjectParams) {
+			p.Type = OrderTypeConditional
+			p.Trigger = &ConditionalTrigger{TriggerPrice: "abc"}
+		}, 4},
+		{"reduce-only position TPSL", func(p *CreateOrderObjectParams) {
+			p.Type = OrderTypeTpsl
+			p.TpSlType = &position
+			p.ReduceOnly = true
+		}, 1},
+		{"TPSL without type", func(p *CreateOrderObjectParams) { p.Type = OrderTypeTpsl }, 1},
+		{"bad enums and amounts", func(p *CreateOrderObjectParams) {
+			p.Side = "LONG"
+			p.TimeInForce = "DAY"
+			p.SyntheticAmount = decimal.Zero
+			p.Price = decimal.NewFromInt(-1)
+		}, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := validOrderParams()
+			tt.modify(&params)
+
+			err := params.Validate()
+			if tt.errors == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, ErrInvalidOrder))
+			joined, ok := err.(interface{ Unwrap() []error })
+			require.True(t, ok)
+			assert.Len(t, joined.Unwrap(), tt.errors, err.Error())
+		})
+	}
+}
+
+func TestCreateOrderObjectValidatesBeforeNonce(t *testing.T) {
+	params := validOrderParams()
+	params.PostOnly = true
+	params.TimeInForce = TimeInForceIOC
+	generator := &countingNonce{}
+	params.NonceGenerator = generator
+
+	_, err := CreateOrderObject(params)
+	assert.True(t, errors.Is(err, ErrInvalidOrder))
+	assert.Zero(t, generator.calls)
+}
+
+type countingNonce struct{ calls int }
+
+func (g *countingNonce) NextNonce() (int, error) {
+	g.calls++
+	return g.calls, nil
+}
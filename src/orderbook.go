@@ -0,0 +1,95 @@
+package sdk
+
+import (
+	"context"
+
+	"github.com/shopspring/decimal"
+)
+
+// Truncate keeps at most depth levels per side. A non-positive depth keeps all levels.
+func (o *OrderbookUpdateModel) Truncate(depth int) {
+	if depth <= 0 {
+		return
+	}
+	if len(o.Bid) > depth {
+		o.Bid = o.Bid[:depth]
+	}
+	if len(o.Ask) > depth {
+		o.Ask = o.Ask[:depth]
+	}
+}
+
+// MidPrice returns the midpoint of the best bid and ask. The boolean is false
+// if either side of the book is empty.
+func (o OrderbookUpdateModel) MidPrice() (decimal.Decimal, bool) {
+	if len(o.Bid) == 0 || len(o.Ask) == 0 {
+		return decimal.Zero, false
+	}
+	return o.Bid[0].Price.Add(o.Ask[0].Price).Div(decimal.NewFromInt(2)), true
+}
+
+type orderbookOptions struct {
+	depth int
+}
+
+// OrderbookOption configures GetOrderbookSnapshot
+type OrderbookOption func(*orderbookOptions)
+
+// WithDepth limits the snapshot to the best n levels on each side
+func WithDepth(n int) OrderbookOption {
+	return func(o *orderbookOptions) {
+		o.depth = n
+	}
+}
+
+// AggregateOrderbook groups the levels of book into price bands of the given
+// width, e.g. 10 for $10 bands. Bids are floored and asks ceiled to the band
+// edge so that aggregated prices are never better than the underlying levels.
+// A non-positive band returns a copy of the book.
+func AggregateOrderbook(book OrderbookUpdateModel, band decimal.Decimal) OrderbookUpdateModel {
+	out := OrderbookUpdateModel{Market: book.Market}
+	if !band.IsPositive() {
+		out.Bid = append([]OrderbookQuantityModel(nil), book.Bid...)
+		out.Ask = append([]OrderbookQuantityModel(nil), book.Ask...)
+		return out
+	}
+	out.Bid = aggregateSide(book.Bid, func(p decimal.Decimal) decimal.Decimal {
+		return p.Div(band).Floor().Mul(band)
+	})
+	out.Ask = aggregateSide(book.Ask, func(p decimal.Decimal) decimal.Decimal {
+		return p.Div(band).Ceil().Mul(band)
+	})
+	return out
+}
+
+// aggregateSide merges consecutive levels falling into the same bucket. Levels
+// are sorted best first, so buckets come out sorted too.
+func aggregateSide(levels []OrderbookQuantityModel, bucket func(decimal.Decimal) decimal.Decimal) []OrderbookQuantityModel {
+	out := make([]OrderbookQuantityModel, 0, len(levels))
+	for _, level := range levels {
+		price := bucket(level.Price)
+		if n := len(out); n > 0 && out[n-1].Price.Equal(price) {
+			out[n-1].Qty = out[n-1].Qty.Add(level.Qty)
+			continue
+		}
+		out = append(out, OrderbookQuantityModel{Price: price, Qty: level.Qty})
+	}
+	return out
+}
+
+// GetAggregatedOrderbook fetches an orderbook snapshot and groups it into price
+// bands, see AggregateOrderbook. WithDepth applies to the aggregated levels.
+func (c *APIClient) GetAggregatedOrderbook(ctx context.Context, market string, band decimal.Decimal, opts ...OrderbookOption) (*OrderbookUpdateModel, error) {
+	var options orderbookOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	book, err := c.GetOrderbookSnapshot(ctx, market)
+	if err != nil {
+		return nil, err
+	}
+	aggregated := AggregateOrderbook(*book, band)
+	aggregated.Truncate(options.depth)
+	return &aggregated, nil
+}
@@ -0,0 +1,53 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func levels(pairs ...string) []OrderbookQuantityModel {
+	out := make([]OrderbookQuantityModel, 0, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		out = append(out, OrderbookQuantityModel{
+			Price: decimal.RequireFromString(pairs[i]),
+			Qty:   decimal.RequireFromString(pairs[i+1]),
+		})
+	}
+	return out
+}
+
+func TestAggregateOrderbook(t *testing.T) {
+	book := OrderbookUpdateModel{
+		Market: "BTC-USD",
+		Bid:    levels("50009.5", "1", "50001", "2", "49999", "3", "49985", "4"),
+		Ask:    levels("50010.5", "1", "50019", "2", "50020", "3", "50021", "4"),
+	}
+
+	aggregated := AggregateOrderbook(book, decimal.NewFromInt(10))
+
+	require.Len(t, aggregated.Bid, 3)
+	assert.Equal(t, "50000", aggregated.Bid[0].Price.String())
+	assert.Equal(t, "3", aggregated.Bid[0].Qty.String())
+	assert.Equal(t, "49990", aggregated.Bid[1].Price.String())
+	assert.Equal(t, "49980", aggregated.Bid[2].Price.String())
+
+	require.Len(t, aggregated.Ask, 2)
+	assert.Equal(t, "50020", aggregated.Ask[0].Price.String())
+	assert.Equal(t, "6", aggregated.Ask[0].Qty.String())
+	assert.Equal(t, "50030", aggregated.Ask[1].Price.String())
+
+	// The source book is left untouched
+	assert.Len(t, book.Bid, 4)
+}
+
+func TestAggregateOrderbook_NoBand(t *testing.T) {
+	book := OrderbookUpdateModel{Bid: levels("1", "1"), Ask: levels("2", "1")}
+
+	aggregated := AggregateOrderbook(book, decimal.Zero)
+	aggregated.Bid[0].Qty = decimal.NewFromInt(5)
+
+	assert.Equal(t, "1", book.Bid[0].Qty.String())
+}
@@ -1,6 +1,8 @@
 package sdk
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"time"
@@ -8,70 +10,14 @@ import (
 	"github.com/shopspring/decimal"
 )
 
-type OrderType string
-
-const (
-	OrderTypeLimit       OrderType = "LIMIT"
-	OrderTypeMarket      OrderType = "MARKET"
-	OrderTypeConditional OrderType = "CONDITIONAL"
-	OrderTypeTpsl        OrderType = "TPSL"
-)
-
-type OrderSide string
-
-const (
-	OrderSideBuy  OrderSide = "BUY"
-	OrderSideSell OrderSide = "SELL"
-)
-
-// TimeInForce represents the time-in-force setting
-type TimeInForce string
-
-const (
-	TimeInForceGTT TimeInForce = "GTT" // Good till time
-	TimeInForceFOK TimeInForce = "FOK" // Fill or kill
-	TimeInForceIOC TimeInForce = "IOC" // Immediate or cancel
-)
-
-type SelfTradeProtectionLevel string
-
-const (
-	SelfTradeProtectionDisabled SelfTradeProtectionLevel = "DISABLED"
-	SelfTradeProtectionAccount  SelfTradeProtectionLevel = "ACCOUNT"
-	SelfTradeProtectionClient   SelfTradeProtectionLevel = "CLIENT"
-)
-
-type TriggerPriceType string
-
-const (
-	TriggerPriceTypeLast  TriggerPriceType = "LAST"
-	TriggerPriceTypeMid   TriggerPriceType = "MID"
-	TriggerPriceTypeMark  TriggerPriceType = "MARK"
-	TriggerPriceTypeIndex TriggerPriceType = "INDEX"
-)
-
-type TriggerDirection string
-
-const (
-	TriggerDirectionUp   TriggerDirection = "UP"
-	TriggerDirectionDown TriggerDirection = "DOWN"
-)
-
-// ExecutionPriceType represents the type of price used for order execution
-type ExecutionPriceType string
-
-const (
-	ExecutionPriceTypeLimit  ExecutionPriceType = "LIMIT"
-	ExecutionPriceTypeMarket ExecutionPriceType = "MARKET"
-)
-
-// TpSlType represents the TPSL type determining order size
-type TpSlType string
-
-const (
-	TpSlTypeOrder    TpSlType = "ORDER"
-	TpSlTypePosition TpSlType = "POSITION"
-)
+// IsFinal reports whether no further state transitions can happen
+func (s OrderStatus) IsFinal() bool {
+	switch s {
+	case OrderStatusFilled, OrderStatusCancelled, OrderStatusRejected, OrderStatusExpired:
+		return true
+	}
+	return false
+}
 
 // Signature represents a cryptographic signature
 type Signature struct {
@@ -123,6 +69,29 @@ type PerpetualOrderModel struct {
 	BuilderFee               *string                  `json:"builderFee,omitempty"`
 	BuilderID                *int                     `json:"builderId,omitempty"`
 	CancelID                 *string                  `json:"cancelId,omitempty"`
+
+	payload []byte
+}
+
+// Payload returns the JSON body submitted for the order. It is marshalled
+// with encoding/json on the first call and kept, so calling it right after
+// signing takes serialization off the submit path: SubmitOrder then sends the
+// kept bytes as they are, regardless of the client codec. Changes made to the
+// order after the first call are not reflected. It returns nil if the order
+// cannot be marshalled.
+func (o *PerpetualOrderModel) Payload() []byte {
+	if o.payload == nil {
+		o.payload, _ = json.Marshal(o)
+	}
+	return o.payload
+}
+
+// TpSlParams describes a take profit or stop loss leg attached to an order
+type TpSlParams struct {
+	TriggerPrice     decimal.Decimal
+	TriggerPriceType TriggerPriceType
+	Price            decimal.Decimal
+	PriceType        ExecutionPriceType
 }
 
 // CreateOrderObjectParams represents the parameters for creating an order object
@@ -132,43 +101,179 @@ type CreateOrderObjectParams struct {
 	SyntheticAmount          decimal.Decimal
 	Price                    decimal.Decimal
 	Side                     OrderSide
+	Type                     OrderType                                // Defaults to OrderTypeLimit
 	Signer                   func(string) (*big.Int, *big.Int, error) // Function that takes string and returns two values
 	StarknetDomain           StarknetDomain
-	ExpireTime               *time.Time
+	ExpireTime               *time.Time // Defaults to one hour from Clock.Now()
 	PostOnly                 bool
+	ReduceOnly               bool
+	Trigger                  *ConditionalTrigger // Required for conditional orders
+	TpSlType                 *TpSlType           // Required for TPSL orders and attached TP/SL legs
+	TakeProfit               *TpSlParams
+	StopLoss                 *TpSlParams
+	Fees                     *TradingFeeModel // Defaults to DefaultFees; PlaceOrder uses the fees cached by the client
 	PreviousOrderExternalID  *string
 	OrderExternalID          *string
 	TimeInForce              TimeInForce
 	SelfTradeProtectionLevel SelfTradeProtectionLevel
 	Nonce                    *int
-	BuilderFee               *decimal.Decimal
+	NonceGenerator           NonceGenerator   // Used when Nonce is nil; PlaceOrder defaults to the client
+	BuilderFee               *decimal.Decimal // Defaults to Fees.BuilderFeeRate when BuilderID is set
 	BuilderID                *int
+	NoDefaultBuilderFee      bool  // Leaves BuilderFee unset when BuilderID is set without it
+	Clock                    Clock // Defaults to SystemClock
 }
 
 // CreateOrderObject creates a PerpetualOrderModel with the given parameters
 func CreateOrderObject(params CreateOrderObjectParams) (*PerpetualOrderModel, error) {
+	return CreateOrderObjectContext(context.Background(), params)
+}
+
+// CreateOrderObjectContext is CreateOrderObject returning ctx.Err() as soon
+// as ctx is done: before a nonce is consumed and between signatures, so that
+// no work is spent on an order that will not be sent
+func CreateOrderObjectContext(ctx context.Context, params CreateOrderObjectParams) (*PerpetualOrderModel, error) {
 	market := params.Market
 
+	// Reject inconsistent options before a nonce is consumed or anything is signed
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if params.Type == "" {
+		params.Type = OrderTypeLimit
+	}
+
 	if params.ExpireTime == nil {
-		cur := time.Now().Add(1 * time.Hour)
+		cur := clockOrDefault(params.Clock).Now().Add(1 * time.Hour)
 		params.ExpireTime = &cur
 	}
 
-	// Error if nonce is nil, we keep the input as a pointer so that
-	// it is the same as the input to the function
+	// Error if nonce is nil and cannot be generated, we keep the input as a
+	// pointer so that it is the same as the input to the function
+	if params.Nonce == nil && params.NonceGenerator != nil {
+		nonce, err := params.NonceGenerator.NextNonce()
+		if err != nil {
+			return nil, fmt.Errorf("nonce generation failed: %w", err)
+		}
+		params.Nonce = &nonce
+	}
 	if params.Nonce == nil {
 		return nil, fmt.Errorf("nonce must be provided")
 	}
 
-	// If we are buying, then we round up, otherwise we round down
-	is_buying_synthetic := params.Side == OrderSideBuy
-	collateral_amount := params.SyntheticAmount.Mul(params.Price)
+	// Amounts are rounded to the market precision up front so that the signed
+	// amounts match the qty and price serialized in the order. Rounding never
+	// makes the order larger or its price worse than requested.
+	params.SyntheticAmount = roundOrderQty(market, params.SyntheticAmount)
+	params.Price = roundOrderPrice(market, params.Side, params.Price)
 
-	// For now we only use the default fee type
-	// TODO: Allow users to add different fee types
 	fees := DefaultFees
+	if params.Fees != nil {
+		fees = *params.Fees
+	}
+	if params.BuilderID != nil && params.BuilderFee == nil && !params.NoDefaultBuilderFee && fees.BuilderFeeRate.IsPositive() {
+		builderFee := fees.BuilderFeeRate
+		params.BuilderFee = &builderFee
+	}
+
+	settlement, order_hash, err := createSettlement(params, params.Side, params.SyntheticAmount, params.Price, fees.TakerFeeRate)
+	if err != nil {
+		return nil, err
+	}
+
+	// Take profit and stop loss legs close the position, so they are signed
+	// for the opposite side with the same quantity, nonce and expiry
+	closingSide := OrderSideSell
+	if params.Side == OrderSideSell {
+		closingSide = OrderSideBuy
+	}
+	if params.TakeProfit != nil || params.StopLoss != nil {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+	takeProfit, err := createTpSlTrigger(params, closingSide, params.TakeProfit, fees.TakerFeeRate)
+	if err != nil {
+		return nil, fmt.Errorf("take profit: %w", err)
+	}
+	if params.StopLoss != nil {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+	stopLoss, err := createTpSlTrigger(params, closingSide, params.StopLoss, fees.TakerFeeRate)
+	if err != nil {
+		return nil, fmt.Errorf("stop loss: %w", err)
+	}
+
+	if params.OrderExternalID == nil {
+		defaultID := order_hash
+		params.OrderExternalID = &defaultID
+	}
+
+	var fee_builder_str *string
+	if params.BuilderFee != nil {
+		builderFeeStr := formatPlain(*params.BuilderFee)
+		fee_builder_str = &builderFeeStr
+	}
+
+	// Convert expire time to epoch milliseconds
+	expiryEpochMillis := params.ExpireTime.UnixNano() / int64(time.Millisecond)
+
+	order := &PerpetualOrderModel{
+		ID:                       *params.OrderExternalID,
+		Market:                   params.Market.Name,
+		Type:                     params.Type,
+		Side:                     params.Side,
+		Qty:                      FormatQty(market, params.SyntheticAmount),
+		Price:                    FormatPrice(market, params.Price),
+		PostOnly:                 params.PostOnly,
+		ReduceOnly:               params.ReduceOnly,
+		TimeInForce:              params.TimeInForce,
+		ExpiryEpochMillis:        expiryEpochMillis,
+		Fee:                      formatPlain(fees.TakerFeeRate),
+		SelfTradeProtectionLevel: params.SelfTradeProtectionLevel,
+		Nonce:                    fmt.Sprintf("%d", *params.Nonce),
+		CancelID:                 params.PreviousOrderExternalID,
+		Settlement:               settlement,
+		Trigger:                  params.Trigger,
+		TpSlType:                 params.TpSlType,
+		TakeProfit:               takeProfit,
+		StopLoss:                 stopLoss,
+		BuilderFee:               fee_builder_str,
+		BuilderID:                params.BuilderID,
+	}
+
+	return order, nil
+}
+
+// CreateOrderObjects signs a batch of orders in turn. It stops at the first
+// failure, or as soon as ctx is done, and returns no orders then.
+func CreateOrderObjects(ctx context.Context, params []CreateOrderObjectParams) ([]*PerpetualOrderModel, error) {
+	orders := make([]*PerpetualOrderModel, 0, len(params))
+	for i, p := range params {
+		order, err := CreateOrderObjectContext(ctx, p)
+		if err != nil {
+			return nil, fmt.Errorf("order %d: %w", i, err)
+		}
+		orders = append(orders, order)
+	}
+	return orders, nil
+}
+
+// createSettlement signs the transfer of qty at price for the given side and
+// returns the settlement together with the order hash
+func createSettlement(params CreateOrderObjectParams, side OrderSide, qty, price, feeRate decimal.Decimal) (Settlement, string, error) {
+	market := params.Market
+
+	// If we are buying, then we round up, otherwise we round down
+	is_buying_synthetic := side == OrderSideBuy
+	collateral_amount := qty.Mul(price)
 
-	total_fee := fees.TakerFeeRate
+	total_fee := feeRate
 	if params.BuilderFee != nil {
 		total_fee = total_fee.Add(*params.BuilderFee)
 	}
@@ -176,7 +281,7 @@ func CreateOrderObject(params CreateOrderObjectParams) (*PerpetualOrderModel, er
 	fee_amount := total_fee.Mul(collateral_amount)
 
 	stark_collateral_amount_dec := collateral_amount.Mul(decimal.NewFromInt(market.L2Config.CollateralResolution))
-	stark_synthetic_amount_dec := params.SyntheticAmount.Mul(decimal.NewFromInt(market.L2Config.SyntheticResolution))
+	stark_synthetic_amount_dec := qty.Mul(decimal.NewFromInt(market.L2Config.SyntheticResolution))
 
 	// Round accordingly
 	if is_buying_synthetic {
@@ -211,12 +316,12 @@ func CreateOrderObject(params CreateOrderObjectParams) (*PerpetualOrderModel, er
 	})
 
 	if err != nil {
-		return nil, fmt.Errorf("hashing order failed: %w", err)
+		return Settlement{}, "", fmt.Errorf("hashing order failed: %w", err)
 	}
 
 	sig_r, sig_s, err := params.Signer(order_hash)
 	if err != nil {
-		return nil, fmt.Errorf("signer function failed: %w", err)
+		return Settlement{}, "", fmt.Errorf("signer function failed: %w", err)
 	}
 
 	settlement := Settlement{
@@ -227,41 +332,41 @@ func CreateOrderObject(params CreateOrderObjectParams) (*PerpetualOrderModel, er
 		StarkKey:           params.Account.PublicKey(),
 		CollateralPosition: fmt.Sprintf("%d", params.Account.Vault()),
 	}
+	return settlement, order_hash, nil
+}
 
-	if params.OrderExternalID == nil {
-		defaultID := order_hash
-		params.OrderExternalID = &defaultID
-	}
-
-	var fee_builder_str *string
-	if params.BuilderFee != nil {
-		builderFeeStr := params.BuilderFee.String()
-		fee_builder_str = &builderFeeStr
+// roundOrderPrice rounds a price to the market precision in favour of the
+// side: buy prices are rounded down and sell prices up
+func roundOrderPrice(market MarketModel, side OrderSide, price decimal.Decimal) decimal.Decimal {
+	if side == OrderSideBuy {
+		return price.RoundFloor(market.PricePrecision())
 	}
+	return price.RoundCeil(market.PricePrecision())
+}
 
-	// Convert expire time to epoch milliseconds
-	expiryEpochMillis := params.ExpireTime.UnixNano() / int64(time.Millisecond)
+// roundOrderQty truncates a quantity to the market precision
+func roundOrderQty(market MarketModel, qty decimal.Decimal) decimal.Decimal {
+	return qty.RoundFloor(market.QtyPrecision())
+}
 
-	order := &PerpetualOrderModel{
-		ID:                       *params.OrderExternalID,
-		Market:                   params.Market.Name,
-		Type:                     OrderTypeLimit,
-		Side:                     params.Side,
-		Qty:                      params.SyntheticAmount.String(),
-		Price:                    params.Price.String(),
-		PostOnly:                 params.PostOnly,
-		TimeInForce:              params.TimeInForce,
-		ExpiryEpochMillis:        expiryEpochMillis,
-		Fee:                      fees.TakerFeeRate.String(),
-		SelfTradeProtectionLevel: params.SelfTradeProtectionLevel,
-		Nonce:                    fmt.Sprintf("%d", *params.Nonce),
-		CancelID:                 params.PreviousOrderExternalID,
-		Settlement:               settlement,
-		BuilderFee:               fee_builder_str,
-		BuilderID:                params.BuilderID,
+// createTpSlTrigger signs a take profit or stop loss leg, returning nil when
+// the leg is not requested
+func createTpSlTrigger(params CreateOrderObjectParams, side OrderSide, leg *TpSlParams, feeRate decimal.Decimal) (*TpSlTrigger, error) {
+	if leg == nil {
+		return nil, nil
 	}
-
-	return order, nil
+	price := roundOrderPrice(params.Market, side, leg.Price)
+	settlement, _, err := createSettlement(params, side, params.SyntheticAmount, price, feeRate)
+	if err != nil {
+		return nil, err
+	}
+	return &TpSlTrigger{
+		TriggerPrice:     FormatPrice(params.Market, leg.TriggerPrice),
+		TriggerPriceType: leg.TriggerPriceType,
+		Price:            FormatPrice(params.Market, price),
+		PriceType:        leg.PriceType,
+		Settlement:       settlement,
+	}, nil
 }
 
 // HashOrderParams represents the parameters for hashing an order
@@ -1,7 +1,10 @@
 package sdk
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"math/big"
 	"testing"
 	"time"
 
@@ -421,7 +424,384 @@ func (suite *OrdersTestSuite) TestExternalOrderID() {
 	suite.Equal(customOrderID, actualOrder["id"])
 }
 
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time { return time.Time(c) }
+
+func (fixedClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+func (suite *OrdersTestSuite) TestDefaultExpirationUsesClock() {
+	params := CreateOrderObjectParams{
+		Market:                   suite.market,
+		Account:                  *suite.account,
+		SyntheticAmount:          decimal.RequireFromString("0.00100000"),
+		Price:                    decimal.RequireFromString("43445.11680000"),
+		Side:                     OrderSideSell,
+		Signer:                   suite.account.Sign,
+		StarknetDomain:           suite.starknetDomain,
+		TimeInForce:              TimeInForceGTT,
+		SelfTradeProtectionLevel: SelfTradeProtectionAccount,
+		Nonce:                    &suite.nonce,
+		Clock:                    fixedClock(suite.frozenTime),
+	}
+
+	order, err := CreateOrderObject(params)
+	suite.Require().NoError(err)
+	suite.Equal(suite.frozenTime.Add(1*time.Hour).UnixMilli(), order.ExpiryEpochMillis)
+
+	// The same clock reading must produce the same signed order
+	again, err := CreateOrderObject(params)
+	suite.Require().NoError(err)
+	suite.Equal(order.ID, again.ID)
+	suite.Equal(order.Settlement, again.Settlement)
+}
+
+func (suite *OrdersTestSuite) TestNonceFromGenerator() {
+	expiryTime := suite.frozenTime.Add(1 * time.Hour)
+	params := CreateOrderObjectParams{
+		Market:                   suite.market,
+		Account:                  *suite.account,
+		SyntheticAmount:          decimal.RequireFromString("0.00100000"),
+		Price:                    decimal.RequireFromString("43445.11680000"),
+		Side:                     OrderSideSell,
+		Signer:                   suite.account.Sign,
+		StarknetDomain:           suite.starknetDomain,
+		ExpireTime:               &expiryTime,
+		TimeInForce:              TimeInForceGTT,
+		SelfTradeProtectionLevel: SelfTradeProtectionAccount,
+		NonceGenerator:           NewUnixSecondsNonce(fixedClock(suite.frozenTime)),
+	}
+
+	order, err := CreateOrderObject(params)
+	suite.Require().NoError(err)
+	suite.Equal(fmt.Sprintf("%d", suite.frozenTime.Unix()), order.Nonce)
+
+	params.NonceGenerator = nil
+	_, err = CreateOrderObject(params)
+	suite.Error(err)
+}
+
+func (suite *OrdersTestSuite) TestCreateOrderObjectsStopsOnCancellation() {
+	expiryTime := suite.frozenTime.Add(1 * time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	signatures := 0
+	generator := &countingNonce{}
+	params := CreateOrderObjectParams{
+		Market:          suite.market,
+		Account:         *suite.account,
+		SyntheticAmount: decimal.RequireFromString("0.001"),
+		Price:           decimal.RequireFromString("43445"),
+		Side:            OrderSideBuy,
+		Signer: func(hash string) (*big.Int, *big.Int, error) {
+			signatures++
+			cancel()
+			return suite.account.Sign(hash)
+		},
+		StarknetDomain:           suite.starknetDomain,
+		ExpireTime:               &expiryTime,
+		TimeInForce:              TimeInForceGTT,
+		SelfTradeProtectionLevel: SelfTradeProtectionAccount,
+		NonceGenerator:           generator,
+	}
+
+	orders, err := CreateOrderObjects(ctx, []CreateOrderObjectParams{params, params, params})
+	suite.ErrorIs(err, context.Canceled)
+	suite.Nil(orders)
+	suite.Equal(1, signatures, "signing stops once the context is cancelled")
+	suite.Equal(1, generator.calls, "no nonce is consumed after cancellation")
+
+	_, err = CreateOrderObjectContext(ctx, params)
+	suite.ErrorIs(err, context.Canceled)
+	suite.Equal(1, signatures)
+
+	orders, err = CreateOrderObjects(context.Background(), []CreateOrderObjectParams{params, params})
+	suite.Require().NoError(err)
+	suite.Len(orders, 2)
+	suite.NotEqual(orders[0].Nonce, orders[1].Nonce)
+}
+
+func (suite *OrdersTestSuite) TestRoundingFavoursTheCaller() {
+	expiryTime := suite.frozenTime.Add(1 * time.Hour)
+	params := CreateOrderObjectParams{
+		Market:                   suite.market,
+		Account:                  *suite.account,
+		SyntheticAmount:          decimal.RequireFromString("0.000123459"),
+		Price:                    decimal.RequireFromString("0.1234561"),
+		Side:                     OrderSideSell,
+		Signer:                   suite.account.Sign,
+		StarknetDomain:           suite.starknetDomain,
+		ExpireTime:               &expiryTime,
+		TimeInForce:              TimeInForceGTT,
+		SelfTradeProtectionLevel: SelfTradeProtectionAccount,
+		Nonce:                    &suite.nonce,
+	}
+
+	// Sells are never priced lower than asked, and no order grows
+	order, err := CreateOrderObject(params)
+	suite.Require().NoError(err)
+	suite.Equal("0.00012345", order.Qty)
+	suite.Equal("0.123457", order.Price)
+
+	params.Side = OrderSideBuy
+	params.Price = decimal.RequireFromString("0.1234569")
+	order, err = CreateOrderObject(params)
+	suite.Require().NoError(err)
+	suite.Equal("0.00012345", order.Qty)
+	suite.Equal("0.123456", order.Price)
+}
+
+func (suite *OrdersTestSuite) TestAmountsSerializedWithoutExponent() {
+	expiryTime := suite.frozenTime.Add(1 * time.Hour)
+	tests := []struct {
+		name  string
+		qty   decimal.Decimal
+		price decimal.Decimal
+		want  [2]string
+	}{
+		{"tiny qty", decimal.New(1, -7), decimal.RequireFromString("43445.1168"), [2]string{"0.0000001", "43445.1168"}},
+		{"qty beyond precision", decimal.New(123456789, -12), decimal.NewFromInt(1), [2]string{"0.00012345", "1"}},
+		{"large price", decimal.NewFromInt(1), decimal.New(5, 7), [2]string{"1", "50000000"}},
+		{"price beyond precision", decimal.NewFromInt(1), decimal.RequireFromString("0.12345678"), [2]string{"1", "0.123456"}},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			params := CreateOrderObjectParams{
+				Market:                   suite.market,
+				Account:                  *suite.account,
+				SyntheticAmount:          tt.qty,
+				Price:                    tt.price,
+				Side:                     OrderSideBuy,
+				Signer:                   suite.account.Sign,
+				StarknetDomain:           suite.starknetDomain,
+				ExpireTime:               &expiryTime,
+				TimeInForce:              TimeInForceGTT,
+				SelfTradeProtectionLevel: SelfTradeProtectionAccount,
+				Nonce:                    &suite.nonce,
+			}
+
+			order, err := CreateOrderObject(params)
+			suite.Require().NoError(err)
+			suite.Equal(tt.want[0], order.Qty)
+			suite.Equal(tt.want[1], order.Price)
+			suite.NotContains(order.Fee, "e")
+			suite.NotContains(order.Fee, "E")
+
+			// The signed amounts must be computed from the serialized values
+			params.SyntheticAmount = decimal.RequireFromString(order.Qty)
+			params.Price = decimal.RequireFromString(order.Price)
+			again, err := CreateOrderObject(params)
+			suite.Require().NoError(err)
+			suite.Equal(order.Settlement, again.Settlement)
+		})
+	}
+}
+
+func (suite *OrdersTestSuite) TestTpSlLegsAndFees() {
+	expiryTime := suite.frozenTime.Add(1 * time.Hour)
+	tpSlType := TpSlTypeOrder
+	fees := TradingFeeModel{Market: "BTC-USD", TakerFeeRate: decimal.RequireFromString("0.0002")}
+	params := CreateOrderObjectParams{
+		Market:                   suite.market,
+		Account:                  *suite.account,
+		SyntheticAmount:          decimal.RequireFromString("0.001"),
+		Price:                    decimal.RequireFromString("43445"),
+		Side:                     OrderSideBuy,
+		Signer:                   suite.account.Sign,
+		StarknetDomain:           suite.starknetDomain,
+		ExpireTime:               &expiryTime,
+		TimeInForce:              TimeInForceGTT,
+		SelfTradeProtectionLevel: SelfTradeProtectionAccount,
+		Nonce:                    &suite.nonce,
+		ReduceOnly:               true,
+		TpSlType:                 &tpSlType,
+		TakeProfit: &TpSlParams{
+			TriggerPrice:     decimal.RequireFromString("45000"),
+			TriggerPriceType: TriggerPriceTypeMark,
+			Price:            decimal.RequireFromString("44990.1234567"),
+			PriceType:        ExecutionPriceTypeLimit,
+		},
+		StopLoss: &TpSlParams{
+			TriggerPrice:     decimal.RequireFromString("42000"),
+			TriggerPriceType: TriggerPriceTypeLast,
+			Price:            decimal.RequireFromString("41900"),
+			PriceType:        ExecutionPriceTypeMarket,
+		},
+		Fees: &fees,
+	}
+
+	order, err := CreateOrderObject(params)
+	suite.Require().NoError(err)
+	suite.Equal("0.0002", order.Fee)
+	suite.True(order.ReduceOnly)
+	suite.Equal(OrderTypeLimit, order.Type)
+	suite.Require().NotNil(order.TpSlType)
+	suite.Equal(TpSlTypeOrder, *order.TpSlType)
+
+	suite.Require().NotNil(order.TakeProfit)
+	suite.Equal("45000", order.TakeProfit.TriggerPrice)
+	suite.Equal("44990.123457", order.TakeProfit.Price)
+	suite.Equal(TriggerPriceTypeMark, order.TakeProfit.TriggerPriceType)
+	suite.Require().NotNil(order.StopLoss)
+	suite.Equal(ExecutionPriceTypeMarket, order.StopLoss.PriceType)
+
+	// Each leg carries its own signature, distinct from the entry order
+	suite.NotEmpty(order.TakeProfit.Settlement.Signature.R)
+	suite.NotEqual(order.Settlement.Signature, order.TakeProfit.Settlement.Signature)
+	suite.NotEqual(order.TakeProfit.Settlement.Signature, order.StopLoss.Settlement.Signature)
+
+	// Without the legs the entry settlement is unchanged
+	params.TakeProfit, params.StopLoss, params.TpSlType = nil, nil, nil
+	plain, err := CreateOrderObject(params)
+	suite.Require().NoError(err)
+	suite.Equal(order.Settlement, plain.Settlement)
+}
+
+func (suite *OrdersTestSuite) TestBuilderFeeDefaultsToFeeModel() {
+	expiryTime := suite.frozenTime.Add(1 * time.Hour)
+	builderID := 7
+	fees := TradingFeeModel{Market: "BTC-USD", TakerFeeRate: decimal.RequireFromString("0.0005"), BuilderFeeRate: decimal.RequireFromString("0.0001")}
+	params := CreateOrderObjectParams{
+		Market:                   suite.market,
+		Account:                  *suite.account,
+		SyntheticAmount:          decimal.RequireFromString("0.001"),
+		Price:                    decimal.RequireFromString("43445"),
+		Side:                     OrderSideBuy,
+		Signer:                   suite.account.Sign,
+		StarknetDomain:           suite.starknetDomain,
+		ExpireTime:               &expiryTime,
+		TimeInForce:              TimeInForceGTT,
+		SelfTradeProtectionLevel: SelfTradeProtectionAccount,
+		Nonce:                    &suite.nonce,
+		Fees:                     &fees,
+		BuilderID:                &builderID,
+	}
+
+	order, err := CreateOrderObject(params)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(order.BuilderFee)
+	suite.Equal("0.0001", *order.BuilderFee)
+
+	explicitFee := decimal.RequireFromString("0.0001")
+	params.BuilderFee = &explicitFee
+	explicit, err := CreateOrderObject(params)
+	suite.Require().NoError(err)
+	suite.Equal(order.Settlement, explicit.Settlement, "the default is signed like an explicit fee")
+
+	params.BuilderFee, params.NoDefaultBuilderFee = nil, true
+	order, err = CreateOrderObject(params)
+	suite.Require().NoError(err)
+	suite.Nil(order.BuilderFee)
+}
+
+func (suite *OrdersTestSuite) TestNewOCOOrders() {
+	entryID := "entry"
+	params := CreateOrderObjectParams{
+		Market:                   suite.market,
+		Account:                  *suite.account,
+		SyntheticAmount:          decimal.RequireFromString("0.001"),
+		Price:                    decimal.RequireFromString("43445"),
+		Side:                     OrderSideBuy,
+		Signer:                   suite.account.Sign,
+		StarknetDomain:           suite.starknetDomain,
+		TimeInForce:              TimeInForceGTT,
+		SelfTradeProtectionLevel: SelfTradeProtectionAccount,
+		OrderExternalID:          &entryID,
+		NonceGenerator:           &countingNonce{},
+		Clock:                    fixedClock(suite.frozenTime),
+	}
+
+	orders, err := NewOCOOrders(params, decimal.RequireFromString("45000"), decimal.RequireFromString("42000"))
+	suite.Require().NoError(err)
+
+	suite.Equal("entry", orders.Entry.ID)
+	suite.False(orders.Entry.ReduceOnly)
+
+	tp := orders.TakeProfit
+	suite.Equal("entry-tp", tp.ID)
+	suite.Equal(OrderSideSell, tp.Side)
+	suite.Equal(OrderTypeLimit, tp.Type)
+	suite.Equal("45000", tp.Price)
+	suite.True(tp.ReduceOnly)
+
+	sl := orders.StopLoss
+	suite.Equal("entry-sl", sl.ID)
+	suite.Equal(OrderTypeConditional, sl.Type)
+	suite.Require().NotNil(sl.Trigger)
+	suite.Equal("42000", sl.Trigger.TriggerPrice)
+	suite.Equal(TriggerDirectionDown, sl.Trigger.Direction)
+	suite.True(sl.ReduceOnly)
+
+	// Every order is signed with its own nonce and the same expiry
+	suite.ElementsMatch([]string{"1", "2", "3"}, []string{orders.Entry.Nonce, tp.Nonce, sl.Nonce})
+	suite.Equal(orders.Entry.ExpiryEpochMillis, sl.ExpiryEpochMillis)
+
+	params.NonceGenerator = nil
+	_, err = NewOCOOrders(params, decimal.RequireFromString("45000"), decimal.RequireFromString("42000"))
+	suite.ErrorIs(err, ErrInvalidOrder)
+}
+
 // TestOrdersTestSuite runs the test suite
 func TestOrdersTestSuite(t *testing.T) {
 	suite.Run(t, new(OrdersTestSuite))
 }
+
+// BenchmarkPlaceOrderBuild measures building an order as PlaceOrder does:
+// hashing, signing and serializing the payload. Compare runs across changes
+// with benchstat, e.g. go test -run '^$' -bench PlaceOrderBuild -count 10.
+func BenchmarkPlaceOrderBuild(b *testing.B) {
+	account, err := createTestAccount()
+	if err != nil {
+		b.Fatal(err)
+	}
+	expire := createTestFrozenTime().Add(time.Hour)
+	externalID := "bench"
+	order := TpSlTypeOrder
+	base := CreateOrderObjectParams{
+		Market:                   createTestBTCUSDMarket(),
+		Account:                  *account,
+		SyntheticAmount:          decimal.RequireFromString("0.001"),
+		Price:                    decimal.RequireFromString("43445.1168"),
+		Side:                     OrderSideBuy,
+		Signer:                   account.Sign,
+		StarknetDomain:           createTestStarknetDomain(),
+		ExpireTime:               &expire,
+		OrderExternalID:          &externalID,
+		TimeInForce:              TimeInForceGTT,
+		SelfTradeProtectionLevel: SelfTradeProtectionAccount,
+		NonceGenerator:           NewUnixNanosNonce(nil),
+		Clock:                    fixedClock(createTestFrozenTime()),
+	}
+	withTpSl := base
+	withTpSl.TpSlType = &order
+	withTpSl.TakeProfit = &TpSlParams{
+		TriggerPrice: decimal.RequireFromString("45000"), TriggerPriceType: TriggerPriceTypeMark,
+		Price: decimal.RequireFromString("45000"), PriceType: ExecutionPriceTypeLimit,
+	}
+	withTpSl.StopLoss = &TpSlParams{
+		TriggerPrice: decimal.RequireFromString("42000"), TriggerPriceType: TriggerPriceTypeMark,
+		Price: decimal.RequireFromString("41900"), PriceType: ExecutionPriceTypeLimit,
+	}
+
+	for _, bc := range []struct {
+		name   string
+		params CreateOrderObjectParams
+	}{
+		{"limit", base},
+		{"limit with TP/SL", withTpSl},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				order, err := CreateOrderObject(bc.params)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if order.Payload() == nil {
+					b.Fatal("order cannot be marshalled")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// PlaceOrder signs an order from params and submits it. With WithReduceOnlyCap
+// the quantity of reduce-only orders is capped by CapReduceOnly first, so the
+// signed quantity may be lower than requested; the returned order carries the
+// quantity that was submitted. Options opt in to remediation of rejections,
+// see WithSnapToTick, WithShrinkOnInsufficientFunds and
+// WithRepriceOnPostOnlyFailed, and to pre-trade checks, see WithLossGuard,
+// WithMaxImpactBps and WithSelfTradeCheck. A remediated order is signed
+// again with a fresh nonce, and a given OrderExternalID is suffixed with
+// "-r1" for the first resubmission, "-r2" for the second and so on, since
+// the rejected order keeps it. Orders without Nonce or
+// NonceGenerator draw their nonce from the client, see NextNonce, so that
+// goroutines placing orders concurrently never share one.
+func (c *APIClient) PlaceOrder(ctx context.Context, params CreateOrderObjectParams, opts ...PlaceOrderOption) (*PerpetualOrderModel, *OrderResponse, error) {
+	var o placeOrderOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	externalID := params.OrderExternalID
+	remediated := make(map[OrderStatusReason]bool)
+	for {
+		order, resp, err := c.placeOrder(ctx, params, o)
+		if err == nil {
+			return order, resp, nil
+		}
+		var apiErr *APIError
+		if order == nil || !errors.As(err, &apiErr) {
+			return order, nil, err
+		}
+		reason, ok := apiErr.Reason()
+		if !ok || remediated[reason] {
+			return order, nil, err
+		}
+		next, event, ok := o.remediate(reason, params)
+		if !ok {
+			return order, nil, err
+		}
+		remediated[reason] = true
+		event.ExternalID = order.ID
+		if o.onRemediation != nil {
+			o.onRemediation(event)
+		}
+		if externalID != nil {
+			// The rejected order holds the external ID
+			id := fmt.Sprintf("%s-r%d", *externalID, len(remediated))
+			next.OrderExternalID = &id
+		}
+		params = next
+	}
+}
+
+func (c *APIClient) placeOrder(ctx context.Context, params CreateOrderObjectParams, o placeOrderOptions) (*PerpetualOrderModel, *OrderResponse, error) {
+	if err := c.AuthError(); err != nil {
+		return nil, nil, err
+	}
+	if o.lossGuard != nil {
+		if err := o.lossGuard.Check(params); err != nil {
+			return nil, nil, err
+		}
+	}
+	if c.capReduceOnly {
+		capped, err := c.CapReduceOnly(ctx, params)
+		if err != nil {
+			return nil, nil, err
+		}
+		params = capped
+	}
+	if params.Nonce == nil && params.NonceGenerator == nil {
+		params.NonceGenerator = c.BaseModule
+	}
+	if params.Fees == nil && !c.manualFees {
+		if fees, ok := c.fees.lookup(params.Market.Name); ok {
+			params.Fees = &fees
+		}
+	}
+	if err := c.checkImpact(ctx, params, o); err != nil {
+		return nil, nil, err
+	}
+	order, err := CreateOrderObjectContext(ctx, params)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := c.checkSelfTrade(ctx, order, o); err != nil {
+		return nil, nil, err
+	}
+	resp, err := c.SubmitOrder(ctx, order)
+	if err != nil {
+		return order, nil, err
+	}
+	return order, resp, nil
+}
+
+// PlaceOrderOption customises a PlaceOrder call
+type PlaceOrderOption func(*placeOrderOptions)
+
+type placeOrderOptions struct {
+	snapToTick      bool
+	shrinkBy        decimal.Decimal
+	repricePostOnly bool
+	onRemediation   func(RemediationEvent)
+
+	lossGuard    *LossGuard
+	maxImpactBps *decimal.Decimal
+	impactBooks  []OrderbookSource
+
+	selfTradeCheck  bool
+	selfTradeAction SelfTradeAction
+	onSelfTrade     func(SelfTradeConflict)
+}
+
+// RemediationEvent describes how a rejected order was changed before it was
+// signed and sent again
+type RemediationEvent struct {
+	// ExternalID of the rejected order
+	ExternalID string
+	Reason     OrderStatusReason
+	// Field is the changed order parameter, "price" or "qty"
+	Field    string
+	Previous decimal.Decimal
+	Current  decimal.Decimal
+}
+
+// WithSnapToTick resends an order rejected with INVALID_PRICE once, with the
+// price rounded to the nearest multiple of the market tick size
+func WithSnapToTick() PlaceOrderOption {
+	return func(o *placeOrderOptions) {
+		o.snapToTick = true
+	}
+}
+
+// WithShrinkOnInsufficientFunds resends an order rejected with
+// NOT_ENOUGH_FUNDS once, with the quantity reduced by fraction, e.g. 0.1 to
+// retry with 90% of the size
+func WithShrinkOnInsufficientFunds(fraction decimal.Decimal) PlaceOrderOption {
+	return func(o *placeOrderOptions) {
+		o.shrinkBy = fraction
+	}
+}
+
+// WithRepriceOnPostOnlyFailed resends a post-only order rejected with
+// POST_ONLY_FAILED once, priced one tick further from the book: one tick
+// lower for buys and one tick higher for sells
+func WithRepriceOnPostOnlyFailed() PlaceOrderOption {
+	return func(o *placeOrderOptions) {
+		o.repricePostOnly = true
+	}
+}
+
+// WithRemediationHandler calls fn for every change made to a rejected order
+// before it is sent again
+func WithRemediationHandler(fn func(RemediationEvent)) PlaceOrderOption {
+	return func(o *placeOrderOptions) {
+		o.onRemediation = fn
+	}
+}
+
+// remediate returns params changed to address the rejection reason, if an
+// enabled policy applies to it
+func (o placeOrderOptions) remediate(reason OrderStatusReason, params CreateOrderObjectParams) (CreateOrderObjectParams, RemediationEvent, bool) {
+	tick := params.Market.TradingConfig.MinPriceChange
+	event := RemediationEvent{Reason: reason}
+
+	switch {
+	case reason == OrderStatusReasonInvalidPrice && o.snapToTick && tick.IsPositive():
+		snapped := params.Price.Div(tick).Round(0).Mul(tick)
+		if snapped.Equal(params.Price) || !snapped.IsPositive() {
+			return params, event, false
+		}
+		event.Field, event.Previous, event.Current = "price", params.Price, snapped
+		params.Price = snapped
+
+	case reason == OrderStatusReasonNotEnoughFunds && o.shrinkBy.IsPositive() && o.shrinkBy.LessThan(decimal.NewFromInt(1)):
+		shrunk := params.SyntheticAmount.Mul(decimal.NewFromInt(1).Sub(o.shrinkBy))
+		if step := params.Market.TradingConfig.MinOrderSizeChange; step.IsPositive() {
+			shrunk = shrunk.Div(step).Floor().Mul(step)
+		} else {
+			shrunk = shrunk.RoundFloor(params.Market.QtyPrecision())
+		}
+		if !shrunk.IsPositive() {
+			return params, event, false
+		}
+		event.Field, event.Previous, event.Current = "qty", params.SyntheticAmount, shrunk
+		params.SyntheticAmount = shrunk
+
+	case reason == OrderStatusReasonPostOnlyFailed && o.repricePostOnly && tick.IsPositive():
+		repriced := params.Price.Add(tick)
+		if params.Side == OrderSideBuy {
+			repriced = params.Price.Sub(tick)
+		}
+		if !repriced.IsPositive() {
+			return params, event, false
+		}
+		event.Field, event.Previous, event.Current = "price", params.Price, repriced
+		params.Price = repriced
+
+	default:
+		return params, event, false
+	}
+
+	// A fresh nonce keeps the new signature distinct from the rejected one;
+	// without a generator placeOrder draws it from the client
+	params.Nonce = nil
+	return params, event, true
+}
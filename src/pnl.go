@@ -0,0 +1,198 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/extended-protocol/extended-sdk-golang/src/internal/fanout"
+	"github.com/shopspring/decimal"
+)
+
+// PositionPnL holds the locally recomputed PnL of a single position
+type PositionPnL struct {
+	Market        string
+	Side          PositionSide
+	Size          decimal.Decimal
+	OpenPrice     decimal.Decimal
+	MarkPrice     decimal.Decimal
+	Value         decimal.Decimal
+	UnrealisedPnl decimal.Decimal
+}
+
+// PnLSnapshot is a point-in-time view of the account PnL and equity
+type PnLSnapshot struct {
+	Balance       decimal.Decimal
+	Equity        decimal.Decimal
+	UnrealisedPnl decimal.Decimal
+	Positions     map[string]PositionPnL
+	// AsOf is the latest version among the cached balance and positions
+	AsOf ConsistencyToken
+}
+
+// PnLTracker recomputes unrealised PnL and equity locally from mark prices.
+// Positions and balance are cached from the last REST refresh, mark prices are
+// pushed in as they arrive so the snapshot stays current between refreshes.
+type PnLTracker struct {
+	mu         sync.RWMutex
+	balance    decimal.Decimal
+	balanceAt  ConsistencyToken
+	positions  map[string]PositionModel
+	markPrices map[string]markPrice
+}
+
+// markPrice is a mark price with the time it was current at
+type markPrice struct {
+	price decimal.Decimal
+	at    time.Time
+}
+
+// NewPnLTracker creates an empty tracker
+func NewPnLTracker() *PnLTracker {
+	return &PnLTracker{
+		positions:  make(map[string]PositionModel),
+		markPrices: make(map[string]markPrice),
+	}
+}
+
+// Refresh reloads balance and positions from the REST API
+func (t *PnLTracker) Refresh(ctx context.Context, client *APIClient) error {
+	var (
+		balance   *BalanceModel
+		positions []PositionModel
+	)
+	err := fanout.Run(ctx, 2, 2, func(ctx context.Context, i int) error {
+		var err error
+		if i == 0 {
+			if balance, err = client.GetBalance(ctx); err != nil {
+				return fmt.Errorf("failed to refresh balance: %w", err)
+			}
+			return nil
+		}
+		if positions, err = client.GetPositions(ctx, nil); err != nil {
+			return fmt.Errorf("failed to refresh positions: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	t.SetBalance(*balance)
+	t.SetPositions(positions)
+	return nil
+}
+
+// SetBalance caches the wallet balance, which excludes unrealised PnL
+func (t *PnLTracker) SetBalance(balance BalanceModel) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.balance = balance.Balance
+	t.balanceAt = balance.ConsistencyToken()
+}
+
+// SetPositions replaces the cached positions. The mark price reported by a
+// position, as of its UpdatedAt, replaces the cached one when it is newer.
+// Mark prices of markets without a position are dropped.
+func (t *PnLTracker) SetPositions(positions []PositionModel) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.positions = make(map[string]PositionModel, len(positions))
+	for _, p := range positions {
+		t.positions[p.Market] = p
+		at := p.ConsistencyToken().Time()
+		if cached, ok := t.markPrices[p.Market]; (!ok || at.After(cached.at)) && !p.MarkPrice.IsZero() {
+			t.markPrices[p.Market] = markPrice{price: p.MarkPrice, at: at}
+		}
+	}
+	for market := range t.markPrices {
+		if _, ok := t.positions[market]; !ok {
+			delete(t.markPrices, market)
+		}
+	}
+}
+
+// UpdateMarkPrice records the latest mark price for a market. The price is
+// dated as of the cached mark it replaces, never by the local clock, so that
+// all marks are dated by the exchange: a refresh reporting a newer UpdatedAt
+// replaces it. Use UpdateMarkPriceAt when the exchange time of the price is
+// known.
+func (t *PnLTracker) UpdateMarkPrice(market string, price decimal.Decimal) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.markPrices[market] = markPrice{price: price, at: t.markPrices[market].at}
+}
+
+// UpdateMarkPriceAt records the mark price of a market as of at, the
+// exchange time of the price, e.g. of a mark price stream event, unless a
+// newer one is cached
+func (t *PnLTracker) UpdateMarkPriceAt(market string, price decimal.Decimal, at time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if cached, ok := t.markPrices[market]; ok && cached.at.After(at) {
+		return
+	}
+	t.markPrices[market] = markPrice{price: price, at: at}
+}
+
+// UnrealisedPnl returns the recomputed unrealised PnL for a market.
+// The boolean is false if there is no cached position for the market.
+func (t *PnLTracker) UnrealisedPnl(market string) (decimal.Decimal, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	p, ok := t.positions[market]
+	if !ok {
+		return decimal.Zero, false
+	}
+	return t.positionPnL(p).UnrealisedPnl, true
+}
+
+// Snapshot recomputes PnL for every cached position and the resulting equity
+func (t *PnLTracker) Snapshot() PnLSnapshot {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	snapshot := PnLSnapshot{
+		Balance:       t.balance,
+		UnrealisedPnl: decimal.Zero,
+		Positions:     make(map[string]PositionPnL, len(t.positions)),
+		AsOf:          t.balanceAt,
+	}
+	for market, p := range t.positions {
+		if p.ConsistencyToken().Newer(snapshot.AsOf) {
+			snapshot.AsOf = p.ConsistencyToken()
+		}
+		pnl := t.positionPnL(p)
+		snapshot.Positions[market] = pnl
+		snapshot.UnrealisedPnl = snapshot.UnrealisedPnl.Add(pnl.UnrealisedPnl)
+	}
+	snapshot.Equity = snapshot.Balance.Add(snapshot.UnrealisedPnl)
+	return snapshot
+}
+
+func (t *PnLTracker) positionPnL(p PositionModel) PositionPnL {
+	mark := p.MarkPrice
+	if cached, ok := t.markPrices[p.Market]; ok {
+		mark = cached.price
+	}
+	return PositionPnL{
+		Market:        p.Market,
+		Side:          p.Side,
+		Size:          p.Size,
+		OpenPrice:     p.OpenPrice,
+		MarkPrice:     mark,
+		Value:         p.Size.Mul(mark),
+		UnrealisedPnl: UnrealisedPnl(p.Side, p.Size, p.OpenPrice, mark),
+	}
+}
+
+// UnrealisedPnl computes the PnL of a position of the given side and
+// absolute size opened at openPrice and valued at markPrice
+func UnrealisedPnl(side PositionSide, size, openPrice, markPrice decimal.Decimal) decimal.Decimal {
+	pnl := size.Abs().Mul(markPrice.Sub(openPrice))
+	if side == PositionSideShort {
+		return pnl.Neg()
+	}
+	return pnl
+}
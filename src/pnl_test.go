@@ -0,0 +1,125 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnrealisedPnl(t *testing.T) {
+	size := decimal.RequireFromString("2")
+	open := decimal.RequireFromString("100")
+	mark := decimal.RequireFromString("110")
+
+	assert.Equal(t, "20", UnrealisedPnl(PositionSideLong, size, open, mark).String())
+	assert.Equal(t, "-20", UnrealisedPnl(PositionSideShort, size, open, mark).String())
+}
+
+func TestPnLTracker_UpdateMarkPrice(t *testing.T) {
+	tracker := NewPnLTracker()
+	tracker.SetBalance(BalanceModel{Balance: decimal.RequireFromString("1000")})
+	tracker.SetPositions([]PositionModel{
+		{
+			Market:    "BTC-USD",
+			Side:      PositionSideLong,
+			Size:      decimal.RequireFromString("0.5"),
+			OpenPrice: decimal.RequireFromString("40000"),
+			MarkPrice: decimal.RequireFromString("40000"),
+		},
+		{
+			Market:    "ETH-USD",
+			Side:      PositionSideShort,
+			Size:      decimal.RequireFromString("2"),
+			OpenPrice: decimal.RequireFromString("2000"),
+			MarkPrice: decimal.RequireFromString("2000"),
+		},
+	})
+
+	snapshot := tracker.Snapshot()
+	assert.True(t, snapshot.UnrealisedPnl.IsZero())
+	assert.Equal(t, "1000", snapshot.Equity.String())
+
+	tracker.UpdateMarkPrice("BTC-USD", decimal.RequireFromString("41000"))
+	tracker.UpdateMarkPrice("ETH-USD", decimal.RequireFromString("2100"))
+
+	pnl, ok := tracker.UnrealisedPnl("BTC-USD")
+	require.True(t, ok)
+	assert.Equal(t, "500", pnl.String())
+
+	snapshot = tracker.Snapshot()
+	assert.Equal(t, "300", snapshot.UnrealisedPnl.String())
+	assert.Equal(t, "1300", snapshot.Equity.String())
+	assert.Equal(t, "4200", snapshot.Positions["ETH-USD"].Value.String())
+
+	_, ok = tracker.UnrealisedPnl("SOL-USD")
+	assert.False(t, ok)
+}
+
+func TestPnLTracker_SetPositionsKeepsNewerMark(t *testing.T) {
+	tracker := NewPnLTracker()
+	at := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	position := func(updated time.Time, mark string) []PositionModel {
+		return []PositionModel{{
+			Market:    "BTC-USD",
+			Side:      PositionSideLong,
+			Size:      decimal.RequireFromString("1"),
+			OpenPrice: decimal.RequireFromString("100"),
+			MarkPrice: decimal.RequireFromString(mark),
+			UpdatedAt: updated.UnixMilli(),
+		}}
+	}
+	mark := func() string { return tracker.Snapshot().Positions["BTC-USD"].MarkPrice.String() }
+
+	tracker.SetPositions(position(at, "110"))
+	assert.Equal(t, "110", mark())
+
+	tracker.UpdateMarkPriceAt("BTC-USD", decimal.RequireFromString("120"), at.Add(time.Minute))
+	tracker.SetPositions(position(at.Add(time.Second), "111"))
+	assert.Equal(t, "120", mark(), "an older refresh keeps the pushed mark")
+
+	tracker.SetPositions(position(at.Add(2*time.Minute), "130"))
+	assert.Equal(t, "130", mark(), "a newer refresh replaces the pushed mark")
+
+	tracker.UpdateMarkPriceAt("BTC-USD", decimal.RequireFromString("125"), at.Add(90*time.Second))
+	assert.Equal(t, "130", mark(), "late pushes are ignored")
+
+	// Undated pushes are dated as of the cached mark, whatever the local clock
+	tracker.UpdateMarkPrice("BTC-USD", decimal.RequireFromString("135"))
+	tracker.SetPositions(position(at.Add(2*time.Minute), "130"))
+	assert.Equal(t, "135", mark(), "a refresh as old as the pushed mark keeps it")
+	tracker.SetPositions(position(at.Add(3*time.Minute), "140"))
+	assert.Equal(t, "140", mark(), "a newer refresh replaces the pushed mark")
+
+	tracker.SetPositions(nil)
+	tracker.SetPositions(position(time.Time{}, "90"))
+	assert.Equal(t, "90", mark(), "the mark of a closed position is dropped")
+}
+
+func TestPnLTracker_Refresh(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/user/balance":
+			w.Write([]byte(`{"status":"OK","data":{"collateralName":"USD","balance":"500","equity":"510"}}`))
+		case "/user/positions":
+			w.Write([]byte(`{"status":"OK","data":[{"market":"BTC-USD","side":"LONG","size":"1","openPrice":"100","markPrice":"110"}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(EndpointConfig{APIBaseURL: server.URL}, "key", nil, 5*time.Second)
+	tracker := NewPnLTracker()
+
+	require.NoError(t, tracker.Refresh(context.Background(), client))
+
+	snapshot := tracker.Snapshot()
+	assert.Equal(t, "10", snapshot.UnrealisedPnl.String())
+	assert.Equal(t, "510", snapshot.Equity.String())
+}
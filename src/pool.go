@@ -0,0 +1,180 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/extended-protocol/extended-sdk-golang/src/internal/fanout"
+)
+
+// PoolConfig configures a ClientPool
+type PoolConfig struct {
+	Endpoint EndpointConfig
+	// Timeout bounds every request; defaults to DefaultClientTimeout
+	Timeout time.Duration
+	// ConnectTimeout bounds establishing connections of the shared
+	// transport, see WithConnectTimeout
+	ConnectTimeout time.Duration
+	// OrderQueue, if set, gives every account its own order queue with this
+	// rate limit, see WithOrderQueue. It is ignored when Scheduler is set.
+	OrderQueue *OrderQueueConfig
+	// Scheduler, if set, sends the order traffic of all accounts through one
+	// PoolScheduler sharing this quota
+	Scheduler *SchedulerConfig
+	// Concurrency bounds the accounts processed at once by ForEach; defaults
+	// to 8
+	Concurrency int
+	// Options are applied to the client of every account
+	Options []ClientOption
+}
+
+// ClientPool manages the clients of many accounts, e.g. for a broker. The
+// clients share one HTTP transport, and so its connection pool, while rate
+// limits, nonces and health stay per account.
+type ClientPool struct {
+	cfg       PoolConfig
+	transport *http.Transport
+	scheduler *PoolScheduler
+
+	mu      sync.RWMutex
+	clients map[uint64]*APIClient
+}
+
+// NewClientPool creates an empty pool
+func NewClientPool(cfg PoolConfig) *ClientPool {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultClientTimeout
+	}
+	// The shared transport and the scheduler follow the options of the clients
+	var shared BaseModule
+	for _, opt := range cfg.Options {
+		opt(&shared)
+	}
+	transportCfg := shared.transportConfig()
+	transportCfg.connectTimeout = cfg.ConnectTimeout
+	transport := newTransport(transportCfg)
+	if transport == nil {
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	}
+	// Every account talks to the same host
+	transport.MaxIdleConnsPerHost = transport.MaxIdleConns
+	pool := &ClientPool{cfg: cfg, transport: transport, clients: make(map[uint64]*APIClient)}
+	if cfg.Scheduler != nil {
+		pool.scheduler = NewPoolScheduler(*cfg.Scheduler, shared.clock)
+	}
+	return pool
+}
+
+// Add creates the client of an account, keyed by its vault. Options are
+// applied after those of the pool.
+func (p *ClientPool) Add(account *StarkPerpetualAccount, opts ...ClientOption) (*APIClient, error) {
+	if account == nil {
+		return nil, ErrStarkAccountNotSet
+	}
+	clientOpts := append([]ClientOption{}, p.cfg.Options...)
+	clientOpts = append(clientOpts, withTransport(p.transport))
+	if p.cfg.OrderQueue != nil && p.scheduler == nil {
+		clientOpts = append(clientOpts, WithOrderQueue(*p.cfg.OrderQueue))
+	}
+	clientOpts = append(clientOpts, opts...)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.clients[account.Vault()]; ok {
+		return nil, fmt.Errorf("vault %d is already in the pool", account.Vault())
+	}
+	client := NewAPIClient(p.cfg.Endpoint, account.APIKey(), account, p.cfg.Timeout, clientOpts...)
+	if p.scheduler != nil {
+		client.orderQueue = p.scheduler.account(account.Vault(), client)
+	}
+	p.clients[account.Vault()] = client
+	return client, nil
+}
+
+// Client returns the client of a vault
+func (p *ClientPool) Client(vault uint64) (*APIClient, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	client, ok := p.clients[vault]
+	return client, ok
+}
+
+// Remove closes the client of a vault and drops it from the pool
+func (p *ClientPool) Remove(vault uint64) {
+	p.mu.Lock()
+	client, ok := p.clients[vault]
+	delete(p.clients, vault)
+	p.mu.Unlock()
+	if ok {
+		client.Close()
+	}
+}
+
+// Vaults returns the vaults in the pool in ascending order
+func (p *ClientPool) Vaults() []uint64 {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	vaults := make([]uint64, 0, len(p.clients))
+	for vault := range p.clients {
+		vaults = append(vaults, vault)
+	}
+	sort.Slice(vaults, func(i, j int) bool { return vaults[i] < vaults[j] })
+	return vaults
+}
+
+// ForEach calls fn for every account of the pool, with at most
+// PoolConfig.Concurrency calls in flight. A failing account does not stop
+// the others: the errors are returned joined, each prefixed with its vault.
+func (p *ClientPool) ForEach(ctx context.Context, fn func(ctx context.Context, vault uint64, client *APIClient) error) error {
+	vaults := p.Vaults()
+	errs := make([]error, len(vaults))
+	err := fanout.Run(ctx, len(vaults), p.cfg.Concurrency, func(ctx context.Context, i int) error {
+		client, ok := p.Client(vaults[i])
+		if !ok {
+			// Removed while iterating
+			return nil
+		}
+		if err := fn(ctx, vaults[i], client); err != nil {
+			errs[i] = fmt.Errorf("vault %d: %w", vaults[i], err)
+		}
+		return nil
+	})
+	return errors.Join(append(errs, err)...)
+}
+
+// Usage returns the order traffic of every account through the scheduler,
+// nil without PoolConfig.Scheduler
+func (p *ClientPool) Usage() []AccountUsage {
+	if p.scheduler == nil {
+		return nil
+	}
+	return p.scheduler.Usage()
+}
+
+// Close closes every client, the scheduler and the shared transport
+func (p *ClientPool) Close() {
+	p.mu.Lock()
+	clients := p.clients
+	p.clients = make(map[uint64]*APIClient)
+	p.mu.Unlock()
+	for _, client := range clients {
+		client.Close()
+	}
+	if p.scheduler != nil {
+		p.scheduler.Close()
+	}
+	p.transport.CloseIdleConnections()
+}
+
+// withTransport makes the client send requests through a transport shared
+// with other clients
+func withTransport(transport http.RoundTripper) ClientOption {
+	return func(m *BaseModule) {
+		m.transport = transport
+	}
+}
@@ -0,0 +1,97 @@
+package sdk
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// encodeQuery encodes the fields of a filter struct tagged `query:"name"` as
+// URL query parameters. Zero values are omitted. Slices add one parameter
+// per element, times are sent as Unix millis and pointers are dereferenced,
+// so a pointer to a zero value is still sent.
+func encodeQuery(filter any) (url.Values, error) {
+	values := url.Values{}
+	v := reflect.ValueOf(filter)
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return values, nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("query filter must be a struct, got %s", v.Kind())
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := t.Field(i).Tag.Get("query")
+		if name == "" || name == "-" {
+			continue
+		}
+		field := v.Field(i)
+		if field.Kind() == reflect.Pointer {
+			if field.IsNil() {
+				continue
+			}
+			field = field.Elem()
+		} else if field.IsZero() {
+			continue
+		}
+
+		if field.Kind() == reflect.Slice {
+			for j := 0; j < field.Len(); j++ {
+				s, err := queryValue(field.Index(j))
+				if err != nil {
+					return nil, fmt.Errorf("query parameter %s: %w", name, err)
+				}
+				values.Add(name, s)
+			}
+			continue
+		}
+		s, err := queryValue(field)
+		if err != nil {
+			return nil, fmt.Errorf("query parameter %s: %w", name, err)
+		}
+		values.Set(name, s)
+	}
+	return values, nil
+}
+
+func queryValue(v reflect.Value) (string, error) {
+	if t, ok := v.Interface().(time.Time); ok {
+		return strconv.FormatInt(t.UnixMilli(), 10), nil
+	}
+	if s, ok := v.Interface().(fmt.Stringer); ok {
+		return s.String(), nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	}
+	return "", fmt.Errorf("unsupported type %s", v.Type())
+}
+
+// getURLWithFilter builds a URL for path with the query parameters of filter
+func (m *BaseModule) getURLWithFilter(path string, filter any) (string, error) {
+	values, err := encodeQuery(filter)
+	if err != nil {
+		return "", err
+	}
+	u := m.endpointConfig.APIBaseURL + path
+	if encoded := values.Encode(); encoded != "" {
+		u += "?" + encoded
+	}
+	if _, err := url.Parse(u); err != nil {
+		return "", err
+	}
+	return u, nil
+}
@@ -0,0 +1,29 @@
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeQuery(t *testing.T) {
+	cursor := int64(0)
+	values, err := encodeQuery(TradesFilter{
+		Markets: []string{"BTC-USD", "ETH-USD"},
+		Side:    OrderSideSell,
+		Since:   time.UnixMilli(1700000000000),
+		Cursor:  &cursor,
+		Limit:   50,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "cursor=0&limit=50&market=BTC-USD&market=ETH-USD&side=SELL&startTime=1700000000000", values.Encode())
+
+	values, err = encodeQuery(&PositionsFilter{})
+	require.NoError(t, err)
+	assert.Empty(t, values)
+
+	_, err = encodeQuery("market=BTC-USD")
+	assert.Error(t, err)
+}
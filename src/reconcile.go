@@ -0,0 +1,72 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ReconcileReport is the account state found by Reconcile, from which a bot
+// rebuilds its local state before trading resumes
+type ReconcileReport struct {
+	// Recovered holds the journaled intents that were pending, see
+	// RecoverJournal. It is empty without a journal.
+	Recovered []RecoveredIntent
+	Positions []PositionModel
+	// Owned are the open orders tagged by the bot and known to it
+	Owned []OpenOrderModel
+	// Cancelled are the open orders tagged by the bot but unknown to it
+	Cancelled []OpenOrderModel
+	// Foreign are the open orders without the bot tag, which are left alone
+	Foreign []OpenOrderModel
+}
+
+// Reconcile brings the account in line with the local state of a bot at
+// startup. It first resolves the intents pending in the journal, if one is
+// set with WithJournal, then lists open orders and positions. Open orders
+// whose external ID starts with ownedPrefix belong to the bot: those for
+// which known returns false, e.g. because they are missing from its
+// persistent store, are cancelled in one mass cancel. A nil known treats
+// every owned order as unknown, cancelling them all for a clean start.
+func (c *APIClient) Reconcile(ctx context.Context, ownedPrefix string, known func(externalID string) bool) (*ReconcileReport, error) {
+	if ownedPrefix == "" {
+		return nil, fmt.Errorf("an owned order prefix is required so that foreign orders are not cancelled")
+	}
+
+	report := &ReconcileReport{}
+	if c.journal != nil {
+		recovered, err := c.RecoverJournal(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to recover journal: %w", err)
+		}
+		report.Recovered = recovered
+	}
+
+	orders, err := c.GetOpenOrders(ctx, OpenOrdersFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch open orders: %w", err)
+	}
+	var unknown []string
+	for _, order := range orders {
+		switch {
+		case !strings.HasPrefix(order.ExternalID, ownedPrefix):
+			report.Foreign = append(report.Foreign, order)
+		case known != nil && known(order.ExternalID):
+			report.Owned = append(report.Owned, order)
+		default:
+			report.Cancelled = append(report.Cancelled, order)
+			unknown = append(unknown, order.ExternalID)
+		}
+	}
+	if len(unknown) > 0 {
+		if err := c.MassCancel(ctx, MassCancelParams{ExternalOrderIDs: unknown}); err != nil {
+			return nil, fmt.Errorf("failed to cancel unknown orders: %w", err)
+		}
+	}
+
+	report.Positions, err = c.GetPositions(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch positions: %w", err)
+	}
+	return report, nil
+}
@@ -0,0 +1,108 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrNoPositionToReduce is returned for a reduce-only order when the account
+// holds no position on the order's side that the order could reduce
+var ErrNoPositionToReduce = errors.New("no position to reduce")
+
+// WithReduceOnlyCap makes PlaceOrder cap the quantity of reduce-only orders at
+// the current position size, fetched immediately before the order is signed.
+// A close sized from a stale position then still goes through when the
+// position shrank concurrently, instead of failing with REDUCE_ONLY_FAILED.
+func WithReduceOnlyCap() ClientOption {
+	return func(m *BaseModule) {
+		m.capReduceOnly = true
+	}
+}
+
+// CapReduceOnly returns params with the quantity lowered to the size of the
+// open position on the market, if it exceeds it. Parameters of orders that
+// are not reduce-only are returned unchanged. ErrNoPositionToReduce is
+// returned when there is no position the order would reduce.
+func (c *APIClient) CapReduceOnly(ctx context.Context, params CreateOrderObjectParams) (CreateOrderObjectParams, error) {
+	if !params.ReduceOnly {
+		return params, nil
+	}
+	positions, err := c.GetPositions(ctx, []string{params.Market.Name})
+	if err != nil {
+		return params, fmt.Errorf("failed to refresh position: %w", err)
+	}
+
+	closing := PositionSideLong
+	if params.Side == OrderSideBuy {
+		closing = PositionSideShort
+	}
+	for _, position := range positions {
+		if position.Market != params.Market.Name || position.Side != closing || !position.Size.IsPositive() {
+			continue
+		}
+		size := position.Size.RoundFloor(params.Market.QtyPrecision())
+		if params.SyntheticAmount.GreaterThan(size) {
+			params.SyntheticAmount = size
+		}
+		return params, nil
+	}
+	return params, fmt.Errorf("%w: no %s position on %s", ErrNoPositionToReduce, closing, params.Market.Name)
+}
+
+// ReducePositionByValue closes the part of the open position on params.Market
+// worth notional at the current mark price. The quantity is rounded down to
+// the market's size step and capped at the position size, and the order is
+// placed reduce-only on the closing side with PlaceOrder. params supplies the
+// account, signing and order settings; its side, quantity and reduce-only flag
+// are overwritten and a zero price defaults to the mark price, rounded to the
+// tick.
+func (c *APIClient) ReducePositionByValue(ctx context.Context, params CreateOrderObjectParams, notional decimal.Decimal, opts ...PlaceOrderOption) (*PerpetualOrderModel, *OrderResponse, error) {
+	if !notional.IsPositive() {
+		return nil, nil, fmt.Errorf("notional must be positive, got %s", notional)
+	}
+	positions, err := c.GetPositions(ctx, []string{params.Market.Name})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch position: %w", err)
+	}
+	var position *PositionModel
+	for i := range positions {
+		if positions[i].Market == params.Market.Name && positions[i].Size.IsPositive() {
+			position = &positions[i]
+			break
+		}
+	}
+	if position == nil {
+		return nil, nil, fmt.Errorf("%w: no position on %s", ErrNoPositionToReduce, params.Market.Name)
+	}
+	if !position.MarkPrice.IsPositive() {
+		return nil, nil, fmt.Errorf("no mark price for %s", params.Market.Name)
+	}
+
+	qty := notional.Div(position.MarkPrice)
+	if step := params.Market.TradingConfig.MinOrderSizeChange; step.IsPositive() {
+		qty = qty.Div(step).Floor().Mul(step)
+	} else {
+		qty = qty.RoundFloor(params.Market.QtyPrecision())
+	}
+	qty = decimal.Min(qty, position.Size)
+	if !qty.IsPositive() || qty.LessThan(params.Market.TradingConfig.MinOrderSize) {
+		return nil, nil, fmt.Errorf("%w: %s of %s is below the minimum order size", ErrInvalidOrder, notional, params.Market.Name)
+	}
+
+	params.Side = OrderSideSell
+	if position.Side == PositionSideShort {
+		params.Side = OrderSideBuy
+	}
+	params.SyntheticAmount = qty
+	params.ReduceOnly = true
+	if params.Price.IsZero() {
+		params.Price = position.MarkPrice
+		if tick := params.Market.TradingConfig.MinPriceChange; tick.IsPositive() {
+			params.Price = params.Price.Div(tick).Round(0).Mul(tick)
+		}
+	}
+	return c.PlaceOrder(ctx, params, opts...)
+}
@@ -0,0 +1,87 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// RetryConfig configures resending of idempotent requests
+type RetryConfig struct {
+	// MaxAttempts is the total number of attempts, including the first
+	MaxAttempts int
+	// BaseDelay is the wait before the first retry, doubled for every further
+	// retry; defaults to 100ms
+	BaseDelay time.Duration
+	// MaxDelay caps the wait between attempts; defaults to 2s
+	MaxDelay time.Duration
+}
+
+func (r RetryConfig) delay(attempt int) time.Duration {
+	d := r.BaseDelay << (attempt - 1)
+	if d <= 0 || d > r.MaxDelay {
+		return r.MaxDelay
+	}
+	return d
+}
+
+// WithRetry resends idempotent requests that fail with a transport error or
+// with status 429, 500, 502, 503 or 504. GET, HEAD, PUT and DELETE requests
+// are idempotent; POST requests only when their context is marked with
+// WithIdempotent. A MaxAttempts below 2 disables retries.
+func WithRetry(cfg RetryConfig) ClientOption {
+	return func(m *BaseModule) {
+		if cfg.MaxAttempts < 2 {
+			m.retry = nil
+			return
+		}
+		if cfg.BaseDelay <= 0 {
+			cfg.BaseDelay = 100 * time.Millisecond
+		}
+		if cfg.MaxDelay <= 0 {
+			cfg.MaxDelay = 2 * time.Second
+		}
+		m.retry = &cfg
+	}
+}
+
+type idempotentKey struct{}
+
+// WithIdempotent marks the requests made with ctx as safe to resend, e.g. an
+// order submission deduplicated by its external ID
+func WithIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentKey{}, true)
+}
+
+func isIdempotent(ctx context.Context, method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	marked, _ := ctx.Value(idempotentKey{}).(bool)
+	return marked
+}
+
+// isRetryable reports whether a failed attempt may succeed when resent
+func isRetryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	// Transport failures are reported by http.Client as *url.Error
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return true
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.StatusCode {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoRequestRetry(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		bodies []string
+	)
+	failures := 2
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		defer mu.Unlock()
+		bodies = append(bodies, string(body))
+		if len(bodies) <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"status":"OK"}`))
+	}))
+	defer server.Close()
+	reset := func(n int) {
+		mu.Lock()
+		defer mu.Unlock()
+		bodies, failures = nil, n
+	}
+
+	m := NewBaseModule(EndpointConfig{APIBaseURL: server.URL}, "", nil, nil, 5*time.Second,
+		WithRetry(RetryConfig{MaxAttempts: 3, BaseDelay: time.Millisecond}))
+	var resp struct{ Status string }
+
+	// Unmarked POSTs are not resent
+	err := m.DoRequest(context.Background(), "POST", server.URL, bytes.NewBufferString(`{"id":"a"}`), &resp)
+	require.Error(t, err)
+	assert.Len(t, bodies, 1)
+
+	// Marked POSTs are resent with the same body
+	reset(2)
+	err = m.DoRequest(WithIdempotent(context.Background()), "POST", server.URL, bytes.NewBufferString(`{"id":"a"}`), &resp)
+	require.NoError(t, err)
+	assert.Equal(t, []string{`{"id":"a"}`, `{"id":"a"}`, `{"id":"a"}`}, bodies)
+
+	// Attempts are bounded
+	reset(5)
+	err = m.DoRequest(context.Background(), "GET", server.URL, nil, &resp)
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+	assert.Len(t, bodies, 3)
+}
+
+func TestRetryConfigDelay(t *testing.T) {
+	cfg := RetryConfig{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	assert.Equal(t, 100*time.Millisecond, cfg.delay(1))
+	assert.Equal(t, 400*time.Millisecond, cfg.delay(3))
+	assert.Equal(t, time.Second, cfg.delay(5))
+	assert.Equal(t, time.Second, cfg.delay(80))
+}
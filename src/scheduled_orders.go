@@ -0,0 +1,429 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// DefaultMaxLateness is used by OrderScheduler when no MaxLateness is given
+const DefaultMaxLateness = time.Minute
+
+// ErrScheduleMissed is reported for a scheduled order that became due more
+// than MaxLateness ago, e.g. while the process was down, and was not sent
+var ErrScheduleMissed = errors.New("scheduled time missed")
+
+// ScheduledOrder is an order to be signed and placed at a future time. It
+// holds only what can be persisted; the account, signer and domain come from
+// the template of the OrderScheduler.
+type ScheduledOrder struct {
+	// ID names the order in the store and is the external ID of the placed
+	// order, so a send interrupted by a crash can be looked up
+	ID          string          `json:"id"`
+	At          time.Time       `json:"at"`
+	Market      string          `json:"market"`
+	Side        OrderSide       `json:"side"`
+	Type        OrderType       `json:"type,omitempty"`
+	Qty         decimal.Decimal `json:"qty"`
+	Price       decimal.Decimal `json:"price"`
+	TimeInForce TimeInForce     `json:"timeInForce,omitempty"`
+	PostOnly    bool            `json:"postOnly,omitempty"`
+	ReduceOnly  bool            `json:"reduceOnly,omitempty"`
+	// ExpireAfter is the lifetime of the order counted from At; defaults to
+	// an hour
+	ExpireAfter time.Duration `json:"expireAfter,omitempty"`
+}
+
+// ScheduledOrderStore persists scheduled orders so a schedule survives
+// restarts
+type ScheduledOrderStore interface {
+	Save(order ScheduledOrder) error
+	// Delete removes the order; unknown IDs are ignored
+	Delete(id string) error
+	// Load returns the stored orders, earliest first
+	Load() ([]ScheduledOrder, error)
+}
+
+// FileScheduledOrderStore keeps scheduled orders in one JSON file. Orders are
+// removed once sent, so the file only holds the pending schedule.
+type FileScheduledOrderStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileScheduledOrderStore returns a store backed by the file at path,
+// which is created on first save
+func NewFileScheduledOrderStore(path string) *FileScheduledOrderStore {
+	return &FileScheduledOrderStore{path: path}
+}
+
+// Save stores the order under its ID, replacing any previous one
+func (s *FileScheduledOrderStore) Save(order ScheduledOrder) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	orders, err := s.read()
+	if err != nil {
+		return err
+	}
+	orders[order.ID] = order
+	return s.write(orders)
+}
+
+// Delete removes the order
+func (s *FileScheduledOrderStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	orders, err := s.read()
+	if err != nil {
+		return err
+	}
+	if _, ok := orders[id]; !ok {
+		return nil
+	}
+	delete(orders, id)
+	return s.write(orders)
+}
+
+// Load returns the stored orders, earliest first
+func (s *FileScheduledOrderStore) Load() ([]ScheduledOrder, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	orders, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	list := make([]ScheduledOrder, 0, len(orders))
+	for _, order := range orders {
+		list = append(list, order)
+	}
+	sortScheduled(list)
+	return list, nil
+}
+
+func (s *FileScheduledOrderStore) read() (map[string]ScheduledOrder, error) {
+	orders := make(map[string]ScheduledOrder)
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return orders, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scheduled orders: %w", err)
+	}
+	if err := json.Unmarshal(data, &orders); err != nil {
+		return nil, fmt.Errorf("invalid scheduled order file %s: %w", s.path, err)
+	}
+	return orders, nil
+}
+
+func (s *FileScheduledOrderStore) write(orders map[string]ScheduledOrder) error {
+	data, err := json.Marshal(orders)
+	if err != nil {
+		return fmt.Errorf("failed to encode scheduled orders: %w", err)
+	}
+	if err := writeFileAtomic(s.path, data); err != nil {
+		return fmt.Errorf("failed to persist scheduled orders: %w", err)
+	}
+	return nil
+}
+
+func sortScheduled(orders []ScheduledOrder) {
+	sort.Slice(orders, func(a, b int) bool {
+		if !orders[a].At.Equal(orders[b].At) {
+			return orders[a].At.Before(orders[b].At)
+		}
+		return orders[a].ID < orders[b].ID
+	})
+}
+
+// OrderSchedulerConfig configures an OrderScheduler
+type OrderSchedulerConfig struct {
+	// Order is the template of every order: account, signer, domain and
+	// optionally nonce generator, self-trade protection and builder. Market,
+	// side, type, quantity, price and flags come from the scheduled order.
+	Order CreateOrderObjectParams
+	// Store persists the schedule, optional
+	Store ScheduledOrderStore
+	// SignAhead is how long before its time an order is signed, so that only
+	// the submission remains at the scheduled time. Zero signs at the
+	// scheduled time.
+	SignAhead time.Duration
+	// MaxLateness bounds how late an order may still be sent; later orders
+	// are dropped with ErrScheduleMissed. Defaults to DefaultMaxLateness.
+	MaxLateness time.Duration
+}
+
+// ScheduledOrderResult is the outcome of a scheduled order. SentAt is zero
+// if the order was not submitted.
+type ScheduledOrderResult struct {
+	Order    ScheduledOrder
+	SentAt   time.Time
+	Response *OrderResponse
+	Err      error
+}
+
+// OrderScheduler signs and places orders at scheduled times, e.g. at a
+// funding flip or a market open. Orders are signed as late as SignAhead
+// allows and expire relative to their scheduled time, not to when they were
+// scheduled. An order is removed from the store once the exchange answered
+// it, so a send interrupted by a crash is retried on restart under the same
+// external ID.
+type OrderScheduler struct {
+	client *APIClient
+	cfg    OrderSchedulerConfig
+
+	mu      sync.Mutex
+	pending map[string]ScheduledOrder
+	notify  chan struct{}
+}
+
+// NewOrderScheduler creates a scheduler, resuming the orders held by the
+// store
+func NewOrderScheduler(client *APIClient, cfg OrderSchedulerConfig) (*OrderScheduler, error) {
+	if cfg.MaxLateness <= 0 {
+		cfg.MaxLateness = DefaultMaxLateness
+	}
+	s := &OrderScheduler{
+		client:  client,
+		cfg:     cfg,
+		pending: make(map[string]ScheduledOrder),
+		notify:  make(chan struct{}, 1),
+	}
+	if cfg.Store != nil {
+		orders, err := cfg.Store.Load()
+		if err != nil {
+			return nil, err
+		}
+		for _, order := range orders {
+			s.pending[order.ID] = order
+		}
+	}
+	return s, nil
+}
+
+// Schedule adds an order to the schedule and persists it
+func (s *OrderScheduler) Schedule(order ScheduledOrder) error {
+	switch {
+	case order.ID == "":
+		return fmt.Errorf("%w: scheduled order ID is required", ErrInvalidOrder)
+	case order.At.IsZero():
+		return fmt.Errorf("%w: scheduled order %s has no time", ErrInvalidOrder, order.ID)
+	case order.Market == "":
+		return fmt.Errorf("%w: scheduled order %s has no market", ErrInvalidOrder, order.ID)
+	case !order.Qty.IsPositive():
+		return fmt.Errorf("%w: scheduled order %s quantity must be positive, got %s", ErrInvalidOrder, order.ID, order.Qty)
+	case !order.Price.IsPositive():
+		return fmt.Errorf("%w: scheduled order %s price must be positive, got %s", ErrInvalidOrder, order.ID, order.Price)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.pending[order.ID]; ok {
+		return fmt.Errorf("%w: scheduled order %s already exists", ErrInvalidOrder, order.ID)
+	}
+	if s.cfg.Store != nil {
+		if err := s.cfg.Store.Save(order); err != nil {
+			return err
+		}
+	}
+	s.pending[order.ID] = order
+	s.wake()
+	return nil
+}
+
+// Cancel removes an order that has not been signed yet. It reports false if
+// the order is unknown or already being sent.
+func (s *OrderScheduler) Cancel(id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.pending[id]; !ok {
+		return false, nil
+	}
+	if s.cfg.Store != nil {
+		if err := s.cfg.Store.Delete(id); err != nil {
+			return false, err
+		}
+	}
+	delete(s.pending, id)
+	s.wake()
+	return true, nil
+}
+
+// Pending returns the orders not signed yet, earliest first
+func (s *OrderScheduler) Pending() []ScheduledOrder {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	orders := make([]ScheduledOrder, 0, len(s.pending))
+	for _, order := range s.pending {
+		orders = append(orders, order)
+	}
+	sortScheduled(orders)
+	return orders
+}
+
+func (s *OrderScheduler) wake() {
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Run sends scheduled orders as they become due until ctx is done, and
+// reports the outcome of each. Orders may be scheduled and cancelled while
+// it runs. The channel is closed once ctx is done and the orders being sent
+// finished.
+func (s *OrderScheduler) Run(ctx context.Context) <-chan ScheduledOrderResult {
+	results := make(chan ScheduledOrderResult, 16)
+	go func() {
+		var wg sync.WaitGroup
+		defer func() {
+			wg.Wait()
+			close(results)
+		}()
+
+		for {
+			for _, order := range s.takeDue() {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					result := s.send(ctx, order)
+					select {
+					case results <- result:
+					case <-ctx.Done():
+					}
+				}()
+			}
+
+			var due <-chan time.Time
+			if wait, ok := s.untilNext(); ok {
+				due = s.client.Clock().After(wait)
+			}
+			select {
+			case <-due:
+			case <-s.notify:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return results
+}
+
+// takeDue removes the orders due for signing from the pending set
+func (s *OrderScheduler) takeDue() []ScheduledOrder {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.client.Clock().Now()
+	var due []ScheduledOrder
+	for id, order := range s.pending {
+		if !order.At.Add(-s.cfg.SignAhead).After(now) {
+			due = append(due, order)
+			delete(s.pending, id)
+		}
+	}
+	sortScheduled(due)
+	return due
+}
+
+// untilNext returns the wait until the next order is due for signing
+func (s *OrderScheduler) untilNext() (time.Duration, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var next time.Time
+	for _, order := range s.pending {
+		if at := order.At.Add(-s.cfg.SignAhead); next.IsZero() || at.Before(next) {
+			next = at
+		}
+	}
+	if next.IsZero() {
+		return 0, false
+	}
+	return max(next.Sub(s.client.Clock().Now()), 0), true
+}
+
+// send signs an order, waits for its time and submits it. The order stays
+// in the store unless the exchange answered or it was never sent.
+func (s *OrderScheduler) send(ctx context.Context, order ScheduledOrder) ScheduledOrderResult {
+	result := ScheduledOrderResult{Order: order}
+	forget := func() {
+		if s.cfg.Store != nil {
+			if err := s.cfg.Store.Delete(order.ID); err != nil && result.Err == nil {
+				result.Err = err
+			}
+		}
+	}
+
+	if late := s.client.Clock().Now().Sub(order.At); late > s.cfg.MaxLateness {
+		result.Err = fmt.Errorf("%w: order %s was due %s ago", ErrScheduleMissed, order.ID, late.Round(time.Second))
+		forget()
+		return result
+	}
+
+	signed, err := s.sign(ctx, order)
+	if err != nil {
+		result.Err = fmt.Errorf("failed to sign scheduled order %s: %w", order.ID, err)
+		forget()
+		return result
+	}
+	if wait := order.At.Sub(s.client.Clock().Now()); wait > 0 {
+		select {
+		case <-s.client.Clock().After(wait):
+		case <-ctx.Done():
+			// Not sent: keep it for the next run
+			s.mu.Lock()
+			s.pending[order.ID] = order
+			s.mu.Unlock()
+			result.Err = ctx.Err()
+			return result
+		}
+	}
+
+	result.SentAt = s.client.Clock().Now()
+	result.Response, result.Err = s.client.SubmitOrder(ctx, signed)
+	if answered(result.Err) {
+		forget()
+	}
+	return result
+}
+
+func (s *OrderScheduler) sign(ctx context.Context, order ScheduledOrder) (*PerpetualOrderModel, error) {
+	market, err := s.client.CachedMarket(ctx, order.Market)
+	if err != nil {
+		return nil, err
+	}
+	expireAfter := order.ExpireAfter
+	if expireAfter <= 0 {
+		expireAfter = time.Hour
+	}
+	expire := order.At.Add(expireAfter)
+	id := order.ID
+
+	params := s.cfg.Order
+	params.Market = *market
+	params.Side = order.Side
+	params.Type = order.Type
+	params.SyntheticAmount = order.Qty
+	params.Price = order.Price
+	params.TimeInForce = order.TimeInForce
+	params.PostOnly = order.PostOnly
+	params.ReduceOnly = order.ReduceOnly
+	params.ExpireTime = &expire
+	params.OrderExternalID = &id
+	params.PreviousOrderExternalID = nil
+	params.Nonce = nil
+	if params.NonceGenerator == nil {
+		params.NonceGenerator = s.client.BaseModule
+	}
+	if params.Fees == nil && !s.client.manualFees {
+		if fees, ok := s.client.fees.lookup(market.Name); ok {
+			params.Fees = &fees
+		}
+	}
+	return CreateOrderObjectContext(ctx, params)
+}
@@ -0,0 +1,306 @@
+package sdk
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SchedulerConfig configures the PoolScheduler of a ClientPool
+type SchedulerConfig struct {
+	// RequestsPerSecond is the order quota shared by all accounts
+	RequestsPerSecond float64
+	// Burst is the number of requests that may be sent back to back; defaults to 1
+	Burst int
+}
+
+// AccountUsage is the order traffic of one account through a PoolScheduler
+type AccountUsage struct {
+	Vault uint64
+	// Sent counts the requests dispatched, RiskReducing those of them from
+	// the risk-reducing lane
+	Sent         uint64
+	RiskReducing uint64
+	// Queued is the number of requests waiting for quota
+	Queued int
+	// Waited is the total time requests of the account waited for quota
+	Waited time.Duration
+}
+
+// PoolScheduler shares one order quota between the accounts of a pool.
+// Risk-reducing requests of every account are dispatched before any normal
+// request; within a lane, accounts with waiting requests take turns, so that
+// a busy account cannot starve the others.
+type PoolScheduler struct {
+	mu     sync.Mutex
+	bucket tokenBucket
+	clock  Clock
+	// lanes queue requests per priority and account; ring lists the accounts
+	// of a lane with queued requests in serving order
+	lanes [2]map[uint64][]*scheduledRequest
+	ring  [2][]uint64
+	usage map[uint64]*AccountUsage
+
+	notify    chan struct{}
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+type scheduledRequest struct {
+	queuedRequest
+	queuedAt time.Time
+}
+
+// NewPoolScheduler creates a scheduler and starts its dispatcher
+func NewPoolScheduler(cfg SchedulerConfig, clock Clock) *PoolScheduler {
+	clock = clockOrDefault(clock)
+	s := &PoolScheduler{
+		bucket: newTokenBucket(clock, cfg.RequestsPerSecond, cfg.Burst),
+		clock:  clock,
+		usage:  make(map[uint64]*AccountUsage),
+		notify: make(chan struct{}, 1),
+		closed: make(chan struct{}),
+	}
+	for i := range s.lanes {
+		s.lanes[i] = make(map[uint64][]*scheduledRequest)
+	}
+	go s.dispatch()
+	return s
+}
+
+// Usage returns the traffic of every account, by vault
+func (s *PoolScheduler) Usage() []AccountUsage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	usage := make([]AccountUsage, 0, len(s.usage))
+	for vault, u := range s.usage {
+		current := *u
+		current.Queued = len(s.lanes[0][vault]) + len(s.lanes[1][vault])
+		usage = append(usage, current)
+	}
+	sort.Slice(usage, func(i, j int) bool { return usage[i].Vault < usage[j].Vault })
+	return usage
+}
+
+// Close stops the dispatcher. Requests still queued fail with ErrOrderQueueClosed.
+func (s *PoolScheduler) Close() {
+	s.closeOnce.Do(func() {
+		s.mu.Lock()
+		close(s.closed)
+		lanes := s.lanes
+		for i := range s.lanes {
+			s.lanes[i] = make(map[uint64][]*scheduledRequest)
+			s.ring[i] = nil
+		}
+		s.mu.Unlock()
+
+		for _, lane := range lanes {
+			for _, queue := range lane {
+				for _, req := range queue {
+					req.done <- ErrOrderQueueClosed
+				}
+			}
+		}
+	})
+}
+
+// account returns the router sending the order traffic of a pool client
+func (s *PoolScheduler) account(vault uint64, client *APIClient) orderRouter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.usage[vault]; !ok {
+		s.usage[vault] = &AccountUsage{Vault: vault}
+	}
+	return &scheduledAccount{scheduler: s, vault: vault, client: client}
+}
+
+func (s *PoolScheduler) do(ctx context.Context, vault uint64, priority OrderPriority, run func(ctx context.Context) error) error {
+	if priority < OrderPriorityRiskReducing || priority > OrderPriorityNormal {
+		priority = OrderPriorityNormal
+	}
+	req := &scheduledRequest{
+		queuedRequest: queuedRequest{ctx: ctx, run: run, done: make(chan error, 1)},
+		queuedAt:      s.clock.Now(),
+	}
+
+	s.mu.Lock()
+	select {
+	case <-s.closed:
+		s.mu.Unlock()
+		return ErrOrderQueueClosed
+	default:
+	}
+	if len(s.lanes[priority][vault]) == 0 {
+		s.ring[priority] = append(s.ring[priority], vault)
+	}
+	s.lanes[priority][vault] = append(s.lanes[priority][vault], req)
+	s.mu.Unlock()
+
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+
+	select {
+	case err := <-req.done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// drop fails the queued requests of an account with ErrOrderQueueClosed
+func (s *PoolScheduler) drop(vault uint64) {
+	s.mu.Lock()
+	var dropped []*scheduledRequest
+	for i := range s.lanes {
+		dropped = append(dropped, s.lanes[i][vault]...)
+		delete(s.lanes[i], vault)
+		ring := s.ring[i][:0]
+		for _, v := range s.ring[i] {
+			if v != vault {
+				ring = append(ring, v)
+			}
+		}
+		s.ring[i] = ring
+	}
+	s.mu.Unlock()
+	for _, req := range dropped {
+		req.done <- ErrOrderQueueClosed
+	}
+}
+
+func (s *PoolScheduler) dispatch() {
+	for {
+		if !s.waitForWork() || !s.waitForToken() {
+			return
+		}
+		req := s.pop()
+		if req == nil {
+			continue
+		}
+		go func() {
+			req.done <- req.run(req.ctx)
+		}()
+	}
+}
+
+func (s *PoolScheduler) waitForWork() bool {
+	for {
+		s.mu.Lock()
+		pending := len(s.ring[0]) + len(s.ring[1])
+		s.mu.Unlock()
+		if pending > 0 {
+			return true
+		}
+		select {
+		case <-s.notify:
+		case <-s.closed:
+			return false
+		}
+	}
+}
+
+func (s *PoolScheduler) waitForToken() bool {
+	for {
+		s.mu.Lock()
+		wait := s.bucket.take()
+		s.mu.Unlock()
+		if wait == 0 {
+			return true
+		}
+
+		select {
+		case <-s.bucket.clock.After(wait):
+		case <-s.closed:
+			return false
+		}
+	}
+}
+
+// pop removes the next request: the risk-reducing lane first, and within a
+// lane the head of the account whose turn it is. Requests whose context is
+// already done are completed without being sent.
+func (s *PoolScheduler) pop() *scheduledRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for lane := range s.lanes {
+		for len(s.ring[lane]) > 0 {
+			vault := s.ring[lane][0]
+			queue := s.lanes[lane][vault]
+			req := queue[0]
+			if len(queue) == 1 {
+				delete(s.lanes[lane], vault)
+				s.ring[lane] = s.ring[lane][1:]
+			} else {
+				s.lanes[lane][vault] = queue[1:]
+				// The account goes to the back of the line
+				s.ring[lane] = append(s.ring[lane][1:], vault)
+			}
+			if err := req.ctx.Err(); err != nil {
+				req.done <- err
+				continue
+			}
+			if u := s.usage[vault]; u != nil {
+				u.Sent++
+				if lane == int(OrderPriorityRiskReducing) {
+					u.RiskReducing++
+				}
+				u.Waited += s.clock.Now().Sub(req.queuedAt)
+			}
+			return req
+		}
+	}
+	return nil
+}
+
+// scheduledAccount routes the order traffic of one pool client through the
+// scheduler, with the same lanes as an OrderQueue
+type scheduledAccount struct {
+	scheduler *PoolScheduler
+	vault     uint64
+	client    *APIClient
+	closed    atomic.Bool
+}
+
+func (a *scheduledAccount) SubmitOrder(ctx context.Context, order *PerpetualOrderModel) (*OrderResponse, error) {
+	priority := OrderPriorityNormal
+	if order != nil && order.ReduceOnly {
+		priority = OrderPriorityRiskReducing
+	}
+	var response *OrderResponse
+	err := a.Do(ctx, priority, func(ctx context.Context) error {
+		var err error
+		response, err = a.client.submitOrder(ctx, order)
+		return err
+	})
+	return response, err
+}
+
+func (a *scheduledAccount) CancelOrder(ctx context.Context, orderID int64) error {
+	return a.Do(ctx, OrderPriorityRiskReducing, func(ctx context.Context) error {
+		return a.client.cancelOrder(ctx, orderID)
+	})
+}
+
+func (a *scheduledAccount) CancelOrderByExternalID(ctx context.Context, externalID string) error {
+	return a.Do(ctx, OrderPriorityRiskReducing, func(ctx context.Context) error {
+		return a.client.cancelOrderByExternalID(ctx, externalID)
+	})
+}
+
+func (a *scheduledAccount) Do(ctx context.Context, priority OrderPriority, run func(ctx context.Context) error) error {
+	if a.closed.Load() {
+		return ErrOrderQueueClosed
+	}
+	return a.scheduler.do(ctx, a.vault, priority, run)
+}
+
+// Close fails the queued and later requests of the account; the scheduler
+// keeps serving the others
+func (a *scheduledAccount) Close() {
+	a.closed.Store(true)
+	a.scheduler.drop(a.vault)
+}
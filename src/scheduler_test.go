@@ -0,0 +1,81 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoolScheduler_RiskReducingFirstThenRoundRobin(t *testing.T) {
+	// One request every 100ms with no burst, so everything below queues up
+	scheduler := NewPoolScheduler(SchedulerConfig{RequestsPerSecond: 10, Burst: 1}, nil)
+	defer scheduler.Close()
+	busy := scheduler.account(1, nil)
+	quiet := scheduler.account(2, nil)
+
+	// Consume the initial token so that later requests compete for slots
+	require.NoError(t, busy.Do(context.Background(), OrderPriorityNormal, func(ctx context.Context) error { return nil }))
+
+	var mu sync.Mutex
+	var sent []string
+	var wg sync.WaitGroup
+	queued := 0
+	enqueue := func(router orderRouter, priority OrderPriority, name string) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, router.Do(context.Background(), priority, func(ctx context.Context) error {
+				mu.Lock()
+				sent = append(sent, name)
+				mu.Unlock()
+				return nil
+			}))
+		}()
+		queued++
+		// Queue in a known order
+		require.Eventually(t, func() bool {
+			total := 0
+			for _, u := range scheduler.Usage() {
+				total += u.Queued
+			}
+			return total == queued
+		}, time.Second, time.Millisecond)
+	}
+	enqueue(busy, OrderPriorityNormal, "busy-1")
+	enqueue(busy, OrderPriorityNormal, "busy-2")
+	enqueue(busy, OrderPriorityNormal, "busy-3")
+	enqueue(quiet, OrderPriorityNormal, "quiet-1")
+	enqueue(quiet, OrderPriorityRiskReducing, "quiet-cancel")
+	wg.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"quiet-cancel", "busy-1", "quiet-1", "busy-2", "busy-3"}, sent, fmt.Sprintf("unexpected order: %v", sent))
+
+	usage := scheduler.Usage()
+	require.Len(t, usage, 2)
+	assert.Equal(t, AccountUsage{Vault: 1, Sent: 4, Waited: usage[0].Waited}, usage[0])
+	assert.Equal(t, AccountUsage{Vault: 2, Sent: 2, RiskReducing: 1, Waited: usage[1].Waited}, usage[1])
+	assert.Positive(t, usage[1].Waited)
+}
+
+func TestPoolScheduler_ClosedAccount(t *testing.T) {
+	scheduler := NewPoolScheduler(SchedulerConfig{RequestsPerSecond: 1000}, nil)
+	defer scheduler.Close()
+	closed := scheduler.account(1, nil)
+	open := scheduler.account(2, nil)
+
+	closed.Close()
+	err := closed.Do(context.Background(), OrderPriorityNormal, func(ctx context.Context) error { return nil })
+	assert.ErrorIs(t, err, ErrOrderQueueClosed)
+	assert.NoError(t, open.Do(context.Background(), OrderPriorityNormal, func(ctx context.Context) error { return nil }), "other accounts are still served")
+
+	scheduler.Close()
+	err = open.Do(context.Background(), OrderPriorityNormal, func(ctx context.Context) error { return nil })
+	assert.ErrorIs(t, err, ErrOrderQueueClosed)
+}
@@ -0,0 +1,83 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/extended-protocol/extended-sdk-golang/src/internal/fanout"
+	"github.com/shopspring/decimal"
+)
+
+// MarketFilter selects markets by their 24h statistics. Unset criteria are
+// not applied, so the zero value matches every active market.
+type MarketFilter struct {
+	// MinDailyVolume is the minimum 24h volume in collateral
+	MinDailyVolume decimal.NullDecimal
+	// MaxSpreadBps is the maximum bid/ask spread in basis points of the mid
+	// price. Markets with an empty side never match when it is set.
+	MaxSpreadBps decimal.NullDecimal
+	// FundingAbove keeps markets whose current funding rate is strictly greater
+	FundingAbove decimal.NullDecimal
+	// Concurrency bounds the number of stats requests in flight. A default
+	// limit is used when it is not positive.
+	Concurrency int
+}
+
+// ScreenedMarket is a market matching a MarketFilter together with the
+// statistics it was evaluated on
+type ScreenedMarket struct {
+	Market    MarketModel
+	Stats     MarketStatsModel
+	SpreadBps decimal.Decimal
+}
+
+// Matches reports whether the given stats satisfy the filter
+func (f MarketFilter) Matches(stats MarketStatsModel) bool {
+	if f.MinDailyVolume.Valid && stats.DailyVolume.LessThan(f.MinDailyVolume.Decimal) {
+		return false
+	}
+	if f.MaxSpreadBps.Valid {
+		spread, ok := stats.SpreadBps()
+		if !ok || spread.GreaterThan(f.MaxSpreadBps.Decimal) {
+			return false
+		}
+	}
+	if f.FundingAbove.Valid && !stats.FundingRate.GreaterThan(f.FundingAbove.Decimal) {
+		return false
+	}
+	return true
+}
+
+// ScreenMarkets fetches the stats of every active market concurrently and
+// returns the markets matching the filter, ranked by 24h volume descending
+func (c *APIClient) ScreenMarkets(ctx context.Context, filter MarketFilter) ([]ScreenedMarket, error) {
+	active, err := c.GetMarkets(ctx, nil, WithInactiveMarkets(false))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list markets: %w", err)
+	}
+
+	stats, err := fanout.Map(ctx, active, filter.Concurrency, func(ctx context.Context, m MarketModel) (*MarketStatsModel, error) {
+		s, err := c.GetMarketStats(ctx, m.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get stats for %s: %w", m.Name, err)
+		}
+		return s, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []ScreenedMarket{}
+	for i, s := range stats {
+		if !filter.Matches(*s) {
+			continue
+		}
+		spread, _ := s.SpreadBps()
+		matches = append(matches, ScreenedMarket{Market: active[i], Stats: *s, SpreadBps: spread})
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Stats.DailyVolume.GreaterThan(matches[j].Stats.DailyVolume)
+	})
+	return matches, nil
+}
@@ -0,0 +1,237 @@
+package sdktest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const withdrawalAddress = "0x8B3a350cf5c34c9194cA85829a2df0ec3153be0"
+
+func TestAddressBook_EncryptedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "addresses.json")
+	book, err := sdk.OpenAddressBook(path, "correct horse")
+	require.NoError(t, err)
+	require.NoError(t, book.Add("ETH", withdrawalAddress, "cold wallet"))
+	require.NoError(t, book.Add("STRK", "0x0123", ""))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.False(t, bytes.Contains(data, []byte("cold wallet")), "entries are stored encrypted")
+
+	book, err = sdk.OpenAddressBook(path, "correct horse")
+	require.NoError(t, err)
+	entries := book.Entries()
+	require.Len(t, entries, 2)
+	assert.Equal(t, "cold wallet", entries[0].Label)
+	assert.NoError(t, book.Check("ETH", withdrawalAddress))
+	assert.NoError(t, book.Check("eth", "0x8b3a350cf5c34c9194ca85829a2df0ec3153be0"), "hex addresses ignore case")
+	assert.ErrorIs(t, book.Check("STRK", withdrawalAddress), sdk.ErrAddressNotListed)
+
+	require.NoError(t, book.Remove("STRK", "0x0123"))
+	assert.ErrorIs(t, book.Check("STRK", "0x0123"), sdk.ErrAddressNotListed)
+
+	_, err = sdk.OpenAddressBook(path, "wrong horse")
+	assert.ErrorIs(t, err, sdk.ErrWrongPassphrase)
+
+	// Tampering with the ciphertext is detected like a wrong passphrase
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	tampered := bytes.Replace(data, []byte(`"ciphertext":"`), []byte(`"ciphertext":"AAAA`), 1)
+	require.NoError(t, os.WriteFile(path, tampered, 0o600))
+	_, err = sdk.OpenAddressBook(path, "correct horse")
+	assert.ErrorIs(t, err, sdk.ErrWrongPassphrase)
+}
+
+func TestValidateWithdrawal(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	ex.SetWithdrawalLimits(sdk.WithdrawalLimitsModel{
+		Chain:     "ETH",
+		MinAmount: decimal.NewFromInt(10),
+		Fees:      []sdk.WithdrawalFeeTierModel{{MinAmount: decimal.Zero, Fee: decimal.NewFromInt(5)}},
+	})
+	ctx := context.Background()
+
+	// Without an address book only the amount is checked
+	fee, err := ex.NewClient().ValidateWithdrawal(ctx, "ETH", withdrawalAddress, decimal.NewFromInt(100))
+	require.NoError(t, err)
+	assert.Equal(t, "5", fee.String())
+
+	book, err := sdk.OpenAddressBook(filepath.Join(t.TempDir(), "addresses.json"), "passphrase")
+	require.NoError(t, err)
+	client := ex.NewClient(sdk.WithAddressBook(book))
+
+	_, err = client.ValidateWithdrawal(ctx, "ETH", withdrawalAddress, decimal.NewFromInt(100))
+	assert.ErrorIs(t, err, sdk.ErrAddressNotListed)
+
+	require.NoError(t, book.Add("ETH", withdrawalAddress, ""))
+	fee, err = client.ValidateWithdrawal(ctx, "ETH", withdrawalAddress, decimal.NewFromInt(100))
+	require.NoError(t, err)
+	assert.Equal(t, "5", fee.String())
+
+	_, err = client.ValidateWithdrawal(ctx, "ETH", withdrawalAddress, decimal.NewFromInt(1))
+	assert.ErrorIs(t, err, sdk.ErrInvalidWithdrawalAmount)
+}
+
+func TestWithdraw_AddressBook(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	ex.SetWithdrawalLimits(sdk.WithdrawalLimitsModel{Chain: "ETH", MinAmount: decimal.NewFromInt(10)})
+	ctx := context.Background()
+
+	book, err := sdk.OpenAddressBook(filepath.Join(t.TempDir(), "addresses.json"), "passphrase")
+	require.NoError(t, err)
+	var anomalies anomalyRecorder
+	monitor := sdk.NewAnomalyMonitor(anomalies.record, nil)
+	client := ex.NewClient(sdk.WithAddressBook(book), sdk.WithAnomalyMonitor(monitor))
+	withdrawal := sdk.WithdrawalModel{
+		AccountID:  OwnAccountID,
+		Amount:     decimal.NewFromInt(100),
+		ChainID:    "ETH",
+		Asset:      "USD",
+		Settlement: sdk.WithdrawalSettlement{Recipient: withdrawalAddress},
+	}
+
+	// An unlisted address is refused before anything is sent
+	_, err = client.Withdraw(ctx, withdrawal)
+	assert.ErrorIs(t, err, sdk.ErrAddressNotListed)
+	assert.Empty(t, ex.AssetOperations())
+
+	require.NoError(t, book.Add("ETH", withdrawalAddress, ""))
+	id, err := client.Withdraw(ctx, withdrawal)
+	require.NoError(t, err)
+	ops := ex.AssetOperations()
+	require.Len(t, ops, 1)
+	assert.Equal(t, id, ops[0].ID)
+	assert.Equal(t, sdk.AssetOperationWithdrawal, ops[0].Type)
+	assert.Equal(t, "100", ops[0].Amount.String())
+
+	// The anomaly monitor knows the withdrawal is our own
+	require.NoError(t, monitor.Sync(ctx, client))
+	assert.Empty(t, anomalies.take())
+
+	// Amounts out of the limits of the chain are refused too
+	withdrawal.Amount = decimal.NewFromInt(1)
+	_, err = client.Withdraw(ctx, withdrawal)
+	assert.ErrorIs(t, err, sdk.ErrInvalidWithdrawalAmount)
+	assert.Len(t, ex.AssetOperations(), 1)
+}
+
+func TestWithdraw_ApprovalHook(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	ex.SetWithdrawalLimits(sdk.WithdrawalLimitsModel{Chain: "ETH", MinAmount: decimal.NewFromInt(10)})
+	ctx := context.Background()
+
+	var requests []sdk.ApprovalRequest
+	approved := true
+	hook := sdk.ApprovalHookFunc(func(ctx context.Context, req sdk.ApprovalRequest) error {
+		requests = append(requests, req)
+		if !approved {
+			return errors.New("rejected by ops")
+		}
+		return nil
+	})
+	client := ex.NewClient(sdk.WithApprovalHook(hook, decimal.NewFromInt(1000)))
+	withdraw := func(ctx context.Context, client *sdk.APIClient, amount int64) error {
+		_, err := client.Withdraw(ctx, sdk.WithdrawalModel{
+			Amount:     decimal.NewFromInt(amount),
+			ChainID:    "ETH",
+			Settlement: sdk.WithdrawalSettlement{Recipient: withdrawalAddress},
+		})
+		return err
+	}
+
+	// At or below the threshold the hook is not asked
+	require.NoError(t, withdraw(ctx, client, 1000))
+	assert.Empty(t, requests)
+
+	// Validating alone does not ask either
+	_, err := client.ValidateWithdrawal(ctx, "ETH", withdrawalAddress, decimal.NewFromInt(5000))
+	require.NoError(t, err)
+	assert.Empty(t, requests)
+
+	require.NoError(t, withdraw(ctx, client, 5000))
+	require.Len(t, requests, 1)
+	assert.Equal(t, sdk.ApprovalWithdrawal, requests[0].Kind)
+	assert.Equal(t, withdrawalAddress, requests[0].Address)
+	assert.Equal(t, "5000", requests[0].Amount.String())
+	assert.Len(t, ex.AssetOperations(), 2)
+
+	approved = false
+	err = withdraw(ctx, client, 3000)
+	assert.ErrorIs(t, err, sdk.ErrApprovalDenied)
+	assert.Contains(t, err.Error(), "rejected by ops")
+	assert.Len(t, ex.AssetOperations(), 2, "a rejected withdrawal is not sent")
+
+	// A hook waiting for a decision gives up with the context
+	waiting := sdk.ApprovalHookFunc(func(ctx context.Context, req sdk.ApprovalRequest) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	client = ex.NewClient(sdk.WithApprovalHook(waiting, decimal.Zero))
+	timeout, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	err = withdraw(timeout, client, 20)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.NotErrorIs(t, err, sdk.ErrApprovalDenied)
+	assert.Len(t, ex.AssetOperations(), 2)
+}
+
+func TestTransfer_ApprovalHook(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	ctx := context.Background()
+
+	var requests []sdk.ApprovalRequest
+	hook := sdk.ApprovalHookFunc(func(ctx context.Context, req sdk.ApprovalRequest) error {
+		requests = append(requests, req)
+		if req.ToAccount == 666 {
+			return errors.New("unknown account")
+		}
+		return nil
+	})
+	var anomalies anomalyRecorder
+	monitor := sdk.NewAnomalyMonitor(anomalies.record, nil)
+	client := ex.NewClient(sdk.WithApprovalHook(hook, decimal.NewFromInt(100)), sdk.WithAnomalyMonitor(monitor))
+	transfer := func(to, amount int64) (string, error) {
+		return client.Transfer(ctx, sdk.TransferModel{
+			FromAccount: OwnAccountID,
+			ToAccount:   to,
+			Amount:      decimal.NewFromInt(amount),
+		})
+	}
+
+	_, err := transfer(2, 50)
+	require.NoError(t, err)
+	assert.Empty(t, requests)
+
+	id, err := transfer(2, 500)
+	require.NoError(t, err)
+	require.Len(t, requests, 1)
+	assert.Equal(t, sdk.ApprovalTransfer, requests[0].Kind)
+	assert.Equal(t, int64(2), requests[0].ToAccount)
+	assert.Equal(t, "500", requests[0].Amount.String())
+	ops := ex.AssetOperations()
+	require.Len(t, ops, 2)
+	assert.Equal(t, id, ops[1].ID)
+	assert.Equal(t, "-500", ops[1].Amount.String())
+
+	_, err = transfer(666, 500)
+	assert.ErrorIs(t, err, sdk.ErrApprovalDenied)
+	assert.Len(t, ex.AssetOperations(), 2, "a rejected transfer is not sent")
+
+	// Transfers submitted through the client are not anomalies
+	require.NoError(t, monitor.Sync(ctx, client))
+	assert.Empty(t, anomalies.take())
+}
@@ -0,0 +1,103 @@
+package sdktest
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type anomalyRecorder struct {
+	mu        sync.Mutex
+	anomalies []sdk.Anomaly
+}
+
+func (r *anomalyRecorder) record(a sdk.Anomaly) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.anomalies = append(r.anomalies, a)
+}
+
+func (r *anomalyRecorder) take() []sdk.Anomaly {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := r.anomalies
+	r.anomalies = nil
+	return out
+}
+
+func TestAnomalyMonitor(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	ctx := context.Background()
+
+	ex.AddAssetOperation(sdk.AssetOperationModel{
+		ID: "old", Type: sdk.AssetOperationWithdrawal, Amount: decimal.NewFromInt(10),
+		Time: time.Now().Add(-time.Hour).UnixMilli(),
+	})
+
+	var recorder anomalyRecorder
+	monitor := sdk.NewAnomalyMonitor(recorder.record, nil)
+	client := ex.NewClient(sdk.WithAnomalyMonitor(monitor))
+	// intruder holds the same keys but is not watched by the monitor
+	intruder := ex.NewClient()
+
+	require.NoError(t, monitor.Sync(ctx, client))
+	assert.Empty(t, recorder.take(), "history before the monitor and baselines are not anomalies")
+
+	// Own activity
+	ex.AddRestingOrder(RestingOrder{Market: "BTC-USD", Side: sdk.OrderSideSell, Price: decimal.NewFromInt(40000), Qty: decimal.NewFromInt(1), AccountID: 2})
+	_, _, err := client.PlaceOrder(ctx, buyParams(t, BTCUSDMarket(), "0.1", "40000"))
+	require.NoError(t, err)
+	_, err = client.UpdateLeverage(ctx, "BTC-USD", decimal.NewFromInt(5))
+	require.NoError(t, err)
+	ex.AddAssetOperation(sdk.AssetOperationModel{
+		ID: "deposit", Type: sdk.AssetOperationDeposit, Amount: decimal.NewFromInt(100), Time: time.Now().UnixMilli(),
+	})
+	require.NoError(t, monitor.Sync(ctx, client))
+	assert.Empty(t, recorder.take())
+
+	// Activity of someone else holding the keys
+	order, _, err := intruder.PlaceOrder(ctx, buyParams(t, BTCUSDMarket(), "0.2", "40000"))
+	require.NoError(t, err)
+	_, err = intruder.UpdateLeverage(ctx, "BTC-USD", decimal.NewFromInt(20))
+	require.NoError(t, err)
+	ex.AddAssetOperation(sdk.AssetOperationModel{
+		ID: "withdrawal", Type: sdk.AssetOperationWithdrawal, Status: sdk.AssetOperationStatusCreated,
+		Amount: decimal.NewFromInt(500), Time: time.Now().UnixMilli(),
+	})
+	require.NoError(t, monitor.Sync(ctx, client))
+
+	kinds := make(map[sdk.AnomalyKind]sdk.Anomaly)
+	for _, a := range recorder.take() {
+		kinds[a.Kind] = a
+	}
+	require.Len(t, kinds, 3)
+	assert.Equal(t, "BTC-USD", kinds[sdk.AnomalyUnknownFill].Market)
+	assert.Contains(t, kinds[sdk.AnomalyLeverageChange].Detail, "from 5 to 20")
+	assert.Contains(t, kinds[sdk.AnomalyWithdrawal].Detail, "WITHDRAWAL of 500")
+
+	// Syncing again reports nothing new
+	require.NoError(t, monitor.Sync(ctx, client))
+	assert.Empty(t, recorder.take())
+
+	// Order updates are checked by external ID, once per order
+	placed, ok := ex.Order(order.ID)
+	require.True(t, ok)
+	monitor.RecordOrder(placed)
+	monitor.RecordOrder(placed)
+	anomalies := recorder.take()
+	require.Len(t, anomalies, 1)
+	assert.Equal(t, sdk.AnomalyUnknownOrder, anomalies[0].Kind)
+	assert.Equal(t, order.ID, anomalies[0].ExternalID)
+
+	monitor.ExpectOrder("trusted")
+	placed.ID, placed.ExternalID = placed.ID+1, "trusted"
+	monitor.RecordOrder(placed)
+	assert.Empty(t, recorder.take())
+}
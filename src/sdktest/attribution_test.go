@@ -0,0 +1,43 @@
+package sdktest
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPnLAttributor_Sync(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	client := ex.NewClient()
+	ctx := context.Background()
+	ex.AddRestingOrder(RestingOrder{
+		Market: "BTC-USD", Side: sdk.OrderSideSell,
+		Price: decimal.NewFromInt(40000), Qty: decimal.NewFromInt(1), AccountID: 2,
+	})
+
+	a := sdk.NewPnLAttributor()
+	_, _, err := a.PlaceOrder(ctx, client, buyParams(t, BTCUSDMarket(), "0.01", "40000"), "breakout")
+	require.NoError(t, err)
+	_, _, err = a.PlaceOrder(ctx, client, buyParams(t, BTCUSDMarket(), "0.02", "40000"), "carry")
+	require.NoError(t, err)
+
+	added, err := a.Sync(ctx, client)
+	require.NoError(t, err)
+	assert.Equal(t, 2, added)
+	added, err = a.Sync(ctx, client)
+	require.NoError(t, err)
+	assert.Zero(t, added, "trades are only attributed once")
+
+	a.UpdateMarkPrice("BTC-USD", decimal.NewFromInt(41000))
+	breakout := a.Tag("breakout")
+	assert.Equal(t, 1, breakout.Fills)
+	assert.Equal(t, "0.01", breakout.Positions["BTC-USD"].String())
+	assert.Equal(t, "10", breakout.UnrealisedPnl.String())
+	assert.Equal(t, "20", a.Tag("carry").UnrealisedPnl.String())
+	assert.Len(t, a.Report(), 2)
+}
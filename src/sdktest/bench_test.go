@@ -0,0 +1,40 @@
+package sdktest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBenchOrders(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	client := ex.NewClient()
+	ctx := context.Background()
+	params := buyParams(t, BTCUSDMarket(), "0.01", "40000")
+
+	report, err := client.BenchOrders(ctx, params, sdk.OrderBenchConfig{Orders: 20, Concurrency: 4, DryRun: true})
+	require.NoError(t, err)
+	assert.Equal(t, 20, report.Orders)
+	assert.Positive(t, report.SignsPerSecond)
+	assert.Positive(t, report.Sign.P50)
+	assert.LessOrEqual(t, report.Sign.P50, report.Sign.P99)
+	assert.LessOrEqual(t, report.Sign.P99, report.Sign.Max)
+	assert.Zero(t, report.Place)
+	assert.Contains(t, report.String(), "skipped (dry run)")
+	assert.Empty(t, ex.RestingOrders(), "dry runs send nothing")
+
+	report, err = client.BenchOrders(ctx, params, sdk.OrderBenchConfig{Orders: 10, Concurrency: 2})
+	require.NoError(t, err)
+	assert.Positive(t, report.Place.P50)
+	assert.Zero(t, report.CancelFailed)
+	assert.Empty(t, ex.RestingOrders(), "placed orders are cancelled")
+
+	ex.FailNext(http.StatusBadRequest, 1)
+	_, err = client.BenchOrders(ctx, params, sdk.OrderBenchConfig{Orders: 5})
+	assert.ErrorContains(t, err, "failed to place order")
+}
@@ -0,0 +1,51 @@
+package sdktest
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlotter_Sync(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	client := ex.NewClient()
+	ctx := context.Background()
+	ex.AddRestingOrder(RestingOrder{
+		Market: "BTC-USD", Side: sdk.OrderSideSell,
+		Price: decimal.NewFromInt(40000), Qty: decimal.NewFromInt(1), AccountID: 2,
+	})
+
+	var events []sdk.BlotterEvent
+	b := sdk.NewBlotter(sdk.BlotterSinkFunc(func(_ context.Context, event sdk.BlotterEvent) error {
+		events = append(events, event)
+		return nil
+	}))
+	for _, qty := range []string{"0.01", "0.02"} {
+		_, _, err := client.PlaceOrder(ctx, buyParams(t, BTCUSDMarket(), qty, "40000"))
+		require.NoError(t, err)
+	}
+
+	published, err := b.Sync(ctx, client)
+	require.NoError(t, err)
+	assert.Equal(t, 2, published)
+	require.Len(t, events, 2)
+	assert.Equal(t, "0.01", events[0].Qty.String(), "oldest first")
+	assert.Equal(t, "0.02", events[1].Qty.String())
+	assert.Equal(t, sdk.BlotterEventFill, events[1].Kind)
+
+	published, err = b.Sync(ctx, client)
+	require.NoError(t, err)
+	assert.Zero(t, published)
+
+	_, _, err = client.PlaceOrder(ctx, buyParams(t, BTCUSDMarket(), "0.03", "40000"))
+	require.NoError(t, err)
+	published, err = b.Sync(ctx, client)
+	require.NoError(t, err)
+	assert.Equal(t, 1, published)
+	assert.Equal(t, "0.03", events[2].Qty.String())
+}
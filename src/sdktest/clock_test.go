@@ -0,0 +1,120 @@
+package sdktest
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManualClock_After(t *testing.T) {
+	clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	now := <-clock.After(0)
+	assert.Equal(t, clock.Now(), now, "non-positive waits fire right away")
+
+	due := clock.After(time.Minute)
+	clock.Advance(59 * time.Second)
+	select {
+	case <-due:
+		t.Fatal("fired before the clock reached it")
+	default:
+	}
+	clock.Advance(time.Second)
+	assert.Equal(t, clock.Now(), <-due)
+}
+
+// waitForWaiters waits until at least n channels wait on the fake clock
+func waitForWaiters(t *testing.T, clock *ManualClock, n int) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		clock.mu.Lock()
+		defer clock.mu.Unlock()
+		return len(clock.waiters) >= n
+	}, time.Second, time.Millisecond)
+}
+
+func TestOrderQueue_WaitsOnInjectedClock(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	client := ex.NewClient(sdk.WithClock(clock))
+	// One request every 1000s, so the second one only goes out on the fake clock
+	queue := sdk.NewOrderQueue(client, sdk.OrderQueueConfig{RequestsPerSecond: 0.001})
+	defer queue.Close()
+
+	noop := func(ctx context.Context) error { return nil }
+	require.NoError(t, queue.Do(context.Background(), sdk.OrderPriorityNormal, noop))
+
+	done := make(chan error, 1)
+	go func() { done <- queue.Do(context.Background(), sdk.OrderPriorityNormal, noop) }()
+	// The queue waits for the token on the fake clock
+	waitForWaiters(t, clock, 1)
+	assert.Empty(t, done, "sent without a token")
+
+	clock.Advance(1000 * time.Second)
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("advancing the clock did not release the queue")
+	}
+}
+
+func TestRetry_WaitsOnInjectedClock(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	clock := NewManualClock(time.Now())
+	client := ex.NewClient(sdk.WithClock(clock), sdk.WithRetry(sdk.RetryConfig{MaxAttempts: 2, BaseDelay: time.Hour, MaxDelay: time.Hour}))
+
+	ex.FailNext(http.StatusServiceUnavailable, 1)
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.GetBalance(context.Background())
+		done <- err
+	}()
+	waitForWaiters(t, clock, 1)
+	assert.Empty(t, done, "retried before the backoff elapsed")
+
+	clock.Advance(time.Hour)
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("advancing the clock did not release the retry")
+	}
+}
+
+func TestOrderScheduler_WaitsOnInjectedClock(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	clock := NewManualClock(time.Now())
+	client := ex.NewClient(sdk.WithClock(clock))
+	store := sdk.NewFileScheduledOrderStore(filepath.Join(t.TempDir(), "schedule.json"))
+	scheduler, err := sdk.NewOrderScheduler(client, sdk.OrderSchedulerConfig{
+		Order: signedOrderParams(t), Store: store, SignAhead: time.Minute,
+	})
+	require.NoError(t, err)
+
+	at := clock.Now().Add(time.Hour)
+	require.NoError(t, scheduler.Schedule(scheduledBuy("open", at)))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	results := scheduler.Run(ctx)
+
+	waitForWaiters(t, clock, 1)
+	assert.Empty(t, results, "sent before the clock reached the schedule")
+
+	clock.Set(at)
+	sent := nextResult(t, results)
+	require.NoError(t, sent.Err)
+	assert.Equal(t, at, sent.SentAt)
+
+	cancel()
+	for range results {
+	}
+}
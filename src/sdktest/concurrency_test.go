@@ -0,0 +1,75 @@
+package sdktest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestClient_ConcurrentUse hammers one client from many goroutines, as the
+// concurrency contract of APIClient allows; run it with -race
+func TestClient_ConcurrentUse(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	client := ex.NewClient(sdk.WithRetry(sdk.RetryConfig{MaxAttempts: 2}), sdk.WithPreloadMarkets(true))
+	defer client.Close()
+	ctx := context.Background()
+
+	params := signedOrderParams(t)
+	// Orders draw their nonces from the client
+	params.NonceGenerator = nil
+	params.Side = sdk.OrderSideBuy
+	params.SyntheticAmount = decimal.RequireFromString("0.01")
+	params.Price = decimal.NewFromInt(40000)
+
+	const workers = 8
+	const rounds = 10
+	var wg sync.WaitGroup
+	nonces := make(chan string, workers*rounds)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < rounds; i++ {
+				p := params
+				externalID := fmt.Sprintf("w%d-%d", w, i)
+				p.OrderExternalID = &externalID
+				order, _, err := client.PlaceOrder(ctx, p)
+				if !assert.NoError(t, err) {
+					return
+				}
+				nonces <- order.Nonce
+
+				_, err = client.GetOpenOrders(ctx, sdk.OpenOrdersFilter{})
+				assert.NoError(t, err)
+				_, err = client.CachedMarketFee(ctx, "BTC-USD")
+				assert.NoError(t, err)
+				_, err = client.CachedMarket(ctx, "BTC-USD")
+				assert.NoError(t, err)
+				if i%3 == 0 {
+					client.InvalidateFeeCache()
+					client.InvalidateMarketCache()
+					assert.NoError(t, client.CancelOrderByExternalID(ctx, externalID))
+				}
+				_ = client.Stats()
+				_ = client.Health()
+				_ = client.HTTPClient()
+			}
+		}()
+	}
+	wg.Wait()
+	close(nonces)
+
+	seen := make(map[string]bool)
+	for nonce := range nonces {
+		require.False(t, seen[nonce], "nonce %s was used twice", nonce)
+		seen[nonce] = true
+	}
+	assert.Len(t, seen, workers*rounds)
+}
@@ -0,0 +1,52 @@
+package sdktest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type offsetClock time.Duration
+
+func (c offsetClock) Now() time.Time { return time.Now().Add(time.Duration(c)) }
+
+func (offsetClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+func TestDiagnose(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	ctx := context.Background()
+
+	report := ex.NewClient().Diagnose(ctx)
+	assert.True(t, report.OK(), report.String())
+	require.Len(t, report.Checks, 4)
+	assert.Equal(t, "rest", report.Checks[0].Name)
+	assert.Equal(t, sdk.DiagnosticOK, report.Checks[0].Status)
+	assert.Equal(t, "1 markets", report.Checks[0].Detail)
+	assert.Equal(t, sdk.DiagnosticOK, report.Checks[1].Status)
+	assert.Less(t, report.ClockSkew.Abs(), time.Second+100*time.Millisecond)
+	assert.Equal(t, sdk.DiagnosticOK, report.Checks[2].Status)
+	assert.Contains(t, report.String(), "auth    OK")
+	assert.Equal(t, "stream", report.Checks[3].Name)
+	assert.Equal(t, sdk.DiagnosticOK, report.Checks[3].Status, report.String())
+
+	report = sdk.NewPublicClient(sdk.EndpointConfig{APIBaseURL: ex.URL()}).Diagnose(ctx)
+	assert.Equal(t, sdk.DiagnosticSkipped, report.Checks[3].Status, "no stream URL to dial")
+
+	report = sdk.NewPublicClient(ex.EndpointConfig(), sdk.WithClock(offsetClock(10*time.Second))).Diagnose(ctx)
+	assert.False(t, report.OK())
+	assert.Equal(t, sdk.DiagnosticFailed, report.Checks[1].Status, report.String())
+	assert.Greater(t, report.ClockSkew, 8*time.Second)
+	assert.Equal(t, sdk.DiagnosticSkipped, report.Checks[2].Status, "public clients have no key to check")
+
+	ex.Close()
+	report = ex.NewClient().Diagnose(ctx)
+	assert.Equal(t, sdk.DiagnosticFailed, report.Checks[0].Status)
+	assert.Error(t, report.Checks[0].Err)
+	assert.Equal(t, sdk.DiagnosticFailed, report.Checks[3].Status)
+	assert.Error(t, report.Checks[3].Err)
+}
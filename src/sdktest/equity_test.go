@@ -0,0 +1,94 @@
+package sdktest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEquitySampler(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	client := ex.NewClient(sdk.WithClock(clock))
+	store := &sdk.MemoryEquityStore{}
+	sampler := sdk.NewEquitySampler(client, store, time.Hour, nil)
+	ctx := context.Background()
+
+	for _, balance := range []int64{10000, 12000, 9000, 11000} {
+		ex.SetBalance(decimal.NewFromInt(balance))
+		_, err := sampler.Sample(ctx)
+		require.NoError(t, err)
+		clock.Advance(time.Hour)
+	}
+
+	samples := store.Samples()
+	require.Len(t, samples, 4)
+	assert.Equal(t, time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC), samples[3].Time)
+	assert.Equal(t, "0.25", sdk.MaxDrawdown(samples).String())
+}
+
+func TestEquitySampler_Start(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	store := &sdk.MemoryEquityStore{}
+	sampler := sdk.NewEquitySampler(ex.NewClient(), store, time.Millisecond, nil)
+
+	h := sampler.Start(context.Background())
+	require.Eventually(t, func() bool { return len(store.Samples()) >= 3 }, time.Second, time.Millisecond)
+	h.Cancel()
+
+	stored, err := h.Result()
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.GreaterOrEqual(t, stored, 3)
+}
+
+func TestEquitySampler_MarginUsage(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewManualClock(start)
+	store := &sdk.MemoryEquityStore{}
+	sampler := sdk.NewEquitySampler(ex.NewClient(sdk.WithClock(clock)), store, time.Hour, nil)
+	ctx := context.Background()
+
+	for _, step := range []struct {
+		after                 time.Duration
+		leverage, marginRatio string
+	}{
+		{0, "2", "0.1"},
+		{6 * time.Hour, "5", "0.3"},
+		{12 * time.Hour, "3", "0.2"},
+		{12 * time.Hour, "1", "0.1"},
+		{6 * time.Hour, "4", "0.5"},
+	} {
+		clock.Advance(step.after)
+		ex.SetMarginUsage(decimal.RequireFromString(step.leverage), decimal.RequireFromString(step.marginRatio))
+		_, err := sampler.Sample(ctx)
+		require.NoError(t, err)
+	}
+	samples := store.Samples()
+	assert.Equal(t, "5", samples[1].Leverage.String())
+
+	// 0.1 for 6h, 0.3 for 12h, 0.2 for 12h and 0.1 for 6h
+	assert.Equal(t, "0.2", sdk.TimeWeightedMarginRatio(samples).String())
+
+	report := sdk.DailyRiskReport(samples)
+	require.Len(t, report, 2)
+	assert.Equal(t, start, report[0].Day)
+	assert.Equal(t, "5", report[0].MaxLeverage.String())
+	assert.Equal(t, start.Add(6*time.Hour), report[0].MaxLeverageTime)
+	assert.Equal(t, "0.225", report[0].MarginUsage.String())
+
+	// The ratio sampled last on the first day holds until the first sample
+	// of the second
+	assert.Equal(t, start.Add(24*time.Hour), report[1].Day)
+	assert.Equal(t, "4", report[1].MaxLeverage.String())
+	assert.Equal(t, "0.15", report[1].MarginUsage.String())
+}
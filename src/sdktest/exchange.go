@@ -0,0 +1,1091 @@
+// Package sdktest provides an in-process fake of the exchange REST API for
+// testing code built on the SDK without network access or testnet keys.
+package sdktest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/extended-protocol/extended-sdk-golang/src/internal/websocket"
+	"github.com/shopspring/decimal"
+)
+
+// Account and client IDs of the API key used against the fake exchange.
+// Resting orders seeded with the same IDs are treated as our own for
+// self-trade protection.
+const (
+	OwnAccountID int64 = 1
+	OwnClientID  int64 = 1
+)
+
+// RestingOrder is a limit order resting on the fake book
+type RestingOrder struct {
+	ID         uint
+	ExternalID string
+	Market     string
+	Side       sdk.OrderSide
+	Price      decimal.Decimal
+	Qty        decimal.Decimal
+	AccountID  int64
+	ClientID   int64
+}
+
+// Exchange is a fake exchange served over HTTP. It accepts unsigned orders,
+// matches them against seeded resting orders and rejects them the way the
+// exchange would for self-trade protection, post-only crossing and
+// reduce-only violations.
+type Exchange struct {
+	server *httptest.Server
+	faults faultInjector
+
+	mu        sync.Mutex
+	nextID    uint
+	markets   map[string]sdk.MarketModel
+	stats     map[string]sdk.MarketStatsModel
+	fees      map[string]sdk.TradingFeeModel
+	orders    []*RestingOrder
+	history   map[string]*sdk.OpenOrderModel // own orders by external ID
+	positions map[string]decimal.Decimal     // signed size, negative when short
+	openPrice map[string]decimal.Decimal
+	balance   decimal.Decimal
+	limits    map[string]sdk.WithdrawalLimitsModel
+	trades    []sdk.AccountTradeModel           // own fills, oldest first
+	funding   map[string][]sdk.FundingRateModel // oldest first
+	leverage  map[string]decimal.Decimal        // per market, 1 unless set
+	assetOps  []sdk.AssetOperationModel         // oldest first
+
+	// reported with the balance
+	accountLeverage decimal.Decimal
+	marginRatio     decimal.Decimal
+}
+
+// NewExchange starts a fake exchange with the BTC-USD market listed
+func NewExchange() *Exchange {
+	e := &Exchange{
+		nextID:    1,
+		markets:   make(map[string]sdk.MarketModel),
+		stats:     make(map[string]sdk.MarketStatsModel),
+		fees:      make(map[string]sdk.TradingFeeModel),
+		positions: make(map[string]decimal.Decimal),
+		openPrice: make(map[string]decimal.Decimal),
+		history:   make(map[string]*sdk.OpenOrderModel),
+		balance:   decimal.NewFromInt(10000),
+		limits:    make(map[string]sdk.WithdrawalLimitsModel),
+		funding:   make(map[string][]sdk.FundingRateModel),
+		leverage:  make(map[string]decimal.Decimal),
+	}
+	e.AddMarket(BTCUSDMarket())
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /info/markets", e.handleMarkets)
+	mux.HandleFunc("GET /info/markets/{market}/orderbook", e.handleOrderbook)
+	mux.HandleFunc("GET /info/markets/{market}/stats", e.handleMarketStats)
+	mux.HandleFunc("GET /info/{market}/funding", e.handleFundingHistory)
+	mux.HandleFunc("GET /user/fees", e.handleFees)
+	mux.HandleFunc("GET /user/balance", e.handleBalance)
+	mux.HandleFunc("GET /user/positions", e.handlePositions)
+	mux.HandleFunc("GET /user/withdrawal/limits", e.handleWithdrawalLimits)
+	mux.HandleFunc("GET /user/trades", e.handleTrades)
+	mux.HandleFunc("GET /user/assetOperations", e.handleAssetOperations)
+	mux.HandleFunc("POST /user/withdrawal", e.handleWithdraw)
+	mux.HandleFunc("POST /user/transfer", e.handleTransfer)
+	mux.HandleFunc("GET /user/leverage", e.handleLeverage)
+	mux.HandleFunc("PATCH /user/leverage", e.handleUpdateLeverage)
+	mux.HandleFunc("POST /user/order", e.handlePlaceOrder)
+	mux.HandleFunc("DELETE /user/order", e.handleCancelByExternalID)
+	mux.HandleFunc("DELETE /user/order/{id}", e.handleCancelByID)
+	mux.HandleFunc("POST /user/order/massCancel", e.handleMassCancel)
+	mux.HandleFunc("GET /user/orders", e.handleOpenOrders)
+	mux.HandleFunc("GET /user/orders/external/{externalId}", e.handleOrderByExternalID)
+	mux.HandleFunc("GET /stream/", e.handleStream)
+	e.server = httptest.NewServer(e.faults.wrap(mux))
+	return e
+}
+
+// BTCUSDMarket returns the market listed by default
+func BTCUSDMarket() sdk.MarketModel {
+	return sdk.MarketModel{
+		Name:                     "BTC-USD",
+		AssetName:                "BTC",
+		AssetPrecision:           5,
+		CollateralAssetName:      "USD",
+		CollateralAssetPrecision: 6,
+		Active:                   true,
+		Status:                   sdk.MarketStatusActive,
+		L2Config: sdk.L2ConfigModel{
+			Type:                 "STARKX",
+			CollateralID:         "0x31857064564ed0ff978e687456963cba09c2c6985d8f9300a1de4962fafa054",
+			CollateralResolution: 1000000,
+			SyntheticID:          "0x4254432d3600000000000000000000",
+			SyntheticResolution:  1000000,
+		},
+	}
+}
+
+// URL returns the base URL of the fake API
+func (e *Exchange) URL() string {
+	return e.server.URL
+}
+
+// EndpointConfig returns a config pointing the SDK at the fake exchange
+func (e *Exchange) EndpointConfig() sdk.EndpointConfig {
+	return sdk.EndpointConfig{
+		APIBaseURL: e.server.URL,
+		StreamURL:  "ws" + strings.TrimPrefix(e.server.URL, "http") + "/stream",
+	}
+}
+
+// NewClient returns an API client talking to the fake exchange
+func (e *Exchange) NewClient(opts ...sdk.ClientOption) *sdk.APIClient {
+	opts = append([]sdk.ClientOption{sdk.WithStrictDecoding()}, opts...)
+	return sdk.NewAPIClient(e.EndpointConfig(), "sdktest-api-key", nil, 5*time.Second, opts...)
+}
+
+// Close shuts the server down
+func (e *Exchange) Close() {
+	e.server.Close()
+}
+
+// AddMarket lists a market, using the default fees for it
+func (e *Exchange) AddMarket(market sdk.MarketModel) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.markets[market.Name] = market
+	fees := sdk.DefaultFees
+	fees.Market = market.Name
+	e.fees[market.Name] = fees
+}
+
+// SetFees replaces the trading fees of the market named in fees
+func (e *Exchange) SetFees(fees sdk.TradingFeeModel) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.fees[fees.Market] = fees
+}
+
+// UpdateMarket replaces the listing of a market, keeping its fees
+func (e *Exchange) UpdateMarket(market sdk.MarketModel) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.markets[market.Name] = market
+}
+
+// RemoveMarket drops a market from the listing
+func (e *Exchange) RemoveMarket(name string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.markets, name)
+}
+
+// SetPosition sets the signed position size in a market; negative sizes are short
+func (e *Exchange) SetPosition(market string, size, openPrice decimal.Decimal) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.positions[market] = size
+	e.openPrice[market] = openPrice
+}
+
+// Position returns the signed position size in a market
+func (e *Exchange) Position(market string) decimal.Decimal {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.positions[market]
+}
+
+// AddRestingOrder seeds the book with a resting limit order and returns it
+// with its assigned ID. Zero account and client IDs are treated as another
+// participant.
+func (e *Exchange) AddRestingOrder(order RestingOrder) RestingOrder {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if order.ID == 0 {
+		order.ID = e.nextID
+		e.nextID++
+	}
+	o := order
+	e.orders = append(e.orders, &o)
+	return order
+}
+
+// RestingOrders returns a copy of the orders currently on the book
+func (e *Exchange) RestingOrders() []RestingOrder {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	out := make([]RestingOrder, 0, len(e.orders))
+	for _, o := range e.orders {
+		out = append(out, *o)
+	}
+	return out
+}
+
+// handleStream accepts stream connections and holds them open without
+// sending anything, enough for connectivity checks
+func (e *Exchange) handleStream(w http.ResponseWriter, r *http.Request) {
+	conn, err := websocket.Accept(w, r)
+	if err != nil {
+		return
+	}
+	defer conn.Close(websocket.CloseNormal, "")
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+func (e *Exchange) handleMarkets(w http.ResponseWriter, r *http.Request) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	names := r.URL.Query()["market"]
+	data := []sdk.MarketModel{}
+	if len(names) == 0 {
+		for _, m := range e.markets {
+			data = append(data, m)
+		}
+	}
+	for _, name := range names {
+		m, ok := e.markets[name]
+		if !ok {
+			writeError(w, http.StatusBadRequest, reasonCodes[sdk.OrderStatusReasonUnknownMarket], "market not found: "+name)
+			return
+		}
+		data = append(data, m)
+	}
+	writeOK(w, data)
+}
+
+// Orderbook aggregates the resting orders of a market into price levels
+func (e *Exchange) Orderbook(market string) sdk.OrderbookUpdateModel {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.orderbook(market)
+}
+
+func (e *Exchange) orderbook(market string) sdk.OrderbookUpdateModel {
+	book := sdk.OrderbookUpdateModel{
+		Market: market,
+		Bid:    []sdk.OrderbookQuantityModel{},
+		Ask:    []sdk.OrderbookQuantityModel{},
+	}
+	// Resting orders crossed by an infinitely aggressive order, best price first
+	bids := e.crossing(market, sdk.OrderSideSell, decimal.Zero)
+	asks := e.crossing(market, sdk.OrderSideBuy, decimal.New(1, 18))
+	book.Bid = aggregateLevels(bids)
+	book.Ask = aggregateLevels(asks)
+	return book
+}
+
+func aggregateLevels(orders []*RestingOrder) []sdk.OrderbookQuantityModel {
+	levels := []sdk.OrderbookQuantityModel{}
+	for _, o := range orders {
+		if n := len(levels); n > 0 && levels[n-1].Price.Equal(o.Price) {
+			levels[n-1].Qty = levels[n-1].Qty.Add(o.Qty)
+			continue
+		}
+		levels = append(levels, sdk.OrderbookQuantityModel{Price: o.Price, Qty: o.Qty})
+	}
+	return levels
+}
+
+func (e *Exchange) handleOrderbook(w http.ResponseWriter, r *http.Request) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	market := r.PathValue("market")
+	if _, ok := e.markets[market]; !ok {
+		writeError(w, http.StatusBadRequest, reasonCodes[sdk.OrderStatusReasonUnknownMarket], "market not found: "+market)
+		return
+	}
+	book := e.orderbook(market)
+	if depth, err := strconv.Atoi(r.URL.Query().Get("depth")); err == nil {
+		book.Truncate(depth)
+	}
+	writeOK(w, book)
+}
+
+// SetMarketStats sets the 24h statistics reported for a market. Bid and ask
+// prices left at zero are taken from the resting orders.
+func (e *Exchange) SetMarketStats(market string, stats sdk.MarketStatsModel) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.stats[market] = stats
+}
+
+func (e *Exchange) handleMarketStats(w http.ResponseWriter, r *http.Request) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	market := r.PathValue("market")
+	if _, ok := e.markets[market]; !ok {
+		writeError(w, http.StatusBadRequest, reasonCodes[sdk.OrderStatusReasonUnknownMarket], "market not found: "+market)
+		return
+	}
+	stats := e.stats[market]
+	book := e.orderbook(market)
+	if stats.BidPrice.IsZero() && len(book.Bid) > 0 {
+		stats.BidPrice = book.Bid[0].Price
+	}
+	if stats.AskPrice.IsZero() && len(book.Ask) > 0 {
+		stats.AskPrice = book.Ask[0].Price
+	}
+	writeOK(w, stats)
+}
+
+func (e *Exchange) handleFees(w http.ResponseWriter, r *http.Request) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	data := []sdk.TradingFeeModel{}
+	for _, name := range r.URL.Query()["market"] {
+		fees, ok := e.fees[name]
+		if !ok {
+			writeError(w, http.StatusBadRequest, reasonCodes[sdk.OrderStatusReasonUnknownMarket], "market not found: "+name)
+			return
+		}
+		data = append(data, fees)
+	}
+	writeOK(w, data)
+}
+
+// AddFundingRate records a funding payment of a market at the given time.
+// Rates must be added in time order.
+func (e *Exchange) AddFundingRate(market string, at time.Time, rate decimal.Decimal) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.funding[market] = append(e.funding[market], sdk.FundingRateModel{Market: market, FundingRate: rate, Timestamp: at.UnixMilli()})
+}
+
+func (e *Exchange) handleFundingHistory(w http.ResponseWriter, r *http.Request) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	q := r.URL.Query()
+	since, _ := strconv.ParseInt(q.Get("startTime"), 10, 64)
+	until, _ := strconv.ParseInt(q.Get("endTime"), 10, 64)
+	history := e.funding[r.PathValue("market")]
+	data := []sdk.FundingRateModel{}
+	for i := len(history) - 1; i >= 0; i-- {
+		f := history[i]
+		if (since > 0 && f.Timestamp < since) || (until > 0 && f.Timestamp > until) {
+			continue
+		}
+		data = append(data, f)
+	}
+	writeOK(w, data)
+}
+
+func (e *Exchange) handleLeverage(w http.ResponseWriter, r *http.Request) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	markets := r.URL.Query()["market"]
+	if len(markets) == 0 {
+		for name := range e.markets {
+			markets = append(markets, name)
+		}
+		slices.Sort(markets)
+	}
+	data := []sdk.AccountLeverageModel{}
+	for _, market := range markets {
+		if _, ok := e.markets[market]; ok {
+			data = append(data, sdk.AccountLeverageModel{Market: market, Leverage: e.leverageOf(market)})
+		}
+	}
+	writeOK(w, data)
+}
+
+// handleUpdateLeverage rejects leverage above the market maximum, when set
+func (e *Exchange) handleUpdateLeverage(w http.ResponseWriter, r *http.Request) {
+	var req sdk.AccountLeverageModel
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidRequest, err.Error())
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	market, ok := e.markets[req.Market]
+	if !ok {
+		writeError(w, http.StatusBadRequest, reasonCodes[sdk.OrderStatusReasonUnknownMarket], "market not found: "+req.Market)
+		return
+	}
+	max := market.TradingConfig.MaxLeverage
+	if !req.Leverage.IsPositive() || (max.IsPositive() && req.Leverage.GreaterThan(max)) {
+		writeError(w, http.StatusBadRequest, codeInvalidLeverage, "leverage out of range: "+req.Leverage.String())
+		return
+	}
+	e.leverage[req.Market] = req.Leverage
+	writeOK(w, sdk.AccountLeverageModel{Market: req.Market, Leverage: req.Leverage})
+}
+
+func (e *Exchange) leverageOf(market string) decimal.Decimal {
+	if leverage, ok := e.leverage[market]; ok {
+		return leverage
+	}
+	return decimal.NewFromInt(1)
+}
+
+// SetBalance sets the collateral balance reported for the account
+func (e *Exchange) SetBalance(balance decimal.Decimal) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.balance = balance
+}
+
+// SetMarginUsage sets the account leverage and margin ratio reported with
+// the balance
+func (e *Exchange) SetMarginUsage(leverage, marginRatio decimal.Decimal) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.accountLeverage, e.marginRatio = leverage, marginRatio
+}
+
+func (e *Exchange) handleBalance(w http.ResponseWriter, r *http.Request) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	writeOK(w, sdk.BalanceModel{
+		CollateralName:         "USD",
+		Balance:                e.balance,
+		Equity:                 e.balance,
+		AvailableForTrade:      e.balance,
+		AvailableForWithdrawal: e.balance,
+		Leverage:               e.accountLeverage,
+		MarginRatio:            e.marginRatio,
+	})
+}
+
+// SetWithdrawalLimits sets the limits reported for limits.Chain
+func (e *Exchange) SetWithdrawalLimits(limits sdk.WithdrawalLimitsModel) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.limits[limits.Chain] = limits
+}
+
+func (e *Exchange) handleWithdrawalLimits(w http.ResponseWriter, r *http.Request) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	chain := r.URL.Query().Get("chain")
+	limits, ok := e.limits[chain]
+	if !ok {
+		writeError(w, http.StatusBadRequest, codeUnknownChain, "chain not supported: "+chain)
+		return
+	}
+	writeOK(w, limits)
+}
+
+func (e *Exchange) handlePositions(w http.ResponseWriter, r *http.Request) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	data := []sdk.PositionModel{}
+	for market, size := range e.positions {
+		if size.IsZero() {
+			continue
+		}
+		side := sdk.PositionSideLong
+		if size.IsNegative() {
+			side = sdk.PositionSideShort
+		}
+		data = append(data, sdk.PositionModel{
+			AccountID: OwnAccountID,
+			Market:    market,
+			Side:      side,
+			Size:      size.Abs(),
+			OpenPrice: e.openPrice[market],
+			MarkPrice: e.openPrice[market],
+		})
+	}
+	writeOK(w, data)
+}
+
+func (e *Exchange) handlePlaceOrder(w http.ResponseWriter, r *http.Request) {
+	var order sdk.PerpetualOrderModel
+	if err := json.NewDecoder(r.Body).Decode(&order); err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidRequest, err.Error())
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	id := e.nextID
+	e.nextID++
+	e.record(id, &order)
+
+	if reason, msg := e.validate(&order); reason != "" {
+		e.setStatus(order.ID, sdk.OrderStatusRejected, reason)
+		writeError(w, http.StatusBadRequest, reasonCodes[reason], msg)
+		return
+	}
+
+	// cancelId atomically replaces a previous order
+	if order.CancelID != nil {
+		e.cancel(func(o *RestingOrder) bool { return o.ExternalID == *order.CancelID })
+	}
+	e.execute(id, &order)
+
+	writeOK(w, map[string]interface{}{"id": id, "externalId": order.ID})
+}
+
+// validate returns the rejection reason for an incoming order, if any
+func (e *Exchange) validate(order *sdk.PerpetualOrderModel) (sdk.OrderStatusReason, string) {
+	if _, ok := e.markets[order.Market]; !ok {
+		return sdk.OrderStatusReasonUnknownMarket, "market not found: " + order.Market
+	}
+	price, err := decimal.NewFromString(order.Price)
+	if err != nil || !price.IsPositive() {
+		return sdk.OrderStatusReasonInvalidPrice, "invalid price: " + order.Price
+	}
+	qty, err := decimal.NewFromString(order.Qty)
+	if err != nil || !qty.IsPositive() {
+		return sdk.OrderStatusReasonInvalidQty, "invalid qty: " + order.Qty
+	}
+
+	// Trading config checks apply only to markets listed with one
+	config := e.markets[order.Market].TradingConfig
+	if tick := config.MinPriceChange; tick.IsPositive() && !price.Mod(tick).IsZero() {
+		return sdk.OrderStatusReasonInvalidPrice, fmt.Sprintf("price %s is not a multiple of the tick size %s", price, tick)
+	}
+	if leverage := config.MaxLeverage; leverage.IsPositive() && !order.ReduceOnly &&
+		qty.Mul(price).GreaterThan(e.balance.Mul(leverage)) {
+		return sdk.OrderStatusReasonNotEnoughFunds, "order value exceeds the available margin"
+	}
+
+	if order.ReduceOnly {
+		position := e.positions[order.Market]
+		reduces := (order.Side == sdk.OrderSideSell && position.IsPositive()) ||
+			(order.Side == sdk.OrderSideBuy && position.IsNegative())
+		if !reduces || qty.GreaterThan(position.Abs()) {
+			return sdk.OrderStatusReasonReduceOnlyFailed, "order would increase or flip the position"
+		}
+	}
+
+	for _, resting := range e.crossing(order.Market, order.Side, price) {
+		if order.PostOnly {
+			return sdk.OrderStatusReasonPostOnlyFailed, "post-only order would take liquidity"
+		}
+		if selfTrade(order.SelfTradeProtectionLevel, resting) {
+			return sdk.OrderStatusReasonSelfTradeProtection, fmt.Sprintf("order would trade against own order %d", resting.ID)
+		}
+	}
+	return "", ""
+}
+
+func selfTrade(level sdk.SelfTradeProtectionLevel, resting *RestingOrder) bool {
+	switch level {
+	case sdk.SelfTradeProtectionAccount:
+		return resting.AccountID == OwnAccountID
+	case sdk.SelfTradeProtectionClient:
+		return resting.AccountID == OwnAccountID || resting.ClientID == OwnClientID
+	default:
+		return false
+	}
+}
+
+// crossing returns the resting orders an incoming order at price would trade
+// against, best price first
+func (e *Exchange) crossing(market string, side sdk.OrderSide, price decimal.Decimal) []*RestingOrder {
+	var out []*RestingOrder
+	for _, o := range e.orders {
+		if o.Market != market || o.Side == side {
+			continue
+		}
+		if (side == sdk.OrderSideBuy && o.Price.LessThanOrEqual(price)) ||
+			(side == sdk.OrderSideSell && o.Price.GreaterThanOrEqual(price)) {
+			out = append(out, o)
+		}
+	}
+	// Insertion sort keeps the fake dependency free and books are tiny
+	for i := 1; i < len(out); i++ {
+		for j := i; j > 0; j-- {
+			better := out[j].Price.LessThan(out[j-1].Price)
+			if side == sdk.OrderSideSell {
+				better = out[j].Price.GreaterThan(out[j-1].Price)
+			}
+			if !better {
+				break
+			}
+			out[j], out[j-1] = out[j-1], out[j]
+		}
+	}
+	return out
+}
+
+// execute fills the order against the book and rests any remainder.
+// IOC remainders are dropped and FOK orders that cannot fill completely are
+// cancelled without trading.
+func (e *Exchange) execute(id uint, order *sdk.PerpetualOrderModel) {
+	price, _ := decimal.NewFromString(order.Price)
+	remaining, _ := decimal.NewFromString(order.Qty)
+	// At most tradable trades against the book, see Faults.PartialFill
+	tradable := remaining
+	if ratio := e.partialFill(); ratio.IsPositive() && ratio.LessThan(decimal.NewFromInt(1)) {
+		tradable = remaining.Mul(ratio).RoundDown(e.markets[order.Market].QtyPrecision())
+	}
+
+	crossing := e.crossing(order.Market, order.Side, price)
+	if order.TimeInForce == sdk.TimeInForceFOK {
+		liquidity := decimal.Zero
+		for _, resting := range crossing {
+			liquidity = liquidity.Add(resting.Qty)
+		}
+		if tradable.LessThan(remaining) || liquidity.LessThan(remaining) {
+			e.setStatus(order.ID, sdk.OrderStatusCancelled, "")
+			return
+		}
+	}
+
+	for _, resting := range crossing {
+		if !tradable.IsPositive() {
+			break
+		}
+		fill := decimal.Min(tradable, resting.Qty)
+		tradable = tradable.Sub(fill)
+		remaining = remaining.Sub(fill)
+		resting.Qty = resting.Qty.Sub(fill)
+		e.applyFill(order.Market, order.Side, fill, resting.Price)
+		e.recordFill(order.ID, fill, resting.Price, true)
+		e.recordFill(resting.ExternalID, fill, resting.Price, false)
+	}
+	e.removeFilled()
+
+	if !remaining.IsPositive() {
+		return
+	}
+	if order.TimeInForce == sdk.TimeInForceIOC || order.TimeInForce == sdk.TimeInForceFOK {
+		e.setStatus(order.ID, sdk.OrderStatusCancelled, "")
+	} else {
+		e.orders = append(e.orders, &RestingOrder{
+			ID:         id,
+			ExternalID: order.ID,
+			Market:     order.Market,
+			Side:       order.Side,
+			Price:      price,
+			Qty:        remaining,
+			AccountID:  OwnAccountID,
+			ClientID:   OwnClientID,
+		})
+	}
+}
+
+// Fill simulates another participant trading qty against our resting order
+// with the given external ID. It returns false if no such order rests.
+func (e *Exchange) Fill(externalID string, qty decimal.Decimal) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, o := range e.orders {
+		if o.ExternalID != externalID || o.AccountID != OwnAccountID {
+			continue
+		}
+		fill := decimal.Min(qty, o.Qty)
+		o.Qty = o.Qty.Sub(fill)
+		e.applyFill(o.Market, o.Side, fill, o.Price)
+		e.recordFill(externalID, fill, o.Price, false)
+		e.removeFilled()
+		return true
+	}
+	return false
+}
+
+// Order returns the state of an order we placed, by external ID
+func (e *Exchange) Order(externalID string) (sdk.OpenOrderModel, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	o, ok := e.history[externalID]
+	if !ok {
+		return sdk.OpenOrderModel{}, false
+	}
+	return *o, true
+}
+
+func (e *Exchange) record(id uint, order *sdk.PerpetualOrderModel) {
+	price, _ := decimal.NewFromString(order.Price)
+	qty, _ := decimal.NewFromString(order.Qty)
+	e.history[order.ID] = &sdk.OpenOrderModel{
+		ID:          int64(id),
+		AccountID:   OwnAccountID,
+		ExternalID:  order.ID,
+		Market:      order.Market,
+		Type:        order.Type,
+		Side:        order.Side,
+		Status:      sdk.OrderStatusNew,
+		Price:       price,
+		Qty:         qty,
+		ReduceOnly:  order.ReduceOnly,
+		PostOnly:    order.PostOnly,
+		CreatedTime: time.Now().UnixMilli(),
+		UpdatedTime: time.Now().UnixMilli(),
+		ExpireTime:  order.ExpiryEpochMillis,
+	}
+}
+
+func (e *Exchange) recordFill(externalID string, qty, price decimal.Decimal, taker bool) {
+	o, ok := e.history[externalID]
+	if !ok {
+		return
+	}
+	e.trades = append(e.trades, sdk.AccountTradeModel{
+		ID:          int64(len(e.trades) + 1),
+		AccountID:   OwnAccountID,
+		Market:      o.Market,
+		OrderID:     o.ID,
+		Side:        o.Side,
+		Price:       price,
+		Qty:         qty,
+		Value:       price.Mul(qty),
+		IsTaker:     taker,
+		TradeType:   sdk.TradeTypeTrade,
+		CreatedTime: time.Now().UnixMilli(),
+	})
+	filled := o.FilledQty.Add(qty)
+	o.AveragePrice = o.AveragePrice.Mul(o.FilledQty).Add(price.Mul(qty)).Div(filled)
+	o.FilledQty = filled
+	o.Status = sdk.OrderStatusPartiallyFilled
+	if o.FilledQty.GreaterThanOrEqual(o.Qty) {
+		o.Status = sdk.OrderStatusFilled
+	}
+	o.UpdatedTime = time.Now().UnixMilli()
+}
+
+func (e *Exchange) setStatus(externalID string, status sdk.OrderStatus, reason sdk.OrderStatusReason) {
+	if o, ok := e.history[externalID]; ok {
+		o.Status = status
+		o.StatusReason = reason
+		o.UpdatedTime = time.Now().UnixMilli()
+	}
+}
+
+// handleTrades lists own fills newest first, honouring the market, side,
+// type, cursor and limit filters
+func (e *Exchange) handleTrades(w http.ResponseWriter, r *http.Request) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	q := r.URL.Query()
+	markets := q["market"]
+	cursor, _ := strconv.ParseInt(q.Get("cursor"), 10, 64)
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	data := []sdk.AccountTradeModel{}
+	for i := len(e.trades) - 1; i >= 0; i-- {
+		t := e.trades[i]
+		if (len(markets) > 0 && !slices.Contains(markets, t.Market)) ||
+			(q.Has("side") && string(t.Side) != q.Get("side")) ||
+			(q.Has("type") && string(t.TradeType) != q.Get("type")) ||
+			(cursor > 0 && t.ID >= cursor) {
+			continue
+		}
+		if limit > 0 && len(data) == limit {
+			break
+		}
+		data = append(data, t)
+	}
+	writeOK(w, data)
+}
+
+// AddAssetOperation records a deposit, withdrawal or transfer of the account
+func (e *Exchange) AddAssetOperation(op sdk.AssetOperationModel) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.assetOps = append(e.assetOps, op)
+}
+
+// handleAssetOperations lists asset operations newest first, honouring the
+// type and status filters
+func (e *Exchange) handleAssetOperations(w http.ResponseWriter, r *http.Request) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	q := r.URL.Query()
+	data := []sdk.AssetOperationModel{}
+	for i := len(e.assetOps) - 1; i >= 0; i-- {
+		op := e.assetOps[i]
+		if (q.Has("type") && !slices.Contains(q["type"], string(op.Type))) ||
+			(q.Has("status") && !slices.Contains(q["status"], string(op.Status))) {
+			continue
+		}
+		data = append(data, op)
+	}
+	writeOK(w, data)
+}
+
+// handleWithdraw accepts unsigned withdrawals within the limits of the chain
+// and the balance, recording them as asset operations
+func (e *Exchange) handleWithdraw(w http.ResponseWriter, r *http.Request) {
+	var req sdk.WithdrawalModel
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidRequest, err.Error())
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	limits, ok := e.limits[req.ChainID]
+	if !ok {
+		writeError(w, http.StatusBadRequest, codeUnknownChain, "chain not supported: "+req.ChainID)
+		return
+	}
+	if err := limits.Validate(req.Amount); err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidRequest, err.Error())
+		return
+	}
+	if req.Amount.GreaterThan(e.balance) {
+		writeError(w, http.StatusBadRequest, codeNotEnoughFunds, "not enough funds: "+req.Amount.String())
+		return
+	}
+	e.balance = e.balance.Sub(req.Amount)
+	op := sdk.AssetOperationModel{
+		ID:        strconv.Itoa(len(e.assetOps) + 1),
+		Type:      sdk.AssetOperationWithdrawal,
+		Status:    sdk.AssetOperationStatusCreated,
+		Amount:    req.Amount,
+		Fee:       limits.Fee(req.Amount),
+		Time:      time.Now().UnixMilli(),
+		AccountID: OwnAccountID,
+	}
+	e.assetOps = append(e.assetOps, op)
+	writeOK(w, op.ID)
+}
+
+// handleTransfer accepts unsigned transfers within the balance, recording
+// them as outgoing asset operations
+func (e *Exchange) handleTransfer(w http.ResponseWriter, r *http.Request) {
+	var req sdk.TransferModel
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidRequest, err.Error())
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !req.Amount.IsPositive() || req.Amount.GreaterThan(e.balance) {
+		writeError(w, http.StatusBadRequest, codeNotEnoughFunds, "not enough funds: "+req.Amount.String())
+		return
+	}
+	e.balance = e.balance.Sub(req.Amount)
+	op := sdk.AssetOperationModel{
+		ID:                    strconv.Itoa(len(e.assetOps) + 1),
+		Type:                  sdk.AssetOperationTransfer,
+		Status:                sdk.AssetOperationStatusCompleted,
+		Amount:                req.Amount.Neg(),
+		Time:                  time.Now().UnixMilli(),
+		AccountID:             OwnAccountID,
+		CounterpartyAccountID: req.ToAccount,
+	}
+	e.assetOps = append(e.assetOps, op)
+	writeOK(w, op.ID)
+}
+
+// AssetOperations returns the deposits, withdrawals and transfers of the
+// account, oldest first
+func (e *Exchange) AssetOperations() []sdk.AssetOperationModel {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return slices.Clone(e.assetOps)
+}
+
+// handleOpenOrders lists own resting orders, honouring the market and side filters
+func (e *Exchange) handleOpenOrders(w http.ResponseWriter, r *http.Request) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	q := r.URL.Query()
+	markets := q["market"]
+	data := []sdk.OpenOrderModel{}
+	for _, o := range e.orders {
+		if o.AccountID != OwnAccountID ||
+			(len(markets) > 0 && !slices.Contains(markets, o.Market)) ||
+			(q.Has("side") && string(o.Side) != q.Get("side")) {
+			continue
+		}
+		if h, ok := e.history[o.ExternalID]; ok {
+			data = append(data, *h)
+			continue
+		}
+		data = append(data, sdk.OpenOrderModel{
+			ID:         int64(o.ID),
+			AccountID:  o.AccountID,
+			ExternalID: o.ExternalID,
+			Market:     o.Market,
+			Type:       sdk.OrderTypeLimit,
+			Side:       o.Side,
+			Status:     sdk.OrderStatusNew,
+			Price:      o.Price,
+			Qty:        o.Qty,
+		})
+	}
+	writeOK(w, data)
+}
+
+func (e *Exchange) handleOrderByExternalID(w http.ResponseWriter, r *http.Request) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	o, ok := e.history[r.PathValue("externalId")]
+	if !ok {
+		writeOK(w, []sdk.OpenOrderModel{})
+		return
+	}
+	writeOK(w, []sdk.OpenOrderModel{*o})
+}
+
+func (e *Exchange) applyFill(market string, side sdk.OrderSide, qty, price decimal.Decimal) {
+	signed := qty
+	if side == sdk.OrderSideSell {
+		signed = qty.Neg()
+	}
+	before := e.positions[market]
+	after := before.Add(signed)
+	if before.IsZero() || before.Sign() != after.Sign() {
+		e.openPrice[market] = price
+	} else if after.Abs().GreaterThan(before.Abs()) {
+		// Weighted average entry when increasing the position
+		cost := before.Abs().Mul(e.openPrice[market]).Add(qty.Mul(price))
+		e.openPrice[market] = cost.Div(after.Abs())
+	}
+	e.positions[market] = after
+}
+
+func (e *Exchange) removeFilled() {
+	kept := e.orders[:0]
+	for _, o := range e.orders {
+		if o.Qty.IsPositive() {
+			kept = append(kept, o)
+		}
+	}
+	e.orders = kept
+}
+
+func (e *Exchange) handleCancelByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidRequest, "invalid order id")
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !e.cancel(func(o *RestingOrder) bool { return o.ID == uint(id) }) {
+		writeError(w, http.StatusNotFound, codeOrderNotFound, "order not found")
+		return
+	}
+	writeOK(w, nil)
+}
+
+func (e *Exchange) handleCancelByExternalID(w http.ResponseWriter, r *http.Request) {
+	externalID := r.URL.Query().Get("externalId")
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !e.cancel(func(o *RestingOrder) bool { return o.ExternalID == externalID }) {
+		writeError(w, http.StatusNotFound, codeOrderNotFound, "order not found")
+		return
+	}
+	writeOK(w, nil)
+}
+
+func (e *Exchange) handleMassCancel(w http.ResponseWriter, r *http.Request) {
+	var params sdk.MassCancelParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidRequest, err.Error())
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.cancel(func(o *RestingOrder) bool {
+		if o.AccountID != OwnAccountID {
+			return false
+		}
+		if params.CancelAll {
+			return true
+		}
+		for _, id := range params.OrderIDs {
+			if uint(id) == o.ID {
+				return true
+			}
+		}
+		for _, id := range params.ExternalOrderIDs {
+			if id == o.ExternalID {
+				return true
+			}
+		}
+		for _, m := range params.Markets {
+			if strings.EqualFold(m, o.Market) {
+				return true
+			}
+		}
+		return false
+	})
+	writeOK(w, nil)
+}
+
+// cancel removes matching own orders and reports whether any matched
+func (e *Exchange) cancel(match func(*RestingOrder) bool) bool {
+	found := false
+	kept := e.orders[:0]
+	for _, o := range e.orders {
+		if o.AccountID == OwnAccountID && match(o) {
+			found = true
+			e.setStatus(o.ExternalID, sdk.OrderStatusCancelled, "")
+			continue
+		}
+		kept = append(kept, o)
+	}
+	e.orders = kept
+	return found
+}
+
+// writeOK writes the success envelope. Responses without data, such as
+// cancels, carry only the status, matching CancelResponse.
+func writeOK(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	body := map[string]interface{}{"status": "OK"}
+	if data != nil {
+		body["data"] = data
+	}
+	json.NewEncoder(w).Encode(body)
+}
+
+// Numeric error codes the fake responds with, as the exchange does
+const (
+	codeInvalidRequest  = 1000
+	codeInvalidLeverage = 1050
+	codeUnknownChain    = 1500
+	codeNotEnoughFunds  = 1510
+	codeOrderNotFound   = 1600
+)
+
+// reasonCodes are the exchange error codes orders are rejected with, one per
+// reason. sdk.APIError.Reason maps them back.
+var reasonCodes = map[sdk.OrderStatusReason]int{
+	sdk.OrderStatusReasonUnknownMarket:       1001,
+	sdk.OrderStatusReasonDisabledMarket:      1002,
+	sdk.OrderStatusReasonInvalidQty:          1100,
+	sdk.OrderStatusReasonInvalidValue:        1102,
+	sdk.OrderStatusReasonInvalidPrice:        1104,
+	sdk.OrderStatusReasonInvalidFee:          1108,
+	sdk.OrderStatusReasonInvalidExpireTime:   1115,
+	sdk.OrderStatusReasonReduceOnlyFailed:    1116,
+	sdk.OrderStatusReasonNotEnoughFunds:      1120,
+	sdk.OrderStatusReasonNoLiquidity:         1136,
+	sdk.OrderStatusReasonSelfTradeProtection: 1137,
+	sdk.OrderStatusReasonPostOnlyFailed:      1138,
+}
+
+func writeError(w http.ResponseWriter, status, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status": "ERROR",
+		"error":  map[string]interface{}{"code": code, "message": message},
+	})
+}
@@ -0,0 +1,341 @@
+package sdktest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func limitOrder(id string, side sdk.OrderSide, qty, price string) *sdk.PerpetualOrderModel {
+	return &sdk.PerpetualOrderModel{
+		ID:                       id,
+		Market:                   "BTC-USD",
+		Type:                     sdk.OrderTypeLimit,
+		Side:                     side,
+		Qty:                      qty,
+		Price:                    price,
+		TimeInForce:              sdk.TimeInForceGTT,
+		SelfTradeProtectionLevel: sdk.SelfTradeProtectionDisabled,
+	}
+}
+
+func TestExchange_SelfTradeProtection(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	client := ex.NewClient()
+	ctx := context.Background()
+
+	ex.AddRestingOrder(RestingOrder{
+		Market: "BTC-USD", Side: sdk.OrderSideSell,
+		Price: decimal.NewFromInt(50000), Qty: decimal.NewFromInt(1),
+		AccountID: 2, ClientID: OwnClientID,
+	})
+
+	// Same client, different account: only CLIENT level protects
+	order := limitOrder("stp-account", sdk.OrderSideBuy, "0.1", "50000")
+	order.SelfTradeProtectionLevel = sdk.SelfTradeProtectionAccount
+	_, err := client.SubmitOrder(ctx, order)
+	require.NoError(t, err)
+
+	order = limitOrder("stp-client", sdk.OrderSideBuy, "0.1", "50000")
+	order.SelfTradeProtectionLevel = sdk.SelfTradeProtectionClient
+	_, err = client.SubmitOrder(ctx, order)
+	require.Error(t, err)
+	assert.True(t, sdk.IsOrderRejected(err, sdk.OrderStatusReasonSelfTradeProtection))
+
+	var apiErr *sdk.APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, 400, apiErr.StatusCode)
+	assert.NotEmpty(t, apiErr.Message)
+}
+
+func TestExchange_FillOrKill(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	client := ex.NewClient()
+	ctx := context.Background()
+
+	seeded := ex.AddRestingOrder(RestingOrder{ExternalID: "ask", Market: "BTC-USD", Side: sdk.OrderSideSell,
+		Price: decimal.NewFromInt(50000), Qty: decimal.RequireFromString("0.5")})
+	assert.NotZero(t, seeded.ID)
+
+	order := limitOrder("fok-too-big", sdk.OrderSideBuy, "1", "50000")
+	order.TimeInForce = sdk.TimeInForceFOK
+	_, err := client.SubmitOrder(ctx, order)
+	require.NoError(t, err)
+	state, _ := ex.Order("fok-too-big")
+	assert.Equal(t, sdk.OrderStatusCancelled, state.Status)
+	assert.True(t, state.FilledQty.IsZero(), "FOK orders fill completely or not at all")
+	assert.True(t, ex.Position("BTC-USD").IsZero())
+	require.Len(t, ex.RestingOrders(), 1)
+	assert.Equal(t, "0.5", ex.RestingOrders()[0].Qty.String())
+
+	order = limitOrder("fok-fits", sdk.OrderSideBuy, "0.5", "50000")
+	order.TimeInForce = sdk.TimeInForceFOK
+	_, err = client.SubmitOrder(ctx, order)
+	require.NoError(t, err)
+	state, _ = ex.Order("fok-fits")
+	assert.Equal(t, sdk.OrderStatusFilled, state.Status)
+	assert.Empty(t, ex.RestingOrders())
+}
+
+func TestExchange_PostOnlyCrossingRejected(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	client := ex.NewClient()
+
+	ex.AddRestingOrder(RestingOrder{
+		Market: "BTC-USD", Side: sdk.OrderSideSell,
+		Price: decimal.NewFromInt(50000), Qty: decimal.NewFromInt(1),
+	})
+
+	order := limitOrder("post-only", sdk.OrderSideBuy, "0.1", "50001")
+	order.PostOnly = true
+	_, err := client.SubmitOrder(context.Background(), order)
+
+	assert.True(t, sdk.IsOrderRejected(err, sdk.OrderStatusReasonPostOnlyFailed))
+	var apiErr *sdk.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "1138", apiErr.Code, "rejections carry numeric codes like the exchange")
+
+	// Below the ask it rests on the book
+	order = limitOrder("post-only-ok", sdk.OrderSideBuy, "0.1", "49999")
+	order.PostOnly = true
+	_, err = client.SubmitOrder(context.Background(), order)
+	require.NoError(t, err)
+	assert.Len(t, ex.RestingOrders(), 2)
+}
+
+func TestExchange_ReduceOnly(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	client := ex.NewClient()
+	ctx := context.Background()
+
+	ex.SetPosition("BTC-USD", decimal.RequireFromString("0.5"), decimal.NewFromInt(48000))
+	ex.AddRestingOrder(RestingOrder{
+		Market: "BTC-USD", Side: sdk.OrderSideBuy,
+		Price: decimal.NewFromInt(49000), Qty: decimal.NewFromInt(2),
+	})
+
+	// Larger than the position
+	order := limitOrder("reduce-too-much", sdk.OrderSideSell, "1", "49000")
+	order.ReduceOnly = true
+	_, err := client.SubmitOrder(ctx, order)
+	assert.True(t, sdk.IsOrderRejected(err, sdk.OrderStatusReasonReduceOnlyFailed))
+
+	// Same side as the position
+	order = limitOrder("reduce-wrong-side", sdk.OrderSideBuy, "0.1", "49000")
+	order.ReduceOnly = true
+	_, err = client.SubmitOrder(ctx, order)
+	assert.True(t, sdk.IsOrderRejected(err, sdk.OrderStatusReasonReduceOnlyFailed))
+
+	order = limitOrder("reduce-ok", sdk.OrderSideSell, "0.2", "49000")
+	order.ReduceOnly = true
+	_, err = client.SubmitOrder(ctx, order)
+	require.NoError(t, err)
+	assert.Equal(t, "0.3", ex.Position("BTC-USD").String())
+
+	positions, err := client.GetPositions(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, positions, 1)
+	assert.Equal(t, sdk.PositionSideLong, positions[0].Side)
+}
+
+func TestExchange_CancelOwnOrders(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	client := ex.NewClient()
+	ctx := context.Background()
+
+	_, err := client.SubmitOrder(ctx, limitOrder("a", sdk.OrderSideBuy, "0.1", "40000"))
+	require.NoError(t, err)
+	_, err = client.SubmitOrder(ctx, limitOrder("b", sdk.OrderSideBuy, "0.1", "40001"))
+	require.NoError(t, err)
+
+	require.NoError(t, client.CancelOrderByExternalID(ctx, "a"))
+	require.NoError(t, client.MassCancel(ctx, sdk.MassCancelParams{ExternalOrderIDs: []string{"b"}}))
+	assert.Empty(t, ex.RestingOrders())
+
+	err = client.CancelOrderByExternalID(ctx, "a")
+	var apiErr *sdk.APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, 404, apiErr.StatusCode)
+}
+
+func TestManualClock_DrivesClientStats(t *testing.T) {
+	start := time.Date(2024, 1, 5, 1, 8, 57, 0, time.UTC)
+	clock := NewManualClock(start)
+
+	ex := NewExchange()
+	defer ex.Close()
+	client := ex.NewClient(sdk.WithClock(clock))
+
+	assert.Equal(t, start, client.Stats().Since)
+
+	clock.Advance(time.Minute)
+	client.ResetStats()
+	assert.Equal(t, start.Add(time.Minute), client.Stats().Since)
+}
+
+func TestExchange_OrderbookSnapshotDepth(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	client := ex.NewClient()
+
+	for i := 0; i < 10; i++ {
+		ex.AddRestingOrder(RestingOrder{
+			Market: "BTC-USD", Side: sdk.OrderSideBuy,
+			Price: decimal.NewFromInt(int64(49990 - i)), Qty: decimal.NewFromInt(1),
+		})
+		ex.AddRestingOrder(RestingOrder{
+			Market: "BTC-USD", Side: sdk.OrderSideSell,
+			Price: decimal.NewFromInt(int64(50010 + i)), Qty: decimal.NewFromInt(1),
+		})
+	}
+	// A second order on the best bid is aggregated into one level
+	ex.AddRestingOrder(RestingOrder{
+		Market: "BTC-USD", Side: sdk.OrderSideBuy,
+		Price: decimal.NewFromInt(49990), Qty: decimal.NewFromInt(2),
+	})
+
+	full, err := client.GetOrderbookSnapshot(context.Background(), "BTC-USD")
+	require.NoError(t, err)
+	assert.Len(t, full.Bid, 10)
+	assert.Len(t, full.Ask, 10)
+
+	top, err := client.GetOrderbookSnapshot(context.Background(), "BTC-USD", sdk.WithDepth(5))
+	require.NoError(t, err)
+	require.Len(t, top.Bid, 5)
+	require.Len(t, top.Ask, 5)
+	assert.Equal(t, "49990", top.Bid[0].Price.String())
+	assert.Equal(t, "3", top.Bid[0].Qty.String())
+	assert.Equal(t, "50010", top.Ask[0].Price.String())
+	assert.Equal(t, "50014", top.Ask[4].Price.String())
+
+	_, err = client.GetOrderbookSnapshot(context.Background(), "NOPE-USD")
+	assert.Error(t, err)
+}
+
+func TestExchange_GetMarketsInactiveFilter(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	delisted := BTCUSDMarket()
+	delisted.Name = "LUNA-USD"
+	delisted.Active = false
+	delisted.Status = sdk.MarketStatusDelisted
+	ex.AddMarket(delisted)
+	client := ex.NewClient()
+	ctx := context.Background()
+
+	all, err := client.GetMarkets(ctx, nil)
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	active, err := client.GetMarkets(ctx, nil, sdk.WithInactiveMarkets(false))
+	require.NoError(t, err)
+	require.Len(t, active, 1)
+	assert.Equal(t, "BTC-USD", active[0].Name)
+
+	markets, err := client.GetMarkets(ctx, []string{"LUNA-USD"})
+	require.NoError(t, err)
+	require.Len(t, markets, 1)
+	assert.Equal(t, sdk.MarketStatusDelisted, markets[0].Status)
+}
+
+func TestExchange_GetMarketsUnknownMarket(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+
+	_, err := ex.NewClient().GetMarkets(context.Background(), []string{"BTC-USD", "NOPE-USD"})
+	require.ErrorIs(t, err, sdk.ErrUnknownMarket)
+	assert.Contains(t, err.Error(), "NOPE-USD")
+
+	var apiErr *sdk.APIError
+	assert.ErrorAs(t, err, &apiErr)
+}
+
+func TestExchange_WithdrawalLimits(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	ex.SetWithdrawalLimits(sdk.WithdrawalLimitsModel{
+		Chain:     "ETH",
+		Asset:     "USDC",
+		MinAmount: decimal.NewFromInt(10),
+		MaxAmount: decimal.NewFromInt(100000),
+		Fees: []sdk.WithdrawalFeeTierModel{
+			{MinAmount: decimal.NewFromInt(10000), Fee: decimal.NewFromInt(5), FeeRate: decimal.RequireFromString("0.0001")},
+			{MinAmount: decimal.Zero, Fee: decimal.NewFromInt(5)},
+		},
+	})
+	client := ex.NewClient()
+
+	limits, err := client.GetWithdrawalLimits(context.Background(), "ETH")
+	require.NoError(t, err)
+	assert.Equal(t, "USDC", limits.Asset)
+
+	assert.NoError(t, limits.Validate(decimal.NewFromInt(500)))
+	assert.ErrorIs(t, limits.Validate(decimal.NewFromInt(5)), sdk.ErrInvalidWithdrawalAmount)
+	assert.ErrorIs(t, limits.Validate(decimal.NewFromInt(200000)), sdk.ErrInvalidWithdrawalAmount)
+
+	assert.Equal(t, "5", limits.Fee(decimal.NewFromInt(500)).String())
+	assert.Equal(t, "7", limits.Fee(decimal.NewFromInt(20000)).String())
+
+	_, err = client.GetWithdrawalLimits(context.Background(), "BTC")
+	var apiErr *sdk.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "1500", apiErr.Code)
+}
+
+func TestExchange_CachedMarketFee(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	client := ex.NewClient()
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		fees, err := client.CachedMarketFee(ctx, "BTC-USD")
+		require.NoError(t, err)
+		assert.Equal(t, "BTC-USD", fees.Market)
+	}
+	assert.Equal(t, uint64(1), client.Stats().Endpoints["GET /user/fees"].Requests)
+
+	client.InvalidateFeeCache()
+	_, err := client.CachedMarketFee(ctx, "BTC-USD")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), client.Stats().Endpoints["GET /user/fees"].Requests)
+}
+
+func TestExchange_GetMarketFeeCachesFees(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	ctx := context.Background()
+	fees := sdk.DefaultFees
+	fees.TakerFeeRate = decimal.RequireFromString("0.0007")
+	ex.SetFees(fees)
+
+	client := ex.NewClient()
+	_, err := client.GetMarketFee(ctx, "BTC-USD")
+	require.NoError(t, err)
+	order, _, err := client.PlaceOrder(ctx, buyParams(t, BTCUSDMarket(), "0.01", "40000"))
+	require.NoError(t, err)
+	assert.Equal(t, "0.0007", order.Fee, "orders are signed with the fetched fees")
+
+	cached, err := client.CachedMarketFee(ctx, "BTC-USD")
+	require.NoError(t, err)
+	assert.True(t, fees.TakerFeeRate.Equal(cached.TakerFeeRate))
+	assert.Equal(t, uint64(1), client.Stats().Endpoints["GET /user/fees"].Requests)
+
+	manual := ex.NewClient(sdk.WithManualFees())
+	_, err = manual.GetMarketFee(ctx, "BTC-USD")
+	require.NoError(t, err)
+	order, _, err = manual.PlaceOrder(ctx, buyParams(t, BTCUSDMarket(), "0.01", "40000"))
+	require.NoError(t, err)
+	assert.Equal(t, "0.0005", order.Fee)
+}
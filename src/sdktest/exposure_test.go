@@ -0,0 +1,27 @@
+package sdktest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetExposure(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	ex.SetBalance(decimal.NewFromInt(10000))
+	ex.SetPosition("BTC-USD", decimal.RequireFromString("-0.5"), decimal.NewFromInt(40000))
+
+	report, err := ex.NewClient().GetExposure(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "10000", report.Equity.String())
+	assert.Equal(t, "20000", report.Short.String())
+	assert.Equal(t, "-20000", report.Net.String())
+	assert.Equal(t, "2", report.Leverage.String())
+	require.Len(t, report.Assets, 1)
+	assert.Equal(t, "BTC", report.Assets[0].Asset)
+	assert.Equal(t, "1", report.Assets[0].Concentration.String())
+}
@@ -0,0 +1,169 @@
+package sdktest
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Latency draws the delay added to a request from a distribution
+type Latency func(r *rand.Rand) time.Duration
+
+// FixedLatency delays every request by d
+func FixedLatency(d time.Duration) Latency {
+	return func(*rand.Rand) time.Duration { return d }
+}
+
+// UniformLatency delays requests by a duration drawn uniformly from [min, max]
+func UniformLatency(min, max time.Duration) Latency {
+	return func(r *rand.Rand) time.Duration {
+		if max <= min {
+			return min
+		}
+		return min + time.Duration(r.Int63n(int64(max-min)+1))
+	}
+}
+
+// ExponentialLatency delays requests by base plus an exponentially
+// distributed duration with the given mean, giving the long tail of a
+// congested network
+func ExponentialLatency(base, mean time.Duration) Latency {
+	return func(r *rand.Rand) time.Duration {
+		return base + time.Duration(r.ExpFloat64()*float64(mean))
+	}
+}
+
+// Faults configures the failures injected by the fake exchange, see
+// SetFaults. Failed requests are answered before they are handled, so they
+// never change the state of the exchange.
+type Faults struct {
+	// Latency delays every request; nil adds no delay
+	Latency Latency
+	// ServerErrorRate is the probability of answering a request with
+	// 503 Service Unavailable
+	ServerErrorRate float64
+	// RateLimitRate is the probability of answering a request with
+	// 429 Too Many Requests
+	RateLimitRate float64
+	// RetryAfter is sent with rate limited answers when positive
+	RetryAfter time.Duration
+	// PartialFill, when between 0 and 1, caps the part of an incoming order
+	// that trades against the book to this fraction of its quantity, rounded
+	// down to the market precision. The rest rests or is cancelled as its
+	// time in force requires.
+	PartialFill decimal.Decimal
+	// Seed seeds the random source, so that a sequence of requests fails the
+	// same way on every run
+	Seed int64
+}
+
+// faultInjector applies Faults to the requests served by the exchange
+type faultInjector struct {
+	mu     sync.Mutex
+	faults Faults
+	rand   *rand.Rand
+	// next holds the statuses forced on the next requests, see FailNext
+	next     []int
+	injected map[int]int
+}
+
+// SetFaults replaces the faults injected into later requests. The zero
+// Faults turns injection off.
+func (e *Exchange) SetFaults(faults Faults) {
+	e.faults.mu.Lock()
+	defer e.faults.mu.Unlock()
+	e.faults.faults = faults
+	e.faults.rand = rand.New(rand.NewSource(faults.Seed))
+}
+
+// FailNext answers the next n requests with status, before any random fault
+func (e *Exchange) FailNext(status, n int) {
+	e.faults.mu.Lock()
+	defer e.faults.mu.Unlock()
+	for i := 0; i < n; i++ {
+		e.faults.next = append(e.faults.next, status)
+	}
+}
+
+// InjectedFaults returns the number of requests failed so far, by status
+func (e *Exchange) InjectedFaults() map[int]int {
+	e.faults.mu.Lock()
+	defer e.faults.mu.Unlock()
+	injected := make(map[int]int, len(e.faults.injected))
+	for status, n := range e.faults.injected {
+		injected[status] = n
+	}
+	return injected
+}
+
+func (e *Exchange) partialFill() decimal.Decimal {
+	e.faults.mu.Lock()
+	defer e.faults.mu.Unlock()
+	return e.faults.faults.PartialFill
+}
+
+// draw returns the delay of the next request and the status to fail it
+// with, 0 to serve it
+func (f *faultInjector) draw() (time.Duration, int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.rand == nil {
+		f.rand = rand.New(rand.NewSource(f.faults.Seed))
+	}
+
+	var delay time.Duration
+	if f.faults.Latency != nil {
+		delay = f.faults.Latency(f.rand)
+	}
+	status := 0
+	switch p := f.rand.Float64(); {
+	case len(f.next) > 0:
+		status, f.next = f.next[0], f.next[1:]
+	case p < f.faults.ServerErrorRate:
+		status = http.StatusServiceUnavailable
+	case p < f.faults.ServerErrorRate+f.faults.RateLimitRate:
+		status = http.StatusTooManyRequests
+	}
+	if status != 0 {
+		if f.injected == nil {
+			f.injected = make(map[int]int)
+		}
+		f.injected[status]++
+	}
+	return delay, status
+}
+
+// wrap serves next with the configured faults injected
+func (f *faultInjector) wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		delay, status := f.draw()
+		if delay > 0 {
+			timer := time.NewTimer(delay)
+			select {
+			case <-timer.C:
+			case <-r.Context().Done():
+				timer.Stop()
+				return
+			}
+		}
+
+		switch status {
+		case 0:
+			next.ServeHTTP(w, r)
+		case http.StatusTooManyRequests:
+			f.mu.Lock()
+			retryAfter := f.faults.RetryAfter
+			f.mu.Unlock()
+			if retryAfter > 0 {
+				w.Header().Set("Retry-After", strconv.Itoa(int((retryAfter+time.Second-1)/time.Second)))
+			}
+			writeError(w, status, status, "rate limit exceeded")
+		default:
+			writeError(w, status, status, http.StatusText(status))
+		}
+	})
+}
@@ -0,0 +1,110 @@
+package sdktest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFaults_RetriesRecover(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	client := ex.NewClient(sdk.WithRetry(sdk.RetryConfig{MaxAttempts: 3, BaseDelay: time.Millisecond}))
+
+	ex.FailNext(http.StatusServiceUnavailable, 1)
+	ex.FailNext(http.StatusTooManyRequests, 1)
+	_, err := client.GetBalance(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[int]int{http.StatusServiceUnavailable: 1, http.StatusTooManyRequests: 1}, ex.InjectedFaults())
+
+	ex.FailNext(http.StatusServiceUnavailable, 3)
+	_, err = client.GetBalance(context.Background())
+	var apiErr *sdk.APIError
+	require.True(t, errors.As(err, &apiErr), "%v", err)
+	assert.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+}
+
+func TestFaults_ServerErrorsDegradeHealth(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	client := ex.NewClient(sdk.WithHealthConfig(sdk.HealthConfig{FailureThreshold: 2, Cooldown: time.Minute}))
+	ex.SetFaults(Faults{ServerErrorRate: 1})
+
+	for i := 0; i < 2; i++ {
+		_, err := client.GetBalance(context.Background())
+		require.Error(t, err)
+	}
+	assert.Equal(t, sdk.HealthDegraded, client.Health().State)
+
+	// The breaker is open, so the exchange is not asked again
+	ex.SetFaults(Faults{})
+	_, err := client.GetBalance(context.Background())
+	assert.ErrorIs(t, err, sdk.ErrServiceDegraded)
+	assert.Equal(t, 2, ex.InjectedFaults()[http.StatusServiceUnavailable])
+}
+
+func TestFaults_SeededRatesAreRepeatable(t *testing.T) {
+	run := func() []bool {
+		ex := NewExchange()
+		defer ex.Close()
+		client := ex.NewClient()
+		ex.SetFaults(Faults{ServerErrorRate: 0.3, RateLimitRate: 0.2, Seed: 7})
+		failed := make([]bool, 20)
+		for i := range failed {
+			_, err := client.GetBalance(context.Background())
+			failed[i] = err != nil
+		}
+		return failed
+	}
+	first := run()
+	assert.Equal(t, first, run())
+	assert.Contains(t, first, true)
+	assert.Contains(t, first, false)
+}
+
+func TestFaults_Latency(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	client := ex.NewClient()
+	ex.SetFaults(Faults{Latency: FixedLatency(200 * time.Millisecond)})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := client.GetBalance(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	ex.SetFaults(Faults{Latency: UniformLatency(time.Millisecond, 5*time.Millisecond)})
+	start := time.Now()
+	_, err = client.GetBalance(context.Background())
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), time.Millisecond)
+}
+
+func TestFaults_PartialFill(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	client := ex.NewClient()
+	ex.AddRestingOrder(RestingOrder{
+		ExternalID: "ask", Market: "BTC-USD", Side: sdk.OrderSideSell,
+		Price: decimal.NewFromInt(50000), Qty: decimal.NewFromInt(1),
+	})
+	ex.SetFaults(Faults{PartialFill: decimal.RequireFromString("0.25")})
+
+	_, err := client.SubmitOrder(context.Background(), limitOrder("partial", sdk.OrderSideBuy, "0.3", "50000"))
+	require.NoError(t, err)
+
+	order, ok := ex.Order("partial")
+	require.True(t, ok)
+	assert.Equal(t, "0.075", order.FilledQty.String())
+	assert.Equal(t, "0.075", ex.Position("BTC-USD").String())
+	resting := ex.RestingOrders()
+	require.Len(t, resting, 2, "the unfilled rest of the order rests")
+	assert.Equal(t, "0.225", resting[1].Qty.String())
+}
@@ -0,0 +1,60 @@
+package sdktest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectCarryCost(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	client := ex.NewClient()
+	ctx := context.Background()
+
+	now := time.Now()
+	ex.AddFundingRate("BTC-USD", now.Add(-48*time.Hour), decimal.RequireFromString("0.01"))
+	ex.AddFundingRate("BTC-USD", now.Add(-2*time.Hour), decimal.RequireFromString("0.0001"))
+	ex.AddFundingRate("BTC-USD", now.Add(-time.Hour), decimal.RequireFromString("-0.0003"))
+
+	history, err := client.GetFundingHistory(ctx, "BTC-USD", sdk.FundingHistoryFilter{})
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+	assert.Equal(t, "-0.0003", history[0].FundingRate.String(), "newest first")
+
+	position := sdk.PositionModel{Market: "BTC-USD", Side: sdk.PositionSideShort, Value: decimal.NewFromInt(100000)}
+	cost, err := client.ProjectCarryCost(ctx, position, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 2, cost.Samples, "the default lookback skips the old rate")
+	assert.Equal(t, "-0.0001", cost.AverageRate.String())
+	assert.Equal(t, "100", cost.Cost.String(), "shorts pay negative funding")
+}
+
+func TestPlaceGoodTillFundingOrder(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	client := ex.NewClient()
+	ctx := context.Background()
+
+	next := time.Now().Add(17 * time.Minute).Truncate(time.Millisecond)
+	ex.SetMarketStats("BTC-USD", sdk.MarketStatsModel{NextFundingRate: next.UnixMilli()})
+
+	order, _, expire, err := client.PlaceGoodTillFundingOrder(ctx, buyParams(t, BTCUSDMarket(), "0.01", "40000"))
+	require.NoError(t, err)
+	assert.True(t, next.Equal(expire), "expires at %s, want %s", expire, next)
+	assert.Equal(t, next.UnixMilli(), order.ExpiryEpochMillis)
+	placed, ok := ex.Order(order.ID)
+	require.True(t, ok)
+	assert.Equal(t, next.UnixMilli(), placed.ExpireTime)
+
+	// A reported payment that already passed rolls forward to the next one
+	ex.SetMarketStats("BTC-USD", sdk.MarketStatsModel{NextFundingRate: next.Add(-time.Hour).UnixMilli()})
+	_, _, expire, err = client.PlaceGoodTillFundingOrder(ctx, buyParams(t, BTCUSDMarket(), "0.01", "40000"))
+	require.NoError(t, err)
+	assert.True(t, next.Equal(expire), "expires at %s, want %s", expire, next)
+}
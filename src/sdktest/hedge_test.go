@@ -0,0 +1,67 @@
+package sdktest
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHedger(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	eth := BTCUSDMarket()
+	eth.Name, eth.AssetName = "ETH-USD", "ETH"
+	eth.TradingConfig.MinOrderSizeChange = decimal.RequireFromString("0.01")
+	eth.TradingConfig.MinPriceChange = decimal.RequireFromString("0.1")
+	ex.AddMarket(eth)
+	ex.SetMarketStats("ETH-USD", sdk.MarketStatsModel{MarkPrice: decimal.NewFromInt(2000)})
+	client := ex.NewClient()
+	ctx := context.Background()
+
+	ex.SetPosition("BTC-USD", decimal.NewFromInt(1), decimal.NewFromInt(40000))
+	ex.AddRestingOrder(RestingOrder{ExternalID: "eth-bid", Market: "ETH-USD", Side: sdk.OrderSideBuy,
+		Price: decimal.NewFromInt(2000), Qty: decimal.NewFromInt(100)})
+	ex.AddRestingOrder(RestingOrder{ExternalID: "eth-ask", Market: "ETH-USD", Side: sdk.OrderSideSell,
+		Price: decimal.NewFromInt(2005), Qty: decimal.NewFromInt(100)})
+
+	order := signedOrderParams(t)
+	order.Market = eth
+	hedger, err := sdk.NewHedger(client, sdk.HedgeConfig{
+		BaseMarket:  "BTC-USD",
+		Order:       order,
+		Ratio:       decimal.RequireFromString("0.5"),
+		SlippageBps: decimal.NewFromInt(50),
+	})
+	require.NoError(t, err)
+
+	// Half of 40000 USD long BTC is 10 ETH short at 2000
+	adjustment, err := hedger.Rebalance(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, adjustment.Order)
+	assert.Equal(t, "-10", adjustment.TargetSize.String())
+	assert.Equal(t, sdk.OrderSideSell, adjustment.Order.Side)
+	assert.Equal(t, "1990", adjustment.Order.Price, "50 bps through the mark")
+	assert.False(t, adjustment.Order.ReduceOnly)
+	assert.Equal(t, "-10", ex.Position("ETH-USD").String())
+
+	adjustment, err = hedger.Rebalance(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, adjustment.Order, "already hedged")
+
+	// The base shrinks and the hedge is bought back
+	ex.SetPosition("BTC-USD", decimal.RequireFromString("0.5"), decimal.NewFromInt(40000))
+	adjustment, err = hedger.Rebalance(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, adjustment.Order)
+	assert.Equal(t, sdk.OrderSideBuy, adjustment.Order.Side)
+	assert.True(t, adjustment.Order.ReduceOnly)
+	assert.Equal(t, "2010", adjustment.Order.Price)
+	assert.Equal(t, "-5", ex.Position("ETH-USD").String())
+
+	_, err = sdk.NewHedger(client, sdk.HedgeConfig{BaseMarket: "ETH-USD", Order: order, Ratio: decimal.NewFromInt(1)})
+	assert.ErrorIs(t, err, sdk.ErrInvalidOrder)
+}
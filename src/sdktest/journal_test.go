@@ -0,0 +1,112 @@
+package sdktest
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJournal_ResolvesAnsweredRequests(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	journal := sdk.NewFileJournal(filepath.Join(t.TempDir(), "journal.json"))
+	client := ex.NewClient(sdk.WithJournal(journal))
+	ctx := context.Background()
+
+	order, _, err := client.PlaceOrder(ctx, buyParams(t, BTCUSDMarket(), "0.01", "40000"))
+	require.NoError(t, err)
+	require.NoError(t, client.CancelOrderByExternalID(ctx, order.ID))
+	// Rejections are answers too
+	require.Error(t, client.CancelOrder(ctx, 424242))
+
+	pending, err := journal.Pending()
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	// Without an answer the intent stays pending
+	ex.Close()
+	order, _, err = client.PlaceOrder(ctx, buyParams(t, BTCUSDMarket(), "0.01", "40000"))
+	require.Error(t, err)
+	pending, err = journal.Pending()
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, sdk.IntentPlaceOrder, pending[0].Kind)
+	assert.Equal(t, order.ID, pending[0].ExternalID)
+}
+
+func TestJournal_KeepsServerErrorsPending(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	journal := sdk.NewFileJournal(filepath.Join(t.TempDir(), "journal.json"))
+	clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	client := ex.NewClient(sdk.WithJournal(journal), sdk.WithClock(clock))
+	ctx := context.Background()
+
+	// A gateway error does not tell whether the request took effect
+	ex.SetFaults(Faults{ServerErrorRate: 1})
+	params := buyParams(t, BTCUSDMarket(), "0.01", "40000")
+	params.Clock = clock
+	_, _, err := client.PlaceOrder(ctx, params)
+	require.Error(t, err)
+	for i := 0; i < 2; i++ {
+		require.Error(t, client.MassCancel(ctx, sdk.MassCancelParams{Markets: []string{"BTC-USD"}}))
+	}
+
+	pending, err := journal.Pending()
+	require.NoError(t, err)
+	kinds := make(map[sdk.IntentKind]int)
+	for _, intent := range pending {
+		kinds[intent.Kind]++
+	}
+	assert.Equal(t, map[sdk.IntentKind]int{sdk.IntentPlaceOrder: 1, sdk.IntentMassCancel: 2}, kinds,
+		"mass cancels sent at the same instant are journaled apart")
+}
+
+func TestRecoverJournal(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	ctx := context.Background()
+
+	// Requests that reached the exchange before the crash
+	placed, _, err := ex.NewClient().PlaceOrder(ctx, buyParams(t, BTCUSDMarket(), "0.01", "40000"))
+	require.NoError(t, err)
+	resting := ex.RestingOrders()
+	require.Len(t, resting, 1)
+
+	journal := sdk.NewFileJournal(filepath.Join(t.TempDir(), "journal.json"))
+	for _, intent := range []sdk.Intent{
+		{ID: "placed", Kind: sdk.IntentPlaceOrder, ExternalID: placed.ID},
+		{ID: "lost", Kind: sdk.IntentPlaceOrder, ExternalID: "never-arrived"},
+		{ID: "cancel", Kind: sdk.IntentCancelOrder, OrderID: int64(resting[0].ID)},
+		{ID: "mass", Kind: sdk.IntentMassCancel, MassCancel: &sdk.MassCancelParams{Markets: []string{"ETH-USD"}}},
+	} {
+		require.NoError(t, journal.Record(intent))
+	}
+
+	client := ex.NewClient(sdk.WithJournal(journal))
+	recovered, err := client.RecoverJournal(ctx)
+	require.NoError(t, err)
+	outcomes := make(map[string]sdk.IntentOutcome)
+	for _, r := range recovered {
+		outcomes[r.Intent.ID] = r.Outcome
+		if r.Intent.ID == "placed" {
+			require.NotNil(t, r.Order)
+			assert.Equal(t, placed.ID, r.Order.ExternalID)
+		}
+	}
+	assert.Equal(t, map[string]sdk.IntentOutcome{
+		"placed": sdk.IntentApplied,
+		"lost":   sdk.IntentNotApplied,
+		"cancel": sdk.IntentNotApplied,
+		"mass":   sdk.IntentApplied,
+	}, outcomes)
+
+	pending, err := journal.Pending()
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
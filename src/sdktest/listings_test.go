@@ -0,0 +1,81 @@
+package sdktest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatchMarkets(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	client := ex.NewClient()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, errs := client.WatchMarkets(ctx, 10*time.Millisecond)
+	next := func() sdk.MarketChangeEvent {
+		t.Helper()
+		select {
+		case event := <-events:
+			return event
+		case err := <-errs:
+			t.Fatal(err)
+		case <-time.After(time.Second):
+			t.Fatal("no market change event")
+		}
+		return sdk.MarketChangeEvent{}
+	}
+	// Let the baseline poll complete before changing the listing
+	time.Sleep(30 * time.Millisecond)
+
+	market := BTCUSDMarket()
+	market.TradingConfig.MaxLeverage = decimal.NewFromInt(20)
+	ex.UpdateMarket(market)
+	event := next()
+	assert.Equal(t, sdk.MarketChangeTradingConfig, event.Kind)
+	assert.Equal(t, "20", event.Current.TradingConfig.MaxLeverage.String())
+
+	ex.RemoveMarket("BTC-USD")
+	event = next()
+	assert.Equal(t, sdk.MarketChangeDelisted, event.Kind)
+	assert.Nil(t, event.Current)
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestWatchTradingConfig(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	client := ex.NewClient()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes, errs := client.WatchTradingConfig(ctx, 10*time.Millisecond)
+	time.Sleep(30 * time.Millisecond)
+
+	market := BTCUSDMarket()
+	market.TradingConfig.MinOrderSize = decimal.RequireFromString("0.01")
+	ex.UpdateMarket(market)
+
+	select {
+	case change := <-changes:
+		assert.Equal(t, sdk.TradingConfigMinOrderSize, change.Field)
+		assert.Equal(t, "BTC-USD", change.Market)
+		assert.Equal(t, "0.01", change.Current.String())
+	case err := <-errs:
+		t.Fatal(err)
+	case <-time.After(time.Second):
+		t.Fatal("no trading config change")
+	}
+
+	cancel()
+	for range changes {
+	}
+}
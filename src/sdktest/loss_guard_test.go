@@ -0,0 +1,38 @@
+package sdktest
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlaceOrder_LossGuard(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	client := ex.NewClient()
+	ctx := context.Background()
+	ex.SetPosition("BTC-USD", decimal.NewFromInt(1), decimal.NewFromInt(40000))
+	ex.AddRestingOrder(RestingOrder{ExternalID: "bid", Market: "BTC-USD", Side: sdk.OrderSideBuy,
+		Price: decimal.NewFromInt(39000), Qty: decimal.NewFromInt(5)})
+
+	tracker := sdk.NewPnLTracker()
+	require.NoError(t, tracker.Refresh(ctx, client))
+	guard := sdk.NewLossGuard(tracker, sdk.LossGuardConfig{MaxLoss: decimal.NewFromInt(500)})
+	require.False(t, guard.Status().Tripped)
+
+	tracker.UpdateMarkPrice("BTC-USD", decimal.NewFromInt(39000))
+
+	_, _, err := client.PlaceOrder(ctx, buyParams(t, BTCUSDMarket(), "1", "38000"), sdk.WithLossGuard(guard))
+	assert.ErrorIs(t, err, sdk.ErrLossLimitReached)
+	assert.Len(t, ex.RestingOrders(), 1, "nothing was sent")
+
+	closing := closeLong(t, "1")
+	closing.Price = decimal.NewFromInt(38000)
+	_, _, err = client.PlaceOrder(ctx, closing, sdk.WithLossGuard(guard))
+	require.NoError(t, err)
+	assert.True(t, ex.Position("BTC-USD").IsZero())
+}
@@ -0,0 +1,52 @@
+package sdktest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachedMarket_Preload(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	client := ex.NewClient(sdk.WithPreloadMarkets(true))
+	defer client.Close()
+
+	select {
+	case <-client.MarketsReady():
+	case <-time.After(5 * time.Second):
+		t.Fatal("markets were not preloaded")
+	}
+	require.NoError(t, client.WaitForMarkets(context.Background()))
+
+	market, err := client.CachedMarket(context.Background(), "BTC-USD")
+	require.NoError(t, err)
+	assert.Equal(t, "BTC-USD", market.Name)
+	assert.Equal(t, uint64(1), client.Stats().Endpoints["GET /info/markets"].Requests, "served from the preload")
+}
+
+func TestCachedMarket_FetchesOnFirstUse(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	client := ex.NewClient()
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		market, err := client.CachedMarket(ctx, "BTC-USD")
+		require.NoError(t, err)
+		assert.Equal(t, "BTC-USD", market.Name)
+	}
+	assert.Equal(t, uint64(1), client.Stats().Endpoints["GET /info/markets"].Requests)
+
+	_, err := client.CachedMarket(ctx, "DOGE-USD")
+	assert.ErrorIs(t, err, sdk.ErrUnknownMarket)
+
+	client.InvalidateMarketCache()
+	_, err = client.CachedMarket(ctx, "BTC-USD")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), client.Stats().Endpoints["GET /info/markets"].Requests)
+}
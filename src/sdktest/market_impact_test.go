@@ -0,0 +1,85 @@
+package sdktest
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlaceOrder_MaxImpact(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	client := ex.NewClient()
+	ctx := context.Background()
+
+	ex.AddRestingOrder(RestingOrder{ExternalID: "bid", Market: "BTC-USD", Side: sdk.OrderSideBuy,
+		Price: decimal.NewFromInt(39990), Qty: decimal.NewFromInt(1)})
+	ex.AddRestingOrder(RestingOrder{ExternalID: "ask-1", Market: "BTC-USD", Side: sdk.OrderSideSell,
+		Price: decimal.NewFromInt(40010), Qty: decimal.NewFromInt(1)})
+	ex.AddRestingOrder(RestingOrder{ExternalID: "ask-2", Market: "BTC-USD", Side: sdk.OrderSideSell,
+		Price: decimal.NewFromInt(40410), Qty: decimal.NewFromInt(1)})
+
+	// Two lots average 40210 against a 40000 mid: 52.5 bps
+	_, _, err := client.PlaceOrder(ctx, buyParams(t, BTCUSDMarket(), "2", "41000"), sdk.WithMaxImpactBps(decimal.NewFromInt(50)))
+	assert.ErrorIs(t, err, sdk.ErrMaxImpactExceeded)
+	assert.Len(t, ex.RestingOrders(), 3, "nothing was sent")
+
+	// Post-only orders are never checked
+	postOnly := buyParams(t, BTCUSDMarket(), "2", "39000")
+	postOnly.PostOnly = true
+	_, _, err = client.PlaceOrder(ctx, postOnly, sdk.WithMaxImpactBps(decimal.Zero))
+	require.NoError(t, err)
+
+	_, _, err = client.PlaceOrder(ctx, buyParams(t, BTCUSDMarket(), "1", "41000"), sdk.WithMaxImpactBps(decimal.NewFromInt(50)))
+	require.NoError(t, err)
+	assert.Equal(t, "1", ex.Position("BTC-USD").String())
+}
+
+// staticBook is a local orderbook that does not change
+type staticBook struct {
+	book   sdk.OrderbookUpdateModel
+	synced bool
+}
+
+func (b staticBook) Snapshot() (sdk.OrderbookUpdateModel, bool) {
+	return b.book, b.synced
+}
+
+func TestPlaceOrder_MaxImpactFromLocalBook(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	client := ex.NewClient()
+	ctx := context.Background()
+
+	// The exchange book makes two lots cost 52.5 bps
+	ex.AddRestingOrder(RestingOrder{ExternalID: "bid", Market: "BTC-USD", Side: sdk.OrderSideBuy,
+		Price: decimal.NewFromInt(39990), Qty: decimal.NewFromInt(1)})
+	ex.AddRestingOrder(RestingOrder{ExternalID: "ask-1", Market: "BTC-USD", Side: sdk.OrderSideSell,
+		Price: decimal.NewFromInt(40010), Qty: decimal.NewFromInt(1)})
+	ex.AddRestingOrder(RestingOrder{ExternalID: "ask-2", Market: "BTC-USD", Side: sdk.OrderSideSell,
+		Price: decimal.NewFromInt(40410), Qty: decimal.NewFromInt(1)})
+	level := func(price, qty int64) []sdk.OrderbookQuantityModel {
+		return []sdk.OrderbookQuantityModel{{Price: decimal.NewFromInt(price), Qty: decimal.NewFromInt(qty)}}
+	}
+	// while the local book holds both lots at the top: 2.5 bps
+	local := sdk.OrderbookUpdateModel{Market: "BTC-USD", Bid: level(39990, 1), Ask: level(40010, 5)}
+	limit := decimal.NewFromInt(50)
+
+	_, _, err := client.PlaceOrder(ctx, buyParams(t, BTCUSDMarket(), "2", "41000"),
+		sdk.WithMaxImpactBps(limit, staticBook{book: local, synced: false}))
+	assert.ErrorIs(t, err, sdk.ErrMaxImpactExceeded, "an unsynced book falls back to the exchange book")
+
+	eth := local
+	eth.Market = "ETH-USD"
+	_, _, err = client.PlaceOrder(ctx, buyParams(t, BTCUSDMarket(), "2", "41000"),
+		sdk.WithMaxImpactBps(limit, staticBook{book: eth, synced: true}))
+	assert.ErrorIs(t, err, sdk.ErrMaxImpactExceeded, "books of other markets are not used")
+
+	_, _, err = client.PlaceOrder(ctx, buyParams(t, BTCUSDMarket(), "2", "41000"),
+		sdk.WithMaxImpactBps(limit, staticBook{book: eth, synced: true}, staticBook{book: local, synced: true}))
+	require.NoError(t, err, "the local book decides")
+}
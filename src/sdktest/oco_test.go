@@ -0,0 +1,117 @@
+package sdktest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ocoExits() sdk.OCOOrders {
+	tp := limitOrder("exit-tp", sdk.OrderSideSell, "1", "45000")
+	tp.ReduceOnly = true
+	sl := limitOrder("exit-sl", sdk.OrderSideSell, "1", "38000")
+	sl.Type = sdk.OrderTypeConditional
+	sl.ReduceOnly = true
+	return sdk.OCOOrders{TakeProfit: tp, StopLoss: sl}
+}
+
+func TestSubmitOCO_TakeProfitCancelsStopLoss(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	ex.SetPosition("BTC-USD", decimal.NewFromInt(1), decimal.NewFromInt(40000))
+	client := ex.NewClient()
+
+	h, err := client.SubmitOCO(context.Background(), ocoExits(), 5*time.Millisecond)
+	require.NoError(t, err)
+	assert.Len(t, ex.RestingOrders(), 2)
+
+	require.True(t, ex.Fill("exit-tp", decimal.NewFromInt(1)))
+
+	result, err := h.Result()
+	require.NoError(t, err)
+	assert.Equal(t, "exit-tp", result.Executed.ExternalID)
+	assert.Equal(t, "exit-sl", result.Cancelled)
+	assert.Empty(t, ex.RestingOrders())
+
+	sl, ok := ex.Order("exit-sl")
+	require.True(t, ok)
+	assert.Equal(t, sdk.OrderStatusCancelled, sl.Status)
+}
+
+func TestSubmitOCO_CancelledLegCancelsSibling(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	ex.SetPosition("BTC-USD", decimal.NewFromInt(1), decimal.NewFromInt(40000))
+	client := ex.NewClient()
+	ctx := context.Background()
+
+	h, err := client.SubmitOCO(ctx, ocoExits(), 5*time.Millisecond)
+	require.NoError(t, err)
+	require.NoError(t, client.CancelOrderByExternalID(ctx, "exit-sl"))
+
+	_, err = h.Result()
+	var notFilled *sdk.OrderNotFilledError
+	require.True(t, errors.As(err, &notFilled))
+	assert.Equal(t, "exit-sl", notFilled.Order.ExternalID)
+	assert.Empty(t, ex.RestingOrders())
+}
+
+func TestSubmitOCO_RejectedExit(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	// Flat, so the reduce-only take profit is rejected and nothing rests
+	client := ex.NewClient()
+
+	_, err := client.SubmitOCO(context.Background(), ocoExits(), 5*time.Millisecond)
+	assert.True(t, sdk.IsOrderRejected(err, sdk.OrderStatusReasonReduceOnlyFailed))
+	assert.Empty(t, ex.RestingOrders())
+}
+
+func TestSubmitOCO_ExitsWaitForEntryFill(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	// Flat, so exits placed before the entry fills would be rejected
+	client := ex.NewClient()
+	orders := ocoExits()
+	orders.Entry = limitOrder("entry", sdk.OrderSideBuy, "1", "40000")
+
+	h, err := client.SubmitOCO(context.Background(), orders, 5*time.Millisecond)
+	require.NoError(t, err)
+	time.Sleep(20 * time.Millisecond)
+	require.Len(t, ex.RestingOrders(), 1, "exits placed before the entry filled")
+
+	require.True(t, ex.Fill("entry", decimal.NewFromInt(1)))
+	require.Eventually(t, func() bool { return len(ex.RestingOrders()) == 2 }, time.Second, time.Millisecond)
+	require.True(t, ex.Fill("exit-tp", decimal.NewFromInt(1)))
+
+	result, err := h.Result()
+	require.NoError(t, err)
+	assert.Equal(t, "exit-tp", result.Executed.ExternalID)
+	assert.True(t, ex.Position("BTC-USD").IsZero())
+}
+
+func TestSubmitOCO_UnfilledEntryPlacesNoExits(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	client := ex.NewClient()
+	ctx := context.Background()
+	orders := ocoExits()
+	orders.Entry = limitOrder("entry", sdk.OrderSideBuy, "1", "40000")
+
+	h, err := client.SubmitOCO(ctx, orders, 5*time.Millisecond)
+	require.NoError(t, err)
+	require.NoError(t, client.CancelOrderByExternalID(ctx, "entry"))
+
+	_, err = h.Result()
+	var notFilled *sdk.OrderNotFilledError
+	require.True(t, errors.As(err, &notFilled))
+	assert.Equal(t, "entry", notFilled.Order.ExternalID)
+	_, ok := ex.Order("exit-tp")
+	assert.False(t, ok)
+}
@@ -0,0 +1,68 @@
+package sdktest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitForFill(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	client := ex.NewClient()
+	ctx := context.Background()
+
+	_, err := client.SubmitOrder(ctx, limitOrder("wait", sdk.OrderSideBuy, "1", "40000"))
+	require.NoError(t, err)
+
+	h := client.WaitForFill(ctx, "wait", 5*time.Millisecond)
+
+	require.True(t, ex.Fill("wait", decimal.RequireFromString("0.25")))
+	require.Eventually(t, func() bool { return h.Progress() == 0.25 }, time.Second, time.Millisecond)
+
+	require.True(t, ex.Fill("wait", decimal.RequireFromString("0.75")))
+	order, err := h.Result()
+	require.NoError(t, err)
+	assert.Equal(t, sdk.OrderStatusFilled, order.Status)
+	assert.Equal(t, "40000", order.AveragePrice.String())
+	assert.Equal(t, uint64(1), client.Stats().OrdersFilled)
+}
+
+func TestWaitForFill_Cancelled(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	client := ex.NewClient()
+	ctx := context.Background()
+
+	_, err := client.SubmitOrder(ctx, limitOrder("gone", sdk.OrderSideBuy, "1", "40000"))
+	require.NoError(t, err)
+
+	h := client.WaitForFill(ctx, "gone", 5*time.Millisecond)
+	require.NoError(t, client.CancelOrderByExternalID(ctx, "gone"))
+
+	_, err = h.Result()
+	var notFilled *sdk.OrderNotFilledError
+	require.True(t, errors.As(err, &notFilled))
+	assert.Equal(t, sdk.OrderStatusCancelled, notFilled.Order.Status)
+}
+
+func TestWaitForFill_HandleCancel(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	client := ex.NewClient()
+
+	_, err := client.SubmitOrder(context.Background(), limitOrder("never", sdk.OrderSideBuy, "1", "40000"))
+	require.NoError(t, err)
+
+	h := client.WaitForFill(context.Background(), "never", 5*time.Millisecond)
+	h.Cancel()
+
+	_, err = h.Result()
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,135 @@
+package sdktest
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tickedMarket lists BTC-USD with a 0.5 tick and margin for 50k of notional
+func tickedMarket(ex *Exchange) sdk.MarketModel {
+	market := BTCUSDMarket()
+	market.TradingConfig.MinPriceChange = decimal.RequireFromString("0.5")
+	market.TradingConfig.MinOrderSizeChange = decimal.RequireFromString("0.001")
+	market.TradingConfig.MaxLeverage = decimal.NewFromInt(5)
+	ex.UpdateMarket(market)
+	return market
+}
+
+func buyParams(t *testing.T, market sdk.MarketModel, qty, price string) sdk.CreateOrderObjectParams {
+	params := signedOrderParams(t)
+	params.Market = market
+	params.Side = sdk.OrderSideBuy
+	params.SyntheticAmount = decimal.RequireFromString(qty)
+	params.Price = decimal.RequireFromString(price)
+	return params
+}
+
+func TestPlaceOrder_Remediation(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	market := tickedMarket(ex)
+	client := ex.NewClient()
+	ctx := context.Background()
+
+	var events []sdk.RemediationEvent
+	record := sdk.WithRemediationHandler(func(e sdk.RemediationEvent) { events = append(events, e) })
+
+	// Without policies rejections are returned as is
+	_, _, err := client.PlaceOrder(ctx, buyParams(t, market, "1", "40000.3"))
+	assert.True(t, sdk.IsOrderRejected(err, sdk.OrderStatusReasonInvalidPrice), "%v", err)
+
+	order, _, err := client.PlaceOrder(ctx, buyParams(t, market, "1", "40000.3"), sdk.WithSnapToTick(), record)
+	require.NoError(t, err)
+	assert.Equal(t, "40000.5", order.Price)
+
+	order, _, err = client.PlaceOrder(ctx, buyParams(t, market, "1.3", "39000"),
+		sdk.WithShrinkOnInsufficientFunds(decimal.RequireFromString("0.1")), record)
+	require.NoError(t, err)
+	assert.Equal(t, "1.17", order.Qty)
+
+	ex.AddRestingOrder(RestingOrder{ExternalID: "ask", Market: "BTC-USD", Side: sdk.OrderSideSell,
+		Price: decimal.NewFromInt(38000), Qty: decimal.NewFromInt(1)})
+	postOnly := buyParams(t, market, "0.1", "38000")
+	postOnly.PostOnly = true
+	order, _, err = client.PlaceOrder(ctx, postOnly, sdk.WithRepriceOnPostOnlyFailed(), record)
+	require.NoError(t, err)
+	assert.Equal(t, "37999.5", order.Price)
+
+	require.Len(t, events, 3)
+	assert.Equal(t, sdk.OrderStatusReasonInvalidPrice, events[0].Reason)
+	assert.Equal(t, "40000.3", events[0].Previous.String())
+	assert.Equal(t, "qty", events[1].Field)
+	assert.Equal(t, "1.17", events[1].Current.String())
+	assert.Equal(t, sdk.OrderStatusReasonPostOnlyFailed, events[2].Reason)
+	assert.NotEmpty(t, events[2].ExternalID)
+}
+
+func TestPlaceOrder_RemediatesOnce(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	market := tickedMarket(ex)
+	client := ex.NewClient()
+
+	// 10% less than 2 BTC is still more than the margin allows
+	var events []sdk.RemediationEvent
+	_, _, err := client.PlaceOrder(context.Background(), buyParams(t, market, "2", "40000"),
+		sdk.WithShrinkOnInsufficientFunds(decimal.RequireFromString("0.1")),
+		sdk.WithRemediationHandler(func(e sdk.RemediationEvent) { events = append(events, e) }))
+	assert.True(t, sdk.IsOrderRejected(err, sdk.OrderStatusReasonNotEnoughFunds), "%v", err)
+	assert.Len(t, events, 1)
+}
+
+func TestSubmitOrder_PreserializedPayload(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	client := ex.NewClient()
+
+	order, err := sdk.CreateOrderObject(buyParams(t, BTCUSDMarket(), "0.01", "40000"))
+	require.NoError(t, err)
+	payload := order.Payload()
+	require.NotNil(t, payload)
+	expected, err := json.Marshal(order)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expected), string(payload))
+
+	// The kept payload is what is sent
+	order.Qty = "5"
+	_, err = client.SubmitOrder(context.Background(), order)
+	require.NoError(t, err)
+	resting := ex.RestingOrders()
+	require.Len(t, resting, 1)
+	assert.Equal(t, "0.01", resting[0].Qty.String())
+}
+
+func TestPlaceOrder_RemediationSignsAfresh(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	market := tickedMarket(ex)
+	client := ex.NewClient()
+
+	params := buyParams(t, market, "1", "40000.3")
+	params.NonceGenerator = nil
+	nonce := 7
+	params.Nonce = &nonce
+	externalID := "mine"
+	params.OrderExternalID = &externalID
+
+	var events []sdk.RemediationEvent
+	order, _, err := client.PlaceOrder(context.Background(), params, sdk.WithSnapToTick(),
+		sdk.WithRemediationHandler(func(e sdk.RemediationEvent) { events = append(events, e) }))
+	require.NoError(t, err)
+	assert.Equal(t, "mine-r1", order.ID)
+	assert.NotEqual(t, "7", order.Nonce)
+	require.Len(t, events, 1)
+	assert.Equal(t, "mine", events[0].ExternalID)
+
+	rejected, ok := ex.Order("mine")
+	require.True(t, ok)
+	assert.Equal(t, sdk.OrderStatusRejected, rejected.Status)
+}
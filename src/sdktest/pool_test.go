@@ -0,0 +1,95 @@
+package sdktest
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientPool(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	pool := sdk.NewClientPool(sdk.PoolConfig{
+		Endpoint:    ex.EndpointConfig(),
+		OrderQueue:  &sdk.OrderQueueConfig{RequestsPerSecond: 100},
+		Concurrency: 2,
+		Options:     []sdk.ClientOption{sdk.WithStrictDecoding()},
+	})
+	defer pool.Close()
+
+	for _, vault := range []uint64{3, 1, 2} {
+		account, err := sdk.NewStarkPerpetualAccount(vault,
+			"0x7a7ff6fd3cab02ccdcd4a572563f5976f8976899b03a39773795a3c486d4986",
+			"0x61c5e7e8339b7d56f197f54ea91b776776690e3232313de0f2ecbd0ef76f466",
+			"sdktest-api-key")
+		require.NoError(t, err)
+		_, err = pool.Add(account)
+		require.NoError(t, err)
+		if vault == 1 {
+			_, err = pool.Add(account)
+			assert.Error(t, err, "vaults are unique")
+		}
+	}
+	assert.Equal(t, []uint64{1, 2, 3}, pool.Vaults())
+
+	first, _ := pool.Client(1)
+	second, _ := pool.Client(2)
+	assert.NotSame(t, first, second)
+	assert.Same(t, first.HTTPClient().Transport, second.HTTPClient().Transport, "transport is shared")
+
+	var calls atomic.Int32
+	errBoom := errors.New("boom")
+	err := pool.ForEach(context.Background(), func(ctx context.Context, vault uint64, client *sdk.APIClient) error {
+		calls.Add(1)
+		if vault == 2 {
+			return errBoom
+		}
+		_, err := client.GetBalance(ctx)
+		return err
+	})
+	assert.Equal(t, int32(3), calls.Load(), "a failing account does not stop the others")
+	assert.ErrorIs(t, err, errBoom)
+	assert.ErrorContains(t, err, "vault 2")
+
+	pool.Remove(2)
+	assert.Equal(t, []uint64{1, 3}, pool.Vaults())
+	require.NoError(t, pool.ForEach(context.Background(), func(ctx context.Context, vault uint64, client *sdk.APIClient) error {
+		_, err := client.GetBalance(ctx)
+		return err
+	}))
+}
+
+func TestClientPool_Scheduler(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	pool := sdk.NewClientPool(sdk.PoolConfig{
+		Endpoint:  ex.EndpointConfig(),
+		Scheduler: &sdk.SchedulerConfig{RequestsPerSecond: 100, Burst: 2},
+		Options:   []sdk.ClientOption{sdk.WithStrictDecoding()},
+	})
+	defer pool.Close()
+
+	account, err := sdk.NewStarkPerpetualAccount(1,
+		"0x7a7ff6fd3cab02ccdcd4a572563f5976f8976899b03a39773795a3c486d4986",
+		"0x61c5e7e8339b7d56f197f54ea91b776776690e3232313de0f2ecbd0ef76f466",
+		"sdktest-api-key")
+	require.NoError(t, err)
+	client, err := pool.Add(account)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	order, _, err := client.PlaceOrder(ctx, buyParams(t, BTCUSDMarket(), "0.01", "40000"))
+	require.NoError(t, err)
+	require.NoError(t, client.CancelOrderByExternalID(ctx, order.ID))
+
+	assert.Equal(t, []sdk.AccountUsage{{Vault: 1, Sent: 2, RiskReducing: 1, Waited: pool.Usage()[0].Waited}}, pool.Usage())
+
+	pool.Remove(1)
+	_, _, err = client.PlaceOrder(ctx, buyParams(t, BTCUSDMarket(), "0.01", "40000"))
+	assert.ErrorIs(t, err, sdk.ErrOrderQueueClosed)
+}
@@ -0,0 +1,29 @@
+package sdktest
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPublicClient(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	client := sdk.NewPublicClient(ex.EndpointConfig(), sdk.WithStrictDecoding())
+	ctx := context.Background()
+
+	markets, err := client.GetMarkets(ctx, nil)
+	require.NoError(t, err)
+	assert.NotEmpty(t, markets)
+	_, err = client.GetOrderbookSnapshot(ctx, "BTC-USD")
+	require.NoError(t, err)
+
+	_, err = client.GetBalance(ctx)
+	assert.ErrorIs(t, err, sdk.ErrAPIKeyNotSet)
+	_, err = client.GetPositions(ctx, nil)
+	assert.ErrorIs(t, err, sdk.ErrAPIKeyNotSet)
+	assert.Equal(t, uint64(2), client.Stats().Requests, "private requests are not sent")
+}
@@ -0,0 +1,52 @@
+package sdktest
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconcile(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	ex.SetPosition("BTC-USD", decimal.RequireFromString("0.5"), decimal.NewFromInt(40000))
+	for _, id := range []string{"bot-known", "bot-stale", "manual"} {
+		ex.AddRestingOrder(RestingOrder{
+			ExternalID: id, Market: "BTC-USD", Side: sdk.OrderSideBuy,
+			Price: decimal.NewFromInt(30000), Qty: decimal.RequireFromString("0.1"),
+			AccountID: OwnAccountID, ClientID: OwnClientID,
+		})
+	}
+	journal := sdk.NewFileJournal(filepath.Join(t.TempDir(), "journal.json"))
+	require.NoError(t, journal.Record(sdk.Intent{ID: "lost", Kind: sdk.IntentPlaceOrder, ExternalID: "bot-lost"}))
+	client := ex.NewClient(sdk.WithJournal(journal))
+
+	known := func(externalID string) bool { return externalID == "bot-known" }
+	report, err := client.Reconcile(context.Background(), "bot-", known)
+	require.NoError(t, err)
+
+	require.Len(t, report.Recovered, 1)
+	assert.Equal(t, sdk.IntentNotApplied, report.Recovered[0].Outcome)
+	require.Len(t, report.Owned, 1)
+	assert.Equal(t, "bot-known", report.Owned[0].ExternalID)
+	require.Len(t, report.Cancelled, 1)
+	assert.Equal(t, "bot-stale", report.Cancelled[0].ExternalID)
+	require.Len(t, report.Foreign, 1)
+	assert.Equal(t, "manual", report.Foreign[0].ExternalID)
+	require.Len(t, report.Positions, 1)
+	assert.Equal(t, "BTC-USD", report.Positions[0].Market)
+
+	var remaining []string
+	for _, o := range ex.RestingOrders() {
+		remaining = append(remaining, o.ExternalID)
+	}
+	assert.ElementsMatch(t, []string{"bot-known", "manual"}, remaining)
+
+	_, err = client.Reconcile(context.Background(), "", known)
+	assert.Error(t, err, "an empty prefix would claim every order")
+}
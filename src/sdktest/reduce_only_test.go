@@ -0,0 +1,116 @@
+package sdktest
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// signedOrderParams returns parameters for orders on BTC-USD signed with a
+// throwaway key and numbered by a file-backed nonce counter
+func signedOrderParams(t *testing.T) sdk.CreateOrderObjectParams {
+	account, err := sdk.NewStarkPerpetualAccount(10002,
+		"0x7a7ff6fd3cab02ccdcd4a572563f5976f8976899b03a39773795a3c486d4986",
+		"0x61c5e7e8339b7d56f197f54ea91b776776690e3232313de0f2ecbd0ef76f466",
+		"sdktest-api-key")
+	require.NoError(t, err)
+	nonces, err := sdk.NewPersistentCounterNonce(filepath.Join(t.TempDir(), "nonce"), 0)
+	require.NoError(t, err)
+
+	return sdk.CreateOrderObjectParams{
+		Market:         BTCUSDMarket(),
+		Account:        *account,
+		Signer:         account.Sign,
+		StarknetDomain: sdk.StarknetDomain{Name: "Perpetuals", Version: "v0", ChainID: "SN_SEPOLIA", Revision: "1"},
+		NonceGenerator: nonces,
+	}
+}
+
+func closeLong(t *testing.T, qty string) sdk.CreateOrderObjectParams {
+	params := signedOrderParams(t)
+	params.Side = sdk.OrderSideSell
+	params.SyntheticAmount = decimal.RequireFromString(qty)
+	params.Price = decimal.NewFromInt(41000)
+	params.ReduceOnly = true
+	return params
+}
+
+func TestPlaceOrder_ReduceOnlyCap(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	// The position shrank to 0.4 since the close was sized
+	ex.SetPosition("BTC-USD", decimal.RequireFromString("0.4"), decimal.NewFromInt(40000))
+	ctx := context.Background()
+
+	_, _, err := ex.NewClient().PlaceOrder(ctx, closeLong(t, "1"))
+	assert.True(t, sdk.IsOrderRejected(err, sdk.OrderStatusReasonReduceOnlyFailed), "uncapped: %v", err)
+
+	client := ex.NewClient(sdk.WithReduceOnlyCap())
+	order, resp, err := client.PlaceOrder(ctx, closeLong(t, "1"))
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	assert.Equal(t, "0.4", order.Qty)
+
+	// Orders within the position and opening orders are left alone
+	params, err := client.CapReduceOnly(ctx, closeLong(t, "0.1"))
+	require.NoError(t, err)
+	assert.Equal(t, "0.1", params.SyntheticAmount.String())
+	opening := closeLong(t, "5")
+	opening.ReduceOnly = false
+	params, err = client.CapReduceOnly(ctx, opening)
+	require.NoError(t, err)
+	assert.Equal(t, "5", params.SyntheticAmount.String())
+}
+
+func TestPlaceOrder_ReduceOnlyCapWithoutPosition(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	ex.SetPosition("BTC-USD", decimal.NewFromInt(-1), decimal.NewFromInt(40000))
+	client := ex.NewClient(sdk.WithReduceOnlyCap())
+
+	_, _, err := client.PlaceOrder(context.Background(), closeLong(t, "1"))
+	assert.True(t, errors.Is(err, sdk.ErrNoPositionToReduce))
+	assert.Empty(t, ex.RestingOrders())
+}
+
+func TestReducePositionByValue(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	market := tickedMarket(ex)
+	market.TradingConfig.MinOrderSize = decimal.RequireFromString("0.01")
+	ex.UpdateMarket(market)
+	client := ex.NewClient()
+	ctx := context.Background()
+
+	ex.SetPosition("BTC-USD", decimal.NewFromInt(-1), decimal.NewFromInt(40000))
+	ex.AddRestingOrder(RestingOrder{ExternalID: "ask", Market: "BTC-USD", Side: sdk.OrderSideSell,
+		Price: decimal.NewFromInt(40000), Qty: decimal.NewFromInt(5)})
+
+	params := signedOrderParams(t)
+	params.Market = market
+
+	// 10005 USD at a 40000 mark is 0.250125 BTC, rounded down to the 0.001 step
+	order, _, err := client.ReducePositionByValue(ctx, params, decimal.NewFromInt(10005))
+	require.NoError(t, err)
+	assert.Equal(t, sdk.OrderSideBuy, order.Side, "a short is reduced by buying")
+	assert.True(t, order.ReduceOnly)
+	assert.Equal(t, "40000", order.Price)
+	assert.Equal(t, "-0.75", ex.Position("BTC-USD").String())
+
+	_, _, err = client.ReducePositionByValue(ctx, params, decimal.NewFromInt(100))
+	assert.ErrorIs(t, err, sdk.ErrInvalidOrder, "0.0025 BTC is below the minimum order size")
+
+	// Notional beyond the position closes it
+	_, _, err = client.ReducePositionByValue(ctx, params, decimal.NewFromInt(1000000))
+	require.NoError(t, err)
+	assert.True(t, ex.Position("BTC-USD").IsZero())
+
+	_, _, err = client.ReducePositionByValue(ctx, params, decimal.NewFromInt(1000))
+	assert.ErrorIs(t, err, sdk.ErrNoPositionToReduce)
+}
@@ -0,0 +1,147 @@
+package sdktest
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scheduledBuy(id string, at time.Time) sdk.ScheduledOrder {
+	return sdk.ScheduledOrder{
+		ID:          id,
+		At:          at,
+		Market:      "BTC-USD",
+		Side:        sdk.OrderSideBuy,
+		Qty:         decimal.RequireFromString("0.01"),
+		Price:       decimal.NewFromInt(40000),
+		ExpireAfter: 10 * time.Minute,
+	}
+}
+
+func nextResult(t *testing.T, results <-chan sdk.ScheduledOrderResult) sdk.ScheduledOrderResult {
+	t.Helper()
+	select {
+	case result, ok := <-results:
+		require.True(t, ok, "results closed")
+		return result
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a scheduled order")
+		return sdk.ScheduledOrderResult{}
+	}
+}
+
+func TestOrderScheduler(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	client := ex.NewClient()
+	store := sdk.NewFileScheduledOrderStore(filepath.Join(t.TempDir(), "schedule.json"))
+	cfg := sdk.OrderSchedulerConfig{Order: signedOrderParams(t), Store: store, SignAhead: 20 * time.Millisecond}
+
+	scheduler, err := sdk.NewOrderScheduler(client, cfg)
+	require.NoError(t, err)
+
+	now := time.Now()
+	at := now.Add(100 * time.Millisecond)
+	require.NoError(t, scheduler.Schedule(scheduledBuy("open", at)))
+	require.NoError(t, scheduler.Schedule(scheduledBuy("cancelled", now.Add(150*time.Millisecond))))
+	require.NoError(t, scheduler.Schedule(scheduledBuy("missed", now.Add(-2*time.Minute))))
+	assert.ErrorIs(t, scheduler.Schedule(scheduledBuy("open", at)), sdk.ErrInvalidOrder)
+
+	ok, err := scheduler.Cancel("cancelled")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// A restarted scheduler resumes the stored schedule
+	scheduler, err = sdk.NewOrderScheduler(client, cfg)
+	require.NoError(t, err)
+	pending := scheduler.Pending()
+	require.Len(t, pending, 2)
+	assert.Equal(t, "missed", pending[0].ID)
+	assert.Equal(t, "open", pending[1].ID)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	results := scheduler.Run(ctx)
+
+	missed := nextResult(t, results)
+	assert.Equal(t, "missed", missed.Order.ID)
+	assert.ErrorIs(t, missed.Err, sdk.ErrScheduleMissed)
+	assert.True(t, missed.SentAt.IsZero())
+
+	sent := nextResult(t, results)
+	require.NoError(t, sent.Err)
+	assert.Equal(t, "open", sent.Order.ID)
+	assert.False(t, sent.SentAt.Before(at), "sent %s before %s", sent.SentAt, at)
+
+	order, ok := ex.Order("open")
+	require.True(t, ok)
+	assert.Equal(t, at.Add(10*time.Minute).UnixMilli(), order.ExpireTime)
+	_, ok = ex.Order("cancelled")
+	assert.False(t, ok)
+
+	stored, err := store.Load()
+	require.NoError(t, err)
+	assert.Empty(t, stored)
+	assert.Empty(t, scheduler.Pending())
+
+	cancel()
+	for range results {
+	}
+}
+
+func TestOrderScheduler_ScheduleWhileRunning(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	scheduler, err := sdk.NewOrderScheduler(ex.NewClient(), sdk.OrderSchedulerConfig{Order: signedOrderParams(t)})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	results := scheduler.Run(ctx)
+
+	require.NoError(t, scheduler.Schedule(scheduledBuy("late", time.Now().Add(time.Hour))))
+	require.NoError(t, scheduler.Schedule(scheduledBuy("soon", time.Now().Add(50*time.Millisecond))))
+
+	result := nextResult(t, results)
+	require.NoError(t, result.Err)
+	assert.Equal(t, "soon", result.Order.ID)
+	assert.Len(t, scheduler.Pending(), 1)
+
+	cancel()
+	for range results {
+	}
+}
+
+func TestOrderScheduler_KeepsOrdersAfterServerErrors(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	client := ex.NewClient()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, err := client.CachedMarket(ctx, "BTC-USD")
+	require.NoError(t, err)
+	store := sdk.NewFileScheduledOrderStore(filepath.Join(t.TempDir(), "schedule.json"))
+	scheduler, err := sdk.NewOrderScheduler(client, sdk.OrderSchedulerConfig{Order: signedOrderParams(t), Store: store})
+	require.NoError(t, err)
+	require.NoError(t, scheduler.Schedule(scheduledBuy("unknown", time.Now())))
+
+	// A gateway error does not tell whether the order was accepted
+	ex.SetFaults(Faults{ServerErrorRate: 1})
+	results := scheduler.Run(ctx)
+	result := nextResult(t, results)
+	require.Error(t, result.Err)
+	stored, err := store.Load()
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+	assert.Equal(t, "unknown", stored[0].ID)
+
+	cancel()
+	for range results {
+	}
+}
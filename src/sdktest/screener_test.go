@@ -0,0 +1,68 @@
+package sdktest
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScreenMarkets(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+
+	for _, name := range []string{"ETH-USD", "SOL-USD", "DOGE-USD"} {
+		m := BTCUSDMarket()
+		m.Name = name
+		ex.AddMarket(m)
+	}
+	delisted := BTCUSDMarket()
+	delisted.Name = "LUNA-USD"
+	delisted.Active = false
+	delisted.Status = sdk.MarketStatusDelisted
+	ex.AddMarket(delisted)
+
+	stats := func(volume, bid, ask, funding string) sdk.MarketStatsModel {
+		return sdk.MarketStatsModel{
+			DailyVolume: decimal.RequireFromString(volume),
+			BidPrice:    decimal.RequireFromString(bid),
+			AskPrice:    decimal.RequireFromString(ask),
+			FundingRate: decimal.RequireFromString(funding),
+		}
+	}
+	ex.SetMarketStats("BTC-USD", stats("5000000", "49995", "50005", "0.0001"))
+	ex.SetMarketStats("ETH-USD", stats("8000000", "2999", "3001", "0.0002"))
+	ex.SetMarketStats("SOL-USD", stats("9000000", "99", "101", "0.0003"))     // 200 bps spread
+	ex.SetMarketStats("DOGE-USD", stats("100", "0.1", "0.1001", "0.0005"))    // low volume
+	ex.SetMarketStats("LUNA-USD", stats("99000000", "1", "1.0001", "0.0009")) // inactive
+
+	client := ex.NewClient()
+	matches, err := client.ScreenMarkets(context.Background(), sdk.MarketFilter{
+		MinDailyVolume: decimal.NewNullDecimal(decimal.NewFromInt(1000000)),
+		MaxSpreadBps:   decimal.NewNullDecimal(decimal.NewFromInt(10)),
+		FundingAbove:   decimal.NewNullDecimal(decimal.Zero),
+	})
+	require.NoError(t, err)
+
+	require.Len(t, matches, 2)
+	assert.Equal(t, "ETH-USD", matches[0].Market.Name)
+	assert.Equal(t, "BTC-USD", matches[1].Market.Name)
+	assert.Equal(t, "2", matches[1].SpreadBps.String())
+}
+
+func TestScreenMarkets_SpreadFromBook(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	ex.AddRestingOrder(RestingOrder{Market: "BTC-USD", Side: sdk.OrderSideBuy, Price: decimal.NewFromInt(39990), Qty: decimal.NewFromInt(1)})
+	ex.AddRestingOrder(RestingOrder{Market: "BTC-USD", Side: sdk.OrderSideSell, Price: decimal.NewFromInt(40010), Qty: decimal.NewFromInt(1)})
+
+	matches, err := ex.NewClient().ScreenMarkets(context.Background(), sdk.MarketFilter{
+		MaxSpreadBps: decimal.NewNullDecimal(decimal.NewFromInt(5)),
+	})
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Equal(t, "5", matches[0].SpreadBps.String())
+}
@@ -0,0 +1,54 @@
+package sdktest
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlaceOrder_SelfTradeCheck(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	client := ex.NewClient()
+	ctx := context.Background()
+
+	ex.AddRestingOrder(RestingOrder{ExternalID: "own-ask", Market: "BTC-USD", Side: sdk.OrderSideSell,
+		Price: decimal.NewFromInt(40000), Qty: decimal.NewFromInt(1), AccountID: OwnAccountID})
+	ex.AddRestingOrder(RestingOrder{ExternalID: "own-far-ask", Market: "BTC-USD", Side: sdk.OrderSideSell,
+		Price: decimal.NewFromInt(42000), Qty: decimal.NewFromInt(1), AccountID: OwnAccountID})
+	ex.AddRestingOrder(RestingOrder{ExternalID: "other-ask", Market: "BTC-USD", Side: sdk.OrderSideSell,
+		Price: decimal.NewFromInt(40500), Qty: decimal.NewFromInt(1)})
+
+	open, err := client.GetOpenOrders(ctx, sdk.OpenOrdersFilter{Markets: []string{"BTC-USD"}})
+	require.NoError(t, err)
+	assert.Len(t, open, 2)
+
+	var conflicts []sdk.SelfTradeConflict
+	onConflict := func(c sdk.SelfTradeConflict) { conflicts = append(conflicts, c) }
+	params := buyParams(t, BTCUSDMarket(), "1", "41000")
+
+	// Warning leaves the resting order; the exchange then rejects the self-trade
+	params.SelfTradeProtectionLevel = sdk.SelfTradeProtectionAccount
+	_, _, err = client.PlaceOrder(ctx, params, sdk.WithSelfTradeCheck(sdk.SelfTradeWarn, onConflict))
+	assert.True(t, sdk.IsOrderRejected(err, sdk.OrderStatusReasonSelfTradeProtection), "%v", err)
+	require.Len(t, conflicts, 1)
+	assert.False(t, conflicts[0].Cancelled)
+	require.Len(t, conflicts[0].Orders, 1)
+	assert.Equal(t, "own-ask", conflicts[0].Orders[0].ExternalID)
+
+	_, _, err = client.PlaceOrder(ctx, params, sdk.WithSelfTradeCheck(sdk.SelfTradeCancel, onConflict))
+	require.NoError(t, err)
+	require.Len(t, conflicts, 2)
+	assert.True(t, conflicts[1].Cancelled)
+	assert.Equal(t, decimal.NewFromInt(1).String(), ex.Position("BTC-USD").String(), "filled against the other account")
+
+	var resting []string
+	for _, o := range ex.RestingOrders() {
+		resting = append(resting, o.ExternalID)
+	}
+	assert.ElementsMatch(t, []string{"own-far-ask"}, resting)
+}
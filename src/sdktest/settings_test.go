@@ -0,0 +1,42 @@
+package sdktest
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeverageSettings(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	tickedMarket(ex) // max leverage 5
+	client := ex.NewClient()
+	ctx := context.Background()
+
+	leverage, err := client.GetLeverage(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, leverage, 1)
+	assert.Equal(t, "BTC-USD", leverage[0].Market)
+	assert.Equal(t, "1", leverage[0].Leverage.String())
+
+	updated, err := client.UpdateLeverage(ctx, "BTC-USD", decimal.NewFromInt(3))
+	require.NoError(t, err)
+	assert.Equal(t, "3", updated.Leverage.String())
+
+	leverage, err = client.GetLeverage(ctx, []string{"BTC-USD"})
+	require.NoError(t, err)
+	assert.Equal(t, "3", leverage[0].Leverage.String())
+
+	_, err = client.UpdateLeverage(ctx, "BTC-USD", decimal.NewFromInt(10))
+	var apiErr *sdk.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "1050", apiErr.Code)
+
+	_, err = client.UpdateLeverage(ctx, "BTC-USD", decimal.Zero)
+	assert.ErrorContains(t, err, "must be positive")
+	assert.Equal(t, uint64(4), client.Stats().Requests, "invalid leverage is not sent")
+}
@@ -0,0 +1,57 @@
+package sdktest
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionStats_CountsFillsOncePerOrder(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	client := ex.NewClient()
+	ctx := context.Background()
+
+	for _, id := range []string{"a", "b"} {
+		_, err := client.SubmitOrder(ctx, limitOrder(id, sdk.OrderSideBuy, "1", "40000"))
+		require.NoError(t, err)
+	}
+	require.True(t, ex.Fill("a", decimal.RequireFromString("0.5")))
+	require.True(t, ex.Fill("a", decimal.RequireFromString("0.5")))
+
+	trades, err := client.GetTrades(ctx, sdk.TradesFilter{})
+	require.NoError(t, err)
+	require.Len(t, trades, 2)
+	_, err = client.GetTrades(ctx, sdk.TradesFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), client.Stats().OrdersFilled)
+
+	// The same fills seen again on a stream are not counted twice
+	client.RecordAccountUpdate(sdk.AccountUpdateModel{Trades: trades})
+	assert.Equal(t, uint64(1), client.Stats().OrdersFilled)
+
+	b, ok := ex.Order("b")
+	require.True(t, ok)
+	b.Status = sdk.OrderStatusFilled
+	client.RecordAccountUpdate(sdk.AccountUpdateModel{Orders: []sdk.OpenOrderModel{b}})
+	assert.Equal(t, uint64(2), client.Stats().OrdersFilled)
+}
+
+func TestSessionStats_CountsMassCancelByMarket(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	client := ex.NewClient()
+	ctx := context.Background()
+
+	for _, id := range []string{"a", "b"} {
+		_, err := client.SubmitOrder(ctx, limitOrder(id, sdk.OrderSideBuy, "1", "40000"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, client.MassCancel(ctx, sdk.MassCancelParams{Markets: []string{"BTC-USD"}, ExternalOrderIDs: []string{"a"}}))
+	assert.Equal(t, uint64(2), client.Stats().OrdersCancelled)
+	assert.Empty(t, ex.RestingOrders())
+}
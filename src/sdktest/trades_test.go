@@ -0,0 +1,49 @@
+package sdktest
+
+import (
+	"context"
+	"testing"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTrades(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	client := ex.NewClient()
+	ctx := context.Background()
+
+	ex.AddRestingOrder(RestingOrder{ExternalID: "maker", Market: "BTC-USD", Side: sdk.OrderSideSell,
+		Price: decimal.NewFromInt(50000), Qty: decimal.NewFromInt(3)})
+	for _, id := range []string{"buy-1", "buy-2"} {
+		_, err := client.SubmitOrder(ctx, limitOrder(id, sdk.OrderSideBuy, "1", "50000"))
+		require.NoError(t, err)
+	}
+	_, err := client.SubmitOrder(ctx, limitOrder("ask", sdk.OrderSideSell, "1", "51000"))
+	require.NoError(t, err)
+	require.True(t, ex.Fill("ask", decimal.NewFromInt(1)))
+
+	trades, err := client.GetTrades(ctx, sdk.TradesFilter{Markets: []string{"BTC-USD"}, Side: sdk.OrderSideBuy})
+	require.NoError(t, err)
+	require.Len(t, trades, 2)
+	assert.True(t, trades[0].IsTaker)
+	assert.Greater(t, trades[0].ID, trades[1].ID, "newest first")
+
+	page, err := client.GetTrades(ctx, sdk.TradesFilter{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, sdk.OrderSideSell, page[0].Side)
+	assert.False(t, page[0].IsTaker)
+
+	next, err := client.GetTrades(ctx, sdk.TradesFilter{Cursor: &page[0].ID})
+	require.NoError(t, err)
+	assert.Len(t, next, 2)
+
+	positions, err := client.GetPositionsFiltered(ctx, sdk.PositionsFilter{Markets: []string{"BTC-USD"}})
+	require.NoError(t, err)
+	require.Len(t, positions, 1)
+	assert.Equal(t, "1", positions[0].Size.String())
+}
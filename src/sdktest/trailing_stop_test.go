@@ -0,0 +1,79 @@
+package sdktest
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func trailingStopConfig(t *testing.T, store sdk.TrailingStopStore) sdk.TrailingStopConfig {
+	order := signedOrderParams(t)
+	order.SyntheticAmount = decimal.NewFromInt(1)
+	order.Side = sdk.OrderSideSell
+	return sdk.TrailingStopConfig{
+		ID:    "trail",
+		Order: order,
+		Trail: decimal.NewFromInt(1000),
+		Step:  decimal.NewFromInt(100),
+		Store: store,
+	}
+}
+
+func TestTrailingStop(t *testing.T) {
+	ex := NewExchange()
+	defer ex.Close()
+	ex.SetPosition("BTC-USD", decimal.NewFromInt(1), decimal.NewFromInt(40000))
+	client := ex.NewClient()
+	store := sdk.NewFileTrailingStopStore(filepath.Join(t.TempDir(), "trails.json"))
+	ctx := context.Background()
+
+	stop, err := sdk.NewTrailingStop(client, trailingStopConfig(t, store))
+	require.NoError(t, err)
+
+	placed, err := stop.UpdateMarkPrice(ctx, decimal.NewFromInt(40000))
+	require.NoError(t, err)
+	assert.True(t, placed)
+	assert.Equal(t, "39000", stop.State().TriggerPrice.String())
+
+	// Adverse moves and improvements below the step keep the order
+	for _, mark := range []int64{39500, 40050} {
+		placed, err = stop.UpdateMarkPrice(ctx, decimal.NewFromInt(mark))
+		require.NoError(t, err)
+		assert.False(t, placed)
+	}
+
+	placed, err = stop.UpdateMarkPrice(ctx, decimal.NewFromInt(40500))
+	require.NoError(t, err)
+	assert.True(t, placed)
+
+	state := stop.State()
+	assert.Equal(t, "39500", state.TriggerPrice.String())
+	assert.Equal(t, "trail-2", state.OrderExternalID)
+
+	// The previous revision was replaced on the exchange
+	resting := ex.RestingOrders()
+	require.Len(t, resting, 1)
+	assert.Equal(t, "trail-2", resting[0].ExternalID)
+	first, _ := ex.Order("trail-1")
+	assert.Equal(t, sdk.OrderStatusCancelled, first.Status)
+
+	// A restarted trail resumes from the stored state
+	resumed, err := sdk.NewTrailingStop(client, trailingStopConfig(t, store))
+	require.NoError(t, err)
+	assert.Equal(t, state.OrderExternalID, resumed.State().OrderExternalID)
+	assert.True(t, state.Extreme.Equal(resumed.State().Extreme))
+	assert.True(t, state.TriggerPrice.Equal(resumed.State().TriggerPrice))
+
+	placed, err = resumed.UpdateMarkPrice(ctx, decimal.NewFromInt(40400))
+	require.NoError(t, err)
+	assert.False(t, placed)
+	placed, err = resumed.UpdateMarkPrice(ctx, decimal.NewFromInt(41000))
+	require.NoError(t, err)
+	assert.True(t, placed)
+	assert.Equal(t, "trail-3", resumed.State().OrderExternalID)
+}
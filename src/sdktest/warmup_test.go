@@ -0,0 +1,23 @@
+package sdktest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWarmup(t *testing.T) {
+	ex := NewExchange()
+	client := ex.NewClient()
+	ctx := context.Background()
+
+	require.NoError(t, client.Warmup(ctx, true))
+	stats := client.Stats()
+	assert.Equal(t, uint64(1), stats.Requests, "only the authenticated call is an API request")
+
+	ex.Close()
+	client.Close()
+	assert.Error(t, client.Warmup(ctx, false))
+}
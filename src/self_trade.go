@@ -0,0 +1,95 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// SelfTradeAction selects what the self-trade pre-check does about own
+// resting orders that an outgoing order would trade against
+type SelfTradeAction int
+
+const (
+	// SelfTradeWarn reports the conflicting orders and sends the order anyway,
+	// leaving it to the exchange's self-trade protection
+	SelfTradeWarn SelfTradeAction = iota
+	// SelfTradeCancel cancels the conflicting orders before sending the order
+	SelfTradeCancel
+)
+
+// SelfTradeConflict lists own resting orders that an outgoing order would cross
+type SelfTradeConflict struct {
+	// ExternalID of the outgoing order
+	ExternalID string
+	Orders     []OpenOrderModel
+	// Cancelled is true when the conflicting orders were cancelled
+	Cancelled bool
+}
+
+// WithSelfTradeCheck makes PlaceOrder look up own open orders on the market
+// before sending an order that may take liquidity, i.e. one that is not
+// post-only, and act on those it would cross. onConflict, if not nil, is
+// called with the conflicting orders.
+func WithSelfTradeCheck(action SelfTradeAction, onConflict func(SelfTradeConflict)) PlaceOrderOption {
+	return func(o *placeOrderOptions) {
+		o.selfTradeCheck = true
+		o.selfTradeAction = action
+		o.onSelfTrade = onConflict
+	}
+}
+
+// CrossingOwnOrders returns the open orders the order would trade against:
+// those on the other side of the same market priced at or through its price
+func CrossingOwnOrders(order *PerpetualOrderModel, open []OpenOrderModel) ([]OpenOrderModel, error) {
+	price, err := decimal.NewFromString(order.Price)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order price %q: %w", order.Price, err)
+	}
+	var crossing []OpenOrderModel
+	for _, o := range open {
+		if o.Market != order.Market || o.Side == order.Side {
+			continue
+		}
+		if (order.Side == OrderSideBuy && o.Price.LessThanOrEqual(price)) ||
+			(order.Side == OrderSideSell && o.Price.GreaterThanOrEqual(price)) {
+			crossing = append(crossing, o)
+		}
+	}
+	return crossing, nil
+}
+
+// checkSelfTrade runs the self-trade pre-check for an order about to be sent
+func (c *APIClient) checkSelfTrade(ctx context.Context, order *PerpetualOrderModel, o placeOrderOptions) error {
+	if !o.selfTradeCheck || order.PostOnly {
+		return nil
+	}
+	open, err := c.GetOpenOrders(ctx, OpenOrdersFilter{Markets: []string{order.Market}})
+	if err != nil {
+		return fmt.Errorf("self-trade check failed: %w", err)
+	}
+	crossing, err := CrossingOwnOrders(order, open)
+	if err != nil || len(crossing) == 0 {
+		return err
+	}
+
+	conflict := SelfTradeConflict{ExternalID: order.ID, Orders: crossing}
+	if o.selfTradeAction == SelfTradeCancel {
+		var errs []error
+		for _, resting := range crossing {
+			if err := c.CancelOrder(ctx, resting.ID); err != nil {
+				errs = append(errs, fmt.Errorf("order %d: %w", resting.ID, err))
+			}
+		}
+		if err := errors.Join(errs...); err != nil {
+			return fmt.Errorf("failed to cancel crossing orders: %w", err)
+		}
+		conflict.Cancelled = true
+	}
+	if o.onSelfTrade != nil {
+		o.onSelfTrade(conflict)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// LeverageResponse represents the API response for leverage queries
+type LeverageResponse struct {
+	Data   []AccountLeverageModel `json:"data"`
+	Status string                 `json:"status"`
+}
+
+// UpdateLeverageResponse represents the API response for a leverage update
+type UpdateLeverageResponse struct {
+	Data   AccountLeverageModel `json:"data"`
+	Status string               `json:"status"`
+}
+
+// GetLeverage retrieves the leverage of the account on the given markets, or
+// on every market if none are given
+func (c *APIClient) GetLeverage(ctx context.Context, markets []string) ([]AccountLeverageModel, error) {
+	baseURL, err := c.getURLWithFilter(getLeveragePath, struct {
+		Markets []string `query:"market"`
+	}{markets})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build URL: %w", err)
+	}
+
+	var leverageResponse LeverageResponse
+	if err := c.BaseModule.DoRequest(ctx, "GET", baseURL, nil, &leverageResponse); err != nil {
+		return nil, err
+	}
+
+	if leverageResponse.Status != "OK" {
+		return nil, fmt.Errorf("API returned error status: %v", leverageResponse.Status)
+	}
+
+	return leverageResponse.Data, nil
+}
+
+// UpdateLeverage sets the leverage of the account on a market and returns the
+// setting in effect. The exchange rejects leverage above the maximum of the
+// market or one the current positions and orders cannot be margined at.
+func (c *APIClient) UpdateLeverage(ctx context.Context, market string, leverage decimal.Decimal) (*AccountLeverageModel, error) {
+	if !leverage.IsPositive() {
+		return nil, fmt.Errorf("leverage must be positive, got %s", leverage)
+	}
+	baseURL, err := c.GetURL(updateLeveragePath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build URL: %w", err)
+	}
+
+	payload, err := c.codec.Marshal(AccountLeverageModel{Market: market, Leverage: leverage})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal leverage to JSON: %w", err)
+	}
+
+	var updateResponse UpdateLeverageResponse
+	// Expected before sending, so that a sync racing the response does not
+	// take the change for someone else's
+	updated := c.anomalyMonitor.expectLeverage(market, leverage)
+	err = c.BaseModule.DoRequest(withRequestSubject(ctx, market, ""), "PATCH", baseURL, bytes.NewBuffer(payload), &updateResponse)
+	updated(err)
+	if err != nil {
+		return nil, err
+	}
+
+	if updateResponse.Status != "OK" {
+		return nil, fmt.Errorf("API returned error status: %v", updateResponse.Status)
+	}
+
+	return &updateResponse.Data, nil
+}
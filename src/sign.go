@@ -30,6 +30,9 @@ import "C"
 
 import (
 	"fmt"
+	"math/big"
+	"strings"
+	"unicode/utf8"
 	"unsafe"
 )
 
@@ -43,6 +46,18 @@ func GetOrderHash(
 	domainName, domainVersion,
 	domainChainID, domainRevision string,
 ) (string, error) {
+	// C strings end at the first NUL, which would silently hash a truncated
+	// value, and the hasher aborts the process on invalid UTF-8
+	for _, s := range []string{
+		positionID, baseAssetIDHex, baseAmount, quoteAssetIDHex, quoteAmount,
+		feeAssetIDHex, feeAmount, expiration, salt, userPublicKeyHex,
+		domainName, domainVersion, domainChainID, domainRevision,
+	} {
+		if strings.IndexByte(s, 0) >= 0 || !utf8.ValidString(s) {
+			return "", fmt.Errorf("order hash parameter %q is not a valid C string", s)
+		}
+	}
+
 	// allocate C strings
 	toC := func(s string) *C.char {
 		return C.CString(s)
@@ -73,13 +88,36 @@ func GetOrderHash(
 	}
 	defer C.free_string(result)
 
-	return C.GoString(result), nil
+	// Invalid parameters are reported as a message in place of the hash
+	hash := C.GoString(result)
+	if !strings.HasPrefix(hash, "0x") {
+		return "", fmt.Errorf("failed to compute order hash: %s", hash)
+	}
+	return hash, nil
 }
 
+// starkCurveOrder is the order of the Stark curve, above which private keys
+// are out of range
+var starkCurveOrder, _ = new(big.Int).SetString("800000000000010ffffffffffffffffb781126dcae7b2321e66a241adc64d2f", 16)
+
 // SignMessage signs a message using the provided private key.
 // It returns the signature as a hex string, where v, r and s are concatenated as left-padded 64-character hex strings.
 // The signature is in the format: {r}{s}{v}
 func SignMessage(messageHex, privateKeyHex string) (string, error) {
+	// The signer aborts the process on malformed hex and wraps oversized values
+	messageHex, err := feltHex(messageHex)
+	if err != nil {
+		return "", fmt.Errorf("invalid message hash: %w", err)
+	}
+	privateKeyHex, err = feltHex(privateKeyHex)
+	if err != nil {
+		return "", fmt.Errorf("invalid private key: %w", err)
+	}
+	// A zero key, modulo the curve order, makes the signer loop forever
+	if key, _ := new(big.Int).SetString(privateKeyHex[2:], 16); key.Sign() == 0 || key.Cmp(starkCurveOrder) >= 0 {
+		return "", fmt.Errorf("invalid private key: not between 1 and the curve order")
+	}
+
 	cmsg := C.CString(messageHex)
 	defer C.free(unsafe.Pointer(cmsg))
 	cpriv := C.CString(privateKeyHex)
@@ -0,0 +1,153 @@
+package sdk
+
+import (
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EndpointStats holds request counters for a single endpoint
+type EndpointStats struct {
+	Requests      uint64
+	Errors        uint64
+	BytesSent     uint64
+	BytesReceived uint64
+}
+
+// SessionStats holds the API usage counters accumulated since the client
+// was created or the stats were last reset
+type SessionStats struct {
+	Since         time.Time
+	Requests      uint64
+	Errors        uint64
+	BytesSent     uint64
+	BytesReceived uint64
+	OrdersPlaced  uint64
+	// OrdersFilled counts the orders with a fill made during the session,
+	// once per order. Fills are seen by WaitForFill, GetTrades and the
+	// account updates passed to RecordAccountUpdate.
+	OrdersFilled    uint64
+	OrdersCancelled uint64
+	// Endpoints is keyed by "METHOD /path", e.g. "GET /info/markets"
+	Endpoints map[string]EndpointStats
+}
+
+type sessionStats struct {
+	mu    sync.Mutex
+	clock Clock
+	stats SessionStats
+	// filled holds the IDs of the orders counted in OrdersFilled
+	filled map[int64]bool
+}
+
+func newSessionStats(clock Clock) *sessionStats {
+	s := &sessionStats{clock: clockOrDefault(clock)}
+	s.reset()
+	return s
+}
+
+func (s *sessionStats) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stats = SessionStats{
+		Since:     s.clock.Now(),
+		Endpoints: make(map[string]EndpointStats),
+	}
+	s.filled = make(map[int64]bool)
+}
+
+func (s *sessionStats) snapshot() SessionStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := s.stats
+	out.Endpoints = make(map[string]EndpointStats, len(s.stats.Endpoints))
+	for k, v := range s.stats.Endpoints {
+		out.Endpoints[k] = v
+	}
+	return out
+}
+
+func (s *sessionStats) recordRequest(endpoint string, sent, received int64, failed bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e := s.stats.Endpoints[endpoint]
+	e.Requests++
+	s.stats.Requests++
+	if sent > 0 {
+		e.BytesSent += uint64(sent)
+		s.stats.BytesSent += uint64(sent)
+	}
+	if received > 0 {
+		e.BytesReceived += uint64(received)
+		s.stats.BytesReceived += uint64(received)
+	}
+	if failed {
+		e.Errors++
+		s.stats.Errors++
+	}
+	s.stats.Endpoints[endpoint] = e
+}
+
+func (s *sessionStats) recordOrders(placed, cancelled uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stats.OrdersPlaced += placed
+	s.stats.OrdersCancelled += cancelled
+}
+
+// recordFill counts an order as filled unless it already was
+func (s *sessionStats) recordFill(orderID int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.filled[orderID] {
+		s.filled[orderID] = true
+		s.stats.OrdersFilled++
+	}
+}
+
+// recordTrades counts the orders of the trades made during the session
+func (s *sessionStats) recordTrades(trades []AccountTradeModel) {
+	s.mu.Lock()
+	since := s.stats.Since.UnixMilli()
+	s.mu.Unlock()
+	for _, trade := range trades {
+		if trade.CreatedTime >= since {
+			s.recordFill(trade.OrderID)
+		}
+	}
+}
+
+// Stats returns a copy of the session counters
+func (m *BaseModule) Stats() SessionStats {
+	return m.stats.snapshot()
+}
+
+// RecordAccountUpdate counts the fills of an account update observed outside
+// the client, e.g. on an account stream, in the session stats
+func (m *BaseModule) RecordAccountUpdate(update AccountUpdateModel) {
+	m.stats.recordTrades(update.Trades)
+	for _, order := range update.Orders {
+		if order.Status == OrderStatusFilled {
+			m.stats.recordFill(order.ID)
+		}
+	}
+}
+
+// ResetStats zeroes the session counters
+func (m *BaseModule) ResetStats() {
+	m.stats.reset()
+}
+
+// endpointKey strips the API base path and query from a request URL so that
+// counters are grouped per endpoint rather than per full URL
+func (m *BaseModule) endpointKey(method, rawURL string) string {
+	path := rawURL
+	if u, err := url.Parse(rawURL); err == nil {
+		path = u.Path
+		if base, err := url.Parse(m.endpointConfig.APIBaseURL); err == nil {
+			path = strings.TrimPrefix(path, strings.TrimSuffix(base.Path, "/"))
+		}
+	}
+	return method + " " + path
+}
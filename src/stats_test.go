@@ -0,0 +1,47 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIClientStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/info/markets":
+			w.Write([]byte(`{"status":"OK","data":[{"name":"BTC-USD"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"status":"ERROR"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewAPIClient(EndpointConfig{APIBaseURL: server.URL + "/api/v1"}, "key", nil, 5*time.Second)
+	ctx := context.Background()
+
+	_, err := client.GetMarkets(ctx, []string{"BTC-USD"})
+	require.NoError(t, err)
+	_, err = client.GetMarkets(ctx, nil)
+	require.NoError(t, err)
+	_, err = client.GetBalance(ctx)
+	require.Error(t, err)
+
+	stats := client.Stats()
+	assert.Equal(t, uint64(3), stats.Requests)
+	assert.Equal(t, uint64(1), stats.Errors)
+	assert.Equal(t, uint64(2), stats.Endpoints["GET /info/markets"].Requests)
+	assert.Equal(t, uint64(1), stats.Endpoints["GET /user/balance"].Errors)
+	assert.Greater(t, stats.BytesReceived, uint64(0))
+
+	client.ResetStats()
+	stats = client.Stats()
+	assert.Equal(t, uint64(0), stats.Requests)
+	assert.Empty(t, stats.Endpoints)
+}
@@ -0,0 +1,58 @@
+package stream
+
+import (
+	"context"
+	"errors"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+)
+
+// Event types of the account stream, after its initial EventSnapshot
+const (
+	EventOrder    = "ORDER"
+	EventTrade    = "TRADE"
+	EventPosition = "POSITION"
+	EventBalance  = "BALANCE"
+)
+
+// SubscribeAccountUpdates streams the order state transitions, fills,
+// position updates and balance changes of the account of the API key. Every
+// (re)connection starts with an EventSnapshot of the open orders, positions
+// and balance, followed by events of type EventOrder, EventTrade,
+// EventPosition or EventBalance. Requires WithAPIKey.
+//
+// The orders and fills can be passed on to a Blotter with
+// RecordAccountUpdate, or to the Record methods of a PnLAttributor or
+// AnomalyMonitor, instead of polling them. Passing them to the
+// RecordAccountUpdate method of the API client counts the fills in its
+// session stats.
+func (c *StreamClient) SubscribeAccountUpdates(ctx context.Context) (*Subscription[sdk.AccountUpdateModel], error) {
+	return Subscribe[sdk.AccountUpdateModel](ctx, c, "/account", true)
+}
+
+// RecordAccountUpdate publishes the order updates and fills of an account
+// stream event to b, e.g. for every event of SubscribeAccountUpdates:
+//
+//	for event := range sub.Events() {
+//		if err := stream.RecordAccountUpdate(ctx, blotter, event); err != nil {
+//			log.Print(err)
+//		}
+//	}
+//
+// Snapshots restate the open orders rather than report changes, so they are
+// not recorded. Fills made while the subscription reconnected are in no
+// event; call Blotter.Sync on a snapshot to publish them. Every order and
+// fill is recorded even if some fail; their errors are joined.
+func RecordAccountUpdate(ctx context.Context, b *sdk.Blotter, event Event[sdk.AccountUpdateModel]) error {
+	if event.Type == EventSnapshot {
+		return nil
+	}
+	var errs []error
+	for _, order := range event.Data.Orders {
+		errs = append(errs, b.RecordOrder(ctx, order))
+	}
+	for _, trade := range event.Data.Trades {
+		errs = append(errs, b.RecordTrade(ctx, trade))
+	}
+	return errors.Join(errs...)
+}
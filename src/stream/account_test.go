@@ -0,0 +1,100 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/extended-protocol/extended-sdk-golang/src/internal/websocket"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscribeAccountUpdates(t *testing.T) {
+	cfg := newStreamServer(t, func(r *http.Request, conn *websocket.Conn) {
+		assert.Equal(t, "/stream.extended.exchange/v1/account", r.URL.Path)
+		assert.Equal(t, "secret", r.Header.Get("X-API-Key"))
+		send(conn, `{"type":"SNAPSHOT","seq":1,"data":{"orders":[],"positions":[{"id":3,"market":"BTC-USD","side":"LONG","size":"0.5"}],"balance":{"collateralName":"USD","balance":"1000","equity":"1010"}}}`)
+		send(conn, `{"type":"ORDER","seq":2,"data":{"orders":[{"id":11,"externalId":"ext-1","market":"BTC-USD","side":"BUY","status":"FILLED","qty":"0.1","filledQty":"0.1"}]}}`)
+		send(conn, `{"type":"TRADE","seq":3,"data":{"trades":[{"id":21,"orderId":11,"market":"BTC-USD","side":"BUY","price":"50000","qty":"0.1"}]}}`)
+		send(conn, `{"type":"BALANCE","seq":4,"data":{"balance":{"collateralName":"USD","balance":"995","equity":"1005"}}}`)
+		waitClosed(conn)
+	})
+
+	sub, err := NewStreamClient(cfg, WithAPIKey("secret")).SubscribeAccountUpdates(context.Background())
+	require.NoError(t, err)
+	defer sub.Close()
+
+	snapshot := next(t, sub)
+	assert.Equal(t, EventSnapshot, snapshot.Type)
+	require.Len(t, snapshot.Data.Positions, 1)
+	assert.Equal(t, sdk.PositionSideLong, snapshot.Data.Positions[0].Side)
+	require.NotNil(t, snapshot.Data.Balance)
+	assert.True(t, decimal.NewFromInt(1010).Equal(snapshot.Data.Balance.Equity))
+
+	order := next(t, sub)
+	assert.Equal(t, EventOrder, order.Type)
+	require.Len(t, order.Data.Orders, 1)
+	assert.Equal(t, "ext-1", order.Data.Orders[0].ExternalID)
+	assert.Equal(t, sdk.OrderStatusFilled, order.Data.Orders[0].Status)
+	assert.Nil(t, order.Data.Balance)
+
+	trade := next(t, sub)
+	assert.Equal(t, EventTrade, trade.Type)
+	require.Len(t, trade.Data.Trades, 1)
+	assert.Equal(t, int64(11), trade.Data.Trades[0].OrderID)
+
+	balance := next(t, sub)
+	assert.Equal(t, EventBalance, balance.Type)
+	require.NotNil(t, balance.Data.Balance)
+	assert.True(t, decimal.NewFromInt(995).Equal(balance.Data.Balance.Balance))
+}
+
+func TestRecordAccountUpdate(t *testing.T) {
+	var published []sdk.BlotterEvent
+	fail := false
+	blotter := sdk.NewBlotter(sdk.BlotterSinkFunc(func(_ context.Context, event sdk.BlotterEvent) error {
+		if fail {
+			return errors.New("sink down")
+		}
+		published = append(published, event)
+		return nil
+	}))
+	ctx := context.Background()
+	order := sdk.OpenOrderModel{ID: 11, ExternalID: "ext-1", Market: "BTC-USD", Status: sdk.OrderStatusFilled}
+	trade := sdk.AccountTradeModel{ID: 21, OrderID: 11, Market: "BTC-USD", Price: decimal.NewFromInt(50000), Qty: decimal.RequireFromString("0.1")}
+
+	snapshot := Event[sdk.AccountUpdateModel]{Type: EventSnapshot, Data: sdk.AccountUpdateModel{Orders: []sdk.OpenOrderModel{order}}}
+	require.NoError(t, RecordAccountUpdate(ctx, blotter, snapshot))
+	assert.Empty(t, published, "snapshots are not recorded")
+
+	require.NoError(t, RecordAccountUpdate(ctx, blotter, Event[sdk.AccountUpdateModel]{Type: EventOrder, Data: sdk.AccountUpdateModel{Orders: []sdk.OpenOrderModel{order}}}))
+	tradeEvent := Event[sdk.AccountUpdateModel]{Type: EventTrade, Data: sdk.AccountUpdateModel{Trades: []sdk.AccountTradeModel{trade}}}
+	require.NoError(t, RecordAccountUpdate(ctx, blotter, tradeEvent))
+	require.NoError(t, RecordAccountUpdate(ctx, blotter, tradeEvent), "fills are published once")
+	require.Len(t, published, 2)
+	assert.Equal(t, sdk.BlotterEventOrder, published[0].Kind)
+	assert.Equal(t, "ext-1", published[0].ExternalID)
+	assert.Equal(t, sdk.BlotterEventFill, published[1].Kind)
+	assert.Equal(t, int64(21), published[1].TradeID)
+
+	fail = true
+	both := Event[sdk.AccountUpdateModel]{Type: EventOrder, Data: sdk.AccountUpdateModel{
+		Orders: []sdk.OpenOrderModel{order},
+		Trades: []sdk.AccountTradeModel{{ID: 22, OrderID: 11, Market: "BTC-USD"}},
+	}}
+	err := RecordAccountUpdate(ctx, blotter, both)
+	assert.ErrorContains(t, err, "sink down")
+	fail = false
+	require.NoError(t, RecordAccountUpdate(ctx, blotter, both))
+	assert.Len(t, published, 4, "a fill that failed to publish is published when recorded again")
+}
+
+func TestSubscribeAccountUpdates_RequiresAPIKey(t *testing.T) {
+	cfg := sdk.EndpointConfig{StreamURL: "ws://127.0.0.1:1"}
+	_, err := NewStreamClient(cfg).SubscribeAccountUpdates(context.Background())
+	assert.ErrorIs(t, err, sdk.ErrAPIKeyNotSet)
+}
@@ -0,0 +1,66 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+)
+
+// SubscribeCandles streams the candles of a market at interval, built from
+// candleType prices. Every event carries the candles that changed, oldest
+// first: the forming candle with Closed unset as it is updated, and a candle
+// once more with Closed set when the next one opens.
+func (c *StreamClient) SubscribeCandles(ctx context.Context, market string, interval sdk.CandleInterval, candleType sdk.CandleType) (*Subscription[[]sdk.CandleModel], error) {
+	if !interval.IsValid() {
+		return nil, fmt.Errorf("%w: candle interval %q", sdk.ErrInvalidEnumValue, interval)
+	}
+	if !candleType.IsValid() {
+		return nil, fmt.Errorf("%w: candle type %q", sdk.ErrInvalidEnumValue, candleType)
+	}
+	path := "/candles/" + url.PathEscape(market) + "/" + string(candleType) + "?interval=" + url.QueryEscape(string(interval))
+	var tracker candleTracker
+	return subscribe(ctx, c, path, false, tracker.decode)
+}
+
+// candleTracker marks the candles of a subscription closed. The stream sends
+// the forming candle on every change, so a candle is final once a later one
+// arrives.
+type candleTracker struct {
+	forming *sdk.CandleModel
+}
+
+func (t *candleTracker) decode(data json.RawMessage) ([]sdk.CandleModel, error) {
+	var candles []sdk.CandleModel
+	if err := json.Unmarshal(data, &candles); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(candles, func(i, j int) bool { return candles[i].Timestamp < candles[j].Timestamp })
+
+	out := make([]sdk.CandleModel, 0, len(candles)+1)
+	updated := false
+	for _, candle := range candles {
+		switch {
+		case t.forming == nil || candle.Timestamp == t.forming.Timestamp:
+		case candle.Timestamp > t.forming.Timestamp:
+			closed := *t.forming
+			closed.Closed = true
+			out = append(out, closed)
+		default:
+			// A candle older than the forming one, e.g. history sent after
+			// a reconnect, is already final
+			candle.Closed = true
+			out = append(out, candle)
+			continue
+		}
+		t.forming = &candle
+		updated = true
+	}
+	if updated {
+		out = append(out, *t.forming)
+	}
+	return out, nil
+}
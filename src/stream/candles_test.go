@@ -0,0 +1,55 @@
+package stream
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/extended-protocol/extended-sdk-golang/src/internal/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscribeCandles(t *testing.T) {
+	cfg := newStreamServer(t, func(r *http.Request, conn *websocket.Conn) {
+		assert.Equal(t, "/stream.extended.exchange/v1/candles/BTC-USD/trades", r.URL.Path)
+		assert.Equal(t, "PT1M", r.URL.Query().Get("interval"))
+		send(conn, `{"seq":1,"data":[{"o":"100","l":"99","h":"101","c":"100.5","v":"2","T":1700000040000},{"o":"98","l":"97","h":"100","c":"100","v":"5","T":1700000000000}]}`)
+		send(conn, `{"seq":2,"data":[{"o":"100","l":"99","h":"102","c":"101","v":"3","T":1700000040000}]}`)
+		send(conn, `{"seq":3,"data":[{"o":"101","l":"101","h":"101","c":"101","v":"0.1","T":1700000100000}]}`)
+		waitClosed(conn)
+	})
+
+	sub, err := NewStreamClient(cfg).SubscribeCandles(context.Background(), "BTC-USD", sdk.CandleInterval1Minute, sdk.CandleTypeTrades)
+	require.NoError(t, err)
+	defer sub.Close()
+
+	first := next(t, sub).Data
+	require.Len(t, first, 2)
+	assert.Equal(t, int64(1700000000000), first[0].Timestamp)
+	assert.True(t, first[0].Closed, "the older candle of a message is final")
+	assert.Equal(t, int64(1700000040000), first[1].Timestamp)
+	assert.False(t, first[1].Closed)
+
+	update := next(t, sub).Data
+	require.Len(t, update, 1)
+	assert.False(t, update[0].Closed)
+	assert.Equal(t, "102", update[0].High.String())
+
+	rollover := next(t, sub).Data
+	require.Len(t, rollover, 2)
+	assert.True(t, rollover[0].Closed)
+	assert.Equal(t, int64(1700000040000), rollover[0].Timestamp)
+	assert.Equal(t, "101", rollover[0].Close.String(), "a closed candle carries its last update")
+	assert.False(t, rollover[1].Closed)
+	assert.Equal(t, int64(1700000100000), rollover[1].Timestamp)
+}
+
+func TestSubscribeCandles_Validation(t *testing.T) {
+	client := NewStreamClient(sdk.EndpointConfig{StreamURL: "ws://127.0.0.1:1"})
+	_, err := client.SubscribeCandles(context.Background(), "BTC-USD", "PT3M", sdk.CandleTypeTrades)
+	assert.ErrorIs(t, err, sdk.ErrInvalidEnumValue)
+	_, err = client.SubscribeCandles(context.Background(), "BTC-USD", sdk.CandleInterval1Hour, "volume")
+	assert.ErrorIs(t, err, sdk.ErrInvalidEnumValue)
+}
@@ -0,0 +1,96 @@
+package stream
+
+import (
+	"errors"
+	"time"
+
+	"github.com/extended-protocol/extended-sdk-golang/src/internal/websocket"
+)
+
+// ErrStreamStale is returned when a connection received nothing, neither a
+// message nor a pong, for the StaleTimeout of the HeartbeatConfig
+var ErrStreamStale = errors.New("stream stale")
+
+// HeartbeatConfig configures how subscriptions detect connections that
+// silently stopped delivering
+type HeartbeatConfig struct {
+	// StaleTimeout is how long a connection may receive nothing before it is
+	// closed and reconnected; zero disables the check
+	StaleTimeout time.Duration
+	// PingInterval is how often a ping is sent. Pongs count as received, so
+	// a quiet but healthy stream is not stale. Defaults to a third of
+	// StaleTimeout.
+	PingInterval time.Duration
+}
+
+// WithHeartbeat pings the exchange on every connection and reconnects
+// connections that went stale, per the ReconnectConfig
+func WithHeartbeat(cfg HeartbeatConfig) Option {
+	return func(c *StreamClient) {
+		if cfg.PingInterval <= 0 {
+			cfg.PingInterval = cfg.StaleTimeout / 3
+		}
+		c.heartbeat = cfg
+	}
+}
+
+// DiagnosticKind is the kind of a Diagnostic
+type DiagnosticKind string
+
+const (
+	// DiagnosticStale is a connection closed for receiving nothing within
+	// the StaleTimeout
+	DiagnosticStale DiagnosticKind = "STALE"
+	// DiagnosticReconnected is a subscription that reconnected after its
+	// connection dropped or went stale
+	DiagnosticReconnected DiagnosticKind = "RECONNECTED"
+	// DiagnosticDecodeFailed is a message skipped because it could not be
+	// decoded, e.g. a frame of a format the SDK does not know yet
+	DiagnosticDecodeFailed DiagnosticKind = "DECODE_FAILED"
+)
+
+// Diagnostic reports a connection or decoding event of a subscription, e.g.
+// for logging or metrics
+type Diagnostic struct {
+	Kind DiagnosticKind
+	// Path is the stream path of the subscription
+	Path   string
+	Time   time.Time
+	Detail string
+}
+
+// WithDiagnostics calls fn with the connection and decoding events of every
+// subscription. fn is called from the goroutine of the subscription and
+// should not block.
+func WithDiagnostics(fn func(Diagnostic)) Option {
+	return func(c *StreamClient) {
+		c.onDiagnostic = fn
+	}
+}
+
+func (c *StreamClient) diagnose(kind DiagnosticKind, path, detail string) {
+	if c.onDiagnostic != nil {
+		c.onDiagnostic(Diagnostic{Kind: kind, Path: path, Time: time.Now().UTC(), Detail: detail})
+	}
+}
+
+// pingEvery pings conn every interval until the returned function is called
+// or a ping fails
+func pingEvery(conn *websocket.Conn, interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := conn.Ping(nil); err != nil {
+					return
+				}
+			}
+		}
+	}()
+	return func() { close(done) }
+}
@@ -0,0 +1,119 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/extended-protocol/extended-sdk-golang/src/internal/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeartbeat_ReconnectsStaleConnection(t *testing.T) {
+	release := make(chan struct{})
+	var connections atomic.Int32
+	cfg := newStreamServer(t, func(r *http.Request, conn *websocket.Conn) {
+		n := connections.Add(1)
+		send(conn, `{"seq":1,"data":[]}`)
+		if n == 1 {
+			// Go silent without reading, so pings are not answered either
+			<-release
+			return
+		}
+		waitClosed(conn)
+	})
+	t.Cleanup(func() { close(release) })
+
+	var mu sync.Mutex
+	var diagnostics []Diagnostic
+	client := NewStreamClient(cfg,
+		WithReconnect(ReconnectConfig{BaseDelay: time.Millisecond}),
+		WithHeartbeat(HeartbeatConfig{StaleTimeout: 100 * time.Millisecond}),
+		WithDiagnostics(func(d Diagnostic) {
+			mu.Lock()
+			defer mu.Unlock()
+			diagnostics = append(diagnostics, d)
+		}),
+	)
+	sub, err := client.SubscribePublicTrades(context.Background(), "")
+	require.NoError(t, err)
+	defer sub.Close()
+
+	next(t, sub)
+	next(t, sub)
+	assert.Equal(t, 1, sub.Reconnects())
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, diagnostics, 2)
+	assert.Equal(t, DiagnosticStale, diagnostics[0].Kind)
+	assert.Equal(t, "/publicTrades", diagnostics[0].Path)
+	assert.Equal(t, DiagnosticReconnected, diagnostics[1].Kind)
+}
+
+func TestHeartbeat_PongsKeepQuietStreamAlive(t *testing.T) {
+	var diagnostics atomic.Int32
+	cfg := newStreamServer(t, func(r *http.Request, conn *websocket.Conn) {
+		send(conn, `{"seq":1,"data":[]}`)
+		// Reading answers the pings of the client without sending messages
+		waitClosed(conn)
+	})
+
+	client := NewStreamClient(cfg, WithHeartbeat(HeartbeatConfig{StaleTimeout: 150 * time.Millisecond, PingInterval: 30 * time.Millisecond}),
+		WithDiagnostics(func(Diagnostic) { diagnostics.Add(1) }))
+	sub, err := client.SubscribePublicTrades(context.Background(), "")
+	require.NoError(t, err)
+	defer sub.Close()
+
+	next(t, sub)
+	time.Sleep(500 * time.Millisecond)
+	assert.Equal(t, 0, sub.Reconnects())
+	assert.Zero(t, diagnostics.Load(), "a healthy stream raises no diagnostics")
+	assert.NoError(t, sub.Err())
+}
+
+func TestHeartbeat_SlowReaderIsNotStale(t *testing.T) {
+	cfg := newStreamServer(t, func(r *http.Request, conn *websocket.Conn) {
+		// One more than fits the buffer blocks delivery; then only pongs follow
+		for i := 1; i <= eventBuffer+1; i++ {
+			send(conn, fmt.Sprintf(`{"seq":%d,"data":[]}`, i))
+		}
+		waitClosed(conn)
+	})
+
+	var diagnostics atomic.Int32
+	client := NewStreamClient(cfg, WithHeartbeat(HeartbeatConfig{StaleTimeout: 100 * time.Millisecond}),
+		WithDiagnostics(func(Diagnostic) { diagnostics.Add(1) }))
+	sub, err := client.SubscribePublicTrades(context.Background(), "")
+	require.NoError(t, err)
+	defer sub.Close()
+
+	// Block delivery for longer than the timeout
+	time.Sleep(300 * time.Millisecond)
+	for i := 1; i <= eventBuffer+1; i++ {
+		assert.Equal(t, int64(i), next(t, sub).Seq)
+	}
+	time.Sleep(300 * time.Millisecond)
+	assert.Equal(t, 0, sub.Reconnects())
+	assert.Zero(t, diagnostics.Load())
+}
+
+func TestHeartbeat_StaleEndsSubscriptionWithoutReconnect(t *testing.T) {
+	release := make(chan struct{})
+	cfg := newStreamServer(t, func(r *http.Request, conn *websocket.Conn) {
+		<-release
+	})
+	t.Cleanup(func() { close(release) })
+
+	client := NewStreamClient(cfg, WithReconnect(ReconnectConfig{Disabled: true}), WithHeartbeat(HeartbeatConfig{StaleTimeout: 50 * time.Millisecond}))
+	sub, err := client.SubscribePublicTrades(context.Background(), "")
+	require.NoError(t, err)
+
+	drain(t, sub)
+	assert.ErrorIs(t, sub.Err(), ErrStreamStale)
+}
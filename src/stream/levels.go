@@ -0,0 +1,182 @@
+package stream
+
+import (
+	"math"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/shopspring/decimal"
+)
+
+// Prices and quantities of an Orderbook are kept as integers of these
+// decimal places, so levels compare and update without big.Int arithmetic.
+// They cover prices up to ~9.2e8 and quantities up to ~9.2e10.
+const (
+	priceScale = 10
+	qtyScale   = 8
+)
+
+// maxSkipHeight bounds the height of the skiplist towers; with a promotion
+// probability of 1/4 it suits books of millions of levels
+const maxSkipHeight = 12
+
+// levelNode is a price level of a levelList
+type levelNode struct {
+	price int64
+	qty   int64
+	next  [maxSkipHeight]*levelNode
+}
+
+// levelList is one side of an Orderbook: a skiplist of price levels keyed by
+// scaled price, best price first, so that updates anywhere in a deep book
+// take O(log n). Removed nodes are kept for reuse, so a book whose depth is
+// steady updates without allocating.
+type levelList struct {
+	bid    bool
+	head   levelNode
+	height int
+	len    int
+	free   *levelNode
+	rand   uint64
+}
+
+func newLevelList(bid bool) *levelList {
+	return &levelList{bid: bid, height: 1, rand: 0x9e3779b97f4a7c15}
+}
+
+// before reports whether price a is better than price b on this side
+func (l *levelList) before(a, b int64) bool {
+	if l.bid {
+		return a > b
+	}
+	return a < b
+}
+
+// add changes the quantity at price by qty, inserting the level if it is new
+// and removing it once its quantity is no longer positive
+func (l *levelList) add(price, qty int64) {
+	var update [maxSkipHeight]*levelNode
+	x := &l.head
+	for i := l.height - 1; i >= 0; i-- {
+		for x.next[i] != nil && l.before(x.next[i].price, price) {
+			x = x.next[i]
+		}
+		update[i] = x
+	}
+
+	if n := x.next[0]; n != nil && n.price == price {
+		n.qty += qty
+		if n.qty > 0 {
+			return
+		}
+		for i := 0; i < l.height && update[i].next[i] == n; i++ {
+			update[i].next[i] = n.next[i]
+		}
+		for l.height > 1 && l.head.next[l.height-1] == nil {
+			l.height--
+		}
+		l.len--
+		l.release(n)
+		return
+	}
+	if qty <= 0 {
+		return
+	}
+
+	height := l.randomHeight()
+	for i := l.height; i < height; i++ {
+		update[i] = &l.head
+	}
+	if height > l.height {
+		l.height = height
+	}
+	n := l.alloc()
+	n.price, n.qty = price, qty
+	for i := 0; i < height; i++ {
+		n.next[i] = update[i].next[i]
+		update[i].next[i] = n
+	}
+	l.len++
+}
+
+// clear removes every level, keeping the nodes for reuse
+func (l *levelList) clear() {
+	for n := l.head.next[0]; n != nil; {
+		next := n.next[0]
+		l.release(n)
+		n = next
+	}
+	l.head.next = [maxSkipHeight]*levelNode{}
+	l.height, l.len = 1, 0
+}
+
+// levels returns the levels best first, at most depth of them when depth is
+// positive
+func (l *levelList) levels(depth int) []sdk.OrderbookQuantityModel {
+	n := l.len
+	if depth > 0 && depth < n {
+		n = depth
+	}
+	out := make([]sdk.OrderbookQuantityModel, 0, n)
+	for x := l.head.next[0]; x != nil && len(out) < n; x = x.next[0] {
+		out = append(out, sdk.OrderbookQuantityModel{
+			Price: decimal.New(x.price, -priceScale),
+			Qty:   decimal.New(x.qty, -qtyScale),
+		})
+	}
+	return out
+}
+
+func (l *levelList) alloc() *levelNode {
+	n := l.free
+	if n == nil {
+		return &levelNode{}
+	}
+	l.free = n.next[0]
+	n.next = [maxSkipHeight]*levelNode{}
+	return n
+}
+
+func (l *levelList) release(n *levelNode) {
+	n.next = [maxSkipHeight]*levelNode{}
+	n.next[0] = l.free
+	l.free = n
+}
+
+// randomHeight draws the height of a new tower, promoting with probability
+// 1/4 per level from an xorshift generator, which keeps inserts free of
+// allocations and locks
+func (l *levelList) randomHeight() int {
+	l.rand ^= l.rand << 13
+	l.rand ^= l.rand >> 7
+	l.rand ^= l.rand << 17
+	height := 1
+	for r := l.rand; height < maxSkipHeight && r&3 == 0; r >>= 2 {
+		height++
+	}
+	return height
+}
+
+// scaledInt returns d as a whole number of 10^-scale units, false if d has
+// more decimal places than scale or does not fit an int64
+func scaledInt(d decimal.Decimal, scale int32) (int64, bool) {
+	if d.NumDigits() > 18 {
+		return 0, false
+	}
+	v := d.CoefficientInt64()
+	for shift := d.Exponent() + scale; shift != 0; {
+		if shift > 0 {
+			if v > math.MaxInt64/10 || v < math.MinInt64/10 {
+				return 0, false
+			}
+			v *= 10
+			shift--
+		} else {
+			if v%10 != 0 {
+				return 0, false
+			}
+			v /= 10
+			shift++
+		}
+	}
+	return v, true
+}
@@ -0,0 +1,172 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrSequenceGap is returned by Orderbook.Apply when an update was
+	// missed. The book is stale until the next snapshot, e.g. after
+	// subscribing again.
+	ErrSequenceGap = errors.New("orderbook sequence gap")
+	// ErrLevelPrecision is returned by Orderbook.Apply for a level whose
+	// price or quantity has more decimal places than the book keeps
+	ErrLevelPrecision = errors.New("orderbook level exceeds the precision of the book")
+)
+
+// orderbookLevel is a price level as sent by the orderbook stream
+type orderbookLevel struct {
+	Price decimal.Decimal `json:"p"`
+	Qty   decimal.Decimal `json:"q"`
+}
+
+// orderbookMessage is the data of an orderbook stream message
+type orderbookMessage struct {
+	Market string           `json:"m"`
+	Bid    []orderbookLevel `json:"b"`
+	Ask    []orderbookLevel `json:"a"`
+}
+
+// orderbookMessages pools the wire messages orderbook frames are decoded
+// into, so their level buffers are reused from frame to frame
+var orderbookMessages = sync.Pool{New: func() any { return new(orderbookMessage) }}
+
+// decodeOrderbook decodes an orderbook frame into a pooled wire message and
+// copies its levels into slices of exactly their length, which the event
+// then owns
+func decodeOrderbook(data json.RawMessage) (sdk.OrderbookUpdateModel, error) {
+	m := orderbookMessages.Get().(*orderbookMessage)
+	defer orderbookMessages.Put(m)
+	m.Market, m.Bid, m.Ask = "", m.Bid[:0], m.Ask[:0]
+	if err := json.Unmarshal(data, m); err != nil {
+		return sdk.OrderbookUpdateModel{}, err
+	}
+	return sdk.OrderbookUpdateModel{Market: m.Market, Bid: modelLevels(m.Bid), Ask: modelLevels(m.Ask)}, nil
+}
+
+func modelLevels(in []orderbookLevel) []sdk.OrderbookQuantityModel {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]sdk.OrderbookQuantityModel, len(in))
+	for i, l := range in {
+		out[i] = sdk.OrderbookQuantityModel{Price: l.Price, Qty: l.Qty}
+	}
+	return out
+}
+
+// SubscribeOrderbook streams the orderbook of a market, or of all markets
+// when market is empty. Every (re)connection starts with an EventSnapshot of
+// the full book, followed by EventDelta events whose levels carry the change
+// of quantity at their price. An Orderbook maintains the book from them.
+func (c *StreamClient) SubscribeOrderbook(ctx context.Context, market string) (*Subscription[sdk.OrderbookUpdateModel], error) {
+	return subscribe(ctx, c, marketPath("/orderbooks", market), false, decodeOrderbook)
+}
+
+// Orderbook is a local copy of the orderbook of one market, maintained from
+// the events of SubscribeOrderbook. Each side is a skiplist keyed by price, so
+// deltas anywhere in a deep book apply in O(log n). It is safe for
+// concurrent use.
+type Orderbook struct {
+	mu     sync.Mutex
+	market string
+	bid    *levelList
+	ask    *levelList
+	seq    int64
+	synced bool
+}
+
+// NewOrderbook creates an empty book, synced by the first snapshot applied
+func NewOrderbook() *Orderbook {
+	return &Orderbook{bid: newLevelList(true), ask: newLevelList(false)}
+}
+
+// Apply updates the book with an event. A snapshot replaces the book; a
+// delta adds its quantities to the levels at its prices, removing levels
+// that drop to zero. A delta that does not directly follow the last event
+// applied returns ErrSequenceGap, and a level with more decimal places than
+// the book keeps returns ErrLevelPrecision; both leave the book unsynced
+// until the next snapshot.
+func (b *Orderbook) Apply(event Event[sdk.OrderbookUpdateModel]) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch event.Type {
+	case EventSnapshot:
+		b.bid.clear()
+		b.ask.clear()
+		b.market = event.Data.Market
+		if err := b.addLevels(event.Data); err != nil {
+			b.synced = false
+			return err
+		}
+		b.seq, b.synced = event.Seq, true
+		return nil
+	case EventDelta:
+		if !b.synced {
+			return fmt.Errorf("%w: delta %d before a snapshot", ErrSequenceGap, event.Seq)
+		}
+		if event.Seq != b.seq+1 {
+			b.synced = false
+			return fmt.Errorf("%w: got delta %d after %d", ErrSequenceGap, event.Seq, b.seq)
+		}
+		if err := b.addLevels(event.Data); err != nil {
+			b.synced = false
+			return err
+		}
+		b.seq = event.Seq
+		return nil
+	default:
+		return fmt.Errorf("unexpected orderbook event type %q", event.Type)
+	}
+}
+
+func (b *Orderbook) addLevels(update sdk.OrderbookUpdateModel) error {
+	for _, level := range update.Bid {
+		if err := addLevel(b.bid, level); err != nil {
+			return err
+		}
+	}
+	for _, level := range update.Ask {
+		if err := addLevel(b.ask, level); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func addLevel(side *levelList, level sdk.OrderbookQuantityModel) error {
+	price, ok := scaledInt(level.Price, priceScale)
+	if !ok || price <= 0 {
+		return fmt.Errorf("%w: price %s", ErrLevelPrecision, level.Price)
+	}
+	qty, ok := scaledInt(level.Qty, qtyScale)
+	if !ok {
+		return fmt.Errorf("%w: quantity %s", ErrLevelPrecision, level.Qty)
+	}
+	side.add(price, qty)
+	return nil
+}
+
+// Synced reports whether the book reflects every update since the last
+// snapshot
+func (b *Orderbook) Synced() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.synced
+}
+
+// Snapshot returns a copy of the book, best price first. The boolean is
+// false while the book is not synced.
+func (b *Orderbook) Snapshot() (sdk.OrderbookUpdateModel, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return sdk.OrderbookUpdateModel{Market: b.market, Bid: b.bid.levels(0), Ask: b.ask.levels(0)}, b.synced
+}
@@ -0,0 +1,223 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"testing"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/extended-protocol/extended-sdk-golang/src/internal/websocket"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ sdk.OrderbookSource = (*Orderbook)(nil)
+
+func levels(pairs ...string) []sdk.OrderbookQuantityModel {
+	out := make([]sdk.OrderbookQuantityModel, 0, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		out = append(out, sdk.OrderbookQuantityModel{
+			Price: decimal.RequireFromString(pairs[i]),
+			Qty:   decimal.RequireFromString(pairs[i+1]),
+		})
+	}
+	return out
+}
+
+func assertLevels(t *testing.T, want, got []sdk.OrderbookQuantityModel) {
+	t.Helper()
+	require.Len(t, got, len(want))
+	for i := range want {
+		assert.True(t, want[i].Price.Equal(got[i].Price), "level %d price: want %s, got %s", i, want[i].Price, got[i].Price)
+		assert.True(t, want[i].Qty.Equal(got[i].Qty), "level %d qty: want %s, got %s", i, want[i].Qty, got[i].Qty)
+	}
+}
+
+func TestSubscribeOrderbook(t *testing.T) {
+	cfg := newStreamServer(t, func(r *http.Request, conn *websocket.Conn) {
+		assert.Equal(t, "/stream.extended.exchange/v1/orderbooks/BTC-USD", r.URL.Path)
+		send(conn, `{"type":"SNAPSHOT","seq":1,"data":{"m":"BTC-USD","b":[{"p":"99","q":"2"},{"p":"100","q":"1"}],"a":[{"p":"101","q":"3"}]}}`)
+		send(conn, `{"type":"DELTA","seq":2,"data":{"m":"BTC-USD","b":[{"p":"100","q":"-1"},{"p":"99.5","q":"4"}],"a":[{"p":"101","q":"1"},{"p":"102","q":"5"}]}}`)
+		waitClosed(conn)
+	})
+
+	sub, err := NewStreamClient(cfg).SubscribeOrderbook(context.Background(), "BTC-USD")
+	require.NoError(t, err)
+	defer sub.Close()
+
+	book := NewOrderbook()
+	_, synced := book.Snapshot()
+	assert.False(t, synced)
+
+	snapshot := next(t, sub)
+	assert.Equal(t, EventSnapshot, snapshot.Type)
+	assert.Equal(t, "BTC-USD", snapshot.Data.Market)
+	require.NoError(t, book.Apply(snapshot))
+
+	delta := next(t, sub)
+	assert.Equal(t, EventDelta, delta.Type)
+	assertLevels(t, levels("100", "-1", "99.5", "4"), delta.Data.Bid)
+	require.NoError(t, book.Apply(delta))
+
+	state, synced := book.Snapshot()
+	require.True(t, synced)
+	assert.Equal(t, "BTC-USD", state.Market)
+	assertLevels(t, levels("99.5", "4", "99", "2"), state.Bid)
+	assertLevels(t, levels("101", "4", "102", "5"), state.Ask)
+}
+
+func TestOrderbook_SequenceGap(t *testing.T) {
+	book := NewOrderbook()
+	delta := Event[sdk.OrderbookUpdateModel]{Type: EventDelta, Seq: 2, Data: sdk.OrderbookUpdateModel{Bid: levels("100", "1")}}
+	assert.ErrorIs(t, book.Apply(delta), ErrSequenceGap, "delta before snapshot")
+
+	snapshot := Event[sdk.OrderbookUpdateModel]{Type: EventSnapshot, Seq: 1, Data: sdk.OrderbookUpdateModel{Ask: levels("101", "1")}}
+	require.NoError(t, book.Apply(snapshot))
+	require.NoError(t, book.Apply(delta))
+
+	delta.Seq = 4
+	assert.ErrorIs(t, book.Apply(delta), ErrSequenceGap)
+	assert.False(t, book.Synced())
+
+	// A fresh snapshot, e.g. after a reconnect, syncs the book again
+	snapshot.Seq = 1
+	require.NoError(t, book.Apply(snapshot))
+	state, synced := book.Snapshot()
+	assert.True(t, synced)
+	assert.Empty(t, state.Bid)
+	assertLevels(t, levels("101", "1"), state.Ask)
+}
+
+func TestOrderbook_DeepBookMatchesReference(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	book := NewOrderbook()
+	require.NoError(t, book.Apply(Event[sdk.OrderbookUpdateModel]{Type: EventSnapshot, Seq: 1}))
+
+	// Reference quantities by price in ticks of 0.5, bids below 2500
+	bids, asks := map[int64]int64{}, map[int64]int64{}
+	for seq := int64(2); seq < 3000; seq++ {
+		tick := 1 + rng.Int64N(10000)
+		qty := rng.Int64N(20) - 8
+		side, ref := &sdk.OrderbookUpdateModel{}, asks
+		level := levels(decimal.New(tick*5, -1).String(), decimal.New(qty, -2).String())
+		if tick < 5000 {
+			side.Bid, ref = level, bids
+		} else {
+			side.Ask = level
+		}
+		if ref[tick]+qty > 0 {
+			ref[tick] += qty
+		} else {
+			delete(ref, tick)
+		}
+		require.NoError(t, book.Apply(Event[sdk.OrderbookUpdateModel]{Type: EventDelta, Seq: seq, Data: *side}))
+	}
+
+	want := func(ref map[int64]int64, bid bool) []sdk.OrderbookQuantityModel {
+		ticks := make([]int64, 0, len(ref))
+		for tick := range ref {
+			ticks = append(ticks, tick)
+		}
+		slices.Sort(ticks)
+		if bid {
+			slices.Reverse(ticks)
+		}
+		out := make([]sdk.OrderbookQuantityModel, len(ticks))
+		for i, tick := range ticks {
+			out[i] = sdk.OrderbookQuantityModel{Price: decimal.New(tick*5, -1), Qty: decimal.New(ref[tick], -2)}
+		}
+		return out
+	}
+	state, synced := book.Snapshot()
+	require.True(t, synced)
+	assertLevels(t, want(bids, true), state.Bid)
+	assertLevels(t, want(asks, false), state.Ask)
+}
+
+func TestOrderbook_LevelPrecision(t *testing.T) {
+	book := NewOrderbook()
+	require.NoError(t, book.Apply(Event[sdk.OrderbookUpdateModel]{Type: EventSnapshot, Seq: 1, Data: sdk.OrderbookUpdateModel{Bid: levels("0.0000000001", "0.00000001")}}))
+
+	delta := Event[sdk.OrderbookUpdateModel]{Type: EventDelta, Seq: 2, Data: sdk.OrderbookUpdateModel{Ask: levels("100.00000000001", "1")}}
+	assert.ErrorIs(t, book.Apply(delta), ErrLevelPrecision)
+	assert.False(t, book.Synced())
+}
+
+// steadyBook returns a book synced with depth levels per side and deltas that
+// take a level out of the middle of each side and put it back, so the depth
+// stays steady. Deltas carry no Seq; apply them with increasing ones.
+func steadyBook(t testing.TB, depth int) (*Orderbook, []Event[sdk.OrderbookUpdateModel]) {
+	snapshot := sdk.OrderbookUpdateModel{Market: "BTC-USD"}
+	for i := 0; i < depth; i++ {
+		snapshot.Bid = append(snapshot.Bid, levels(fmt.Sprintf("%d.5", 50000-i), "1")...)
+		snapshot.Ask = append(snapshot.Ask, levels(fmt.Sprintf("%d.5", 50001+i), "1")...)
+	}
+	book := NewOrderbook()
+	require.NoError(t, book.Apply(Event[sdk.OrderbookUpdateModel]{Type: EventSnapshot, Data: snapshot}))
+
+	var deltas []Event[sdk.OrderbookUpdateModel]
+	for i := 0; i < depth; i += 7 {
+		bid, ask := snapshot.Bid[i].Price.String(), snapshot.Ask[i].Price.String()
+		deltas = append(deltas,
+			Event[sdk.OrderbookUpdateModel]{Type: EventDelta, Data: sdk.OrderbookUpdateModel{Bid: levels(bid, "-1"), Ask: levels(ask, "-1")}},
+			Event[sdk.OrderbookUpdateModel]{Type: EventDelta, Data: sdk.OrderbookUpdateModel{Bid: levels(bid, "1"), Ask: levels(ask, "1.25")}},
+			Event[sdk.OrderbookUpdateModel]{Type: EventDelta, Data: sdk.OrderbookUpdateModel{Ask: levels(ask, "-0.25")}},
+		)
+	}
+	return book, deltas
+}
+
+func TestOrderbook_DeltasDoNotAllocate(t *testing.T) {
+	book, deltas := steadyBook(t, 500)
+	var seq int64
+	apply := func() {
+		event := deltas[int(seq)%len(deltas)]
+		seq++
+		event.Seq = seq
+		if err := book.Apply(event); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Warm up, so removed levels are ready for reuse
+	for range deltas {
+		apply()
+	}
+	assert.Zero(t, testing.AllocsPerRun(1000, apply))
+
+	state, synced := book.Snapshot()
+	require.True(t, synced)
+	assert.Len(t, state.Bid, 500)
+	assert.Len(t, state.Ask, 500)
+}
+
+// BenchmarkOrderbookDelta applies deltas to a deep book in steady state,
+// which allocates nothing
+func BenchmarkOrderbookDelta(b *testing.B) {
+	book, deltas := steadyBook(b, 5000)
+	b.ReportAllocs()
+	var seq int64
+	for b.Loop() {
+		event := deltas[int(seq)%len(deltas)]
+		seq++
+		event.Seq = seq
+		if err := book.Apply(event); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkDecodeOrderbook decodes delta frames into events. The decimals of
+// the levels allocate; the level buffers are pooled.
+func BenchmarkDecodeOrderbook(b *testing.B) {
+	frame := []byte(`{"type":"DELTA","seq":2,"data":{"m":"BTC-USD","b":[{"p":"49990.5","q":"-1"},{"p":"49980.5","q":"2.5"}],"a":[{"p":"50010.5","q":"1"}]}}`)
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := decodeEvent(frame, decodeOrderbook); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package stream
+
+import (
+	"context"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/shopspring/decimal"
+)
+
+// PriceModel is a mark or index price tick of a market
+type PriceModel struct {
+	Market    string          `json:"m"`
+	Price     decimal.Decimal `json:"p"`
+	Timestamp int64           `json:"ts"`
+}
+
+// SubscribeFundingRates streams the funding rate of a market, or of all
+// markets when market is empty, as it is updated between funding payments
+func (c *StreamClient) SubscribeFundingRates(ctx context.Context, market string) (*Subscription[sdk.FundingRateModel], error) {
+	return Subscribe[sdk.FundingRateModel](ctx, c, marketPath("/funding", market), false)
+}
+
+// SubscribeMarkPrices streams the mark price ticks of a market, or of all
+// markets when market is empty. Mark prices value positions and trigger
+// liquidations and mark price conditional orders.
+func (c *StreamClient) SubscribeMarkPrices(ctx context.Context, market string) (*Subscription[PriceModel], error) {
+	return Subscribe[PriceModel](ctx, c, marketPath("/prices/mark", market), false)
+}
+
+// SubscribeIndexPrices streams the index price ticks of a market, or of all
+// markets when market is empty. The index price is the spot reference price
+// funding is computed from.
+func (c *StreamClient) SubscribeIndexPrices(ctx context.Context, market string) (*Subscription[PriceModel], error) {
+	return Subscribe[PriceModel](ctx, c, marketPath("/prices/index", market), false)
+}
@@ -0,0 +1,57 @@
+package stream
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/extended-protocol/extended-sdk-golang/src/internal/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscribeFundingRates(t *testing.T) {
+	cfg := newStreamServer(t, func(r *http.Request, conn *websocket.Conn) {
+		assert.Equal(t, "/stream.extended.exchange/v1/funding/BTC-USD", r.URL.Path)
+		send(conn, `{"ts":1700000000500,"seq":1,"data":{"m":"BTC-USD","f":"0.0001","T":1700000000000}}`)
+		waitClosed(conn)
+	})
+
+	sub, err := NewStreamClient(cfg).SubscribeFundingRates(context.Background(), "BTC-USD")
+	require.NoError(t, err)
+	defer sub.Close()
+
+	event := next(t, sub)
+	assert.Equal(t, "BTC-USD", event.Data.Market)
+	assert.Equal(t, "0.0001", event.Data.FundingRate.String())
+	assert.Equal(t, int64(1700000000000), event.Data.Timestamp)
+}
+
+func TestSubscribePrices(t *testing.T) {
+	cfg := newStreamServer(t, func(r *http.Request, conn *websocket.Conn) {
+		switch r.URL.Path {
+		case "/stream.extended.exchange/v1/prices/mark/BTC-USD":
+			send(conn, `{"seq":1,"data":{"m":"BTC-USD","p":"50001.5","ts":1700000000100}}`)
+		case "/stream.extended.exchange/v1/prices/index":
+			send(conn, `{"seq":1,"data":{"m":"ETH-USD","p":"3000.25","ts":1700000000200}}`)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		waitClosed(conn)
+	})
+	client := NewStreamClient(cfg)
+
+	mark, err := client.SubscribeMarkPrices(context.Background(), "BTC-USD")
+	require.NoError(t, err)
+	defer mark.Close()
+	event := next(t, mark)
+	assert.Equal(t, "BTC-USD", event.Data.Market)
+	assert.Equal(t, "50001.5", event.Data.Price.String())
+
+	index, err := client.SubscribeIndexPrices(context.Background(), "")
+	require.NoError(t, err)
+	defer index.Close()
+	event = next(t, index)
+	assert.Equal(t, "ETH-USD", event.Data.Market)
+	assert.Equal(t, int64(1700000000200), event.Data.Timestamp)
+}
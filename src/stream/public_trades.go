@@ -0,0 +1,36 @@
+package stream
+
+import (
+	"context"
+	"net/url"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/shopspring/decimal"
+)
+
+// PublicTradeModel is a trade of a market, as sent by the public trades
+// stream
+type PublicTradeModel struct {
+	ID        int64           `json:"i"`
+	Market    string          `json:"m"`
+	Side      sdk.OrderSide   `json:"S"`
+	TradeType sdk.TradeType   `json:"tT"`
+	Timestamp int64           `json:"T"`
+	Price     decimal.Decimal `json:"p"`
+	Qty       decimal.Decimal `json:"q"`
+}
+
+// SubscribePublicTrades streams the trades of a market, or of all markets
+// when market is empty. Every event carries the trades of one match.
+func (c *StreamClient) SubscribePublicTrades(ctx context.Context, market string) (*Subscription[[]PublicTradeModel], error) {
+	return Subscribe[[]PublicTradeModel](ctx, c, marketPath("/publicTrades", market), false)
+}
+
+// marketPath returns the path of a per-market stream, or of the stream of
+// all markets when market is empty
+func marketPath(base, market string) string {
+	if market == "" {
+		return base
+	}
+	return base + "/" + url.PathEscape(market)
+}
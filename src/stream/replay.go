@@ -0,0 +1,172 @@
+package stream
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/extended-protocol/extended-sdk-golang/src/internal/websocket"
+)
+
+// closeReplayFinished is the close code a Replayer ends a connection with
+// once all frames of its path are sent, from the range reserved for
+// applications
+const closeReplayFinished = 4000
+
+// Frame is a stream message as received, with the time it arrived and the
+// path of its subscription
+type Frame struct {
+	Time time.Time       `json:"time"`
+	Path string          `json:"path"`
+	Msg  json.RawMessage `json:"msg"`
+}
+
+// Recorder writes the messages passed to its Hook as JSON lines of Frames,
+// for ReadFrames. It is safe for concurrent use by several subscriptions.
+type Recorder struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+	err error
+}
+
+// NewRecorder creates a recorder writing to w. Pass its Hook to WithRawHook.
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{enc: json.NewEncoder(w)}
+}
+
+// Hook records msg as received now. A failed write stops the recording
+// rather than the subscription; Err reports it.
+func (r *Recorder) Hook(path string, msg []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return
+	}
+	if err := r.enc.Encode(Frame{Time: time.Now().UTC(), Path: path, Msg: msg}); err != nil {
+		r.err = fmt.Errorf("failed to record stream frame: %w", err)
+	}
+}
+
+// Err returns the error that stopped the recording, if any
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// ReadFrames reads the frames written by a Recorder, in recording order
+func ReadFrames(r io.Reader) ([]Frame, error) {
+	dec := json.NewDecoder(r)
+	var frames []Frame
+	for {
+		var f Frame
+		err := dec.Decode(&f)
+		if errors.Is(err, io.EOF) {
+			return frames, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read frame %d: %w", len(frames)+1, err)
+		}
+		frames = append(frames, f)
+	}
+}
+
+// ReplayConfig configures a Replayer
+type ReplayConfig struct {
+	// Speed scales the pauses between frames: 1 replays in real time, 10 ten
+	// times faster. Zero sends every frame without pausing.
+	Speed float64
+}
+
+// Replayer serves recorded frames as a local stream endpoint, so that
+// strategies consume a recording through the same subscriptions, decoding
+// and book maintenance as a live stream. Every subscription receives the
+// frames recorded for its path. The pauses between frames are replayed
+// against one clock started by the first subscription, so frames of
+// different streams keep their recorded order.
+type Replayer struct {
+	frames   []Frame
+	cfg      ReplayConfig
+	listener net.Listener
+	server   *http.Server
+
+	startOnce sync.Once
+	start     time.Time
+}
+
+// NewReplayer starts serving frames on a loopback port
+func NewReplayer(frames []Frame, cfg ReplayConfig) (*Replayer, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to start replayer: %w", err)
+	}
+	r := &Replayer{frames: frames, cfg: cfg, listener: listener}
+	r.server = &http.Server{Handler: http.HandlerFunc(r.serve)}
+	go r.server.Serve(listener)
+	return r, nil
+}
+
+// NewClient creates a StreamClient subscribing to the replay. Subscriptions
+// end without an error once the frames of their path are replayed, and do
+// not reconnect, as a replay cannot resume.
+func (r *Replayer) NewClient(opts ...Option) *StreamClient {
+	c := NewStreamClient(sdk.EndpointConfig{StreamURL: "ws://" + r.listener.Addr().String()}, opts...)
+	c.reconnect.Disabled = true
+	c.replay = true
+	if c.apiKey == "" {
+		// Private streams need a key to subscribe, which the replay ignores
+		c.apiKey = "replay"
+	}
+	return c
+}
+
+// Close stops serving and ends the open subscriptions
+func (r *Replayer) Close() error {
+	return r.server.Close()
+}
+
+func (r *Replayer) serve(w http.ResponseWriter, req *http.Request) {
+	conn, err := websocket.Accept(w, req)
+	if err != nil {
+		return
+	}
+	defer conn.Close(closeReplayFinished, "replay finished")
+
+	r.startOnce.Do(func() { r.start = time.Now() })
+	// Reading answers the pings of the client and notices it leaving
+	gone := make(chan struct{})
+	go func() {
+		defer close(gone)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	path := req.URL.RequestURI()
+	for _, f := range r.frames {
+		if f.Path != path {
+			continue
+		}
+		if r.cfg.Speed > 0 {
+			at := r.start.Add(time.Duration(float64(f.Time.Sub(r.frames[0].Time)) / r.cfg.Speed))
+			timer := time.NewTimer(time.Until(at))
+			select {
+			case <-timer.C:
+			case <-gone:
+				timer.Stop()
+				return
+			}
+		}
+		if err := conn.WriteMessage(websocket.TextMessage, f.Msg); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package stream
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/extended-protocol/extended-sdk-golang/src/internal/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplay_RecordedFrames(t *testing.T) {
+	cfg := newStreamServer(t, func(r *http.Request, conn *websocket.Conn) {
+		send(conn, `{"seq":1,"data":[{"i":1,"m":"BTC-USD","p":"50000"}]}`)
+		send(conn, `{"seq":2,"data":[{"i":2,"m":"BTC-USD","p":"50001"}]}`)
+		waitClosed(conn)
+	})
+	var log bytes.Buffer
+	recorder := NewRecorder(&log)
+	sub, err := NewStreamClient(cfg, WithRawHook(recorder.Hook)).SubscribePublicTrades(context.Background(), "BTC-USD")
+	require.NoError(t, err)
+	next(t, sub)
+	next(t, sub)
+	sub.Close()
+	require.NoError(t, recorder.Err())
+
+	frames, err := ReadFrames(&log)
+	require.NoError(t, err)
+	require.Len(t, frames, 2)
+	assert.Equal(t, "/publicTrades/BTC-USD", frames[0].Path)
+	assert.False(t, frames[1].Time.Before(frames[0].Time))
+
+	replayer, err := NewReplayer(frames, ReplayConfig{})
+	require.NoError(t, err)
+	defer replayer.Close()
+	client := replayer.NewClient()
+
+	sub, err = client.SubscribePublicTrades(context.Background(), "BTC-USD")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), next(t, sub).Data[0].ID)
+	event := next(t, sub)
+	assert.Equal(t, int64(2), event.Data[0].ID)
+	assert.Equal(t, int64(2), event.Seq)
+	drain(t, sub)
+	assert.NoError(t, sub.Err(), "the subscription ends once the recording is replayed")
+	assert.Zero(t, sub.Reconnects())
+
+	other, err := client.SubscribePublicTrades(context.Background(), "ETH-USD")
+	require.NoError(t, err)
+	drain(t, other)
+	assert.NoError(t, other.Err(), "streams without frames end right away")
+
+	_, err = ReadFrames(bytes.NewBufferString(`{"path":`))
+	assert.ErrorContains(t, err, "failed to read frame 1")
+}
+
+func TestReplay_Speed(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	frame := func(after time.Duration, msg string) Frame {
+		return Frame{Time: start.Add(after), Path: "/prices/mark/BTC-USD", Msg: json.RawMessage(msg)}
+	}
+	frames := []Frame{
+		frame(0, `{"seq":1,"data":{"m":"BTC-USD","p":"50000"}}`),
+		frame(800*time.Millisecond, `{"seq":2,"data":{"m":"BTC-USD","p":"50010"}}`),
+	}
+	replayer, err := NewReplayer(frames, ReplayConfig{Speed: 4})
+	require.NoError(t, err)
+	defer replayer.Close()
+
+	sub, err := replayer.NewClient().SubscribeMarkPrices(context.Background(), "BTC-USD")
+	require.NoError(t, err)
+	defer sub.Close()
+
+	next(t, sub)
+	begin := time.Now()
+	assert.Equal(t, "50010", next(t, sub).Data.Price.String())
+	elapsed := time.Since(begin)
+	assert.Greater(t, elapsed, 150*time.Millisecond, "800ms at 4x is 200ms")
+	assert.Less(t, elapsed, 700*time.Millisecond)
+}
@@ -0,0 +1,383 @@
+// Package stream subscribes to the WebSocket streams of the exchange. A
+// StreamClient is created from the same EndpointConfig as the REST client
+// and opens one connection per subscription, reconnecting with backoff when
+// the connection drops. Events are delivered decoded into the SDK models.
+package stream
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/extended-protocol/extended-sdk-golang/src/internal/websocket"
+)
+
+var (
+	// ErrStreamURLNotSet is returned when the EndpointConfig has no StreamURL
+	ErrStreamURLNotSet = errors.New("stream URL is not set")
+	// ErrSubscriptionRejected wraps the error message the exchange sent for
+	// a subscription, e.g. for an unknown market
+	ErrSubscriptionRejected = errors.New("subscription rejected")
+)
+
+// eventBuffer is the number of events a subscription holds for a slow reader
+// before it stops reading from the connection
+const eventBuffer = 64
+
+// ReconnectConfig configures reconnecting a subscription whose connection
+// dropped
+type ReconnectConfig struct {
+	// Disabled ends the subscription when its connection drops
+	Disabled bool
+	// MaxAttempts is the number of consecutive failed reconnects after which
+	// the subscription ends; zero retries until the context is done
+	MaxAttempts int
+	// BaseDelay is the wait before the first reconnect, doubled for every
+	// further attempt; defaults to 500ms
+	BaseDelay time.Duration
+	// MaxDelay caps the wait between attempts; defaults to 30s
+	MaxDelay time.Duration
+}
+
+func (r ReconnectConfig) delay(attempt int) time.Duration {
+	d := r.BaseDelay << (attempt - 1)
+	if d <= 0 || d > r.MaxDelay {
+		return r.MaxDelay
+	}
+	return d
+}
+
+// StreamClient opens subscriptions to the streams under the StreamURL of an
+// EndpointConfig
+type StreamClient struct {
+	baseURL   string
+	apiKey    string
+	tlsConfig *tls.Config
+	userAgent string
+	reconnect ReconnectConfig
+	heartbeat HeartbeatConfig
+
+	onDiagnostic func(Diagnostic)
+	onRaw        func(path string, msg []byte)
+
+	// replay is set for clients of a Replayer
+	replay bool
+}
+
+// Option configures a StreamClient
+type Option func(*StreamClient)
+
+// WithAPIKey sets the API key sent when subscribing to private streams
+func WithAPIKey(apiKey string) Option {
+	return func(c *StreamClient) {
+		c.apiKey = apiKey
+	}
+}
+
+// WithTLSConfig sets the TLS configuration of wss connections
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(c *StreamClient) {
+		c.tlsConfig = cfg
+	}
+}
+
+// WithReconnect sets how subscriptions reconnect when their connection drops
+func WithReconnect(cfg ReconnectConfig) Option {
+	return func(c *StreamClient) {
+		if cfg.BaseDelay <= 0 {
+			cfg.BaseDelay = 500 * time.Millisecond
+		}
+		if cfg.MaxDelay <= 0 {
+			cfg.MaxDelay = 30 * time.Second
+		}
+		c.reconnect = cfg
+	}
+}
+
+// WithRawHook calls fn with every message of every subscription before it is
+// decoded, e.g. to log full streams for replay or to read fields the SDK
+// does not model. path is the stream path of the subscription. fn is called
+// from the goroutine of the subscription, must not block and must not keep
+// msg after it returns.
+func WithRawHook(fn func(path string, msg []byte)) Option {
+	return func(c *StreamClient) {
+		c.onRaw = fn
+	}
+}
+
+// NewStreamClient creates a client for the streams under cfg.StreamURL.
+// Subscriptions reconnect with the ReconnectConfig defaults unless
+// WithReconnect is given.
+func NewStreamClient(cfg sdk.EndpointConfig, opts ...Option) *StreamClient {
+	c := &StreamClient{
+		baseURL:   strings.TrimRight(cfg.StreamURL, "/"),
+		userAgent: "extended-sdk-golang/" + sdk.SDKVersion,
+	}
+	WithReconnect(ReconnectConfig{})(c)
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// dial opens a connection to a stream. A handshake the exchange refuses with
+// 401 or 403 is reported as sdk.ErrAPIKeyRejected.
+func (c *StreamClient) dial(ctx context.Context, path string, private bool) (*websocket.Conn, error) {
+	header := http.Header{"User-Agent": {c.userAgent}}
+	if private {
+		header.Set("X-API-Key", c.apiKey)
+	}
+	conn, err := websocket.Dial(ctx, c.baseURL+path, websocket.DialOptions{Header: header, TLSConfig: c.tlsConfig})
+	var hsErr *websocket.HandshakeError
+	if errors.As(err, &hsErr) && (hsErr.StatusCode == http.StatusUnauthorized || hsErr.StatusCode == http.StatusForbidden) {
+		return nil, fmt.Errorf("%w: %v", sdk.ErrAPIKeyRejected, err)
+	}
+	return conn, err
+}
+
+// Event types of streams that send a snapshot followed by deltas
+const (
+	EventSnapshot = "SNAPSHOT"
+	EventDelta    = "DELTA"
+)
+
+// Event is a message of a stream. Type is SNAPSHOT for the full state sent
+// when a subscription (re)connects and DELTA for the changes after it, for
+// streams that distinguish them. Seq numbers the messages of a connection;
+// it restarts when the subscription reconnects.
+type Event[T any] struct {
+	Type string
+	Data T
+	Seq  int64
+	Time time.Time
+}
+
+// envelope is the message format shared by all streams
+type envelope struct {
+	Type  string          `json:"type"`
+	Data  json.RawMessage `json:"data"`
+	Error string          `json:"error"`
+	Ts    int64           `json:"ts"`
+	Seq   int64           `json:"seq"`
+}
+
+// decodeFunc decodes the data of a stream message
+type decodeFunc[T any] func(data json.RawMessage) (T, error)
+
+// decodeJSON decodes data as the JSON encoding of T
+func decodeJSON[T any](data json.RawMessage) (T, error) {
+	var v T
+	err := json.Unmarshal(data, &v)
+	return v, err
+}
+
+func decodeEvent[T any](msg []byte, decode decodeFunc[T]) (Event[T], error) {
+	var env envelope
+	if err := json.Unmarshal(msg, &env); err != nil {
+		return Event[T]{}, fmt.Errorf("failed to decode stream message: %w", err)
+	}
+	if env.Error != "" {
+		return Event[T]{}, fmt.Errorf("%w: %s", ErrSubscriptionRejected, env.Error)
+	}
+	event := Event[T]{Type: env.Type, Seq: env.Seq, Time: time.UnixMilli(env.Ts).UTC()}
+	if len(env.Data) > 0 {
+		data, err := decode(env.Data)
+		if err != nil {
+			return Event[T]{}, fmt.Errorf("failed to decode stream data: %w", err)
+		}
+		event.Data = data
+	}
+	return event, nil
+}
+
+// Subscription delivers the events of a stream until its context is done,
+// it is closed, or it fails. Events is closed when it ends; Err then tells
+// why.
+type Subscription[T any] struct {
+	events     chan Event[T]
+	decode     decodeFunc[T]
+	cancel     context.CancelFunc
+	done       chan struct{}
+	err        error
+	reconnects atomic.Int64
+}
+
+// Events returns the channel events are delivered on
+func (s *Subscription[T]) Events() <-chan Event[T] {
+	return s.events
+}
+
+// Err returns the error that ended the subscription once Events is closed,
+// nil if it was closed or its context is done
+func (s *Subscription[T]) Err() error {
+	select {
+	case <-s.done:
+		return s.err
+	default:
+		return nil
+	}
+}
+
+// Reconnects returns how many times the subscription reconnected. A
+// reconnect means events may have been missed; streams with snapshots send
+// a fresh one.
+func (s *Subscription[T]) Reconnects() int {
+	return int(s.reconnects.Load())
+}
+
+// Close ends the subscription and waits until Events is closed
+func (s *Subscription[T]) Close() {
+	s.cancel()
+	<-s.done
+}
+
+// Subscribe connects to the stream at path under the StreamURL, e.g.
+// /orderbooks/BTC-USD, and delivers its messages with data decoded into T.
+// Private streams send the API key. The first connection is made before
+// Subscribe returns, so an unreachable stream or rejected key is reported
+// here; later drops reconnect per the ReconnectConfig. ctx bounds the whole
+// subscription.
+func Subscribe[T any](ctx context.Context, c *StreamClient, path string, private bool) (*Subscription[T], error) {
+	return subscribe(ctx, c, path, private, decodeJSON[T])
+}
+
+func subscribe[T any](ctx context.Context, c *StreamClient, path string, private bool, decode decodeFunc[T]) (*Subscription[T], error) {
+	if c.baseURL == "" {
+		return nil, ErrStreamURLNotSet
+	}
+	if private && c.apiKey == "" {
+		return nil, sdk.ErrAPIKeyNotSet
+	}
+	conn, err := c.dial(ctx, path, private)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to %s: %w", path, err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	s := &Subscription[T]{
+		events: make(chan Event[T], eventBuffer),
+		decode: decode,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	go s.run(ctx, c, path, private, conn)
+	return s, nil
+}
+
+func (s *Subscription[T]) run(ctx context.Context, c *StreamClient, path string, private bool, conn *websocket.Conn) {
+	defer close(s.done)
+	defer close(s.events)
+	defer s.cancel()
+
+	for {
+		retry, err := s.read(ctx, c, path, conn)
+		if ctx.Err() != nil {
+			return
+		}
+		var closeErr *websocket.CloseError
+		if c.replay && errors.As(err, &closeErr) && closeErr.Code == closeReplayFinished {
+			return
+		}
+		if !retry || c.reconnect.Disabled {
+			s.err = fmt.Errorf("subscription to %s ended: %w", path, err)
+			return
+		}
+		if conn, err = s.redial(ctx, c, path, private); err != nil {
+			if ctx.Err() == nil {
+				s.err = fmt.Errorf("subscription to %s ended: %w", path, err)
+			}
+			return
+		}
+	}
+}
+
+// read delivers the messages of conn until it fails, and reports whether
+// reconnecting may help. Messages that fail to decode are skipped and
+// reported as DiagnosticDecodeFailed. With a StaleTimeout, every message and pong
+// extends the read deadline of conn, so a connection that went silent fails
+// with ErrStreamStale.
+func (s *Subscription[T]) read(ctx context.Context, c *StreamClient, path string, conn *websocket.Conn) (retry bool, err error) {
+	stop := context.AfterFunc(ctx, func() { conn.Close(websocket.CloseNormal, "") })
+	defer stop()
+	defer conn.Close(websocket.CloseNormal, "")
+
+	timeout := c.heartbeat.StaleTimeout
+	alive := func() {
+		if timeout > 0 {
+			conn.SetReadDeadline(time.Now().Add(timeout))
+		}
+	}
+	alive()
+	conn.SetPongHandler(func([]byte) { alive() })
+	if c.heartbeat.PingInterval > 0 {
+		defer pingEvery(conn, c.heartbeat.PingInterval)()
+	}
+
+	for {
+		_, msg, err := conn.ReadMessage()
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() && timeout > 0 {
+			detail := fmt.Sprintf("nothing received for %s", timeout)
+			c.diagnose(DiagnosticStale, path, detail)
+			return true, fmt.Errorf("%w: %s", ErrStreamStale, detail)
+		}
+		if err != nil {
+			return true, err
+		}
+		alive()
+		if c.onRaw != nil {
+			c.onRaw(path, msg)
+		}
+		event, err := decodeEvent(msg, s.decode)
+		if errors.Is(err, ErrSubscriptionRejected) {
+			return false, err
+		}
+		if err != nil {
+			// One odd frame must not end a long-lived feed
+			c.diagnose(DiagnosticDecodeFailed, path, err.Error())
+			continue
+		}
+		select {
+		case s.events <- event:
+		case <-ctx.Done():
+			return false, ctx.Err()
+		}
+		// Pongs are not read while a slow reader blocks delivery, so the
+		// time spent waiting must not count towards the StaleTimeout
+		alive()
+	}
+}
+
+// redial reconnects with backoff, giving up after MaxAttempts or when the
+// API key is rejected
+func (s *Subscription[T]) redial(ctx context.Context, c *StreamClient, path string, private bool) (*websocket.Conn, error) {
+	var lastErr error
+	for attempt := 1; c.reconnect.MaxAttempts == 0 || attempt <= c.reconnect.MaxAttempts; attempt++ {
+		timer := time.NewTimer(c.reconnect.delay(attempt))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+		conn, err := c.dial(ctx, path, private)
+		if err == nil {
+			s.reconnects.Add(1)
+			c.diagnose(DiagnosticReconnected, path, fmt.Sprintf("reconnected after %d attempts", attempt))
+			return conn, nil
+		}
+		if errors.Is(err, sdk.ErrAPIKeyRejected) {
+			return nil, err
+		}
+		lastErr = err
+	}
+	return nil, fmt.Errorf("failed to reconnect after %d attempts: %w", c.reconnect.MaxAttempts, lastErr)
+}
@@ -0,0 +1,253 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	sdk "github.com/extended-protocol/extended-sdk-golang/src"
+	"github.com/extended-protocol/extended-sdk-golang/src/internal/websocket"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newStreamServer serves handler as a WebSocket endpoint and returns a config
+// pointing at it
+func newStreamServer(t *testing.T, handler func(r *http.Request, conn *websocket.Conn)) sdk.EndpointConfig {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := websocket.Accept(w, r)
+		if err != nil {
+			return
+		}
+		defer conn.Close(websocket.CloseNormal, "")
+		handler(r, conn)
+	}))
+	t.Cleanup(server.Close)
+	return sdk.EndpointConfig{StreamURL: "ws" + strings.TrimPrefix(server.URL, "http") + "/stream.extended.exchange/v1"}
+}
+
+func send(conn *websocket.Conn, msg string) {
+	conn.WriteMessage(websocket.TextMessage, []byte(msg))
+}
+
+// waitClosed blocks until the client goes away
+func waitClosed(conn *websocket.Conn) {
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+func next[T any](t *testing.T, sub *Subscription[T]) Event[T] {
+	t.Helper()
+	select {
+	case event, ok := <-sub.Events():
+		require.True(t, ok, "subscription ended: %v", sub.Err())
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for an event")
+		return Event[T]{}
+	}
+}
+
+func drain[T any](t *testing.T, sub *Subscription[T]) {
+	t.Helper()
+	for {
+		select {
+		case _, ok := <-sub.Events():
+			if !ok {
+				return
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for the subscription to end")
+		}
+	}
+}
+
+func TestSubscribePublicTrades(t *testing.T) {
+	cfg := newStreamServer(t, func(r *http.Request, conn *websocket.Conn) {
+		assert.Equal(t, "/stream.extended.exchange/v1/publicTrades/BTC-USD", r.URL.Path)
+		assert.Equal(t, "extended-sdk-golang/"+sdk.SDKVersion, r.Header.Get("User-Agent"))
+		assert.Empty(t, r.Header.Get("X-API-Key"))
+		send(conn, `{"ts":1700000000000,"seq":1,"data":[{"i":7,"m":"BTC-USD","S":"BUY","tT":"TRADE","T":1699999999000,"p":"50000.5","q":"0.01"}]}`)
+		waitClosed(conn)
+	})
+
+	sub, err := NewStreamClient(cfg, WithAPIKey("secret")).SubscribePublicTrades(context.Background(), "BTC-USD")
+	require.NoError(t, err)
+	defer sub.Close()
+
+	event := next(t, sub)
+	assert.Equal(t, int64(1), event.Seq)
+	assert.Equal(t, time.UnixMilli(1700000000000).UTC(), event.Time)
+	require.Len(t, event.Data, 1)
+	trade := event.Data[0]
+	assert.Equal(t, int64(7), trade.ID)
+	assert.Equal(t, sdk.OrderSideBuy, trade.Side)
+	assert.Equal(t, sdk.TradeTypeTrade, trade.TradeType)
+	assert.True(t, decimal.RequireFromString("50000.5").Equal(trade.Price))
+	assert.True(t, decimal.RequireFromString("0.01").Equal(trade.Qty))
+
+	sub.Close()
+	drain(t, sub)
+	assert.NoError(t, sub.Err())
+}
+
+func TestSubscribe_Validation(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := NewStreamClient(sdk.EndpointConfig{}).SubscribePublicTrades(ctx, "")
+	assert.ErrorIs(t, err, ErrStreamURLNotSet)
+
+	cfg := sdk.EndpointConfig{StreamURL: "ws://127.0.0.1:1"}
+	_, err = Subscribe[struct{}](ctx, NewStreamClient(cfg), "/account", true)
+	assert.ErrorIs(t, err, sdk.ErrAPIKeyNotSet)
+}
+
+func TestSubscribe_PrivateSendsAPIKey(t *testing.T) {
+	cfg := newStreamServer(t, func(r *http.Request, conn *websocket.Conn) {
+		assert.Equal(t, "secret", r.Header.Get("X-API-Key"))
+		send(conn, `{"type":"SNAPSHOT","seq":1,"data":{"ok":true}}`)
+		waitClosed(conn)
+	})
+
+	sub, err := Subscribe[map[string]bool](context.Background(), NewStreamClient(cfg, WithAPIKey("secret")), "/account", true)
+	require.NoError(t, err)
+	defer sub.Close()
+
+	event := next(t, sub)
+	assert.Equal(t, "SNAPSHOT", event.Type)
+	assert.True(t, event.Data["ok"])
+}
+
+func TestSubscribe_RejectedKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid api key", http.StatusUnauthorized)
+	}))
+	defer server.Close()
+	cfg := sdk.EndpointConfig{StreamURL: "ws" + strings.TrimPrefix(server.URL, "http")}
+
+	_, err := Subscribe[struct{}](context.Background(), NewStreamClient(cfg, WithAPIKey("bad")), "/account", true)
+	assert.ErrorIs(t, err, sdk.ErrAPIKeyRejected)
+}
+
+func TestSubscribe_Reconnects(t *testing.T) {
+	var connections atomic.Int32
+	cfg := newStreamServer(t, func(r *http.Request, conn *websocket.Conn) {
+		n := connections.Add(1)
+		send(conn, fmt.Sprintf(`{"seq":1,"data":[{"i":%d}]}`, n))
+		if n == 1 {
+			// Drop the first connection
+			return
+		}
+		waitClosed(conn)
+	})
+
+	client := NewStreamClient(cfg, WithReconnect(ReconnectConfig{BaseDelay: time.Millisecond}))
+	sub, err := client.SubscribePublicTrades(context.Background(), "")
+	require.NoError(t, err)
+	defer sub.Close()
+
+	assert.Equal(t, int64(1), next(t, sub).Data[0].ID)
+	assert.Equal(t, int64(2), next(t, sub).Data[0].ID)
+	assert.Equal(t, 1, sub.Reconnects())
+}
+
+func TestSubscribe_ReconnectDisabled(t *testing.T) {
+	cfg := newStreamServer(t, func(r *http.Request, conn *websocket.Conn) {
+		send(conn, `{"seq":1,"data":[]}`)
+	})
+
+	client := NewStreamClient(cfg, WithReconnect(ReconnectConfig{Disabled: true}))
+	sub, err := client.SubscribePublicTrades(context.Background(), "ETH-USD")
+	require.NoError(t, err)
+
+	next(t, sub)
+	drain(t, sub)
+	var closeErr *websocket.CloseError
+	assert.ErrorAs(t, sub.Err(), &closeErr)
+}
+
+func TestSubscribe_ErrorMessageEndsSubscription(t *testing.T) {
+	cfg := newStreamServer(t, func(r *http.Request, conn *websocket.Conn) {
+		send(conn, `{"error":"unknown market"}`)
+		waitClosed(conn)
+	})
+
+	sub, err := NewStreamClient(cfg).SubscribePublicTrades(context.Background(), "NOPE-USD")
+	require.NoError(t, err)
+
+	drain(t, sub)
+	assert.ErrorIs(t, sub.Err(), ErrSubscriptionRejected)
+	assert.Contains(t, sub.Err().Error(), "unknown market")
+}
+
+func TestSubscribe_SkipsUndecodableMessages(t *testing.T) {
+	cfg := newStreamServer(t, func(r *http.Request, conn *websocket.Conn) {
+		send(conn, `{"seq":1,"data":[{"i":1}]}`)
+		send(conn, `{"seq":2,"data":[{"i":"two"}]}`)
+		send(conn, `not json`)
+		send(conn, `{"seq":4,"data":[{"i":4}]}`)
+		waitClosed(conn)
+	})
+
+	var diagnostics []Diagnostic
+	client := NewStreamClient(cfg, WithDiagnostics(func(d Diagnostic) { diagnostics = append(diagnostics, d) }))
+	sub, err := client.SubscribePublicTrades(context.Background(), "")
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(1), next(t, sub).Data[0].ID)
+	assert.Equal(t, int64(4), next(t, sub).Data[0].ID, "the subscription keeps reading")
+	sub.Close()
+	assert.NoError(t, sub.Err())
+	require.Len(t, diagnostics, 2)
+	for _, d := range diagnostics {
+		assert.Equal(t, DiagnosticDecodeFailed, d.Kind)
+		assert.Equal(t, "/publicTrades", d.Path)
+	}
+	assert.Contains(t, diagnostics[0].Detail, "failed to decode stream data")
+}
+
+func TestSubscribe_ContextEndsSubscription(t *testing.T) {
+	cfg := newStreamServer(t, func(r *http.Request, conn *websocket.Conn) {
+		waitClosed(conn)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sub, err := NewStreamClient(cfg).SubscribePublicTrades(ctx, "")
+	require.NoError(t, err)
+
+	cancel()
+	drain(t, sub)
+	assert.NoError(t, sub.Err())
+}
+
+func TestSubscribe_RawHook(t *testing.T) {
+	frames := []string{`{"seq":1,"data":[{"i":1}],"extra":"kept"}`, `{"error":"unknown market"}`}
+	cfg := newStreamServer(t, func(r *http.Request, conn *websocket.Conn) {
+		for _, frame := range frames {
+			send(conn, frame)
+		}
+		waitClosed(conn)
+	})
+
+	var paths, raw []string
+	client := NewStreamClient(cfg, WithRawHook(func(path string, msg []byte) {
+		paths = append(paths, path)
+		raw = append(raw, string(msg))
+	}))
+	sub, err := client.SubscribePublicTrades(context.Background(), "BTC-USD")
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(1), next(t, sub).Data[0].ID)
+	drain(t, sub)
+	assert.Equal(t, frames, raw, "frames are passed before decoding, including those that fail")
+	assert.Equal(t, []string{"/publicTrades/BTC-USD", "/publicTrades/BTC-USD"}, paths)
+}
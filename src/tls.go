@@ -0,0 +1,73 @@
+package sdk
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+)
+
+// ErrPinMismatch is returned when the server presents no certificate matching
+// the keys pinned with WithPinnedSPKI
+var ErrPinMismatch = errors.New("no certificate matches the pinned public keys")
+
+// WithTLSConfig sets the TLS configuration of connections to the API, e.g. to
+// raise MinVersion or to trust a private CA. The config is cloned, so later
+// changes to it have no effect. Clients of a ClientPool share its transport,
+// whose TLS configuration comes from PoolConfig.Options.
+func WithTLSConfig(cfg *tls.Config) ClientOption {
+	return func(m *BaseModule) {
+		m.tlsConfig = cfg.Clone()
+	}
+}
+
+// WithPinnedSPKI only accepts servers whose certificate chain contains one of
+// the pinned public keys, each given as the base64 SHA-256 of its DER
+// SubjectPublicKeyInfo, like the pin-sha256 values of HPKP. The usual chain
+// verification still applies. Pins are checked on every handshake, so
+// rotating a server key requires pinning the new key first.
+func WithPinnedSPKI(pins ...string) ClientOption {
+	return func(m *BaseModule) {
+		m.spkiPins = append(m.spkiPins, pins...)
+	}
+}
+
+// SPKIHash returns the pin of the public key of a certificate, in the format
+// taken by WithPinnedSPKI
+func SPKIHash(rawSubjectPublicKeyInfo []byte) string {
+	sum := sha256.Sum256(rawSubjectPublicKeyInfo)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// clientTLSConfig returns the TLS configuration set by the options, nil if
+// the defaults of net/http apply
+func (m *BaseModule) clientTLSConfig() *tls.Config {
+	if m.tlsConfig == nil && len(m.spkiPins) == 0 {
+		return nil
+	}
+	cfg := &tls.Config{}
+	if m.tlsConfig != nil {
+		cfg = m.tlsConfig.Clone()
+	}
+	if len(m.spkiPins) > 0 {
+		pinned := make(map[string]bool, len(m.spkiPins))
+		for _, pin := range m.spkiPins {
+			pinned[pin] = true
+		}
+		verify := cfg.VerifyConnection
+		cfg.VerifyConnection = func(cs tls.ConnectionState) error {
+			if verify != nil {
+				if err := verify(cs); err != nil {
+					return err
+				}
+			}
+			for _, cert := range cs.PeerCertificates {
+				if pinned[SPKIHash(cert.RawSubjectPublicKeyInfo)] {
+					return nil
+				}
+			}
+			return ErrPinMismatch
+		}
+	}
+	return cfg
+}
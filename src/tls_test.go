@@ -0,0 +1,63 @@
+package sdk
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTLSOptions(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"OK","data":[]}`))
+	}))
+	server.TLS = &tls.Config{MaxVersion: tls.VersionTLS12}
+	// Rejected handshakes are expected
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	server.StartTLS()
+	defer server.Close()
+	cfg := EndpointConfig{APIBaseURL: server.URL}
+	ctx := context.Background()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+	trusted := &tls.Config{RootCAs: roots}
+	pin := SPKIHash(server.Certificate().RawSubjectPublicKeyInfo)
+
+	_, err := NewAPIClient(cfg, "", nil, time.Second, WithTLSConfig(trusted)).GetMarkets(ctx, nil)
+	require.NoError(t, err)
+
+	_, err = NewAPIClient(cfg, "", nil, time.Second, WithTLSConfig(trusted), WithPinnedSPKI("other", pin)).GetMarkets(ctx, nil)
+	assert.NoError(t, err, "one matching pin is enough")
+
+	_, err = NewAPIClient(cfg, "", nil, time.Second, WithTLSConfig(trusted), WithPinnedSPKI("other")).GetMarkets(ctx, nil)
+	assert.ErrorIs(t, err, ErrPinMismatch)
+
+	// Pinning does not replace chain verification
+	_, err = NewAPIClient(cfg, "", nil, time.Second, WithPinnedSPKI(pin)).GetMarkets(ctx, nil)
+	var unknownAuthority x509.UnknownAuthorityError
+	assert.ErrorAs(t, err, &unknownAuthority)
+
+	modern := trusted.Clone()
+	modern.MinVersion = tls.VersionTLS13
+	_, err = NewAPIClient(cfg, "", nil, time.Second, WithTLSConfig(modern)).GetMarkets(ctx, nil)
+	assert.ErrorContains(t, err, "protocol version")
+
+	// The pool transport follows the options of the pool
+	pool := NewClientPool(PoolConfig{Endpoint: cfg, Options: []ClientOption{WithTLSConfig(trusted), WithPinnedSPKI("other")}})
+	defer pool.Close()
+	account, err := NewStarkPerpetualAccount(1, TestPrivateKeyHex, TestPublicKeyHex, TestAPIKey)
+	require.NoError(t, err)
+	client, err := pool.Add(account)
+	require.NoError(t, err)
+	_, err = client.GetMarkets(ctx, nil)
+	assert.ErrorIs(t, err, ErrPinMismatch)
+}
@@ -0,0 +1,73 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// TradesFilter selects account trades. Zero fields are not filtered on.
+type TradesFilter struct {
+	Markets []string  `query:"market"`
+	Side    OrderSide `query:"side"`
+	Type    TradeType `query:"type"`
+	// Since and Until bound the trade creation time
+	Since time.Time `query:"startTime"`
+	Until time.Time `query:"endTime"`
+	// Cursor continues a previous listing, pass the ID of its last trade
+	Cursor *int64 `query:"cursor"`
+	Limit  int    `query:"limit"`
+}
+
+// TradesResponse represents the API response for account trades
+type TradesResponse struct {
+	Data   []AccountTradeModel `json:"data"`
+	Status string              `json:"status"`
+}
+
+// GetTrades retrieves the trades of the account matching filter, newest first
+func (c *APIClient) GetTrades(ctx context.Context, filter TradesFilter) ([]AccountTradeModel, error) {
+	baseURL, err := c.getURLWithFilter(getTradesPath, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build URL: %w", err)
+	}
+
+	var tradesResponse TradesResponse
+	if err := c.BaseModule.DoRequest(ctx, "GET", baseURL, nil, &tradesResponse); err != nil {
+		return nil, err
+	}
+
+	if tradesResponse.Status != "OK" {
+		return nil, fmt.Errorf("API returned error status: %v", tradesResponse.Status)
+	}
+
+	c.stats.recordTrades(tradesResponse.Data)
+	return tradesResponse.Data, nil
+}
+
+// tradesPageSize is the number of trades tradesSince requests at once
+const tradesPageSize = 100
+
+// tradesSince returns the trades created at or after since, oldest first. It
+// pages back from the newest trade and stops at the first page holding a
+// trade seen reports as already known, so the result may include a few of
+// them.
+func (c *APIClient) tradesSince(ctx context.Context, since time.Time, seen func(id int64) bool) ([]AccountTradeModel, error) {
+	filter := TradesFilter{Since: since, Limit: tradesPageSize}
+	var trades []AccountTradeModel
+	for {
+		page, err := c.GetTrades(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		trades = append(trades, page...)
+		if slices.ContainsFunc(page, func(t AccountTradeModel) bool { return seen(t.ID) }) || len(page) < filter.Limit {
+			break
+		}
+		last := page[len(page)-1].ID
+		filter.Cursor = &last
+	}
+	slices.Reverse(trades)
+	return trades, nil
+}
@@ -0,0 +1,242 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/shopspring/decimal"
+)
+
+// TrailingStopState is the persisted progress of a trailing stop
+type TrailingStopState struct {
+	// Extreme is the best mark price seen: the highest for a sell stop
+	// protecting a long, the lowest for a buy stop protecting a short
+	Extreme decimal.Decimal `json:"extreme"`
+	// TriggerPrice of the stop order currently on the book
+	TriggerPrice decimal.Decimal `json:"triggerPrice"`
+	// OrderExternalID of the stop order currently on the book
+	OrderExternalID string `json:"orderExternalId"`
+	// Revision counts the stop orders placed so far
+	Revision int `json:"revision"`
+}
+
+// TrailingStopStore persists trailing stop state so a trail survives restarts
+type TrailingStopStore interface {
+	// Load returns the state saved under id. The boolean is false if none was saved.
+	Load(id string) (TrailingStopState, bool, error)
+	Save(id string, state TrailingStopState) error
+}
+
+// FileTrailingStopStore keeps the state of every trailing stop in one JSON file
+type FileTrailingStopStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileTrailingStopStore returns a store backed by the file at path, which
+// is created on first save
+func NewFileTrailingStopStore(path string) *FileTrailingStopStore {
+	return &FileTrailingStopStore{path: path}
+}
+
+// Load returns the state saved under id
+func (s *FileTrailingStopStore) Load(id string) (TrailingStopState, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	states, err := s.read()
+	if err != nil {
+		return TrailingStopState{}, false, err
+	}
+	state, ok := states[id]
+	return state, ok, nil
+}
+
+// Save stores the state under id, replacing any previous state
+func (s *FileTrailingStopStore) Save(id string, state TrailingStopState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	states, err := s.read()
+	if err != nil {
+		return err
+	}
+	states[id] = state
+	data, err := json.Marshal(states)
+	if err != nil {
+		return fmt.Errorf("failed to encode trailing stops: %w", err)
+	}
+	if err := writeFileAtomic(s.path, data); err != nil {
+		return fmt.Errorf("failed to persist trailing stops: %w", err)
+	}
+	return nil
+}
+
+func (s *FileTrailingStopStore) read() (map[string]TrailingStopState, error) {
+	states := make(map[string]TrailingStopState)
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return states, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trailing stops: %w", err)
+	}
+	if err := json.Unmarshal(data, &states); err != nil {
+		return nil, fmt.Errorf("invalid trailing stop file %s: %w", s.path, err)
+	}
+	return states, nil
+}
+
+// TrailingStopConfig configures a TrailingStop
+type TrailingStopConfig struct {
+	// ID names the trail in the store and prefixes the stop order external IDs
+	ID string
+	// Order is the template of the stop order: market, account, signer,
+	// domain, quantity and the side of the stop, i.e. SELL to protect a long.
+	// It must carry a NonceGenerator since every re-placement is re-signed.
+	// Without an ExpireTime each revision expires an hour after it is signed.
+	Order CreateOrderObjectParams
+	// Trail is the distance kept between the best mark price and the trigger
+	Trail decimal.Decimal
+	// Step is the minimum trigger improvement worth re-placing the order for.
+	// Zero re-places on every improvement of at least one tick.
+	Step decimal.Decimal
+	// Store persists the trail, optional
+	Store TrailingStopStore
+}
+
+// TrailingStop keeps a reduce-only stop order a fixed distance behind the
+// best mark price, re-signing and replacing it as the price moves in favour
+// of the position. Mark prices are pushed in with UpdateMarkPrice, e.g. from
+// a mark price stream or by polling market stats.
+type TrailingStop struct {
+	client *APIClient
+	cfg    TrailingStopConfig
+
+	mu    sync.Mutex
+	state TrailingStopState
+}
+
+// NewTrailingStop creates a trailing stop, resuming the persisted trail if
+// the store holds one under cfg.ID
+func NewTrailingStop(client *APIClient, cfg TrailingStopConfig) (*TrailingStop, error) {
+	if cfg.ID == "" {
+		return nil, fmt.Errorf("%w: trailing stop ID is required", ErrInvalidOrder)
+	}
+	if !cfg.Trail.IsPositive() {
+		return nil, fmt.Errorf("%w: trail must be positive, got %s", ErrInvalidOrder, cfg.Trail)
+	}
+	if cfg.Order.NonceGenerator == nil {
+		return nil, fmt.Errorf("%w: a nonce generator is required to re-sign stop orders", ErrInvalidOrder)
+	}
+
+	t := &TrailingStop{client: client, cfg: cfg}
+	if cfg.Store != nil {
+		state, ok, err := cfg.Store.Load(cfg.ID)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			t.state = state
+		}
+	}
+	return t, nil
+}
+
+// State returns the current trail
+func (t *TrailingStop) State() TrailingStopState {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.state
+}
+
+// UpdateMarkPrice feeds a mark price. When the price sets a new extreme and
+// moves the trigger by at least Step, the stop order is replaced. It reports
+// whether a new order was placed.
+func (t *TrailingStop) UpdateMarkPrice(ctx context.Context, mark decimal.Decimal) (bool, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	sell := t.cfg.Order.Side == OrderSideSell
+	better := t.state.Extreme.IsZero() ||
+		(sell && mark.GreaterThan(t.state.Extreme)) ||
+		(!sell && mark.LessThan(t.state.Extreme))
+	if !better {
+		return false, nil
+	}
+
+	market := t.cfg.Order.Market
+	trigger := mark.Add(t.cfg.Trail)
+	if sell {
+		trigger = mark.Sub(t.cfg.Trail)
+	}
+	trigger = trigger.Round(market.PricePrecision())
+
+	if t.state.OrderExternalID != "" {
+		improvement := trigger.Sub(t.state.TriggerPrice)
+		if !sell {
+			improvement = improvement.Neg()
+		}
+		if !improvement.IsPositive() || improvement.LessThan(t.cfg.Step) {
+			// Remember the extreme so later moves are measured from it
+			t.state.Extreme = mark
+			return false, t.save()
+		}
+	}
+
+	order, err := t.stopOrder(ctx, trigger)
+	if err != nil {
+		return false, err
+	}
+	if _, err := t.client.SubmitOrder(ctx, order); err != nil {
+		return false, fmt.Errorf("failed to replace trailing stop: %w", err)
+	}
+
+	t.state = TrailingStopState{
+		Extreme:         mark,
+		TriggerPrice:    trigger,
+		OrderExternalID: order.ID,
+		Revision:        t.state.Revision + 1,
+	}
+	return true, t.save()
+}
+
+// stopOrder signs the next revision of the stop, replacing the current one
+func (t *TrailingStop) stopOrder(ctx context.Context, trigger decimal.Decimal) (*PerpetualOrderModel, error) {
+	params := t.cfg.Order
+	direction := TriggerDirectionDown
+	if params.Side == OrderSideBuy {
+		direction = TriggerDirectionUp
+	}
+	id := fmt.Sprintf("%s-%d", t.cfg.ID, t.state.Revision+1)
+
+	params.Type = OrderTypeConditional
+	params.ReduceOnly = true
+	params.PostOnly = false
+	params.TimeInForce = TimeInForceGTT
+	params.Price = trigger
+	params.Nonce = nil
+	params.OrderExternalID = &id
+	params.PreviousOrderExternalID = nil
+	if t.state.OrderExternalID != "" {
+		previous := t.state.OrderExternalID
+		params.PreviousOrderExternalID = &previous
+	}
+	params.Trigger = &ConditionalTrigger{
+		TriggerPrice:       FormatPrice(params.Market, trigger),
+		TriggerPriceType:   TriggerPriceTypeMark,
+		Direction:          direction,
+		ExecutionPriceType: ExecutionPriceTypeMarket,
+	}
+	params.TakeProfit, params.StopLoss, params.TpSlType = nil, nil, nil
+	return CreateOrderObjectContext(ctx, params)
+}
+
+func (t *TrailingStop) save() error {
+	if t.cfg.Store == nil {
+		return nil
+	}
+	return t.cfg.Store.Save(t.cfg.ID, t.state)
+}
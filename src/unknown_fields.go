@@ -0,0 +1,124 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// UnknownFields holds the response fields that the SDK does not model yet,
+// keyed by the JSON path of the object carrying them, e.g.
+// "data[0].tradingConfig" for a field of the trading config of the first
+// market. Each entry maps the unmodelled keys of that object to their raw
+// values.
+type UnknownFields map[string]map[string]json.RawMessage
+
+type unknownFieldsKey struct{}
+
+// WithUnknownFields makes requests made with the returned context record the
+// response fields the SDK does not model into u, so that consumers can read
+// new exchange fields before the SDK is updated, e.g.
+//
+//	var unknown UnknownFields
+//	markets, err := client.GetMarkets(WithUnknownFields(ctx, &unknown), nil)
+//	depth := unknown["data[0].tradingConfig"]["maxOrderBookDepth"]
+//
+// The fields are kept out of the models, which stay comparable. Capturing
+// costs a second pass over the response body. u is reset by every request;
+// it must not be shared by concurrent requests.
+func WithUnknownFields(ctx context.Context, u *UnknownFields) context.Context {
+	return context.WithValue(ctx, unknownFieldsKey{}, u)
+}
+
+// recordUnknownFields fills the UnknownFields carried by ctx, if any, from a
+// response decoded into result
+func recordUnknownFields(ctx context.Context, data []byte, result any) {
+	u, _ := ctx.Value(unknownFieldsKey{}).(*UnknownFields)
+	if u == nil {
+		return
+	}
+	*u = UnknownFields{}
+	captureUnknownValue(*u, "", data, reflect.ValueOf(result))
+}
+
+// structFields maps lowercased JSON names to the field indexes of a struct
+// type; encoding/json falls back to case-insensitive matching, so lookups do
+// the same
+type structFields map[string][]int
+
+var structFieldsCache sync.Map // reflect.Type -> structFields
+
+func fieldsOf(t reflect.Type) structFields {
+	if cached, ok := structFieldsCache.Load(t); ok {
+		return cached.(structFields)
+	}
+	fields := make(structFields)
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" || (f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct) {
+			// Promoted fields of embedded structs are listed on their own
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if _, ok := fields[strings.ToLower(name)]; !ok {
+			fields[strings.ToLower(name)] = f.Index
+		}
+	}
+	structFieldsCache.Store(t, fields)
+	return fields
+}
+
+// captureUnknownValue walks a decoded value alongside its JSON and records
+// the keys of JSON objects without a matching struct field under path. Parts
+// that do not match, e.g. a null where an object is expected, are skipped.
+func captureUnknownValue(unknown UnknownFields, path string, data json.RawMessage, v reflect.Value) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		var object map[string]json.RawMessage
+		if json.Unmarshal(data, &object) != nil {
+			return
+		}
+		fields := fieldsOf(v.Type())
+		for key, value := range object {
+			index, ok := fields[strings.ToLower(key)]
+			if !ok {
+				if unknown[path] == nil {
+					unknown[path] = make(map[string]json.RawMessage)
+				}
+				unknown[path][key] = value
+				continue
+			}
+			if field, err := v.FieldByIndexErr(index); err == nil {
+				fieldPath := key
+				if path != "" {
+					fieldPath = path + "." + key
+				}
+				captureUnknownValue(unknown, fieldPath, value, field)
+			}
+		}
+
+	case reflect.Slice, reflect.Array:
+		var elements []json.RawMessage
+		if json.Unmarshal(data, &elements) != nil {
+			return
+		}
+		for i := 0; i < len(elements) && i < v.Len(); i++ {
+			captureUnknownValue(unknown, path+"["+strconv.Itoa(i)+"]", elements[i], v.Index(i))
+		}
+	}
+}
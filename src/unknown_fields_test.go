@@ -0,0 +1,47 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnknownFields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"OK","data":[{
+			"name":"BTC-USD","Active":true,"status":"ACTIVE","visibility":"PUBLIC",
+			"tradingConfig":{"minOrderSize":"0.001","maxOrderBookDepth":50},
+			"marketStats":null
+		}]}`))
+	}))
+	defer server.Close()
+	ctx := context.Background()
+
+	m := NewBaseModule(EndpointConfig{APIBaseURL: server.URL}, "", nil, nil, 5*time.Second)
+	var plain MarketResponse
+	require.NoError(t, m.DoRequest(ctx, "GET", server.URL, nil, &plain))
+
+	unknown := UnknownFields{"stale": nil}
+	var resp MarketResponse
+	require.NoError(t, m.DoRequest(WithUnknownFields(ctx, &unknown), "GET", server.URL, nil, &resp))
+	assert.Equal(t, plain, resp, "decoding is unchanged and models stay comparable")
+	assert.True(t, resp.Data[0].Active)
+	assert.Equal(t, UnknownFields{
+		"data[0]": {
+			"visibility":  json.RawMessage(`"PUBLIC"`),
+			"marketStats": json.RawMessage(`null`),
+		},
+		"data[0].tradingConfig": {"maxOrderBookDepth": json.RawMessage(`50`)},
+	}, unknown, "keys matching a field case-insensitively are known; earlier captures are reset")
+	assert.Equal(t, "0.001", resp.Data[0].TradingConfig.MinOrderSize.String())
+
+	// Models carry no captured fields, so they can be compared with ==
+	fees := DefaultFees
+	assert.True(t, fees == DefaultFees)
+}
@@ -3,6 +3,9 @@ package sdk
 import (
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
 	"strings"
 )
 
@@ -24,3 +27,65 @@ func isHexString(s string) error {
 	}
 	return nil
 }
+
+// feltHex checks that s is a hex string of at most 256 bits, the width of
+// the values taken by the signer, and returns it with a lowercase 0x prefix
+// and without leading zeros
+func feltHex(s string) (string, error) {
+	if err := isHexString(s); err != nil {
+		return "", err
+	}
+	digits := strings.TrimLeft(s[strings.IndexAny(s, "xX")+1:], "0")
+	if len(digits) > 64 {
+		return "", fmt.Errorf("hex string of %d digits exceeds 256 bits", len(digits))
+	}
+	if digits == "" {
+		digits = "0"
+	}
+	return "0x" + digits, nil
+}
+
+// writeFileAtomic writes data to a temporary file in the directory of path,
+// syncs it and renames it over path, then syncs the directory, so that after
+// a crash path holds either the old or the new contents
+func writeFileAtomic(path string, data []byte) (err error) {
+	path = filepath.Clean(path)
+	dir := filepath.Dir(path)
+	f, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			f.Close()
+			os.Remove(f.Name())
+		}
+	}()
+	if _, err = f.Write(data); err != nil {
+		return err
+	}
+	if err = f.Sync(); err != nil {
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(f.Name(), path); err != nil {
+		return err
+	}
+	return syncDir(dir)
+}
+
+// syncDir makes a rename in dir durable. Windows cannot sync directories and
+// persists renames without it.
+func syncDir(dir string) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
@@ -0,0 +1,34 @@
+package sdk
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "state.json")
+	require.NoError(t, writeFileAtomic(path, []byte("old")))
+	require.NoError(t, writeFileAtomic(path, []byte("new")))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(data))
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "no temporary files are left behind")
+
+	// A failed write leaves neither a temporary file nor a changed target
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "busy"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "busy", "x"), nil, 0o600))
+	assert.Error(t, writeFileAtomic(filepath.Join(dir, "busy"), []byte("data")))
+	entries, err = os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+
+	assert.Error(t, writeFileAtomic(filepath.Join(dir, "missing", "state.json"), nil))
+}
@@ -0,0 +1,51 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+)
+
+// Warmup prepares the client for latency sensitive traffic: it resolves the
+// API host and opens a connection, including the TLS handshake, which is kept
+// in the connection pool for the next request. With authenticated set, it
+// also fetches the balance so that the API key is checked up front.
+func (c *APIClient) Warmup(ctx context.Context, authenticated bool) error {
+	if c.closed.Load() {
+		return ErrClientClosed
+	}
+	base, err := url.Parse(c.EndpointConfig().APIBaseURL)
+	if err != nil {
+		return fmt.Errorf("invalid API base URL: %w", err)
+	}
+
+	if host := base.Hostname(); net.ParseIP(host) == nil {
+		if _, err := net.DefaultResolver.LookupHost(ctx, host); err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", host, err)
+		}
+	}
+
+	// Any response proves the connection is up; the body is drained so that
+	// the connection is returned to the pool
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, base.String(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+	resp, err := c.HTTPClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", base.Host, err)
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	if authenticated {
+		if _, err := c.GetBalance(ctx); err != nil {
+			return fmt.Errorf("authenticated warmup failed: %w", err)
+		}
+	}
+	return nil
+}